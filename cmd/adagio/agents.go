@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/georgemac/adagio/pkg/adagio"
+	"github.com/georgemac/adagio/pkg/rpc/controlplane"
+)
+
+func agents(ctxt context.Context, client controlplane.ControlPlaneClient, args []string) {
+	var (
+		fs = flag.NewFlagSet(args[0], flag.ExitOnError)
+		_  = fs.Bool("help", false, "print usage")
+	)
+
+	fs.Usage = func() {
+		fmt.Println()
+		fmt.Print("Usage: adagio agents <COMMAND> [OPTIONS]\n\n")
+		fmt.Println("Commands:")
+		fmt.Println("\tls      - list registered agents and their current work")
+		fmt.Println("\tinspect - prints out an agent with all its details")
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+	}
+
+	fs.Parse(args[1:])
+
+	if fs.NArg() < 1 {
+		exit(fs.Usage, 2)
+	}
+
+	switch fs.Arg(0) {
+	case "ls":
+		listAgents(ctxt, client)
+	case "inspect":
+		inspectAgent(ctxt, client, fs.Args()...)
+	default:
+		exit(fs.Usage, 2)
+	}
+}
+
+func listAgents(ctxt context.Context, client controlplane.ControlPlaneClient) {
+	resp, err := client.ListAgents(ctxt, &controlplane.ListRequest{})
+	exitIfError(err)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 4, ' ', 0)
+
+	fmt.Fprintln(w, "ID\tHostname\tPID\tVersion\tLast Heartbeat\tCurrent\t")
+	for _, agent := range resp.Agents {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\t%s\t\n", agent.Id, agent.Hostname, agent.Pid, agent.Version, agent.LastHeartbeat, current(agent))
+	}
+
+	w.Flush()
+}
+
+func inspectAgent(ctxt context.Context, client controlplane.ControlPlaneClient, args ...string) {
+	var (
+		fs = flag.NewFlagSet(args[0], flag.ExitOnError)
+		_  = fs.Bool("help", false, "print usage")
+	)
+
+	fs.Usage = func() {
+		fmt.Println()
+		fmt.Print("Usage: adagio agents inspect [OPTIONS] <agent_id>\n\n")
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+	}
+
+	fs.Parse(args[1:])
+
+	if fs.NArg() < 1 {
+		exit(fs.Usage, 2)
+	}
+
+	resp, err := client.InspectAgent(ctxt, &controlplane.InspectAgentRequest{Id: fs.Arg(0)})
+	exitIfError(err)
+
+	var (
+		agent    = resp.Agent
+		runtimes = make([]string, 0, len(agent.Runtimes))
+		labels   = make([]string, 0, len(agent.Labels))
+		w        = tabwriter.NewWriter(os.Stdout, 0, 0, 4, ' ', 0)
+	)
+
+	for _, runtime := range agent.Runtimes {
+		runtimes = append(runtimes, runtime.Name)
+	}
+
+	for key, value := range agent.Labels {
+		labels = append(labels, key+"="+value)
+	}
+
+	sort.Strings(labels)
+
+	fmt.Fprintf(w, "ID\t%s\t\n", agent.Id)
+	fmt.Fprintf(w, "Hostname\t%s\t\n", agent.Hostname)
+	fmt.Fprintf(w, "PID\t%d\t\n", agent.Pid)
+	fmt.Fprintf(w, "Version\t%s\t\n", agent.Version)
+	fmt.Fprintf(w, "Started At\t%s\t\n", agent.StartedAt)
+	fmt.Fprintf(w, "Last Heartbeat\t%s\t\n", agent.LastHeartbeat)
+	fmt.Fprintf(w, "Capacity\t%d\t\n", agent.Capacity)
+	fmt.Fprintf(w, "Runtimes\t%s\t\n", strings.Join(runtimes, ","))
+	fmt.Fprintf(w, "Labels\t%s\t\n", strings.Join(labels, ","))
+	fmt.Fprintf(w, "Current\t%s\t\n", current(agent))
+
+	if agent.Current != nil {
+		fmt.Fprintf(w, "Current Started At\t%s\t\n", agent.Current.StartedAt)
+	}
+
+	w.Flush()
+}
+
+// current describes the node the agent is currently executing
+func current(agent *adagio.Agent) string {
+	if agent.Current == nil {
+		return "-"
+	}
+
+	return fmt.Sprintf("%s/%s", agent.Current.RunId, agent.Current.Node)
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/georgemac/adagio/pkg/rpc/controlplane"
+)
+
+func audit(ctxt context.Context, client controlplane.ControlPlaneClient, args []string) {
+	var (
+		fs = flag.NewFlagSet(args[0], flag.ExitOnError)
+		_  = fs.Bool("help", false, "print usage")
+	)
+
+	fs.Usage = func() {
+		fmt.Println()
+		fmt.Print("Usage: adagio audit <COMMAND> [OPTIONS]\n\n")
+		fmt.Println("Commands:")
+		fmt.Println("\tls - list the audit log of mutating control plane calls")
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+	}
+
+	fs.Parse(args[1:])
+
+	if fs.NArg() < 1 {
+		exit(fs.Usage, 2)
+	}
+
+	switch fs.Arg(0) {
+	case "ls":
+		listAuditEvents(ctxt, client, fs.Args()...)
+	default:
+		exit(fs.Usage, 2)
+	}
+}
+
+func listAuditEvents(ctxt context.Context, client controlplane.ControlPlaneClient, args ...string) {
+	var (
+		fs    = flag.NewFlagSet(args[0], flag.ExitOnError)
+		runID = fs.String("run", "", "only list the events of calls which targeted the run")
+		limit = fs.Uint64("limit", 50, "maximum number of events listed (0 is unlimited)")
+		_     = fs.Bool("help", false, "print usage")
+	)
+
+	fs.Usage = func() {
+		fmt.Println()
+		fmt.Print("Usage: adagio audit ls [OPTIONS]\n\n")
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+	}
+
+	fs.Parse(args[1:])
+
+	resp, err := client.ListAuditEvents(ctxt, &controlplane.ListAuditEventsRequest{RunId: *runID, Limit: *limit})
+	exitIfError(err)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 4, ' ', 0)
+
+	fmt.Fprintln(w, "At\tActor\tPeer\tRPC\tRun\tSummary\tOutcome\tError\t")
+	for _, event := range resp.Events {
+		runID := event.RunId
+		if runID == "" {
+			runID = "-"
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t\n", event.At, event.Actor, event.Peer, event.Rpc, runID, event.Summary, event.Outcome, event.Error)
+	}
+
+	w.Flush()
+}
@@ -5,25 +5,42 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"sort"
 	"text/tabwriter"
 
+	"github.com/georgemac/adagio/pkg/adagio"
+	"github.com/georgemac/adagio/pkg/auth"
 	"github.com/georgemac/adagio/pkg/rpc/controlplane"
+	controlservice "github.com/georgemac/adagio/pkg/service/controlplane"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
 )
 
 func main() {
 	var (
-		fs   = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
-		host = fs.String("host", "localhost:7890", "host address of adagio control plane")
-		_    = fs.Bool("help", false, "print usage")
+		fs         = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+		host       = fs.String("host", "localhost:7890", "host address of adagio control plane")
+		namespace  = fs.String("n", envOr("ADAGIO_NAMESPACE", adagio.DefaultNamespace), "namespace in which commands are run")
+		actor      = fs.String("actor", os.Getenv("USER"), "advisory identity recorded in the audit log for mutating calls (ignored when the control plane authenticates callers)")
+		useTLS     = fs.Bool("tls", false, "connect to the control plane using TLS")
+		caFile     = fs.String("tls-ca", "", "PEM CA certificates file by which the control plane is verified (system roots when empty)")
+		certFile   = fs.String("tls-cert", "", "PEM client certificate file presented to the control plane (mutual TLS)")
+		keyFile    = fs.String("tls-key", "", "PEM private key file of the client certificate")
+		serverName = fs.String("tls-server-name", "", "name by which the control plane certificate is verified (host when empty)")
+		token      = fs.String("token", os.Getenv("ADAGIO_TOKEN"), "bearer token presented to the control plane (requires TLS)")
+		_          = fs.Bool("help", false, "print usage")
 	)
 
 	fs.Usage = func() {
 		fmt.Println()
 		fmt.Print("Usage: adagio <COMMAND> [OPTIONS]\n\n")
 		fmt.Println("Commands:")
-		fmt.Println("\truns  - manage adagio runs")
-		fmt.Println("\tstats - view adagio statistics")
+		fmt.Println("\truns     - manage adagio runs")
+		fmt.Println("\tagents   - view adagio agents")
+		fmt.Println("\tstats    - view adagio statistics")
+		fmt.Println("\twebhooks - manage adagio webhooks")
+		fmt.Println("\taudit    - view the adagio audit log")
 		fmt.Println("Options:")
 		fs.PrintDefaults()
 	}
@@ -34,16 +51,39 @@ func main() {
 		exit(fs.Usage, 2)
 	}
 
-	conn, err := grpc.Dial(*host, grpc.WithInsecure())
+	opts := []grpc.DialOption{grpc.WithInsecure()}
+	if *useTLS || *caFile != "" || *certFile != "" {
+		config, err := auth.ClientTLSConfig(*caFile, *certFile, *keyFile, *serverName)
+		exitIfError(err)
+
+		opts = []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(config))}
+	}
+
+	if *token != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(auth.BearerToken(*token)))
+	}
+
+	conn, err := grpc.Dial(*host, opts...)
 	exitIfError(err)
 
 	defer conn.Close()
 
+	var (
+		ctxt   = metadata.AppendToOutgoingContext(context.Background(), controlservice.ActorMetadataKey, *actor)
+		client = namespacedClient{controlplane.NewControlPlaneClient(conn), *namespace}
+	)
+
 	switch fs.Arg(0) {
 	case "runs":
-		runs(context.Background(), controlplane.NewControlPlaneClient(conn), fs.Args())
+		runs(ctxt, client, fs.Args())
+	case "agents":
+		agents(ctxt, client, fs.Args())
 	case "stats":
-		stats(context.Background(), controlplane.NewControlPlaneClient(conn), fs.Args())
+		stats(ctxt, client, fs.Args())
+	case "webhooks":
+		webhooks(ctxt, client, fs.Args())
+	case "audit":
+		audit(ctxt, client, fs.Args())
 	default:
 		exit(fs.Usage, 2)
 	}
@@ -76,10 +116,32 @@ func stats(ctxt context.Context, client controlplane.ControlPlaneClient, args []
 	fmt.Fprintf(w, "nodes ready\t%d\t\n", stats.NodeCounts.ReadyCount)
 	fmt.Fprintf(w, "nodes running\t%d\t\n", stats.NodeCounts.RunningCount)
 	fmt.Fprintf(w, "nodes completed\t%d\t\n", stats.NodeCounts.CompletedCount)
+	fmt.Fprintf(w, "nodes skipped\t%d\t\n", stats.NodeCounts.SkippedCount)
+	fmt.Fprintf(w, "nodes scheduled\t%d\t\n", stats.NodeCounts.ScheduledCount)
+
+	runtimes := make([]string, 0, len(stats.UnschedulableCounts))
+	for runtime := range stats.UnschedulableCounts {
+		runtimes = append(runtimes, runtime)
+	}
+
+	sort.Strings(runtimes)
+
+	for _, runtime := range runtimes {
+		fmt.Fprintf(w, "nodes unschedulable (%s)\t%d\t\n", runtime, stats.UnschedulableCounts[runtime])
+	}
 
 	w.Flush()
 }
 
+// envOr returns the value of the environment variable or the fallback given it is empty
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+
+	return fallback
+}
+
 func exitIfError(err error) {
 	if err == nil {
 		return
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+
+	"github.com/georgemac/adagio/pkg/rpc/controlplane"
+	"google.golang.org/grpc"
+)
+
+var _ controlplane.ControlPlaneClient = namespacedClient{}
+
+// namespacedClient is a control plane client which makes
+// each call in the namespace selected by the -n flag
+type namespacedClient struct {
+	client    controlplane.ControlPlaneClient
+	namespace string
+}
+
+func (c namespacedClient) Stats(ctx context.Context, in *controlplane.StatsRequest, opts ...grpc.CallOption) (*controlplane.StatsResponse, error) {
+	in.Namespace = c.namespace
+	return c.client.Stats(ctx, in, opts...)
+}
+
+func (c namespacedClient) Start(ctx context.Context, in *controlplane.StartRequest, opts ...grpc.CallOption) (*controlplane.StartResponse, error) {
+	in.Namespace = c.namespace
+	return c.client.Start(ctx, in, opts...)
+}
+
+func (c namespacedClient) ListRuns(ctx context.Context, in *controlplane.ListRequest, opts ...grpc.CallOption) (*controlplane.ListRunsResponse, error) {
+	in.Namespace = c.namespace
+	return c.client.ListRuns(ctx, in, opts...)
+}
+
+func (c namespacedClient) Inspect(ctx context.Context, in *controlplane.InspectRequest, opts ...grpc.CallOption) (*controlplane.InspectResponse, error) {
+	in.Namespace = c.namespace
+	return c.client.Inspect(ctx, in, opts...)
+}
+
+func (c namespacedClient) ListAgents(ctx context.Context, in *controlplane.ListRequest, opts ...grpc.CallOption) (*controlplane.ListAgentsResponse, error) {
+	in.Namespace = c.namespace
+	return c.client.ListAgents(ctx, in, opts...)
+}
+
+func (c namespacedClient) InspectAgent(ctx context.Context, in *controlplane.InspectAgentRequest, opts ...grpc.CallOption) (*controlplane.InspectAgentResponse, error) {
+	in.Namespace = c.namespace
+	return c.client.InspectAgent(ctx, in, opts...)
+}
+
+func (c namespacedClient) Approve(ctx context.Context, in *controlplane.ApprovalRequest, opts ...grpc.CallOption) (*controlplane.ApprovalResponse, error) {
+	in.Namespace = c.namespace
+	return c.client.Approve(ctx, in, opts...)
+}
+
+func (c namespacedClient) Reject(ctx context.Context, in *controlplane.ApprovalRequest, opts ...grpc.CallOption) (*controlplane.ApprovalResponse, error) {
+	in.Namespace = c.namespace
+	return c.client.Reject(ctx, in, opts...)
+}
+
+func (c namespacedClient) ListUnschedulable(ctx context.Context, in *controlplane.UnschedulableRequest, opts ...grpc.CallOption) (*controlplane.UnschedulableResponse, error) {
+	in.Namespace = c.namespace
+	return c.client.ListUnschedulable(ctx, in, opts...)
+}
+
+func (c namespacedClient) StreamLogs(ctx context.Context, in *controlplane.StreamLogsRequest, opts ...grpc.CallOption) (controlplane.ControlPlane_StreamLogsClient, error) {
+	in.Namespace = c.namespace
+	return c.client.StreamLogs(ctx, in, opts...)
+}
+
+func (c namespacedClient) CreateWebhook(ctx context.Context, in *controlplane.CreateWebhookRequest, opts ...grpc.CallOption) (*controlplane.WebhookResponse, error) {
+	in.Namespace = c.namespace
+	return c.client.CreateWebhook(ctx, in, opts...)
+}
+
+func (c namespacedClient) ListWebhooks(ctx context.Context, in *controlplane.ListWebhooksRequest, opts ...grpc.CallOption) (*controlplane.ListWebhooksResponse, error) {
+	in.Namespace = c.namespace
+	return c.client.ListWebhooks(ctx, in, opts...)
+}
+
+func (c namespacedClient) InspectWebhook(ctx context.Context, in *controlplane.InspectWebhookRequest, opts ...grpc.CallOption) (*controlplane.WebhookResponse, error) {
+	in.Namespace = c.namespace
+	return c.client.InspectWebhook(ctx, in, opts...)
+}
+
+func (c namespacedClient) UpdateWebhook(ctx context.Context, in *controlplane.UpdateWebhookRequest, opts ...grpc.CallOption) (*controlplane.WebhookResponse, error) {
+	in.Namespace = c.namespace
+	return c.client.UpdateWebhook(ctx, in, opts...)
+}
+
+func (c namespacedClient) DeleteWebhook(ctx context.Context, in *controlplane.DeleteWebhookRequest, opts ...grpc.CallOption) (*controlplane.DeleteWebhookResponse, error) {
+	in.Namespace = c.namespace
+	return c.client.DeleteWebhook(ctx, in, opts...)
+}
+
+func (c namespacedClient) ListDeliveries(ctx context.Context, in *controlplane.ListDeliveriesRequest, opts ...grpc.CallOption) (*controlplane.ListDeliveriesResponse, error) {
+	in.Namespace = c.namespace
+	return c.client.ListDeliveries(ctx, in, opts...)
+}
+
+func (c namespacedClient) ListAuditEvents(ctx context.Context, in *controlplane.ListAuditEventsRequest, opts ...grpc.CallOption) (*controlplane.ListAuditEventsResponse, error) {
+	in.Namespace = c.namespace
+	return c.client.ListAuditEvents(ctx, in, opts...)
+}
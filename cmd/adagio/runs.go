@@ -30,6 +30,9 @@ func runs(ctxt context.Context, client controlplane.ControlPlaneClient, args []s
 		fmt.Println("\tstart   - starts a new run from the provided graph spec")
 		fmt.Println("\tinspect - prints out a run with all its details")
 		fmt.Println("\tls      - list current and previous runs")
+		fmt.Println("\tapprove - approves (or rejects) a node awaiting approval")
+		fmt.Println("\tlogs    - prints the logs of an attempt of a node")
+		fmt.Println("\tunschedulable - list ready nodes which no agent can claim")
 		fmt.Println("Options:")
 		fs.PrintDefaults()
 	}
@@ -47,6 +50,12 @@ func runs(ctxt context.Context, client controlplane.ControlPlaneClient, args []s
 		inspect(ctxt, client, fs.Args()...)
 	case "ls":
 		list(ctxt, client)
+	case "approve":
+		approve(ctxt, client, fs.Args()...)
+	case "logs":
+		logs(ctxt, client, fs.Args()...)
+	case "unschedulable":
+		unschedulable(ctxt, client, fs.Args()...)
 	default:
 		exit(fs.Usage, 2)
 	}
@@ -91,7 +100,7 @@ func start(ctxt context.Context, client controlplane.ControlPlaneClient, args ..
 
 	exitIfError(json.NewDecoder(input).Decode(req.Spec))
 
-	resp, err := client.Start(context.Background(), req)
+	resp, err := client.Start(ctxt, req)
 	exitIfError(err)
 
 	if *q {
@@ -216,3 +225,136 @@ func list(ctxt context.Context, client controlplane.ControlPlaneClient) {
 
 	w.Flush()
 }
+
+func approve(ctxt context.Context, client controlplane.ControlPlaneClient, args ...string) {
+	var (
+		fs       = flag.NewFlagSet(args[0], flag.ExitOnError)
+		reject   = fs.Bool("reject", false, "reject rather than approve the node")
+		approver = fs.String("approver", "", "name of the approver (ignored unless it is the authenticated caller when the control plane authenticates callers, -actor when empty)")
+		comment  = fs.String("comment", "", "comment stored alongside the approval")
+		_        = fs.Bool("help", false, "print usage")
+	)
+
+	fs.Usage = func() {
+		fmt.Println()
+		fmt.Print("Usage: adagio runs approve [OPTIONS] <run_id> <node>\n\n")
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+	}
+
+	fs.Parse(args[1:])
+
+	if fs.NArg() < 2 {
+		exit(fs.Usage, 2)
+	}
+
+	var (
+		req = &controlplane.ApprovalRequest{
+			RunId:    fs.Arg(0),
+			Node:     fs.Arg(1),
+			Approver: *approver,
+			Comment:  *comment,
+		}
+		call = client.Approve
+	)
+
+	if *reject {
+		call = client.Reject
+	}
+
+	resp, err := call(ctxt, req)
+	exitIfError(err)
+
+	// the approver is recorded by the control plane on the result of the node
+	approvedBy := req.Approver
+	if node, err := resp.Run.GetNodeByName(req.Node); err == nil && len(node.Attempts) > 0 {
+		if values := node.Attempts[len(node.Attempts)-1].Metadata[adagio.MetadataApprover].GetValues(); len(values) > 0 {
+			approvedBy = values[0]
+		}
+	}
+
+	if *reject {
+		fmt.Printf("Node %q rejected by %q\n", req.Node, approvedBy)
+		return
+	}
+
+	fmt.Printf("Node %q approved by %q\n", req.Node, approvedBy)
+}
+
+func logs(ctxt context.Context, client controlplane.ControlPlaneClient, args ...string) {
+	var (
+		fs         = flag.NewFlagSet(args[0], flag.ExitOnError)
+		follow     = fs.Bool("f", false, "follow the logs until the attempt concludes")
+		attempt    = fs.Int("attempt", 0, "attempt to print the logs of (defaults to the latest)")
+		timestamps = fs.Bool("timestamps", false, "prefix each line with its timestamp")
+		_          = fs.Bool("help", false, "print usage")
+	)
+
+	fs.Usage = func() {
+		fmt.Println()
+		fmt.Print("Usage: adagio runs logs [OPTIONS] <run_id> <node>\n\n")
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+	}
+
+	fs.Parse(args[1:])
+
+	if fs.NArg() < 2 {
+		exit(fs.Usage, 2)
+	}
+
+	stream, err := client.StreamLogs(ctxt, &controlplane.StreamLogsRequest{
+		RunId:   fs.Arg(0),
+		Node:    fs.Arg(1),
+		Attempt: int32(*attempt),
+		Follow:  *follow,
+	})
+	exitIfError(err)
+
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return
+		}
+
+		exitIfError(err)
+
+		for _, line := range resp.Lines {
+			if *timestamps {
+				fmt.Printf("%s %s\n", line.Timestamp, line.Text)
+				continue
+			}
+
+			fmt.Println(line.Text)
+		}
+	}
+}
+
+func unschedulable(ctxt context.Context, client controlplane.ControlPlaneClient, args ...string) {
+	var (
+		fs        = flag.NewFlagSet(args[0], flag.ExitOnError)
+		threshold = fs.Duration("threshold", time.Minute, "minimum duration nodes have been ready for")
+		_         = fs.Bool("help", false, "print usage")
+	)
+
+	fs.Usage = func() {
+		fmt.Println()
+		fmt.Print("Usage: adagio runs unschedulable [OPTIONS]\n\n")
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+	}
+
+	fs.Parse(args[1:])
+
+	resp, err := client.ListUnschedulable(ctxt, &controlplane.UnschedulableRequest{ThresholdNs: int64(*threshold)})
+	exitIfError(err)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 4, ' ', 0)
+
+	fmt.Fprintln(w, "Run ID\tNode\tRuntime\tReady At\t")
+	for _, n := range resp.Nodes {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t\n", n.RunId, n.Node.Spec.Name, n.Node.Spec.Runtime, n.Node.ReadyAt)
+	}
+
+	w.Flush()
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/georgemac/adagio/pkg/adagio"
+	"github.com/georgemac/adagio/pkg/rpc/controlplane"
+)
+
+func webhooks(ctxt context.Context, client controlplane.ControlPlaneClient, args []string) {
+	var (
+		fs = flag.NewFlagSet(args[0], flag.ExitOnError)
+		_  = fs.Bool("help", false, "print usage")
+	)
+
+	fs.Usage = func() {
+		fmt.Println()
+		fmt.Print("Usage: adagio webhooks <COMMAND> [OPTIONS]\n\n")
+		fmt.Println("Commands:")
+		fmt.Println("\tcreate     - registers a webhook notified of run and node events")
+		fmt.Println("\tls         - list registered webhooks")
+		fmt.Println("\tupdate     - replaces the url, events and secret of a webhook")
+		fmt.Println("\trm         - removes a webhook and its deliveries")
+		fmt.Println("\tdeliveries - list the deliveries made to a webhook")
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+	}
+
+	fs.Parse(args[1:])
+
+	if fs.NArg() < 1 {
+		exit(fs.Usage, 2)
+	}
+
+	switch fs.Arg(0) {
+	case "create":
+		createWebhook(ctxt, client, fs.Args()...)
+	case "ls":
+		listWebhooks(ctxt, client)
+	case "update":
+		updateWebhook(ctxt, client, fs.Args()...)
+	case "rm":
+		removeWebhook(ctxt, client, fs.Args()...)
+	case "deliveries":
+		listDeliveries(ctxt, client, fs.Args()...)
+	default:
+		exit(fs.Usage, 2)
+	}
+}
+
+func createWebhook(ctxt context.Context, client controlplane.ControlPlaneClient, args ...string) {
+	var (
+		fs     = flag.NewFlagSet(args[0], flag.ExitOnError)
+		secret = fs.String("secret", "", "key with which the payloads posted to the webhook are signed")
+		events = fs.String("events", "run_failed", `comma separated list of events ("run_failed"|"run_succeeded"|"node_retried"|"approval_requested")`)
+		_      = fs.Bool("help", false, "print usage")
+	)
+
+	fs.Usage = func() {
+		fmt.Println()
+		fmt.Print("Usage: adagio webhooks create [OPTIONS] <url>\n\n")
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+	}
+
+	fs.Parse(args[1:])
+
+	if fs.NArg() < 1 {
+		exit(fs.Usage, 2)
+	}
+
+	parsed, err := parseEvents(*events)
+	exitIfError(err)
+
+	resp, err := client.CreateWebhook(ctxt, &controlplane.CreateWebhookRequest{
+		Url:    fs.Arg(0),
+		Secret: *secret,
+		Events: parsed,
+	})
+	exitIfError(err)
+
+	fmt.Printf("Webhook created %q\n", resp.Webhook.Id)
+}
+
+func listWebhooks(ctxt context.Context, client controlplane.ControlPlaneClient) {
+	resp, err := client.ListWebhooks(ctxt, &controlplane.ListWebhooksRequest{})
+	exitIfError(err)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 4, ' ', 0)
+
+	fmt.Fprintln(w, "ID\tURL\tEvents\tCreated At\t")
+	for _, webhook := range resp.Webhooks {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t\n", webhook.Id, webhook.Url, formatEvents(webhook.Events), webhook.CreatedAt)
+	}
+
+	w.Flush()
+}
+
+func updateWebhook(ctxt context.Context, client controlplane.ControlPlaneClient, args ...string) {
+	var (
+		fs     = flag.NewFlagSet(args[0], flag.ExitOnError)
+		secret = fs.String("secret", "", "replaces the key with which the payloads are signed (unchanged when empty)")
+		events = fs.String("events", "run_failed", `comma separated list of events ("run_failed"|"run_succeeded"|"node_retried"|"approval_requested")`)
+		_      = fs.Bool("help", false, "print usage")
+	)
+
+	fs.Usage = func() {
+		fmt.Println()
+		fmt.Print("Usage: adagio webhooks update [OPTIONS] <webhook_id> <url>\n\n")
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+	}
+
+	fs.Parse(args[1:])
+
+	if fs.NArg() < 2 {
+		exit(fs.Usage, 2)
+	}
+
+	parsed, err := parseEvents(*events)
+	exitIfError(err)
+
+	_, err = client.UpdateWebhook(ctxt, &controlplane.UpdateWebhookRequest{
+		Id:     fs.Arg(0),
+		Url:    fs.Arg(1),
+		Secret: *secret,
+		Events: parsed,
+	})
+	exitIfError(err)
+
+	fmt.Printf("Webhook updated %q\n", fs.Arg(0))
+}
+
+func removeWebhook(ctxt context.Context, client controlplane.ControlPlaneClient, args ...string) {
+	var (
+		fs = flag.NewFlagSet(args[0], flag.ExitOnError)
+		_  = fs.Bool("help", false, "print usage")
+	)
+
+	fs.Usage = func() {
+		fmt.Println()
+		fmt.Print("Usage: adagio webhooks rm [OPTIONS] <webhook_id>\n\n")
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+	}
+
+	fs.Parse(args[1:])
+
+	if fs.NArg() < 1 {
+		exit(fs.Usage, 2)
+	}
+
+	_, err := client.DeleteWebhook(ctxt, &controlplane.DeleteWebhookRequest{Id: fs.Arg(0)})
+	exitIfError(err)
+
+	fmt.Printf("Webhook removed %q\n", fs.Arg(0))
+}
+
+func listDeliveries(ctxt context.Context, client controlplane.ControlPlaneClient, args ...string) {
+	var (
+		fs = flag.NewFlagSet(args[0], flag.ExitOnError)
+		_  = fs.Bool("help", false, "print usage")
+	)
+
+	fs.Usage = func() {
+		fmt.Println()
+		fmt.Print("Usage: adagio webhooks deliveries [OPTIONS] <webhook_id>\n\n")
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+	}
+
+	fs.Parse(args[1:])
+
+	if fs.NArg() < 1 {
+		exit(fs.Usage, 2)
+	}
+
+	resp, err := client.ListDeliveries(ctxt, &controlplane.ListDeliveriesRequest{WebhookId: fs.Arg(0)})
+	exitIfError(err)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 4, ' ', 0)
+
+	fmt.Fprintln(w, "Notification\tEvent\tStatus\tAttempts\tResponse\tUpdated At\tError\t")
+	for _, delivery := range resp.Deliveries {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t%s\t%s\t\n",
+			delivery.Notification.Id,
+			strings.ToLower(delivery.Notification.Event.String()),
+			strings.ToLower(delivery.Status.String()),
+			delivery.Attempts,
+			delivery.ResponseCode,
+			delivery.UpdatedAt,
+			delivery.Error)
+	}
+
+	w.Flush()
+}
+
+// parseEvents parses a comma separated list of lower case webhook event names
+func parseEvents(v string) (events []adagio.Webhook_Event, err error) {
+	for _, name := range strings.Split(v, ",") {
+		event, ok := adagio.Webhook_Event_value[strings.ToUpper(strings.TrimSpace(name))]
+		if !ok {
+			return nil, fmt.Errorf("unknown webhook event %q", name)
+		}
+
+		events = append(events, adagio.Webhook_Event(event))
+	}
+
+	return
+}
+
+// formatEvents formats webhook events as a comma separated list of lower case names
+func formatEvents(events []adagio.Webhook_Event) string {
+	names := make([]string, 0, len(events))
+	for _, event := range events {
+		names = append(names, strings.ToLower(event.String()))
+	}
+
+	return strings.Join(names, ",")
+}
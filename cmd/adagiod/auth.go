@@ -0,0 +1,167 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/BurntSushi/toml"
+	"github.com/georgemac/adagio/pkg/auth"
+	"github.com/georgemac/adagio/pkg/logging"
+	controlservice "github.com/georgemac/adagio/pkg/service/controlplane"
+	"github.com/georgemac/adagio/pkg/tracing"
+	"github.com/peterbourgon/ff/fftoml"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// authConfig is the transport security, authentication and authorization configuration of the control plane API
+type authConfig struct {
+	certFile     string
+	keyFile      string
+	clientCAFile string
+	tokensFile   string
+	jwksFile     string
+	issuer       string
+	audience     string
+	// common names of the client certificates which only authenticate the transport
+	transports []string
+	// policy authorizes authenticated callers (nil disables authorization)
+	policy *auth.Policy
+}
+
+// authenticated returns true given callers of the control plane API are authenticated
+func (c authConfig) authenticated() bool {
+	return c.tokensFile != "" || c.jwksFile != "" || c.clientCAFile != ""
+}
+
+// setupAuth returns the server options which configure the transport credentials and
+// the interceptors of the control plane API. Callers are only authenticated given a
+// static token file, a JWKS file or a client CA is configured and are then authorized
+// given a policy. Calls denied by the policy are passed to the denied function
+func setupAuth(logger logging.Logger, conf authConfig, denied auth.DeniedFunc) ([]grpc.ServerOption, error) {
+	var (
+		opts   []grpc.ServerOption
+		unary  = []grpc.UnaryServerInterceptor{tracing.UnaryServerInterceptor()}
+		stream = []grpc.StreamServerInterceptor{tracing.StreamServerInterceptor()}
+	)
+
+	if conf.certFile != "" || conf.keyFile != "" || conf.clientCAFile != "" {
+		config, err := auth.ServerTLSConfig(conf.certFile, conf.keyFile, conf.clientCAFile)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, grpc.Creds(credentials.NewTLS(config)))
+
+		logger.WithField("mutual", conf.clientCAFile != "").Info("control plane TLS enabled")
+	}
+
+	var authenticators auth.Authenticators
+
+	if conf.tokensFile != "" {
+		tokens, err := auth.LoadTokens(conf.tokensFile)
+		if err != nil {
+			return nil, err
+		}
+
+		authenticators = append(authenticators, tokens)
+	}
+
+	if conf.jwksFile != "" {
+		verifier, err := auth.LoadJWKS(conf.jwksFile, auth.WithIssuer(conf.issuer), auth.WithAudience(conf.audience))
+		if err != nil {
+			return nil, err
+		}
+
+		authenticators = append(authenticators, verifier)
+	}
+
+	authenticated := conf.authenticated()
+	if authenticated {
+		// a nil authenticator only accepts client certificates
+		var authenticator auth.Authenticator
+		if len(authenticators) > 0 {
+			authenticator = authenticators
+		}
+
+		unary = append(unary, auth.UnaryServerInterceptor(authenticator, conf.transports...))
+		stream = append(stream, auth.StreamServerInterceptor(authenticator, conf.transports...))
+
+		if conf.certFile == "" {
+			logger.Warn("control plane bearer tokens are accepted without TLS")
+		}
+
+		logger.WithField("authenticators", len(authenticators)).Info("control plane authentication enabled")
+	}
+
+	if conf.policy != nil {
+		if !authenticated {
+			return nil, errors.New("role-based access control requires authentication (-auth-tokens, -auth-jwks or -tls-client-ca)")
+		}
+
+		unary = append(unary, auth.AuthorizeUnaryServerInterceptor(conf.policy, controlservice.Permissions, denied))
+		stream = append(stream, auth.AuthorizeStreamServerInterceptor(conf.policy, controlservice.Permissions, denied))
+
+		logger.Info("control plane role-based access control enabled")
+	}
+
+	return append(opts,
+		grpc.UnaryInterceptor(chainUnary(unary...)),
+		grpc.StreamInterceptor(chainStream(stream...))), nil
+}
+
+// chainUnary returns a unary interceptor which calls the interceptors in order
+func chainUnary(interceptors ...grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		next := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, handler := interceptors[i], next
+			next = func(ctx context.Context, req interface{}) (interface{}, error) {
+				return interceptor(ctx, req, info, handler)
+			}
+		}
+
+		return next(ctx, req)
+	}
+}
+
+// chainStream returns a stream interceptor which calls the interceptors in order
+func chainStream(interceptors ...grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		next := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, handler := interceptors[i], next
+			next = func(srv interface{}, stream grpc.ServerStream) error {
+				return interceptor(srv, stream, info, handler)
+			}
+		}
+
+		return next(srv, stream)
+	}
+}
+
+// configParser parses flags from the key/value pairs of the toml config file while
+// ignoring its tables (e.g. the [rbac] policy) which do not configure flags
+func configParser(r io.Reader, set func(name, value string) error) error {
+	var config map[string]interface{}
+	if _, err := toml.DecodeReader(r, &config); err != nil {
+		return fmt.Errorf("error parsing TOML config: %w", err)
+	}
+
+	for key, value := range config {
+		switch value.(type) {
+		case map[string]interface{}, []map[string]interface{}:
+			delete(config, key)
+		}
+	}
+
+	var flags bytes.Buffer
+	if err := toml.NewEncoder(&flags).Encode(config); err != nil {
+		return err
+	}
+
+	return fftoml.Parser(&flags, set)
+}
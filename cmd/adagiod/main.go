@@ -2,35 +2,70 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
-	"log"
 	"net"
+	nethttp "net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"syscall"
 	"time"
 
+	"github.com/georgemac/adagio/pkg/adagio"
 	"github.com/georgemac/adagio/pkg/agent"
+	"github.com/georgemac/adagio/pkg/auth"
 	"github.com/georgemac/adagio/pkg/etcd"
+	"github.com/georgemac/adagio/pkg/logging"
 	"github.com/georgemac/adagio/pkg/memory"
+	"github.com/georgemac/adagio/pkg/metrics"
+	"github.com/georgemac/adagio/pkg/notify"
 	"github.com/georgemac/adagio/pkg/rpc/controlplane"
 	"github.com/georgemac/adagio/pkg/runtimes/debug"
 	"github.com/georgemac/adagio/pkg/runtimes/exec"
+	"github.com/georgemac/adagio/pkg/runtimes/http"
+	"github.com/georgemac/adagio/pkg/runtimes/sensor"
+	"github.com/georgemac/adagio/pkg/runtimes/shell"
+	"github.com/georgemac/adagio/pkg/runtimes/workflow"
 	controlservice "github.com/georgemac/adagio/pkg/service/controlplane"
+	"github.com/georgemac/adagio/pkg/tracing"
 	"github.com/peterbourgon/ff"
-	"github.com/peterbourgon/ff/fftoml"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.etcd.io/etcd/clientv3"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"google.golang.org/grpc"
 )
 
-// Repository is a type which is both a control plane
-// and an agent repository implementation
+// Repository is a type which is a control plane, an agent
+// and a notify repository implementation
 type Repository interface {
 	controlservice.Repository
 	agent.Repository
+	CancelRun(ctx context.Context, id string) error
+	WatchRuns(ctx context.Context, events chan<- *adagio.Event) error
+	CreateDelivery(context.Context, *adagio.Delivery) error
+	UpdateDelivery(context.Context, *adagio.Delivery) error
+}
+
+// admittedRepository is a Repository of a namespace whose runs are started through
+// an admission, so that the child runs started by the workflow runtime are subject
+// to the same placement checks as the runs started via the API
+type admittedRepository struct {
+	Repository
+	admission *controlservice.Admission
+	namespace string
+}
+
+// StartRun starts a run through the admission of the namespace
+func (r admittedRepository) StartRun(ctx context.Context, spec *adagio.GraphSpec, opts ...adagio.RunOption) (*adagio.Run, error) {
+	return r.admission.StartRun(ctx, r.Repository, r.namespace, spec, opts...)
 }
 
 func main() {
@@ -38,13 +73,40 @@ func main() {
 		fs        = flag.NewFlagSet("adagiod", flag.ExitOnError)
 		backend   = fs.String("backend-type", "memory", `backend repository type ("memory"|"etcd")`)
 		etcdAddrs = fs.String("etcd-addresses", "http://127.0.0.1:2379", "list of etcd node addresses")
-		_         = fs.String("config", "", "location of config toml file")
+		workflows = fs.String("workflows-dir", "", "directory of graph spec json files registered as named workflows")
+		expiry    = fs.Duration("approval-expiry-interval", 10*time.Second, "interval on which expired approvals are failed")
+		promotion = fs.Duration("scheduled-promotion-interval", 10*time.Second, "interval on which scheduled nodes left unpromoted past their delay are made ready")
+		labels    = fs.String("agent-labels", "", "comma separated list of agent labels (e.g. zone=a,gpu=true)")
+		pools     = fs.String("resource-pools", "", "comma separated list of global resource pools and their slots (e.g. db=2,gpu=1)")
+		hang      = fs.Duration("hang-timeout", 0, "duration after which a node whose function stopped sending heartbeats is cancelled (0 disables)")
+		limits    = fs.String("runtime-concurrency", "", "comma separated list of runtimes and the number of their nodes each agent process runs at once (e.g. shell=2)")
+		metrAddr  = fs.String("metrics-address", ":7892", `address on which prometheus metrics are served at "/metrics" (empty disables)`)
+		exporter  = fs.String("trace-exporter", "none", `exporter of trace spans ("none"|"stdout"|"file"|"otlp")`)
+		traceFile = fs.String("trace-file", "adagiod-traces.json", `file to which trace spans are written by the "file" exporter`)
+		otlpAddr  = fs.String("otlp-endpoint", "http://127.0.0.1:4318/v1/traces", `OTLP/HTTP endpoint to which trace spans are sent by the "otlp" exporter`)
+		logFormat = fs.String("log-format", "logfmt", `format of log messages written to stderr ("logfmt"|"json")`)
+		logLevel  = fs.String("log-level", "info", `minimum level of log messages ("debug"|"info"|"warn"|"error")`)
+		lookback  = fs.Duration("webhook-lookback", 24*time.Hour, "window before startup within which runs must have been created for webhooks to be caught up on their events")
+		hookTries = fs.Int("webhook-max-attempts", 5, "number of attempts made to deliver a notification to a webhook before it is marked as failed")
+		tlsCert   = fs.String("tls-cert", "", "PEM certificate file presented by the control plane API (enables TLS)")
+		tlsKey    = fs.String("tls-key", "", "PEM private key file of the control plane API certificate")
+		clientCA  = fs.String("tls-client-ca", "", "PEM CA certificates file by which the client certificates presented to the API are verified (enables mutual TLS)")
+		tokens    = fs.String("auth-tokens", "", `static token file of "token,subject" lines authenticating bearer tokens presented to the API`)
+		jwks      = fs.String("auth-jwks", "", "JSON Web Key Set file of the keys by which JWT bearer tokens presented to the API are verified")
+		issuer    = fs.String("auth-jwt-issuer", "", `required "iss" claim of JWT bearer tokens (empty accepts any)`)
+		audience  = fs.String("auth-jwt-audience", "", `required "aud" claim of JWT bearer tokens (empty accepts any)`)
+		transport = fs.String("auth-transport-subjects", "", "comma separated list of client certificate common names which only authenticate the connection (e.g. that of adagiogw) so calls over it must present a bearer token")
+		served    = fs.String("namespaces", "", "comma separated list of namespaces served by the API (empty serves any namespace, each constructed on its first mutating call)")
+		agentNS   = fs.String("agent-namespaces", adagio.DefaultNamespace, "comma separated list of namespaces whose nodes agents claim")
+		quotas    = fs.String("namespace-run-quotas", "", "comma separated list of namespaces and the number of their runs which can be incomplete at once (e.g. team-a=10)")
+		config    = fs.String("config", "", "location of config toml file (its [rbac] table configures role-based access control)")
 
 		ctxt, cancel     = context.WithCancel(context.Background())
 		runAPI, runAgent = true, true
 
-		repo Repository
-		wg   sync.WaitGroup
+		newRepo     func(namespace string) Repository
+		instruments = metrics.New()
+		wg          sync.WaitGroup
 	)
 
 	fs.Usage = func() {
@@ -57,12 +119,49 @@ func main() {
 
 	ff.Parse(fs, os.Args[1:],
 		ff.WithConfigFileFlag("config"),
-		ff.WithConfigFileParser(fftoml.Parser),
+		ff.WithConfigFileParser(configParser),
 		ff.WithEnvVarPrefix("ADAGIOD"))
 
+	logger, err := logging.New(os.Stderr, *logFormat, *logLevel)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	resourcePools, err := parseCounts(*pools)
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	shutdownTracing, err := setupTracing(logger, *exporter, *traceFile, *otlpAddr)
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	defer shutdownTracing()
+
+	servedNamespaces, err := parseNamespaces(*served)
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	agentNamespaces, err := parseNamespaces(*agentNS)
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	runQuotas, err := parseCounts(*quotas)
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	admission := controlservice.NewAdmission(runQuotas)
+
 	switch *backend {
 	case "memory":
-		repo = memory.New()
+		newRepo = func(namespace string) Repository {
+			return memory.New(memory.WithResourcePools(adagio.ResourcePools(resourcePools)), memory.WithMetrics(instruments), memory.WithLogger(logger.WithField(logging.NamespaceKey, namespace)))
+		}
 	case "etcd":
 		endpoints := strings.Split(*etcdAddrs, ",")
 		cli, err := clientv3.New(clientv3.Config{
@@ -70,15 +169,20 @@ func main() {
 			DialTimeout: 3 * time.Second,
 		})
 		if err != nil {
-			log.Fatal(err)
+			logger.Fatal(err)
 		}
 
-		repo = etcd.New(cli.KV, cli.Watcher, cli.Lease)
+		// each namespace is a separate list of the etcd keyspace
+		newRepo = func(namespace string) Repository {
+			return etcd.New(cli.KV, cli.Watcher, cli.Lease, etcd.ForList(namespace), etcd.WithResourcePools(adagio.ResourcePools(resourcePools)), etcd.WithMetrics(instruments), etcd.WithLogger(logger.WithField(logging.NamespaceKey, namespace)))
+		}
 	default:
 		fmt.Printf("unexpected backend repository type %q expected one of [memory|etcd]\n", *backend)
 		os.Exit(1)
 	}
 
+	repos := newNamespaces(servedNamespaces, newRepo)
+
 	stop := make(chan os.Signal, 1)
 
 	signal.Notify(stop, syscall.SIGTERM, syscall.SIGINT)
@@ -98,12 +202,63 @@ func main() {
 		}
 	}
 
+	if *metrAddr != "" {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			startMetrics(ctxt, logger, *metrAddr, repos, instruments, runAPI)
+		}()
+	}
+
 	if runAPI {
+		var policy *auth.Policy
+		if *config != "" {
+			if policy, err = auth.LoadPolicy(*config); err != nil {
+				logger.Fatal(err)
+			}
+		}
+
+		conf := authConfig{
+			certFile:     *tlsCert,
+			keyFile:      *tlsKey,
+			clientCAFile: *clientCA,
+			tokensFile:   *tokens,
+			jwksFile:     *jwks,
+			issuer:       *issuer,
+			audience:     *audience,
+			transports:   parseList(*transport),
+			policy:       policy,
+		}
+
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
 
-			startAPI(ctxt, repo)
+			// notify the webhooks of each namespace once its repository is constructed
+			repos.OnCreate(func(namespace string, repo Repository) {
+				notifier := notify.New(repo,
+					notify.WithLookback(*lookback),
+					notify.WithMaxAttempts(int32(*hookTries)),
+					notify.WithLogger(logger.WithField(logging.NamespaceKey, namespace)))
+
+				go notifier.Run(ctxt)
+			})
+
+			// construct the served namespaces (or the default namespace given any is served)
+			// up front so their approvals are expired and their webhooks notified from startup
+			eager := servedNamespaces
+			if len(eager) == 0 {
+				eager = []string{adagio.DefaultNamespace}
+			}
+
+			for _, namespace := range eager {
+				if _, err := repos.Get(namespace); err != nil {
+					logger.Fatal(err)
+				}
+			}
+
+			startAPI(ctxt, logger, repos, *expiry, *promotion, conf, controlservice.WithAdmission(admission))
 		}()
 	}
 
@@ -112,30 +267,71 @@ func main() {
 		go func() {
 			defer wg.Done()
 
-			log.Printf("Agent accepting work from %q backend\n", *backend)
+			logger.WithFields(logging.Fields{
+				"backend":    *backend,
+				"namespaces": strings.Join(agentNamespaces, ","),
+			}).Info("agents accepting work")
 
-			startAgents(ctxt, repo)
+			labels, err := parseLabels(*labels)
+			if err != nil {
+				logger.Fatal(err)
+			}
+
+			limits, err := parseCounts(*limits)
+			if err != nil {
+				logger.Fatal(err)
+			}
+
+			var agents sync.WaitGroup
+			for _, namespace := range agentNamespaces {
+				repo, err := repos.Get(namespace)
+				if err != nil {
+					logger.Fatal(err)
+				}
+
+				agents.Add(1)
+				go func(namespace string) {
+					defer agents.Done()
+
+					children := admittedRepository{Repository: repo, admission: admission, namespace: namespace}
+
+					startAgents(ctxt, logger.WithField(logging.NamespaceKey, namespace), repo, children, *workflows, labels, limits, *hang, instruments)
+				}(namespace)
+			}
+
+			agents.Wait()
 		}()
 	}
 
 	wg.Wait()
 }
 
-func startAPI(ctxt context.Context, repo controlservice.Repository) {
+func startAPI(ctxt context.Context, logger logging.Logger, repos controlservice.Namespaces, expiryInterval, promotionInterval time.Duration, conf authConfig, opts ...controlservice.Option) {
+	opts = append(opts, controlservice.WithLogger(logger))
+	if conf.authenticated() {
+		opts = append(opts, controlservice.WithAuthentication())
+	}
+
 	var (
-		service       = controlservice.New(repo)
+		service       = controlservice.New(repos, opts...)
 		addr          = ":7890"
-		grpcServer    = grpc.NewServer()
 		listener, err = net.Listen("tcp", addr)
 	)
 
 	if err != nil {
-		log.Fatal(err)
+		logger.Fatal(err)
 	}
 
+	serverOpts, err := setupAuth(logger, conf, service.Denied)
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	grpcServer := grpc.NewServer(serverOpts...)
+
 	controlplane.RegisterControlPlaneServer(grpcServer, service)
 
-	log.Printf("Control plane listening on %q\n", addr)
+	logger.WithField("address", addr).Info("control plane listening")
 
 	go func() {
 		<-ctxt.Done()
@@ -143,15 +339,204 @@ func startAPI(ctxt context.Context, repo controlservice.Repository) {
 		grpcServer.GracefulStop()
 	}()
 
+	go service.ExpireApprovals(ctxt, expiryInterval)
+	go service.PromoteScheduled(ctxt, promotionInterval)
+
 	if err := grpcServer.Serve(listener); err != nil {
-		log.Println(err)
+		logger.WithError(err).Error("serving control plane")
+	}
+}
+
+// startMetrics serves the prometheus metrics of the process on the provided address
+// The node and run counts of each constructed namespace are only exposed by control plane processes
+func startMetrics(ctxt context.Context, logger logging.Logger, addr string, repos *namespaces, m *metrics.Metrics, stats bool) {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(prometheus.NewGoCollector(), prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}))
+
+	if err := m.Register(registry); err != nil {
+		logger.Fatal(err)
+	}
+
+	if stats {
+		repos.OnCreate(func(namespace string, repo Repository) {
+			collector := metrics.NewStatsCollector(repo, prometheus.Labels{"namespace": namespace})
+			if err := registry.Register(collector); err != nil {
+				logger.WithError(err).WithField(logging.NamespaceKey, namespace).Error("registering stats")
+			}
+		})
+	}
+
+	mux := nethttp.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+
+	server := &nethttp.Server{Addr: addr, Handler: mux}
+
+	go func() {
+		<-ctxt.Done()
+
+		server.Shutdown(context.Background())
+	}()
+
+	logger.WithField("address", addr).Info("metrics listening")
+
+	if err := server.ListenAndServe(); err != nil && err != nethttp.ErrServerClosed {
+		logger.WithError(err).Error("serving metrics")
+	}
+}
+
+// setupTracing registers a global tracer provider which sends spans to the named
+// exporter and returns a function which flushes any pending spans on shutdown
+func setupTracing(logger logging.Logger, exporter, file, endpoint string) (func(), error) {
+	var spans sdktrace.SpanExporter
+
+	switch exporter {
+	case "none":
+		return func() {}, nil
+	case "stdout":
+		exp, err := stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+		if err != nil {
+			return nil, err
+		}
+
+		spans = exp
+	case "file":
+		fi, err := os.OpenFile(file, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			return nil, err
+		}
+
+		exp, err := stdouttrace.New(stdouttrace.WithWriter(fi))
+		if err != nil {
+			return nil, err
+		}
+
+		spans = exp
+	case "otlp":
+		spans = tracing.NewOTLPExporter(endpoint)
+	default:
+		return nil, fmt.Errorf("unexpected trace exporter %q expected one of [none|stdout|file|otlp]", exporter)
 	}
+
+	provider := tracing.NewProvider("adagiod", spans)
+
+	otel.SetTracerProvider(provider)
+
+	logger.WithField("exporter", exporter).Info("exporting traces")
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := provider.Shutdown(ctx); err != nil {
+			logger.WithError(err).Error("shutting down tracing")
+		}
+	}, nil
 }
 
-func startAgents(ctxt context.Context, repo agent.Repository) {
+func startAgents(ctxt context.Context, logger logging.Logger, repo Repository, children workflow.Repository, workflowsDir string, labels map[string]string, limits map[string]int, hangTimeout time.Duration, m *metrics.Metrics) {
+	workflowOpts, err := loadWorkflows(workflowsDir)
+	if err != nil {
+		logger.Fatal(err)
+	}
+
 	runtimes := agent.RuntimeMap{}
 	runtimes.Register(exec.Runtime())
 	runtimes.Register(debug.Runtime())
+	runtimes.Register(shell.Runtime())
+	runtimes.Register(http.Runtime())
+	runtimes.Register(workflow.Runtime(children, workflowOpts...))
+	runtimes.Register(sensor.Runtime(repo))
+
+	opts := []agent.Option{agent.WithAgentCount(5), agent.WithLabels(labels), agent.WithHangTimeout(hangTimeout), agent.WithMetrics(m), agent.WithLogger(logger)}
+	for runtime, limit := range limits {
+		opts = append(opts, agent.WithRuntimeConcurrency(runtime, limit))
+	}
+
+	agent.NewPool(repo, runtimes, opts...).Run(ctxt)
+}
+
+// parseLabels parses a comma separated list of key=value pairs into a map of labels
+func parseLabels(v string) (map[string]string, error) {
+	labels := map[string]string{}
+	if v == "" {
+		return labels, nil
+	}
+
+	for _, pair := range strings.Split(v, ",") {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) < 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("malformed agent label %q expected key=value", pair)
+		}
+
+		labels[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+
+	return labels, nil
+}
+
+// parseList parses a comma separated list of names ignoring empty names
+func parseList(v string) (names []string) {
+	for _, name := range strings.Split(v, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	return
+}
+
+// parseCounts parses a comma separated list of name=count pairs into a map of counts
+func parseCounts(v string) (map[string]int, error) {
+	counts := map[string]int{}
+	if v == "" {
+		return counts, nil
+	}
+
+	for _, pair := range strings.Split(v, ",") {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) < 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("malformed count %q expected name=count", pair)
+		}
+
+		count, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err != nil || count < 1 {
+			return nil, fmt.Errorf("malformed count %q expected a positive integer", pair)
+		}
+
+		counts[strings.TrimSpace(parts[0])] = count
+	}
+
+	return counts, nil
+}
+
+// loadWorkflows parses each json graph specification file in the provided
+// directory and registers it as a workflow named after the file
+func loadWorkflows(dir string) (opts []workflow.RuntimeOption, err error) {
+	if dir == "" {
+		return nil, nil
+	}
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, path := range paths {
+		fi, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+
+		var spec adagio.GraphSpec
+		err = json.NewDecoder(fi).Decode(&spec)
+		fi.Close()
+		if err != nil {
+			return nil, fmt.Errorf("workflow %q: %w", path, err)
+		}
+
+		name := strings.TrimSuffix(filepath.Base(path), ".json")
+		opts = append(opts, workflow.WithWorkflow(name, &spec))
+	}
 
-	agent.NewPool(repo, runtimes, agent.WithAgentCount(5)).Run(ctxt)
+	return
 }
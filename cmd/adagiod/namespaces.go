@@ -0,0 +1,173 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/georgemac/adagio/pkg/adagio"
+	controlservice "github.com/georgemac/adagio/pkg/service/controlplane"
+)
+
+var _ controlservice.Namespaces = (*namespaces)(nil)
+
+// namespaces constructs the repository of each namespace on its first mutating use
+// and caches it for the lifetime of the process
+type namespaces struct {
+	// namespaces served by the control plane API (empty serves any)
+	served  map[string]bool
+	newRepo func(namespace string) Repository
+
+	mu    sync.Mutex
+	repos map[string]Repository
+	// repositories of namespaces which have only been read
+	lookups map[string]Repository
+	created []func(namespace string, repo Repository)
+}
+
+func newNamespaces(served []string, newRepo func(namespace string) Repository) *namespaces {
+	n := &namespaces{
+		served:  map[string]bool{},
+		newRepo: newRepo,
+		repos:   map[string]Repository{},
+		lookups: map[string]Repository{},
+	}
+
+	for _, namespace := range served {
+		n.served[namespace] = true
+	}
+
+	return n
+}
+
+// Get returns the repository of the namespace constructing it given it does not yet exist
+func (n *namespaces) Get(namespace string) (Repository, error) {
+	if err := adagio.ValidateNamespace(namespace); err != nil {
+		return nil, err
+	}
+
+	n.mu.Lock()
+
+	repo, ok := n.repos[namespace]
+	if ok {
+		n.mu.Unlock()
+		return repo, nil
+	}
+
+	// a repository constructed to serve reads is reused
+	repo, ok = n.lookups[namespace]
+	if ok {
+		delete(n.lookups, namespace)
+	} else {
+		repo = n.newRepo(namespace)
+	}
+
+	n.repos[namespace] = repo
+
+	created := n.created
+
+	n.mu.Unlock()
+
+	for _, fn := range created {
+		fn(namespace, repo)
+	}
+
+	return repo, nil
+}
+
+// Repository returns the repository of a namespace served by the control plane API
+func (n *namespaces) Repository(namespace string) (controlservice.Repository, error) {
+	if err := n.checkServed(namespace); err != nil {
+		return nil, err
+	}
+
+	return n.Get(namespace)
+}
+
+// Lookup returns the repository of a namespace served by the control plane API
+// Given it has not been constructed a repository is constructed and cached separately,
+// so that read-only calls do not construct namespaces (nor notify their webhooks).
+// It becomes the repository of the namespace on its first mutating use
+func (n *namespaces) Lookup(namespace string) (controlservice.Repository, error) {
+	if err := n.checkServed(namespace); err != nil {
+		return nil, err
+	}
+
+	if err := adagio.ValidateNamespace(namespace); err != nil {
+		return nil, err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if repo, ok := n.repos[namespace]; ok {
+		return repo, nil
+	}
+
+	repo, ok := n.lookups[namespace]
+	if !ok {
+		repo = n.newRepo(namespace)
+		n.lookups[namespace] = repo
+	}
+
+	return repo, nil
+}
+
+func (n *namespaces) checkServed(namespace string) error {
+	if len(n.served) > 0 && !n.served[namespace] {
+		return fmt.Errorf("%w: %q", adagio.ErrNamespaceNotServed, namespace)
+	}
+
+	return nil
+}
+
+// Namespaces returns the sorted names of the namespaces whose repositories have been constructed
+func (n *namespaces) Namespaces() (names []string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for namespace := range n.repos {
+		names = append(names, namespace)
+	}
+
+	sort.Strings(names)
+
+	return
+}
+
+// OnCreate calls fn with the repository of each namespace which has been
+// constructed and with each one constructed from now on
+func (n *namespaces) OnCreate(fn func(namespace string, repo Repository)) {
+	n.mu.Lock()
+
+	n.created = append(n.created, fn)
+
+	existing := make(map[string]Repository, len(n.repos))
+	for namespace, repo := range n.repos {
+		existing[namespace] = repo
+	}
+
+	n.mu.Unlock()
+
+	for namespace, repo := range existing {
+		fn(namespace, repo)
+	}
+}
+
+// parseNamespaces parses a comma separated list of namespaces
+func parseNamespaces(v string) (names []string, err error) {
+	for _, namespace := range strings.Split(v, ",") {
+		if namespace = strings.TrimSpace(namespace); namespace == "" {
+			continue
+		}
+
+		if err := adagio.ValidateNamespace(namespace); err != nil {
+			return nil, err
+		}
+
+		names = append(names, namespace)
+	}
+
+	return
+}
@@ -5,14 +5,27 @@ import (
 	"flag"
 	"log"
 	"net/http"
+	"strings"
 
+	"github.com/georgemac/adagio/pkg/auth"
 	"github.com/georgemac/adagio/pkg/rpc/controlplane"
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 var (
-	adagiodAddr = flag.String("adagiod-addr", "localhost:7890", "gRPC server endpoint")
+	addr            = flag.String("addr", ":7891", "address on which the gateway listens")
+	adagiodAddr     = flag.String("adagiod-addr", "localhost:7890", "gRPC server endpoint")
+	adagiodTLS      = flag.Bool("adagiod-tls", false, "dial the gRPC server endpoint using TLS")
+	adagiodCA       = flag.String("adagiod-ca", "", "PEM CA certificates file by which the gRPC server is verified (system roots when empty)")
+	adagiodCert     = flag.String("adagiod-cert", "", "PEM client certificate file presented to the gRPC server (mutual TLS)")
+	adagiodKey      = flag.String("adagiod-key", "", "PEM private key file of the client certificate presented to the gRPC server")
+	adagiodHostname = flag.String("adagiod-server-name", "", "name by which the gRPC server certificate is verified (host of the endpoint when empty)")
+	tlsCert         = flag.String("tls-cert", "", "PEM certificate file presented by the gateway (enables TLS)")
+	tlsKey          = flag.String("tls-key", "", "PEM private key file of the gateway certificate")
+	tlsClientCA     = flag.String("tls-client-ca", "", "PEM CA certificates file by which client certificates presented to the gateway are verified (enables mutual TLS)")
+	corsOrigins     = flag.String("cors-origins", "", "comma separated list of origins allowed to make cross-origin requests (e.g. http://localhost:8080)")
 )
 
 func run() error {
@@ -25,21 +38,67 @@ func run() error {
 		opts = []grpc.DialOption{grpc.WithInsecure()}
 	)
 
+	if *adagiodTLS || *adagiodCA != "" || *adagiodCert != "" {
+		config, err := auth.ClientTLSConfig(*adagiodCA, *adagiodCert, *adagiodKey, *adagiodHostname)
+		if err != nil {
+			return err
+		}
+
+		opts = []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(config))}
+	}
+
 	if err := controlplane.RegisterControlPlaneHandlerFromEndpoint(ctx, mux, *adagiodAddr, opts); err != nil {
 		return err
 	}
 
-	return http.ListenAndServe(":7891", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, PUT, PATCH, POST, DELETE")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+	server := &http.Server{
+		Addr:    *addr,
+		Handler: cors(parseOrigins(*corsOrigins), mux),
+	}
+
+	if *tlsCert != "" || *tlsKey != "" || *tlsClientCA != "" {
+		config, err := auth.ServerTLSConfig(*tlsCert, *tlsKey, *tlsClientCA)
+		if err != nil {
+			return err
+		}
+
+		server.TLSConfig = config
+
+		return server.ListenAndServeTLS("", "")
+	}
+
+	return server.ListenAndServe()
+}
+
+// cors wraps the handler and permits cross-origin requests from the allowed origins
+// Bearer tokens are forwarded to the gRPC server from the "Authorization" header
+func cors(allowed map[string]bool, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if origin := r.Header.Get("Origin"); origin != "" && (allowed["*"] || allowed[origin]) {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Access-Control-Allow-Methods", "GET, PUT, PATCH, POST, DELETE")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.Header().Add("Vary", "Origin")
+		}
+
 		if r.Method == "OPTIONS" {
 			w.WriteHeader(http.StatusOK)
 			return
 		}
 
-		mux.ServeHTTP(w, r)
-	}))
+		handler.ServeHTTP(w, r)
+	})
+}
+
+func parseOrigins(v string) map[string]bool {
+	origins := map[string]bool{}
+	for _, origin := range strings.Split(v, ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			origins[origin] = true
+		}
+	}
+
+	return origins
 }
 
 func main() {
@@ -53,16 +53,20 @@ type Event_Type int32
 const (
 	Event_NODE_READY    Event_Type = 0
 	Event_NODE_ORPHANED Event_Type = 1
+	// the state of a node of the run changed (only the run ID is set)
+	Event_RUN_UPDATED Event_Type = 2
 )
 
 var Event_Type_name = map[int32]string{
 	0: "NODE_READY",
 	1: "NODE_ORPHANED",
+	2: "RUN_UPDATED",
 }
 
 var Event_Type_value = map[string]int32{
 	"NODE_READY":    0,
 	"NODE_ORPHANED": 1,
+	"RUN_UPDATED":   2,
 }
 
 func (x Event_Type) String() string {
@@ -81,6 +85,9 @@ const (
 	Node_READY     Node_Status = 2
 	Node_RUNNING   Node_Status = 3
 	Node_COMPLETED Node_Status = 4
+	Node_SKIPPED   Node_Status = 5
+	// awaiting the time it is not to be claimed before, after which it becomes ready
+	Node_SCHEDULED Node_Status = 6
 )
 
 var Node_Status_name = map[int32]string{
@@ -89,6 +96,8 @@ var Node_Status_name = map[int32]string{
 	2: "READY",
 	3: "RUNNING",
 	4: "COMPLETED",
+	5: "SKIPPED",
+	6: "SCHEDULED",
 }
 
 var Node_Status_value = map[string]int32{
@@ -97,6 +106,8 @@ var Node_Status_value = map[string]int32{
 	"READY":     2,
 	"RUNNING":   3,
 	"COMPLETED": 4,
+	"SKIPPED":   5,
+	"SCHEDULED": 6,
 }
 
 func (x Node_Status) String() string {
@@ -107,6 +118,37 @@ func (Node_Status) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_5eb97351c0f66fbe, []int{4, 0}
 }
 
+type Node_Spec_Selector_Requirement_Operator int32
+
+const (
+	Node_Spec_Selector_Requirement_IN             Node_Spec_Selector_Requirement_Operator = 0
+	Node_Spec_Selector_Requirement_NOT_IN         Node_Spec_Selector_Requirement_Operator = 1
+	Node_Spec_Selector_Requirement_EXISTS         Node_Spec_Selector_Requirement_Operator = 2
+	Node_Spec_Selector_Requirement_DOES_NOT_EXIST Node_Spec_Selector_Requirement_Operator = 3
+)
+
+var Node_Spec_Selector_Requirement_Operator_name = map[int32]string{
+	0: "IN",
+	1: "NOT_IN",
+	2: "EXISTS",
+	3: "DOES_NOT_EXIST",
+}
+
+var Node_Spec_Selector_Requirement_Operator_value = map[string]int32{
+	"IN":             0,
+	"NOT_IN":         1,
+	"EXISTS":         2,
+	"DOES_NOT_EXIST": 3,
+}
+
+func (x Node_Spec_Selector_Requirement_Operator) String() string {
+	return proto.EnumName(Node_Spec_Selector_Requirement_Operator_name, int32(x))
+}
+
+func (Node_Spec_Selector_Requirement_Operator) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_5eb97351c0f66fbe, []int{4, 0, 3, 0, 0}
+}
+
 type Node_Result_Conclusion int32
 
 const (
@@ -138,6 +180,49 @@ func (Node_Result_Conclusion) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_5eb97351c0f66fbe, []int{4, 1, 0}
 }
 
+type Node_Transition_Type int32
+
+const (
+	Node_Transition_NONE        Node_Transition_Type = 0
+	Node_Transition_READY       Node_Transition_Type = 1
+	Node_Transition_CLAIMED     Node_Transition_Type = 2
+	Node_Transition_RESCHEDULED Node_Transition_Type = 3
+	Node_Transition_FINISHED    Node_Transition_Type = 4
+	Node_Transition_ORPHANED    Node_Transition_Type = 5
+	Node_Transition_RETRIED     Node_Transition_Type = 6
+	Node_Transition_SKIPPED     Node_Transition_Type = 7
+)
+
+var Node_Transition_Type_name = map[int32]string{
+	0: "NONE",
+	1: "READY",
+	2: "CLAIMED",
+	3: "RESCHEDULED",
+	4: "FINISHED",
+	5: "ORPHANED",
+	6: "RETRIED",
+	7: "SKIPPED",
+}
+
+var Node_Transition_Type_value = map[string]int32{
+	"NONE":        0,
+	"READY":       1,
+	"CLAIMED":     2,
+	"RESCHEDULED": 3,
+	"FINISHED":    4,
+	"ORPHANED":    5,
+	"RETRIED":     6,
+	"SKIPPED":     7,
+}
+
+func (x Node_Transition_Type) String() string {
+	return proto.EnumName(Node_Transition_Type_name, int32(x))
+}
+
+func (Node_Transition_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_5eb97351c0f66fbe, []int{4, 3, 0}
+}
+
 type Result_Conclusion int32
 
 const (
@@ -166,15 +251,115 @@ func (Result_Conclusion) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_5eb97351c0f66fbe, []int{6, 0}
 }
 
+type Webhook_Event int32
+
+const (
+	Webhook_NONE               Webhook_Event = 0
+	Webhook_RUN_FAILED         Webhook_Event = 1
+	Webhook_RUN_SUCCEEDED      Webhook_Event = 2
+	Webhook_NODE_RETRIED       Webhook_Event = 3
+	Webhook_APPROVAL_REQUESTED Webhook_Event = 4
+)
+
+var Webhook_Event_name = map[int32]string{
+	0: "NONE",
+	1: "RUN_FAILED",
+	2: "RUN_SUCCEEDED",
+	3: "NODE_RETRIED",
+	4: "APPROVAL_REQUESTED",
+}
+
+var Webhook_Event_value = map[string]int32{
+	"NONE":               0,
+	"RUN_FAILED":         1,
+	"RUN_SUCCEEDED":      2,
+	"NODE_RETRIED":       3,
+	"APPROVAL_REQUESTED": 4,
+}
+
+func (x Webhook_Event) String() string {
+	return proto.EnumName(Webhook_Event_name, int32(x))
+}
+
+func (Webhook_Event) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_5eb97351c0f66fbe, []int{10, 0}
+}
+
+type Delivery_Status int32
+
+const (
+	Delivery_PENDING   Delivery_Status = 0
+	Delivery_DELIVERED Delivery_Status = 1
+	Delivery_FAILED    Delivery_Status = 2
+)
+
+var Delivery_Status_name = map[int32]string{
+	0: "PENDING",
+	1: "DELIVERED",
+	2: "FAILED",
+}
+
+var Delivery_Status_value = map[string]int32{
+	"PENDING":   0,
+	"DELIVERED": 1,
+	"FAILED":    2,
+}
+
+func (x Delivery_Status) String() string {
+	return proto.EnumName(Delivery_Status_name, int32(x))
+}
+
+func (Delivery_Status) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_5eb97351c0f66fbe, []int{12, 0}
+}
+
+type AuditEvent_Outcome int32
+
+const (
+	AuditEvent_NONE      AuditEvent_Outcome = 0
+	AuditEvent_SUCCEEDED AuditEvent_Outcome = 1
+	AuditEvent_FAILED    AuditEvent_Outcome = 2
+	// the caller does not hold the role required to make the call
+	AuditEvent_DENIED AuditEvent_Outcome = 3
+)
+
+var AuditEvent_Outcome_name = map[int32]string{
+	0: "NONE",
+	1: "SUCCEEDED",
+	2: "FAILED",
+	3: "DENIED",
+}
+
+var AuditEvent_Outcome_value = map[string]int32{
+	"NONE":      0,
+	"SUCCEEDED": 1,
+	"FAILED":    2,
+	"DENIED":    3,
+}
+
+func (x AuditEvent_Outcome) String() string {
+	return proto.EnumName(AuditEvent_Outcome_name, int32(x))
+}
+
+func (AuditEvent_Outcome) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_5eb97351c0f66fbe, []int{13, 0}
+}
+
 type Run struct {
-	Id                   string     `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	CreatedAt            string     `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	Nodes                []*Node    `protobuf:"bytes,3,rep,name=nodes,proto3" json:"nodes,omitempty"`
-	Edges                []*Edge    `protobuf:"bytes,4,rep,name=edges,proto3" json:"edges,omitempty"`
-	Status               Run_Status `protobuf:"varint,5,opt,name=status,proto3,enum=adagio.Run_Status" json:"status,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+	Id             string      `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CreatedAt      string      `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Nodes          []*Node     `protobuf:"bytes,3,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	Edges          []*Edge     `protobuf:"bytes,4,rep,name=edges,proto3" json:"edges,omitempty"`
+	Status         Run_Status  `protobuf:"varint,5,opt,name=status,proto3,enum=adagio.Run_Status" json:"status,omitempty"`
+	Parent         *Run_Link   `protobuf:"bytes,6,opt,name=parent,proto3" json:"parent,omitempty"`
+	Children       []*Run_Link `protobuf:"bytes,7,rep,name=children,proto3" json:"children,omitempty"`
+	MaxParallelism int32       `protobuf:"varint,8,opt,name=max_parallelism,json=maxParallelism,proto3" json:"max_parallelism,omitempty"`
+	Priority       int32       `protobuf:"varint,9,opt,name=priority,proto3" json:"priority,omitempty"`
+	// W3C trace context of the root span of the run
+	TraceContext         map[string]string `protobuf:"bytes,10,rep,name=trace_context,json=traceContext,proto3" json:"trace_context,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *Run) Reset()         { *m = Run{} }
@@ -237,13 +422,99 @@ func (m *Run) GetStatus() Run_Status {
 	return Run_WAITING
 }
 
+func (m *Run) GetParent() *Run_Link {
+	if m != nil {
+		return m.Parent
+	}
+	return nil
+}
+
+func (m *Run) GetChildren() []*Run_Link {
+	if m != nil {
+		return m.Children
+	}
+	return nil
+}
+
+func (m *Run) GetMaxParallelism() int32 {
+	if m != nil {
+		return m.MaxParallelism
+	}
+	return 0
+}
+
+func (m *Run) GetPriority() int32 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
+func (m *Run) GetTraceContext() map[string]string {
+	if m != nil {
+		return m.TraceContext
+	}
+	return nil
+}
+
+type Run_Link struct {
+	RunId                string   `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	Node                 string   `protobuf:"bytes,2,opt,name=node,proto3" json:"node,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Run_Link) Reset()         { *m = Run_Link{} }
+func (m *Run_Link) String() string { return proto.CompactTextString(m) }
+func (*Run_Link) ProtoMessage()    {}
+func (*Run_Link) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5eb97351c0f66fbe, []int{0, 0}
+}
+
+func (m *Run_Link) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Run_Link.Unmarshal(m, b)
+}
+func (m *Run_Link) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Run_Link.Marshal(b, m, deterministic)
+}
+func (m *Run_Link) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Run_Link.Merge(m, src)
+}
+func (m *Run_Link) XXX_Size() int {
+	return xxx_messageInfo_Run_Link.Size(m)
+}
+func (m *Run_Link) XXX_DiscardUnknown() {
+	xxx_messageInfo_Run_Link.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Run_Link proto.InternalMessageInfo
+
+func (m *Run_Link) GetRunId() string {
+	if m != nil {
+		return m.RunId
+	}
+	return ""
+}
+
+func (m *Run_Link) GetNode() string {
+	if m != nil {
+		return m.Node
+	}
+	return ""
+}
+
 type Event struct {
-	Type                 Event_Type `protobuf:"varint,1,opt,name=type,proto3,enum=adagio.Event_Type" json:"type,omitempty"`
-	RunID                string     `protobuf:"bytes,2,opt,name=runID,proto3" json:"runID,omitempty"`
-	NodeSpec             *Node_Spec `protobuf:"bytes,3,opt,name=nodeSpec,proto3" json:"nodeSpec,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+	Type     Event_Type `protobuf:"varint,1,opt,name=type,proto3,enum=adagio.Event_Type" json:"type,omitempty"`
+	RunID    string     `protobuf:"bytes,2,opt,name=runID,proto3" json:"runID,omitempty"`
+	NodeSpec *Node_Spec `protobuf:"bytes,3,opt,name=nodeSpec,proto3" json:"nodeSpec,omitempty"`
+	// effective priority of the node (see Run and Node.Spec priority)
+	Priority int32 `protobuf:"varint,4,opt,name=priority,proto3" json:"priority,omitempty"`
+	// W3C trace context of the root span of the run (see Run trace_context)
+	TraceContext         map[string]string `protobuf:"bytes,5,rep,name=trace_context,json=traceContext,proto3" json:"trace_context,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *Event) Reset()         { *m = Event{} }
@@ -292,12 +563,30 @@ func (m *Event) GetNodeSpec() *Node_Spec {
 	return nil
 }
 
+func (m *Event) GetPriority() int32 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
+func (m *Event) GetTraceContext() map[string]string {
+	if m != nil {
+		return m.TraceContext
+	}
+	return nil
+}
+
 type GraphSpec struct {
-	Nodes                []*Node_Spec `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
-	Edges                []*Edge      `protobuf:"bytes,2,rep,name=edges,proto3" json:"edges,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+	Nodes []*Node_Spec `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	Edges []*Edge      `protobuf:"bytes,2,rep,name=edges,proto3" json:"edges,omitempty"`
+	// maximum number of nodes of the run which can be running at once (0 is unlimited)
+	MaxParallelism int32 `protobuf:"varint,3,opt,name=max_parallelism,json=maxParallelism,proto3" json:"max_parallelism,omitempty"`
+	// priority of runs of the graph where ready nodes of higher priority runs are preferred by agents
+	Priority             int32    `protobuf:"varint,4,opt,name=priority,proto3" json:"priority,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *GraphSpec) Reset()         { *m = GraphSpec{} }
@@ -339,6 +628,20 @@ func (m *GraphSpec) GetEdges() []*Edge {
 	return nil
 }
 
+func (m *GraphSpec) GetMaxParallelism() int32 {
+	if m != nil {
+		return m.MaxParallelism
+	}
+	return 0
+}
+
+func (m *GraphSpec) GetPriority() int32 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
 type MetadataValue struct {
 	Values               []string `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -379,16 +682,20 @@ func (m *MetadataValue) GetValues() []string {
 }
 
 type Node struct {
-	Spec                 *Node_Spec        `protobuf:"bytes,1,opt,name=spec,proto3" json:"spec,omitempty"`
-	Status               Node_Status       `protobuf:"varint,2,opt,name=status,proto3,enum=adagio.Node_Status" json:"status,omitempty"`
-	Attempts             []*Node_Result    `protobuf:"bytes,3,rep,name=attempts,proto3" json:"attempts,omitempty"`
-	StartedAt            string            `protobuf:"bytes,4,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
-	FinishedAt           string            `protobuf:"bytes,5,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
-	Inputs               map[string][]byte `protobuf:"bytes,6,rep,name=inputs,proto3" json:"inputs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	Claim                *Claim            `protobuf:"bytes,7,opt,name=claim,proto3" json:"claim,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	Spec                 *Node_Spec         `protobuf:"bytes,1,opt,name=spec,proto3" json:"spec,omitempty"`
+	Status               Node_Status        `protobuf:"varint,2,opt,name=status,proto3,enum=adagio.Node_Status" json:"status,omitempty"`
+	Attempts             []*Node_Result     `protobuf:"bytes,3,rep,name=attempts,proto3" json:"attempts,omitempty"`
+	StartedAt            string             `protobuf:"bytes,4,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt           string             `protobuf:"bytes,5,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	Inputs               map[string][]byte  `protobuf:"bytes,6,rep,name=inputs,proto3" json:"inputs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Claim                *Claim             `protobuf:"bytes,7,opt,name=claim,proto3" json:"claim,omitempty"`
+	NotBefore            string             `protobuf:"bytes,8,opt,name=not_before,json=notBefore,proto3" json:"not_before,omitempty"`
+	ReadyAt              string             `protobuf:"bytes,9,opt,name=ready_at,json=readyAt,proto3" json:"ready_at,omitempty"`
+	Progress             *Node_Progress     `protobuf:"bytes,10,opt,name=progress,proto3" json:"progress,omitempty"`
+	History              []*Node_Transition `protobuf:"bytes,11,rep,name=history,proto3" json:"history,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
 func (m *Node) Reset()         { *m = Node{} }
@@ -465,14 +772,49 @@ func (m *Node) GetClaim() *Claim {
 	return nil
 }
 
+func (m *Node) GetNotBefore() string {
+	if m != nil {
+		return m.NotBefore
+	}
+	return ""
+}
+
+func (m *Node) GetReadyAt() string {
+	if m != nil {
+		return m.ReadyAt
+	}
+	return ""
+}
+
+func (m *Node) GetProgress() *Node_Progress {
+	if m != nil {
+		return m.Progress
+	}
+	return nil
+}
+
+func (m *Node) GetHistory() []*Node_Transition {
+	if m != nil {
+		return m.History
+	}
+	return nil
+}
+
 type Node_Spec struct {
-	Name                 string                      `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Runtime              string                      `protobuf:"bytes,2,opt,name=runtime,proto3" json:"runtime,omitempty"`
-	Metadata             map[string]*MetadataValue   `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	Retry                map[string]*Node_Spec_Retry `protobuf:"bytes,4,rep,name=retry,proto3" json:"retry,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
-	XXX_unrecognized     []byte                      `json:"-"`
-	XXX_sizecache        int32                       `json:"-"`
+	Name     string                      `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Runtime  string                      `protobuf:"bytes,2,opt,name=runtime,proto3" json:"runtime,omitempty"`
+	Metadata map[string]*MetadataValue   `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Retry    map[string]*Node_Spec_Retry `protobuf:"bytes,4,rep,name=retry,proto3" json:"retry,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Map      *Node_Spec_Map              `protobuf:"bytes,5,opt,name=map,proto3" json:"map,omitempty"`
+	Approval *Node_Spec_Approval         `protobuf:"bytes,6,opt,name=approval,proto3" json:"approval,omitempty"`
+	Selector *Node_Spec_Selector         `protobuf:"bytes,7,opt,name=selector,proto3" json:"selector,omitempty"`
+	// number of slots required from each named global resource pool
+	Resources map[string]int32 `protobuf:"bytes,8,rep,name=resources,proto3" json:"resources,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// priority relative to the other nodes, added to the priority of the run
+	Priority             int32    `protobuf:"varint,9,opt,name=priority,proto3" json:"priority,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Node_Spec) Reset()         { *m = Node_Spec{} }
@@ -528,8 +870,51 @@ func (m *Node_Spec) GetRetry() map[string]*Node_Spec_Retry {
 	return nil
 }
 
+func (m *Node_Spec) GetMap() *Node_Spec_Map {
+	if m != nil {
+		return m.Map
+	}
+	return nil
+}
+
+func (m *Node_Spec) GetApproval() *Node_Spec_Approval {
+	if m != nil {
+		return m.Approval
+	}
+	return nil
+}
+
+func (m *Node_Spec) GetSelector() *Node_Spec_Selector {
+	if m != nil {
+		return m.Selector
+	}
+	return nil
+}
+
+func (m *Node_Spec) GetResources() map[string]int32 {
+	if m != nil {
+		return m.Resources
+	}
+	return nil
+}
+
+func (m *Node_Spec) GetPriority() int32 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
 type Node_Spec_Retry struct {
-	MaxAttempts          int32    `protobuf:"varint,1,opt,name=max_attempts,json=maxAttempts,proto3" json:"max_attempts,omitempty"`
+	MaxAttempts int32 `protobuf:"varint,1,opt,name=max_attempts,json=maxAttempts,proto3" json:"max_attempts,omitempty"`
+	// delay before the first retry (e.g. "1s")
+	InitialDelay string `protobuf:"bytes,2,opt,name=initial_delay,json=initialDelay,proto3" json:"initial_delay,omitempty"`
+	// factor applied to the delay for each subsequent retry (defaults to 1)
+	Multiplier float64 `protobuf:"fixed64,3,opt,name=multiplier,proto3" json:"multiplier,omitempty"`
+	// upper bound on the delay between retries (e.g. "1m")
+	MaxDelay string `protobuf:"bytes,4,opt,name=max_delay,json=maxDelay,proto3" json:"max_delay,omitempty"`
+	// fraction (0-1) by which each delay is randomly reduced
+	Jitter               float64  `protobuf:"fixed64,5,opt,name=jitter,proto3" json:"jitter,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -567,262 +952,1303 @@ func (m *Node_Spec_Retry) GetMaxAttempts() int32 {
 	return 0
 }
 
-type Node_Result struct {
-	Conclusion           Node_Result_Conclusion    `protobuf:"varint,1,opt,name=conclusion,proto3,enum=adagio.Node_Result_Conclusion" json:"conclusion,omitempty"`
-	Metadata             map[string]*MetadataValue `protobuf:"bytes,2,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	Output               []byte                    `protobuf:"bytes,3,opt,name=output,proto3" json:"output,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
-	XXX_unrecognized     []byte                    `json:"-"`
-	XXX_sizecache        int32                     `json:"-"`
-}
-
-func (m *Node_Result) Reset()         { *m = Node_Result{} }
-func (m *Node_Result) String() string { return proto.CompactTextString(m) }
-func (*Node_Result) ProtoMessage()    {}
-func (*Node_Result) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5eb97351c0f66fbe, []int{4, 1}
-}
-
-func (m *Node_Result) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Node_Result.Unmarshal(m, b)
-}
-func (m *Node_Result) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Node_Result.Marshal(b, m, deterministic)
-}
-func (m *Node_Result) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Node_Result.Merge(m, src)
-}
-func (m *Node_Result) XXX_Size() int {
-	return xxx_messageInfo_Node_Result.Size(m)
-}
-func (m *Node_Result) XXX_DiscardUnknown() {
-	xxx_messageInfo_Node_Result.DiscardUnknown(m)
+func (m *Node_Spec_Retry) GetInitialDelay() string {
+	if m != nil {
+		return m.InitialDelay
+	}
+	return ""
 }
 
-var xxx_messageInfo_Node_Result proto.InternalMessageInfo
-
-func (m *Node_Result) GetConclusion() Node_Result_Conclusion {
+func (m *Node_Spec_Retry) GetMultiplier() float64 {
 	if m != nil {
-		return m.Conclusion
+		return m.Multiplier
 	}
-	return Node_Result_NONE
+	return 0
 }
 
-func (m *Node_Result) GetMetadata() map[string]*MetadataValue {
+func (m *Node_Spec_Retry) GetMaxDelay() string {
 	if m != nil {
-		return m.Metadata
+		return m.MaxDelay
 	}
-	return nil
+	return ""
 }
 
-func (m *Node_Result) GetOutput() []byte {
+func (m *Node_Spec_Retry) GetJitter() float64 {
 	if m != nil {
-		return m.Output
+		return m.Jitter
 	}
-	return nil
+	return 0
 }
 
-type Edge struct {
-	Source               string   `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
-	Destination          string   `protobuf:"bytes,2,opt,name=destination,proto3" json:"destination,omitempty"`
+type Node_Spec_Map struct {
+	Over                 string   `protobuf:"bytes,1,opt,name=over,proto3" json:"over,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Edge) Reset()         { *m = Edge{} }
-func (m *Edge) String() string { return proto.CompactTextString(m) }
-func (*Edge) ProtoMessage()    {}
-func (*Edge) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5eb97351c0f66fbe, []int{5}
+func (m *Node_Spec_Map) Reset()         { *m = Node_Spec_Map{} }
+func (m *Node_Spec_Map) String() string { return proto.CompactTextString(m) }
+func (*Node_Spec_Map) ProtoMessage()    {}
+func (*Node_Spec_Map) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5eb97351c0f66fbe, []int{4, 0, 1}
 }
 
-func (m *Edge) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Edge.Unmarshal(m, b)
+func (m *Node_Spec_Map) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Node_Spec_Map.Unmarshal(m, b)
 }
-func (m *Edge) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Edge.Marshal(b, m, deterministic)
+func (m *Node_Spec_Map) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Node_Spec_Map.Marshal(b, m, deterministic)
 }
-func (m *Edge) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Edge.Merge(m, src)
+func (m *Node_Spec_Map) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Node_Spec_Map.Merge(m, src)
 }
-func (m *Edge) XXX_Size() int {
-	return xxx_messageInfo_Edge.Size(m)
+func (m *Node_Spec_Map) XXX_Size() int {
+	return xxx_messageInfo_Node_Spec_Map.Size(m)
 }
-func (m *Edge) XXX_DiscardUnknown() {
+func (m *Node_Spec_Map) XXX_DiscardUnknown() {
+	xxx_messageInfo_Node_Spec_Map.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Node_Spec_Map proto.InternalMessageInfo
+
+func (m *Node_Spec_Map) GetOver() string {
+	if m != nil {
+		return m.Over
+	}
+	return ""
+}
+
+type Node_Spec_Approval struct {
+	// duration (e.g. "24h") after which the approval expires and the node fails
+	Timeout              string   `protobuf:"bytes,1,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Node_Spec_Approval) Reset()         { *m = Node_Spec_Approval{} }
+func (m *Node_Spec_Approval) String() string { return proto.CompactTextString(m) }
+func (*Node_Spec_Approval) ProtoMessage()    {}
+func (*Node_Spec_Approval) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5eb97351c0f66fbe, []int{4, 0, 2}
+}
+
+func (m *Node_Spec_Approval) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Node_Spec_Approval.Unmarshal(m, b)
+}
+func (m *Node_Spec_Approval) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Node_Spec_Approval.Marshal(b, m, deterministic)
+}
+func (m *Node_Spec_Approval) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Node_Spec_Approval.Merge(m, src)
+}
+func (m *Node_Spec_Approval) XXX_Size() int {
+	return xxx_messageInfo_Node_Spec_Approval.Size(m)
+}
+func (m *Node_Spec_Approval) XXX_DiscardUnknown() {
+	xxx_messageInfo_Node_Spec_Approval.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Node_Spec_Approval proto.InternalMessageInfo
+
+func (m *Node_Spec_Approval) GetTimeout() string {
+	if m != nil {
+		return m.Timeout
+	}
+	return ""
+}
+
+type Node_Spec_Selector struct {
+	// labels an agent must have with the given values
+	MatchLabels map[string]string `protobuf:"bytes,1,rep,name=match_labels,json=matchLabels,proto3" json:"match_labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// requirements on the labels of an agent which must all be met
+	MatchExpressions     []*Node_Spec_Selector_Requirement `protobuf:"bytes,2,rep,name=match_expressions,json=matchExpressions,proto3" json:"match_expressions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                          `json:"-"`
+	XXX_unrecognized     []byte                            `json:"-"`
+	XXX_sizecache        int32                             `json:"-"`
+}
+
+func (m *Node_Spec_Selector) Reset()         { *m = Node_Spec_Selector{} }
+func (m *Node_Spec_Selector) String() string { return proto.CompactTextString(m) }
+func (*Node_Spec_Selector) ProtoMessage()    {}
+func (*Node_Spec_Selector) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5eb97351c0f66fbe, []int{4, 0, 3}
+}
+
+func (m *Node_Spec_Selector) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Node_Spec_Selector.Unmarshal(m, b)
+}
+func (m *Node_Spec_Selector) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Node_Spec_Selector.Marshal(b, m, deterministic)
+}
+func (m *Node_Spec_Selector) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Node_Spec_Selector.Merge(m, src)
+}
+func (m *Node_Spec_Selector) XXX_Size() int {
+	return xxx_messageInfo_Node_Spec_Selector.Size(m)
+}
+func (m *Node_Spec_Selector) XXX_DiscardUnknown() {
+	xxx_messageInfo_Node_Spec_Selector.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Node_Spec_Selector proto.InternalMessageInfo
+
+func (m *Node_Spec_Selector) GetMatchLabels() map[string]string {
+	if m != nil {
+		return m.MatchLabels
+	}
+	return nil
+}
+
+func (m *Node_Spec_Selector) GetMatchExpressions() []*Node_Spec_Selector_Requirement {
+	if m != nil {
+		return m.MatchExpressions
+	}
+	return nil
+}
+
+type Node_Spec_Selector_Requirement struct {
+	Key                  string                                  `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Operator             Node_Spec_Selector_Requirement_Operator `protobuf:"varint,2,opt,name=operator,proto3,enum=adagio.Node_Spec_Selector_Requirement_Operator" json:"operator,omitempty"`
+	Values               []string                                `protobuf:"bytes,3,rep,name=values,proto3" json:"values,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                `json:"-"`
+	XXX_unrecognized     []byte                                  `json:"-"`
+	XXX_sizecache        int32                                   `json:"-"`
+}
+
+func (m *Node_Spec_Selector_Requirement) Reset()         { *m = Node_Spec_Selector_Requirement{} }
+func (m *Node_Spec_Selector_Requirement) String() string { return proto.CompactTextString(m) }
+func (*Node_Spec_Selector_Requirement) ProtoMessage()    {}
+func (*Node_Spec_Selector_Requirement) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5eb97351c0f66fbe, []int{4, 0, 3, 0}
+}
+
+func (m *Node_Spec_Selector_Requirement) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Node_Spec_Selector_Requirement.Unmarshal(m, b)
+}
+func (m *Node_Spec_Selector_Requirement) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Node_Spec_Selector_Requirement.Marshal(b, m, deterministic)
+}
+func (m *Node_Spec_Selector_Requirement) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Node_Spec_Selector_Requirement.Merge(m, src)
+}
+func (m *Node_Spec_Selector_Requirement) XXX_Size() int {
+	return xxx_messageInfo_Node_Spec_Selector_Requirement.Size(m)
+}
+func (m *Node_Spec_Selector_Requirement) XXX_DiscardUnknown() {
+	xxx_messageInfo_Node_Spec_Selector_Requirement.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Node_Spec_Selector_Requirement proto.InternalMessageInfo
+
+func (m *Node_Spec_Selector_Requirement) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *Node_Spec_Selector_Requirement) GetOperator() Node_Spec_Selector_Requirement_Operator {
+	if m != nil {
+		return m.Operator
+	}
+	return Node_Spec_Selector_Requirement_IN
+}
+
+func (m *Node_Spec_Selector_Requirement) GetValues() []string {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+type Node_Result struct {
+	Conclusion Node_Result_Conclusion    `protobuf:"varint,1,opt,name=conclusion,proto3,enum=adagio.Node_Result_Conclusion" json:"conclusion,omitempty"`
+	Metadata   map[string]*MetadataValue `protobuf:"bytes,2,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Output     []byte                    `protobuf:"bytes,3,opt,name=output,proto3" json:"output,omitempty"`
+	// times at which the agent began and concluded the attempt
+	StartedAt  string `protobuf:"bytes,4,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt string `protobuf:"bytes,5,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	// claim under which the attempt was made and the agent which made it
+	ClaimId              string   `protobuf:"bytes,6,opt,name=claim_id,json=claimId,proto3" json:"claim_id,omitempty"`
+	AgentId              string   `protobuf:"bytes,7,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	Hostname             string   `protobuf:"bytes,8,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Node_Result) Reset()         { *m = Node_Result{} }
+func (m *Node_Result) String() string { return proto.CompactTextString(m) }
+func (*Node_Result) ProtoMessage()    {}
+func (*Node_Result) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5eb97351c0f66fbe, []int{4, 1}
+}
+
+func (m *Node_Result) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Node_Result.Unmarshal(m, b)
+}
+func (m *Node_Result) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Node_Result.Marshal(b, m, deterministic)
+}
+func (m *Node_Result) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Node_Result.Merge(m, src)
+}
+func (m *Node_Result) XXX_Size() int {
+	return xxx_messageInfo_Node_Result.Size(m)
+}
+func (m *Node_Result) XXX_DiscardUnknown() {
+	xxx_messageInfo_Node_Result.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Node_Result proto.InternalMessageInfo
+
+func (m *Node_Result) GetConclusion() Node_Result_Conclusion {
+	if m != nil {
+		return m.Conclusion
+	}
+	return Node_Result_NONE
+}
+
+func (m *Node_Result) GetMetadata() map[string]*MetadataValue {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *Node_Result) GetOutput() []byte {
+	if m != nil {
+		return m.Output
+	}
+	return nil
+}
+
+func (m *Node_Result) GetStartedAt() string {
+	if m != nil {
+		return m.StartedAt
+	}
+	return ""
+}
+
+func (m *Node_Result) GetFinishedAt() string {
+	if m != nil {
+		return m.FinishedAt
+	}
+	return ""
+}
+
+func (m *Node_Result) GetClaimId() string {
+	if m != nil {
+		return m.ClaimId
+	}
+	return ""
+}
+
+func (m *Node_Result) GetAgentId() string {
+	if m != nil {
+		return m.AgentId
+	}
+	return ""
+}
+
+func (m *Node_Result) GetHostname() string {
+	if m != nil {
+		return m.Hostname
+	}
+	return ""
+}
+
+// progress reported by the function executing a running node
+type Node_Progress struct {
+	// percentage (0-100) of the work completed
+	Percent float64 `protobuf:"fixed64,1,opt,name=percent,proto3" json:"percent,omitempty"`
+	Message string  `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// intermediate metadata produced so far
+	Metadata             map[string]*MetadataValue `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	UpdatedAt            string                    `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *Node_Progress) Reset()         { *m = Node_Progress{} }
+func (m *Node_Progress) String() string { return proto.CompactTextString(m) }
+func (*Node_Progress) ProtoMessage()    {}
+func (*Node_Progress) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5eb97351c0f66fbe, []int{4, 2}
+}
+
+func (m *Node_Progress) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Node_Progress.Unmarshal(m, b)
+}
+func (m *Node_Progress) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Node_Progress.Marshal(b, m, deterministic)
+}
+func (m *Node_Progress) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Node_Progress.Merge(m, src)
+}
+func (m *Node_Progress) XXX_Size() int {
+	return xxx_messageInfo_Node_Progress.Size(m)
+}
+func (m *Node_Progress) XXX_DiscardUnknown() {
+	xxx_messageInfo_Node_Progress.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Node_Progress proto.InternalMessageInfo
+
+func (m *Node_Progress) GetPercent() float64 {
+	if m != nil {
+		return m.Percent
+	}
+	return 0
+}
+
+func (m *Node_Progress) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *Node_Progress) GetMetadata() map[string]*MetadataValue {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *Node_Progress) GetUpdatedAt() string {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return ""
+}
+
+// an entry in the append-only history of the state transitions of a node
+type Node_Transition struct {
+	Type Node_Transition_Type `protobuf:"varint,1,opt,name=type,proto3,enum=adagio.Node_Transition_Type" json:"type,omitempty"`
+	At   string               `protobuf:"bytes,2,opt,name=at,proto3" json:"at,omitempty"`
+	// attempt the transition relates to (0 for nodes which are never attempted)
+	Attempt int32 `protobuf:"varint,3,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	// agent and claim under which the node was claimed, rescheduled, finished or orphaned
+	AgentId string `protobuf:"bytes,4,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	ClaimId string `protobuf:"bytes,5,opt,name=claim_id,json=claimId,proto3" json:"claim_id,omitempty"`
+	// conclusion of a finished attempt
+	Conclusion           Node_Result_Conclusion `protobuf:"varint,6,opt,name=conclusion,proto3,enum=adagio.Node_Result_Conclusion" json:"conclusion,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *Node_Transition) Reset()         { *m = Node_Transition{} }
+func (m *Node_Transition) String() string { return proto.CompactTextString(m) }
+func (*Node_Transition) ProtoMessage()    {}
+func (*Node_Transition) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5eb97351c0f66fbe, []int{4, 3}
+}
+
+func (m *Node_Transition) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Node_Transition.Unmarshal(m, b)
+}
+func (m *Node_Transition) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Node_Transition.Marshal(b, m, deterministic)
+}
+func (m *Node_Transition) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Node_Transition.Merge(m, src)
+}
+func (m *Node_Transition) XXX_Size() int {
+	return xxx_messageInfo_Node_Transition.Size(m)
+}
+func (m *Node_Transition) XXX_DiscardUnknown() {
+	xxx_messageInfo_Node_Transition.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Node_Transition proto.InternalMessageInfo
+
+func (m *Node_Transition) GetType() Node_Transition_Type {
+	if m != nil {
+		return m.Type
+	}
+	return Node_Transition_NONE
+}
+
+func (m *Node_Transition) GetAt() string {
+	if m != nil {
+		return m.At
+	}
+	return ""
+}
+
+func (m *Node_Transition) GetAttempt() int32 {
+	if m != nil {
+		return m.Attempt
+	}
+	return 0
+}
+
+func (m *Node_Transition) GetAgentId() string {
+	if m != nil {
+		return m.AgentId
+	}
+	return ""
+}
+
+func (m *Node_Transition) GetClaimId() string {
+	if m != nil {
+		return m.ClaimId
+	}
+	return ""
+}
+
+func (m *Node_Transition) GetConclusion() Node_Result_Conclusion {
+	if m != nil {
+		return m.Conclusion
+	}
+	return Node_Result_NONE
+}
+
+type Edge struct {
+	Source               string          `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Destination          string          `protobuf:"bytes,2,opt,name=destination,proto3" json:"destination,omitempty"`
+	Condition            *Edge_Condition `protobuf:"bytes,3,opt,name=condition,proto3" json:"condition,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *Edge) Reset()         { *m = Edge{} }
+func (m *Edge) String() string { return proto.CompactTextString(m) }
+func (*Edge) ProtoMessage()    {}
+func (*Edge) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5eb97351c0f66fbe, []int{5}
+}
+
+func (m *Edge) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Edge.Unmarshal(m, b)
+}
+func (m *Edge) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Edge.Marshal(b, m, deterministic)
+}
+func (m *Edge) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Edge.Merge(m, src)
+}
+func (m *Edge) XXX_Size() int {
+	return xxx_messageInfo_Edge.Size(m)
+}
+func (m *Edge) XXX_DiscardUnknown() {
 	xxx_messageInfo_Edge.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Edge proto.InternalMessageInfo
+var xxx_messageInfo_Edge proto.InternalMessageInfo
+
+func (m *Edge) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *Edge) GetDestination() string {
+	if m != nil {
+		return m.Destination
+	}
+	return ""
+}
+
+func (m *Edge) GetCondition() *Edge_Condition {
+	if m != nil {
+		return m.Condition
+	}
+	return nil
+}
+
+type Edge_Condition struct {
+	// conclusions of which the source result must have one (defaults to success)
+	Conclusions []Node_Result_Conclusion `protobuf:"varint,1,rep,packed,name=conclusions,proto3,enum=adagio.Node_Result_Conclusion" json:"conclusions,omitempty"`
+	// metadata keys and values the source result must contain
+	Metadata map[string]string `protobuf:"bytes,2,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// dot separated paths into the JSON output of the source result and their expected values
+	Output map[string]string `protobuf:"bytes,3,rep,name=output,proto3" json:"output,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// negate inverts the metadata and output comparisons
+	Negate               bool     `protobuf:"varint,4,opt,name=negate,proto3" json:"negate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Edge_Condition) Reset()         { *m = Edge_Condition{} }
+func (m *Edge_Condition) String() string { return proto.CompactTextString(m) }
+func (*Edge_Condition) ProtoMessage()    {}
+func (*Edge_Condition) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5eb97351c0f66fbe, []int{5, 0}
+}
+
+func (m *Edge_Condition) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Edge_Condition.Unmarshal(m, b)
+}
+func (m *Edge_Condition) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Edge_Condition.Marshal(b, m, deterministic)
+}
+func (m *Edge_Condition) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Edge_Condition.Merge(m, src)
+}
+func (m *Edge_Condition) XXX_Size() int {
+	return xxx_messageInfo_Edge_Condition.Size(m)
+}
+func (m *Edge_Condition) XXX_DiscardUnknown() {
+	xxx_messageInfo_Edge_Condition.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Edge_Condition proto.InternalMessageInfo
+
+func (m *Edge_Condition) GetConclusions() []Node_Result_Conclusion {
+	if m != nil {
+		return m.Conclusions
+	}
+	return nil
+}
+
+func (m *Edge_Condition) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *Edge_Condition) GetOutput() map[string]string {
+	if m != nil {
+		return m.Output
+	}
+	return nil
+}
+
+func (m *Edge_Condition) GetNegate() bool {
+	if m != nil {
+		return m.Negate
+	}
+	return false
+}
+
+type Result struct {
+	Conclusion           Result_Conclusion         `protobuf:"varint,1,opt,name=conclusion,proto3,enum=adagio.Result_Conclusion" json:"conclusion,omitempty"`
+	Metadata             map[string]*MetadataValue `protobuf:"bytes,2,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Output               []byte                    `protobuf:"bytes,3,opt,name=output,proto3" json:"output,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *Result) Reset()         { *m = Result{} }
+func (m *Result) String() string { return proto.CompactTextString(m) }
+func (*Result) ProtoMessage()    {}
+func (*Result) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5eb97351c0f66fbe, []int{6}
+}
+
+func (m *Result) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Result.Unmarshal(m, b)
+}
+func (m *Result) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Result.Marshal(b, m, deterministic)
+}
+func (m *Result) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Result.Merge(m, src)
+}
+func (m *Result) XXX_Size() int {
+	return xxx_messageInfo_Result.Size(m)
+}
+func (m *Result) XXX_DiscardUnknown() {
+	xxx_messageInfo_Result.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Result proto.InternalMessageInfo
+
+func (m *Result) GetConclusion() Result_Conclusion {
+	if m != nil {
+		return m.Conclusion
+	}
+	return Result_NONE
+}
+
+func (m *Result) GetMetadata() map[string]*MetadataValue {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *Result) GetOutput() []byte {
+	if m != nil {
+		return m.Output
+	}
+	return nil
+}
+
+type Runtime struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Runtime) Reset()         { *m = Runtime{} }
+func (m *Runtime) String() string { return proto.CompactTextString(m) }
+func (*Runtime) ProtoMessage()    {}
+func (*Runtime) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5eb97351c0f66fbe, []int{7}
+}
+
+func (m *Runtime) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Runtime.Unmarshal(m, b)
+}
+func (m *Runtime) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Runtime.Marshal(b, m, deterministic)
+}
+func (m *Runtime) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Runtime.Merge(m, src)
+}
+func (m *Runtime) XXX_Size() int {
+	return xxx_messageInfo_Runtime.Size(m)
+}
+func (m *Runtime) XXX_DiscardUnknown() {
+	xxx_messageInfo_Runtime.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Runtime proto.InternalMessageInfo
+
+func (m *Runtime) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type Agent struct {
+	Id            string            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Runtimes      []*Runtime        `protobuf:"bytes,2,rep,name=runtimes,proto3" json:"runtimes,omitempty"`
+	Labels        map[string]string `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Hostname      string            `protobuf:"bytes,4,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Pid           int32             `protobuf:"varint,5,opt,name=pid,proto3" json:"pid,omitempty"`
+	Version       string            `protobuf:"bytes,6,opt,name=version,proto3" json:"version,omitempty"`
+	StartedAt     string            `protobuf:"bytes,7,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	LastHeartbeat string            `protobuf:"bytes,8,opt,name=last_heartbeat,json=lastHeartbeat,proto3" json:"last_heartbeat,omitempty"`
+	// number of nodes the agent can execute at once
+	Capacity int32 `protobuf:"varint,9,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	// node the agent is currently executing
+	Current              *Agent_Work `protobuf:"bytes,10,opt,name=current,proto3" json:"current,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *Agent) Reset()         { *m = Agent{} }
+func (m *Agent) String() string { return proto.CompactTextString(m) }
+func (*Agent) ProtoMessage()    {}
+func (*Agent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5eb97351c0f66fbe, []int{8}
+}
+
+func (m *Agent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Agent.Unmarshal(m, b)
+}
+func (m *Agent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Agent.Marshal(b, m, deterministic)
+}
+func (m *Agent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Agent.Merge(m, src)
+}
+func (m *Agent) XXX_Size() int {
+	return xxx_messageInfo_Agent.Size(m)
+}
+func (m *Agent) XXX_DiscardUnknown() {
+	xxx_messageInfo_Agent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Agent proto.InternalMessageInfo
+
+func (m *Agent) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Agent) GetRuntimes() []*Runtime {
+	if m != nil {
+		return m.Runtimes
+	}
+	return nil
+}
+
+func (m *Agent) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *Agent) GetHostname() string {
+	if m != nil {
+		return m.Hostname
+	}
+	return ""
+}
+
+func (m *Agent) GetPid() int32 {
+	if m != nil {
+		return m.Pid
+	}
+	return 0
+}
+
+func (m *Agent) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *Agent) GetStartedAt() string {
+	if m != nil {
+		return m.StartedAt
+	}
+	return ""
+}
+
+func (m *Agent) GetLastHeartbeat() string {
+	if m != nil {
+		return m.LastHeartbeat
+	}
+	return ""
+}
+
+func (m *Agent) GetCapacity() int32 {
+	if m != nil {
+		return m.Capacity
+	}
+	return 0
+}
+
+func (m *Agent) GetCurrent() *Agent_Work {
+	if m != nil {
+		return m.Current
+	}
+	return nil
+}
+
+type Agent_Work struct {
+	RunId                string   `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	Node                 string   `protobuf:"bytes,2,opt,name=node,proto3" json:"node,omitempty"`
+	StartedAt            string   `protobuf:"bytes,3,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Agent_Work) Reset()         { *m = Agent_Work{} }
+func (m *Agent_Work) String() string { return proto.CompactTextString(m) }
+func (*Agent_Work) ProtoMessage()    {}
+func (*Agent_Work) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5eb97351c0f66fbe, []int{8, 0}
+}
+
+func (m *Agent_Work) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Agent_Work.Unmarshal(m, b)
+}
+func (m *Agent_Work) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Agent_Work.Marshal(b, m, deterministic)
+}
+func (m *Agent_Work) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Agent_Work.Merge(m, src)
+}
+func (m *Agent_Work) XXX_Size() int {
+	return xxx_messageInfo_Agent_Work.Size(m)
+}
+func (m *Agent_Work) XXX_DiscardUnknown() {
+	xxx_messageInfo_Agent_Work.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Agent_Work proto.InternalMessageInfo
+
+func (m *Agent_Work) GetRunId() string {
+	if m != nil {
+		return m.RunId
+	}
+	return ""
+}
+
+func (m *Agent_Work) GetNode() string {
+	if m != nil {
+		return m.Node
+	}
+	return ""
+}
+
+func (m *Agent_Work) GetStartedAt() string {
+	if m != nil {
+		return m.StartedAt
+	}
+	return ""
+}
+
+type LogLine struct {
+	Timestamp string `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// line of output without its trailing newline
+	Text                 string   `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LogLine) Reset()         { *m = LogLine{} }
+func (m *LogLine) String() string { return proto.CompactTextString(m) }
+func (*LogLine) ProtoMessage()    {}
+func (*LogLine) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5eb97351c0f66fbe, []int{9}
+}
+
+func (m *LogLine) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LogLine.Unmarshal(m, b)
+}
+func (m *LogLine) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LogLine.Marshal(b, m, deterministic)
+}
+func (m *LogLine) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogLine.Merge(m, src)
+}
+func (m *LogLine) XXX_Size() int {
+	return xxx_messageInfo_LogLine.Size(m)
+}
+func (m *LogLine) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogLine.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogLine proto.InternalMessageInfo
 
-func (m *Edge) GetSource() string {
+func (m *LogLine) GetTimestamp() string {
 	if m != nil {
-		return m.Source
+		return m.Timestamp
 	}
 	return ""
 }
 
-func (m *Edge) GetDestination() string {
+func (m *LogLine) GetText() string {
 	if m != nil {
-		return m.Destination
+		return m.Text
 	}
 	return ""
 }
 
-type Result struct {
-	Conclusion           Result_Conclusion         `protobuf:"varint,1,opt,name=conclusion,proto3,enum=adagio.Result_Conclusion" json:"conclusion,omitempty"`
-	Metadata             map[string]*MetadataValue `protobuf:"bytes,2,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	Output               []byte                    `protobuf:"bytes,3,opt,name=output,proto3" json:"output,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
-	XXX_unrecognized     []byte                    `json:"-"`
-	XXX_sizecache        int32                     `json:"-"`
+// an HTTP endpoint to which signed notifications of run and node events are posted
+type Webhook struct {
+	Id  string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Url string `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	// key with which the HMAC-SHA256 signature of each payload is computed
+	Secret string `protobuf:"bytes,3,opt,name=secret,proto3" json:"secret,omitempty"`
+	// events of which the webhook is notified
+	Events               []Webhook_Event `protobuf:"varint,4,rep,packed,name=events,proto3,enum=adagio.Webhook_Event" json:"events,omitempty"`
+	CreatedAt            string          `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *Webhook) Reset()         { *m = Webhook{} }
+func (m *Webhook) String() string { return proto.CompactTextString(m) }
+func (*Webhook) ProtoMessage()    {}
+func (*Webhook) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5eb97351c0f66fbe, []int{10}
 }
 
-func (m *Result) Reset()         { *m = Result{} }
-func (m *Result) String() string { return proto.CompactTextString(m) }
-func (*Result) ProtoMessage()    {}
-func (*Result) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5eb97351c0f66fbe, []int{6}
+func (m *Webhook) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Webhook.Unmarshal(m, b)
+}
+func (m *Webhook) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Webhook.Marshal(b, m, deterministic)
+}
+func (m *Webhook) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Webhook.Merge(m, src)
+}
+func (m *Webhook) XXX_Size() int {
+	return xxx_messageInfo_Webhook.Size(m)
+}
+func (m *Webhook) XXX_DiscardUnknown() {
+	xxx_messageInfo_Webhook.DiscardUnknown(m)
 }
 
-func (m *Result) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Result.Unmarshal(m, b)
+var xxx_messageInfo_Webhook proto.InternalMessageInfo
+
+func (m *Webhook) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
 }
-func (m *Result) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Result.Marshal(b, m, deterministic)
+
+func (m *Webhook) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
 }
-func (m *Result) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Result.Merge(m, src)
+
+func (m *Webhook) GetSecret() string {
+	if m != nil {
+		return m.Secret
+	}
+	return ""
 }
-func (m *Result) XXX_Size() int {
-	return xxx_messageInfo_Result.Size(m)
+
+func (m *Webhook) GetEvents() []Webhook_Event {
+	if m != nil {
+		return m.Events
+	}
+	return nil
 }
-func (m *Result) XXX_DiscardUnknown() {
-	xxx_messageInfo_Result.DiscardUnknown(m)
+
+func (m *Webhook) GetCreatedAt() string {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return ""
 }
 
-var xxx_messageInfo_Result proto.InternalMessageInfo
+// the payload posted to a webhook describing a run or node event
+type Notification struct {
+	// identifies the event and remains the same across delivery attempts
+	Id    string        `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Event Webhook_Event `protobuf:"varint,2,opt,name=event,proto3,enum=adagio.Webhook_Event" json:"event,omitempty"`
+	RunId string        `protobuf:"bytes,3,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	// node the event relates to (empty for run events)
+	Node string `protobuf:"bytes,4,opt,name=node,proto3" json:"node,omitempty"`
+	// attempt of the node the event relates to
+	Attempt int32 `protobuf:"varint,5,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	// conclusion of the attempt which was retried
+	Conclusion Node_Result_Conclusion `protobuf:"varint,6,opt,name=conclusion,proto3,enum=adagio.Node_Result_Conclusion" json:"conclusion,omitempty"`
+	// nodes of a failed run whose latest attempt did not succeed
+	FailedNodes          []string `protobuf:"bytes,7,rep,name=failed_nodes,json=failedNodes,proto3" json:"failed_nodes,omitempty"`
+	OccurredAt           string   `protobuf:"bytes,8,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
 
-func (m *Result) GetConclusion() Result_Conclusion {
+func (m *Notification) Reset()         { *m = Notification{} }
+func (m *Notification) String() string { return proto.CompactTextString(m) }
+func (*Notification) ProtoMessage()    {}
+func (*Notification) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5eb97351c0f66fbe, []int{11}
+}
+
+func (m *Notification) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Notification.Unmarshal(m, b)
+}
+func (m *Notification) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Notification.Marshal(b, m, deterministic)
+}
+func (m *Notification) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Notification.Merge(m, src)
+}
+func (m *Notification) XXX_Size() int {
+	return xxx_messageInfo_Notification.Size(m)
+}
+func (m *Notification) XXX_DiscardUnknown() {
+	xxx_messageInfo_Notification.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Notification proto.InternalMessageInfo
+
+func (m *Notification) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Notification) GetEvent() Webhook_Event {
+	if m != nil {
+		return m.Event
+	}
+	return Webhook_NONE
+}
+
+func (m *Notification) GetRunId() string {
+	if m != nil {
+		return m.RunId
+	}
+	return ""
+}
+
+func (m *Notification) GetNode() string {
+	if m != nil {
+		return m.Node
+	}
+	return ""
+}
+
+func (m *Notification) GetAttempt() int32 {
+	if m != nil {
+		return m.Attempt
+	}
+	return 0
+}
+
+func (m *Notification) GetConclusion() Node_Result_Conclusion {
 	if m != nil {
 		return m.Conclusion
 	}
-	return Result_NONE
+	return Node_Result_NONE
 }
 
-func (m *Result) GetMetadata() map[string]*MetadataValue {
+func (m *Notification) GetFailedNodes() []string {
 	if m != nil {
-		return m.Metadata
+		return m.FailedNodes
 	}
 	return nil
 }
 
-func (m *Result) GetOutput() []byte {
+func (m *Notification) GetOccurredAt() string {
 	if m != nil {
-		return m.Output
+		return m.OccurredAt
 	}
-	return nil
+	return ""
 }
 
-type Runtime struct {
-	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+// a record of the delivery of a notification to a webhook
+type Delivery struct {
+	WebhookId    string          `protobuf:"bytes,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	Notification *Notification   `protobuf:"bytes,2,opt,name=notification,proto3" json:"notification,omitempty"`
+	Status       Delivery_Status `protobuf:"varint,3,opt,name=status,proto3,enum=adagio.Delivery_Status" json:"status,omitempty"`
+	// number of attempts made to post the notification
+	Attempts int32 `protobuf:"varint,4,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	// HTTP status code of the latest attempt (0 given no response was received)
+	ResponseCode int32 `protobuf:"varint,5,opt,name=response_code,json=responseCode,proto3" json:"response_code,omitempty"`
+	// error of the latest attempt which did not succeed
+	Error     string `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	CreatedAt string `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt string `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// time before which the next attempt is not made
+	NextAttemptAt        string   `protobuf:"bytes,9,opt,name=next_attempt_at,json=nextAttemptAt,proto3" json:"next_attempt_at,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Runtime) Reset()         { *m = Runtime{} }
-func (m *Runtime) String() string { return proto.CompactTextString(m) }
-func (*Runtime) ProtoMessage()    {}
-func (*Runtime) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5eb97351c0f66fbe, []int{7}
+func (m *Delivery) Reset()         { *m = Delivery{} }
+func (m *Delivery) String() string { return proto.CompactTextString(m) }
+func (*Delivery) ProtoMessage()    {}
+func (*Delivery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5eb97351c0f66fbe, []int{12}
 }
 
-func (m *Runtime) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Runtime.Unmarshal(m, b)
+func (m *Delivery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Delivery.Unmarshal(m, b)
 }
-func (m *Runtime) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Runtime.Marshal(b, m, deterministic)
+func (m *Delivery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Delivery.Marshal(b, m, deterministic)
 }
-func (m *Runtime) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Runtime.Merge(m, src)
+func (m *Delivery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Delivery.Merge(m, src)
 }
-func (m *Runtime) XXX_Size() int {
-	return xxx_messageInfo_Runtime.Size(m)
+func (m *Delivery) XXX_Size() int {
+	return xxx_messageInfo_Delivery.Size(m)
 }
-func (m *Runtime) XXX_DiscardUnknown() {
-	xxx_messageInfo_Runtime.DiscardUnknown(m)
+func (m *Delivery) XXX_DiscardUnknown() {
+	xxx_messageInfo_Delivery.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Runtime proto.InternalMessageInfo
+var xxx_messageInfo_Delivery proto.InternalMessageInfo
 
-func (m *Runtime) GetName() string {
+func (m *Delivery) GetWebhookId() string {
 	if m != nil {
-		return m.Name
+		return m.WebhookId
 	}
 	return ""
 }
 
-type Agent struct {
-	Id                   string     `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Runtimes             []*Runtime `protobuf:"bytes,2,rep,name=runtimes,proto3" json:"runtimes,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+func (m *Delivery) GetNotification() *Notification {
+	if m != nil {
+		return m.Notification
+	}
+	return nil
 }
 
-func (m *Agent) Reset()         { *m = Agent{} }
-func (m *Agent) String() string { return proto.CompactTextString(m) }
-func (*Agent) ProtoMessage()    {}
-func (*Agent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5eb97351c0f66fbe, []int{8}
+func (m *Delivery) GetStatus() Delivery_Status {
+	if m != nil {
+		return m.Status
+	}
+	return Delivery_PENDING
 }
 
-func (m *Agent) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Agent.Unmarshal(m, b)
+func (m *Delivery) GetAttempts() int32 {
+	if m != nil {
+		return m.Attempts
+	}
+	return 0
 }
-func (m *Agent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Agent.Marshal(b, m, deterministic)
+
+func (m *Delivery) GetResponseCode() int32 {
+	if m != nil {
+		return m.ResponseCode
+	}
+	return 0
 }
-func (m *Agent) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Agent.Merge(m, src)
+
+func (m *Delivery) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
 }
-func (m *Agent) XXX_Size() int {
-	return xxx_messageInfo_Agent.Size(m)
+
+func (m *Delivery) GetCreatedAt() string {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return ""
 }
-func (m *Agent) XXX_DiscardUnknown() {
-	xxx_messageInfo_Agent.DiscardUnknown(m)
+
+func (m *Delivery) GetUpdatedAt() string {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return ""
 }
 
-var xxx_messageInfo_Agent proto.InternalMessageInfo
+func (m *Delivery) GetNextAttemptAt() string {
+	if m != nil {
+		return m.NextAttemptAt
+	}
+	return ""
+}
 
-func (m *Agent) GetId() string {
+// a record in the append-only audit log of a mutating control plane call
+// or of any call denied by the role-based access control policy
+type AuditEvent struct {
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	At string `protobuf:"bytes,2,opt,name=at,proto3" json:"at,omitempty"`
+	// identity of the caller and the network address from which it called
+	Actor string `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	Peer  string `protobuf:"bytes,4,opt,name=peer,proto3" json:"peer,omitempty"`
+	// name of the control plane RPC (e.g. "Start")
+	Rpc string `protobuf:"bytes,5,opt,name=rpc,proto3" json:"rpc,omitempty"`
+	// run targeted by the call (empty given it does not target a run)
+	RunId string `protobuf:"bytes,6,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	// summary of the request (secrets are omitted)
+	Summary string             `protobuf:"bytes,7,opt,name=summary,proto3" json:"summary,omitempty"`
+	Outcome AuditEvent_Outcome `protobuf:"varint,8,opt,name=outcome,proto3,enum=adagio.AuditEvent_Outcome" json:"outcome,omitempty"`
+	// error returned by a failed or denied call
+	Error                string   `protobuf:"bytes,9,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AuditEvent) Reset()         { *m = AuditEvent{} }
+func (m *AuditEvent) String() string { return proto.CompactTextString(m) }
+func (*AuditEvent) ProtoMessage()    {}
+func (*AuditEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5eb97351c0f66fbe, []int{13}
+}
+
+func (m *AuditEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuditEvent.Unmarshal(m, b)
+}
+func (m *AuditEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuditEvent.Marshal(b, m, deterministic)
+}
+func (m *AuditEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuditEvent.Merge(m, src)
+}
+func (m *AuditEvent) XXX_Size() int {
+	return xxx_messageInfo_AuditEvent.Size(m)
+}
+func (m *AuditEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuditEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuditEvent proto.InternalMessageInfo
+
+func (m *AuditEvent) GetId() string {
 	if m != nil {
 		return m.Id
 	}
 	return ""
 }
 
-func (m *Agent) GetRuntimes() []*Runtime {
+func (m *AuditEvent) GetAt() string {
 	if m != nil {
-		return m.Runtimes
+		return m.At
 	}
-	return nil
+	return ""
+}
+
+func (m *AuditEvent) GetActor() string {
+	if m != nil {
+		return m.Actor
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetPeer() string {
+	if m != nil {
+		return m.Peer
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetRpc() string {
+	if m != nil {
+		return m.Rpc
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetRunId() string {
+	if m != nil {
+		return m.RunId
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetSummary() string {
+	if m != nil {
+		return m.Summary
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetOutcome() AuditEvent_Outcome {
+	if m != nil {
+		return m.Outcome
+	}
+	return AuditEvent_NONE
+}
+
+func (m *AuditEvent) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
 }
 
 type Claim struct {
-	Id                   string                    `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Metadata             map[string]*MetadataValue `protobuf:"bytes,2,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
-	XXX_unrecognized     []byte                    `json:"-"`
-	XXX_sizecache        int32                     `json:"-"`
+	Id       string                    `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Metadata map[string]*MetadataValue `protobuf:"bytes,2,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// ID of the agent which made the claim
+	AgentId              string   `protobuf:"bytes,3,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Claim) Reset()         { *m = Claim{} }
 func (m *Claim) String() string { return proto.CompactTextString(m) }
 func (*Claim) ProtoMessage()    {}
 func (*Claim) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5eb97351c0f66fbe, []int{9}
+	return fileDescriptor_5eb97351c0f66fbe, []int{14}
 }
 
 func (m *Claim) XXX_Unmarshal(b []byte) error {
@@ -857,19 +2283,28 @@ func (m *Claim) GetMetadata() map[string]*MetadataValue {
 	return nil
 }
 
+func (m *Claim) GetAgentId() string {
+	if m != nil {
+		return m.AgentId
+	}
+	return ""
+}
+
 type Stats struct {
-	RunCount             int64             `protobuf:"varint,1,opt,name=run_count,json=runCount,proto3" json:"run_count,omitempty"`
-	NodeCounts           *Stats_NodeCounts `protobuf:"bytes,2,opt,name=node_counts,json=nodeCounts,proto3" json:"node_counts,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	RunCount   int64             `protobuf:"varint,1,opt,name=run_count,json=runCount,proto3" json:"run_count,omitempty"`
+	NodeCounts *Stats_NodeCounts `protobuf:"bytes,2,opt,name=node_counts,json=nodeCounts,proto3" json:"node_counts,omitempty"`
+	// number of ready nodes which no registered agent can claim keyed by runtime
+	UnschedulableCounts  map[string]int64 `protobuf:"bytes,3,rep,name=unschedulable_counts,json=unschedulableCounts,proto3" json:"unschedulable_counts,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
 
 func (m *Stats) Reset()         { *m = Stats{} }
 func (m *Stats) String() string { return proto.CompactTextString(m) }
 func (*Stats) ProtoMessage()    {}
 func (*Stats) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5eb97351c0f66fbe, []int{10}
+	return fileDescriptor_5eb97351c0f66fbe, []int{15}
 }
 
 func (m *Stats) XXX_Unmarshal(b []byte) error {
@@ -904,11 +2339,20 @@ func (m *Stats) GetNodeCounts() *Stats_NodeCounts {
 	return nil
 }
 
+func (m *Stats) GetUnschedulableCounts() map[string]int64 {
+	if m != nil {
+		return m.UnschedulableCounts
+	}
+	return nil
+}
+
 type Stats_NodeCounts struct {
 	WaitingCount         int64    `protobuf:"varint,1,opt,name=waiting_count,json=waitingCount,proto3" json:"waiting_count,omitempty"`
 	ReadyCount           int64    `protobuf:"varint,2,opt,name=ready_count,json=readyCount,proto3" json:"ready_count,omitempty"`
 	RunningCount         int64    `protobuf:"varint,3,opt,name=running_count,json=runningCount,proto3" json:"running_count,omitempty"`
 	CompletedCount       int64    `protobuf:"varint,4,opt,name=completed_count,json=completedCount,proto3" json:"completed_count,omitempty"`
+	SkippedCount         int64    `protobuf:"varint,5,opt,name=skipped_count,json=skippedCount,proto3" json:"skipped_count,omitempty"`
+	ScheduledCount       int64    `protobuf:"varint,6,opt,name=scheduled_count,json=scheduledCount,proto3" json:"scheduled_count,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -918,7 +2362,7 @@ func (m *Stats_NodeCounts) Reset()         { *m = Stats_NodeCounts{} }
 func (m *Stats_NodeCounts) String() string { return proto.CompactTextString(m) }
 func (*Stats_NodeCounts) ProtoMessage()    {}
 func (*Stats_NodeCounts) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5eb97351c0f66fbe, []int{10, 0}
+	return fileDescriptor_5eb97351c0f66fbe, []int{15, 0}
 }
 
 func (m *Stats_NodeCounts) XXX_Unmarshal(b []byte) error {
@@ -967,101 +2411,241 @@ func (m *Stats_NodeCounts) GetCompletedCount() int64 {
 	return 0
 }
 
+func (m *Stats_NodeCounts) GetSkippedCount() int64 {
+	if m != nil {
+		return m.SkippedCount
+	}
+	return 0
+}
+
+func (m *Stats_NodeCounts) GetScheduledCount() int64 {
+	if m != nil {
+		return m.ScheduledCount
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterEnum("adagio.Run_Status", Run_Status_name, Run_Status_value)
 	proto.RegisterEnum("adagio.Event_Type", Event_Type_name, Event_Type_value)
 	proto.RegisterEnum("adagio.Node_Status", Node_Status_name, Node_Status_value)
+	proto.RegisterEnum("adagio.Node_Spec_Selector_Requirement_Operator", Node_Spec_Selector_Requirement_Operator_name, Node_Spec_Selector_Requirement_Operator_value)
 	proto.RegisterEnum("adagio.Node_Result_Conclusion", Node_Result_Conclusion_name, Node_Result_Conclusion_value)
+	proto.RegisterEnum("adagio.Node_Transition_Type", Node_Transition_Type_name, Node_Transition_Type_value)
 	proto.RegisterEnum("adagio.Result_Conclusion", Result_Conclusion_name, Result_Conclusion_value)
+	proto.RegisterEnum("adagio.Webhook_Event", Webhook_Event_name, Webhook_Event_value)
+	proto.RegisterEnum("adagio.Delivery_Status", Delivery_Status_name, Delivery_Status_value)
+	proto.RegisterEnum("adagio.AuditEvent_Outcome", AuditEvent_Outcome_name, AuditEvent_Outcome_value)
 	proto.RegisterType((*Run)(nil), "adagio.Run")
+	proto.RegisterMapType((map[string]string)(nil), "adagio.Run.TraceContextEntry")
+	proto.RegisterType((*Run_Link)(nil), "adagio.Run.Link")
 	proto.RegisterType((*Event)(nil), "adagio.Event")
+	proto.RegisterMapType((map[string]string)(nil), "adagio.Event.TraceContextEntry")
 	proto.RegisterType((*GraphSpec)(nil), "adagio.GraphSpec")
 	proto.RegisterType((*MetadataValue)(nil), "adagio.MetadataValue")
 	proto.RegisterType((*Node)(nil), "adagio.Node")
 	proto.RegisterMapType((map[string][]byte)(nil), "adagio.Node.InputsEntry")
 	proto.RegisterType((*Node_Spec)(nil), "adagio.Node.Spec")
 	proto.RegisterMapType((map[string]*MetadataValue)(nil), "adagio.Node.Spec.MetadataEntry")
+	proto.RegisterMapType((map[string]int32)(nil), "adagio.Node.Spec.ResourcesEntry")
 	proto.RegisterMapType((map[string]*Node_Spec_Retry)(nil), "adagio.Node.Spec.RetryEntry")
 	proto.RegisterType((*Node_Spec_Retry)(nil), "adagio.Node.Spec.Retry")
+	proto.RegisterType((*Node_Spec_Map)(nil), "adagio.Node.Spec.Map")
+	proto.RegisterType((*Node_Spec_Approval)(nil), "adagio.Node.Spec.Approval")
+	proto.RegisterType((*Node_Spec_Selector)(nil), "adagio.Node.Spec.Selector")
+	proto.RegisterMapType((map[string]string)(nil), "adagio.Node.Spec.Selector.MatchLabelsEntry")
+	proto.RegisterType((*Node_Spec_Selector_Requirement)(nil), "adagio.Node.Spec.Selector.Requirement")
 	proto.RegisterType((*Node_Result)(nil), "adagio.Node.Result")
 	proto.RegisterMapType((map[string]*MetadataValue)(nil), "adagio.Node.Result.MetadataEntry")
+	proto.RegisterType((*Node_Progress)(nil), "adagio.Node.Progress")
+	proto.RegisterMapType((map[string]*MetadataValue)(nil), "adagio.Node.Progress.MetadataEntry")
+	proto.RegisterType((*Node_Transition)(nil), "adagio.Node.Transition")
 	proto.RegisterType((*Edge)(nil), "adagio.Edge")
+	proto.RegisterType((*Edge_Condition)(nil), "adagio.Edge.Condition")
+	proto.RegisterMapType((map[string]string)(nil), "adagio.Edge.Condition.MetadataEntry")
+	proto.RegisterMapType((map[string]string)(nil), "adagio.Edge.Condition.OutputEntry")
 	proto.RegisterType((*Result)(nil), "adagio.Result")
 	proto.RegisterMapType((map[string]*MetadataValue)(nil), "adagio.Result.MetadataEntry")
 	proto.RegisterType((*Runtime)(nil), "adagio.Runtime")
 	proto.RegisterType((*Agent)(nil), "adagio.Agent")
+	proto.RegisterMapType((map[string]string)(nil), "adagio.Agent.LabelsEntry")
+	proto.RegisterType((*Agent_Work)(nil), "adagio.Agent.Work")
+	proto.RegisterType((*LogLine)(nil), "adagio.LogLine")
+	proto.RegisterType((*Webhook)(nil), "adagio.Webhook")
+	proto.RegisterType((*Notification)(nil), "adagio.Notification")
+	proto.RegisterType((*Delivery)(nil), "adagio.Delivery")
+	proto.RegisterType((*AuditEvent)(nil), "adagio.AuditEvent")
 	proto.RegisterType((*Claim)(nil), "adagio.Claim")
 	proto.RegisterMapType((map[string]*MetadataValue)(nil), "adagio.Claim.MetadataEntry")
 	proto.RegisterType((*Stats)(nil), "adagio.Stats")
+	proto.RegisterMapType((map[string]int64)(nil), "adagio.Stats.UnschedulableCountsEntry")
 	proto.RegisterType((*Stats_NodeCounts)(nil), "adagio.Stats.NodeCounts")
 }
 
 func init() { proto.RegisterFile("pkg/adagio/adagio.proto", fileDescriptor_5eb97351c0f66fbe) }
 
 var fileDescriptor_5eb97351c0f66fbe = []byte{
-	// 1020 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x56, 0xcd, 0x73, 0xdb, 0x54,
-	0x10, 0xaf, 0x64, 0xc9, 0x89, 0x57, 0x71, 0xea, 0x3e, 0x4a, 0x2b, 0xdc, 0x96, 0xa4, 0xca, 0xd0,
-	0x84, 0x66, 0xe2, 0x40, 0x38, 0x90, 0xc0, 0xc0, 0x60, 0x6c, 0xb5, 0x64, 0xa6, 0x75, 0xca, 0x73,
-	0xca, 0xd7, 0x25, 0xf3, 0x2a, 0x3d, 0x1c, 0x4d, 0xad, 0x8f, 0x91, 0x9e, 0x4a, 0x7d, 0xe5, 0xaf,
-	0xe0, 0x06, 0x07, 0xae, 0xfc, 0x2f, 0xdc, 0xf9, 0x47, 0x38, 0x32, 0xef, 0x43, 0xb2, 0x14, 0xdb,
-	0xcc, 0x70, 0xe8, 0x0c, 0x27, 0xbd, 0xdd, 0xfd, 0xed, 0xee, 0xdb, 0x8f, 0xb7, 0x2b, 0xb8, 0x9d,
-	0xbc, 0x9c, 0x1c, 0x12, 0x9f, 0x4c, 0x82, 0x58, 0x7d, 0x7a, 0x49, 0x1a, 0xb3, 0x18, 0x35, 0x25,
-	0xe5, 0xfc, 0xa5, 0x41, 0x03, 0xe7, 0x11, 0xda, 0x04, 0x3d, 0xf0, 0x6d, 0x6d, 0x5b, 0xdb, 0x6b,
-	0x61, 0x3d, 0xf0, 0xd1, 0x3d, 0x00, 0x2f, 0xa5, 0x84, 0x51, 0xff, 0x82, 0x30, 0x5b, 0x17, 0xfc,
-	0x96, 0xe2, 0xf4, 0x19, 0x72, 0xc0, 0x8c, 0x62, 0x9f, 0x66, 0x76, 0x63, 0xbb, 0xb1, 0x67, 0x1d,
-	0x6d, 0xf4, 0x94, 0xf1, 0x51, 0xec, 0x53, 0x2c, 0x45, 0x1c, 0x43, 0xfd, 0x09, 0xcd, 0x6c, 0xa3,
-	0x8e, 0x71, 0xfd, 0x09, 0xc5, 0x52, 0x84, 0x1e, 0x42, 0x33, 0x63, 0x84, 0xe5, 0x99, 0x6d, 0x6e,
-	0x6b, 0x7b, 0x9b, 0x47, 0xa8, 0x00, 0xe1, 0x3c, 0xea, 0x8d, 0x85, 0x04, 0x2b, 0x84, 0xf3, 0x21,
-	0x34, 0x25, 0x07, 0x59, 0xb0, 0xf6, 0x6d, 0xff, 0xf4, 0xfc, 0x74, 0xf4, 0xb8, 0x73, 0x8d, 0x13,
-	0xf8, 0xf9, 0x68, 0xc4, 0x09, 0x0d, 0xb5, 0xa1, 0x35, 0x38, 0x7b, 0xfa, 0xec, 0x89, 0x7b, 0xee,
-	0x0e, 0x3b, 0xba, 0xf3, 0xab, 0x06, 0xa6, 0xfb, 0x8a, 0x46, 0x0c, 0x3d, 0x00, 0x83, 0xcd, 0x12,
-	0x2a, 0x22, 0xac, 0xb8, 0x11, 0xc2, 0xde, 0xf9, 0x2c, 0xa1, 0x58, 0xc8, 0xd1, 0x4d, 0x30, 0xd3,
-	0x3c, 0x3a, 0x1d, 0xaa, 0x90, 0x25, 0x81, 0x0e, 0x60, 0x9d, 0xc7, 0x34, 0x4e, 0xa8, 0x67, 0x37,
-	0xb6, 0xb5, 0x3d, 0xeb, 0xe8, 0x46, 0x35, 0xe2, 0x1e, 0x17, 0xe0, 0x12, 0xe2, 0xbc, 0x0f, 0x06,
-	0x37, 0x89, 0x36, 0x01, 0x46, 0x67, 0x43, 0xf7, 0x02, 0xbb, 0xfd, 0xe1, 0xf7, 0x9d, 0x6b, 0xe8,
-	0x06, 0xb4, 0x05, 0x7d, 0x86, 0x9f, 0x7d, 0xd5, 0x1f, 0xb9, 0xc3, 0x8e, 0xe6, 0x7c, 0x07, 0xad,
-	0xc7, 0x29, 0x49, 0x2e, 0xb9, 0x1e, 0xda, 0x2d, 0xb2, 0xaa, 0x89, 0x8c, 0x2d, 0xf1, 0x71, 0x35,
-	0xb5, 0xfa, 0xca, 0xd4, 0x3a, 0xbb, 0xd0, 0x7e, 0x4a, 0x19, 0xf1, 0x09, 0x23, 0xdf, 0x90, 0x69,
-	0x4e, 0xd1, 0x2d, 0x68, 0xbe, 0xe2, 0x07, 0x69, 0xbe, 0x85, 0x15, 0xe5, 0xfc, 0xb9, 0x0e, 0x06,
-	0xf7, 0x80, 0xde, 0x03, 0x23, 0xe3, 0x11, 0x6a, 0xab, 0x22, 0x14, 0x62, 0xb4, 0x5f, 0xd6, 0x4c,
-	0x17, 0xc9, 0x7c, 0xab, 0x0e, 0xac, 0x15, 0x0d, 0x1d, 0xc2, 0x3a, 0x61, 0x8c, 0x86, 0x09, 0x2b,
-	0x7a, 0xa5, 0x0e, 0xc7, 0x34, 0xcb, 0xa7, 0x0c, 0x97, 0x20, 0xde, 0x78, 0x19, 0x23, 0xa9, 0x6a,
-	0x3c, 0x43, 0x36, 0x9e, 0xe2, 0xf4, 0x19, 0xda, 0x02, 0xeb, 0xc7, 0x20, 0x0a, 0xb2, 0x4b, 0x29,
-	0x37, 0x85, 0x1c, 0x0a, 0x56, 0x9f, 0xa1, 0x0f, 0xa0, 0x19, 0x44, 0x49, 0xce, 0x32, 0xbb, 0x29,
-	0xdc, 0xd9, 0x35, 0x77, 0xa7, 0x42, 0xe4, 0x46, 0x2c, 0x9d, 0x61, 0x85, 0x43, 0x3b, 0x60, 0x7a,
-	0x53, 0x12, 0x84, 0xf6, 0x9a, 0x88, 0xbb, 0x5d, 0x28, 0x0c, 0x38, 0x13, 0x4b, 0x59, 0xf7, 0x6f,
-	0x1d, 0x0c, 0x51, 0x23, 0x04, 0x46, 0x44, 0x42, 0xaa, 0x9e, 0x8a, 0x38, 0x23, 0x1b, 0xd6, 0xd2,
-	0x3c, 0x62, 0x41, 0x48, 0x55, 0xdb, 0x14, 0x24, 0xfa, 0x14, 0xd6, 0x43, 0x55, 0x04, 0x15, 0xfe,
-	0xd6, 0x42, 0x5a, 0x7b, 0x45, 0x99, 0xe4, 0xb5, 0x4a, 0x05, 0x74, 0x04, 0x66, 0x4a, 0x59, 0x3a,
-	0x53, 0x0f, 0xe8, 0xee, 0xa2, 0x26, 0xe6, 0x62, 0xa9, 0x26, 0xa1, 0xdd, 0x87, 0x60, 0x0a, 0x26,
-	0xba, 0x0f, 0x1b, 0x21, 0x79, 0x7d, 0x51, 0x26, 0x9f, 0xdf, 0xd7, 0xc4, 0x56, 0x48, 0x5e, 0xf7,
-	0x15, 0xab, 0x8b, 0xe7, 0x1d, 0x22, 0x6c, 0xa0, 0x0e, 0x34, 0x5e, 0xd2, 0x99, 0x0a, 0x8d, 0x1f,
-	0xd1, 0x3e, 0x98, 0xa2, 0x4b, 0x44, 0x5c, 0xd6, 0xd1, 0xdb, 0xc5, 0x15, 0x6a, 0x9d, 0x85, 0x25,
-	0xe6, 0x13, 0xfd, 0x58, 0xeb, 0x7e, 0x0d, 0x30, 0xbf, 0xd4, 0x12, 0x83, 0x07, 0x75, 0x83, 0xb7,
-	0x57, 0xc4, 0x54, 0x35, 0xf9, 0x87, 0x0e, 0x4d, 0xd9, 0x26, 0xe8, 0x73, 0x00, 0x2f, 0x8e, 0xbc,
-	0x69, 0x9e, 0x05, 0x71, 0xa4, 0xde, 0xf2, 0xbb, 0x4b, 0xfa, 0xa9, 0x37, 0x28, 0x51, 0xb8, 0xa2,
-	0x81, 0x3e, 0xab, 0x94, 0x43, 0x3e, 0x9d, 0xfb, 0xcb, 0xb4, 0x57, 0x15, 0xe4, 0x16, 0x34, 0xe3,
-	0x9c, 0x25, 0x39, 0x13, 0x43, 0x60, 0x03, 0x2b, 0xea, 0x4d, 0x24, 0xd2, 0x39, 0x06, 0x98, 0x07,
-	0x81, 0xf8, 0x13, 0x3d, 0x1b, 0xb9, 0x72, 0xdc, 0x8d, 0x9f, 0x0f, 0x06, 0xee, 0x78, 0xdc, 0xd1,
-	0x38, 0xfb, 0x51, 0xff, 0xf4, 0x49, 0x47, 0x47, 0x2d, 0x30, 0x5d, 0x8c, 0xcf, 0x70, 0xa7, 0xd1,
-	0x3d, 0x01, 0xab, 0xd2, 0xe6, 0x4b, 0xee, 0x72, 0xb3, 0x7a, 0x97, 0x8d, 0xaa, 0xd3, 0x47, 0xe5,
-	0x88, 0xad, 0x39, 0x2c, 0x86, 0xad, 0xc6, 0xdd, 0xc8, 0x61, 0xa6, 0x57, 0xe7, 0x6e, 0xa3, 0x3e,
-	0x77, 0x0d, 0xe7, 0x0b, 0x30, 0xf8, 0x28, 0xe2, 0x09, 0xcb, 0xe2, 0x3c, 0xf5, 0x8a, 0xe7, 0xa2,
-	0x28, 0xb4, 0x0d, 0x96, 0x4f, 0x33, 0x16, 0x44, 0x84, 0xf1, 0x42, 0xca, 0x47, 0x53, 0x65, 0x39,
-	0xbf, 0xcc, 0x8b, 0x7e, 0xb2, 0xa4, 0xe8, 0xef, 0x94, 0x7b, 0xe2, 0x5f, 0xeb, 0x7d, 0xbc, 0x50,
-	0xef, 0xbb, 0x57, 0x14, 0xff, 0x0f, 0xa5, 0x3e, 0xf8, 0x4f, 0xa5, 0x76, 0xee, 0xc1, 0x1a, 0x56,
-	0xe3, 0x65, 0xc9, 0x30, 0x72, 0x86, 0x60, 0xf6, 0x27, 0x7c, 0xe5, 0x5d, 0x5d, 0xe9, 0xfb, 0xb0,
-	0xae, 0xc6, 0x52, 0xb1, 0x37, 0xae, 0x57, 0xb6, 0x2d, 0xe7, 0xe3, 0x12, 0xe0, 0xfc, 0xae, 0x81,
-	0x29, 0x06, 0xe0, 0x82, 0x99, 0x8f, 0x17, 0x72, 0x7a, 0xa7, 0x36, 0x31, 0x57, 0xa5, 0xf4, 0x8d,
-	0xa4, 0xee, 0x67, 0x1d, 0x4c, 0xde, 0xb1, 0x19, 0xba, 0x03, 0xad, 0x34, 0x8f, 0x2e, 0xbc, 0x38,
-	0x8f, 0x98, 0x30, 0xd9, 0x10, 0xd1, 0x0c, 0x38, 0x8d, 0x4e, 0xc0, 0xe2, 0x8b, 0x53, 0x4a, 0x33,
-	0x65, 0xbd, 0xdc, 0x0c, 0xc2, 0x80, 0x18, 0x00, 0x02, 0x9d, 0x61, 0x88, 0xca, 0x73, 0xf7, 0x37,
-	0x0d, 0x60, 0x2e, 0x42, 0x3b, 0xd0, 0xfe, 0x89, 0x04, 0x2c, 0x88, 0x26, 0x35, 0x57, 0x1b, 0x8a,
-	0x29, 0xdd, 0x6d, 0x81, 0x95, 0x52, 0xe2, 0xcf, 0x14, 0x44, 0x17, 0x10, 0x10, 0x2c, 0x09, 0xd8,
-	0x81, 0x76, 0x9a, 0x47, 0xd1, 0xdc, 0x4a, 0x43, 0x5a, 0x51, 0x4c, 0x09, 0xda, 0x85, 0xeb, 0x5e,
-	0x1c, 0x26, 0x53, 0xca, 0x77, 0xa1, 0x84, 0x19, 0x02, 0xb6, 0x59, 0xb2, 0x05, 0xf0, 0xcb, 0xbd,
-	0x1f, 0x1e, 0x4c, 0x02, 0x76, 0x99, 0xbf, 0xe8, 0x79, 0x71, 0x78, 0x38, 0xa1, 0x71, 0x3a, 0xa1,
-	0x21, 0xf1, 0x8a, 0xff, 0xbe, 0xf9, 0x2f, 0xe0, 0x8b, 0xa6, 0xf8, 0xf9, 0xfb, 0xe8, 0x9f, 0x00,
-	0x00, 0x00, 0xff, 0xff, 0x87, 0x66, 0xce, 0x82, 0x17, 0x0a, 0x00, 0x00,
+	// 2587 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x59, 0xcd, 0x93, 0x1b, 0x47,
+	0x15, 0xcf, 0x68, 0x34, 0x23, 0xe9, 0x69, 0x57, 0x96, 0x3b, 0x8e, 0x3d, 0x56, 0xec, 0x64, 0xa3,
+	0x24, 0xf6, 0x16, 0x8e, 0xe5, 0xd8, 0xa4, 0xc0, 0x76, 0xc0, 0x89, 0xb2, 0x9a, 0xc4, 0xaa, 0xac,
+	0xb5, 0x9b, 0xd6, 0x6e, 0x42, 0x38, 0xa0, 0xea, 0x9d, 0x69, 0x6b, 0x07, 0x4b, 0x33, 0xc3, 0x4c,
+	0x8f, 0xb3, 0x7b, 0xe6, 0xcc, 0x8d, 0x03, 0x17, 0xaa, 0xa0, 0x8a, 0x3f, 0x80, 0x13, 0xd7, 0x5c,
+	0xb8, 0x73, 0x80, 0x1b, 0xfc, 0x0d, 0x54, 0x01, 0xff, 0x00, 0xd5, 0x1f, 0xf3, 0xa5, 0x8f, 0x2c,
+	0x8b, 0xcb, 0x27, 0xcd, 0x7b, 0xef, 0xd7, 0x5f, 0xaf, 0xdf, 0x57, 0x3f, 0xc1, 0x95, 0xf0, 0xd9,
+	0xf4, 0x0e, 0x71, 0xc9, 0xd4, 0x0b, 0xd4, 0x4f, 0x2f, 0x8c, 0x02, 0x16, 0x20, 0x53, 0x52, 0xdd,
+	0x3f, 0x56, 0x41, 0xc7, 0x89, 0x8f, 0x5a, 0x50, 0xf1, 0x5c, 0x4b, 0xdb, 0xd2, 0xb6, 0x1b, 0xb8,
+	0xe2, 0xb9, 0xe8, 0x3a, 0x80, 0x13, 0x51, 0xc2, 0xa8, 0x3b, 0x21, 0xcc, 0xaa, 0x08, 0x7e, 0x43,
+	0x71, 0xfa, 0x0c, 0x75, 0xc1, 0xf0, 0x03, 0x97, 0xc6, 0x96, 0xbe, 0xa5, 0x6f, 0x37, 0xef, 0x6d,
+	0xf4, 0xd4, 0xe4, 0xa3, 0xc0, 0xa5, 0x58, 0x8a, 0x38, 0x86, 0xba, 0x53, 0x1a, 0x5b, 0xd5, 0x32,
+	0xc6, 0x76, 0xa7, 0x14, 0x4b, 0x11, 0xfa, 0x1e, 0x98, 0x31, 0x23, 0x2c, 0x89, 0x2d, 0x63, 0x4b,
+	0xdb, 0x6e, 0xdd, 0x43, 0x29, 0x08, 0x27, 0x7e, 0x6f, 0x2c, 0x24, 0x58, 0x21, 0xd0, 0x36, 0x98,
+	0x21, 0x89, 0xa8, 0xcf, 0x2c, 0x73, 0x4b, 0xdb, 0x6e, 0xde, 0x6b, 0x17, 0xb1, 0xbb, 0x9e, 0xff,
+	0x0c, 0x2b, 0x39, 0x7a, 0x0f, 0xea, 0xce, 0xb1, 0x37, 0x73, 0x23, 0xea, 0x5b, 0xb5, 0x2d, 0x7d,
+	0x25, 0x36, 0x43, 0xa0, 0x9b, 0x70, 0x61, 0x4e, 0x4e, 0x26, 0x21, 0x89, 0xc8, 0x6c, 0x46, 0x67,
+	0x5e, 0x3c, 0xb7, 0xea, 0x5b, 0xda, 0xb6, 0x81, 0x5b, 0x73, 0x72, 0xb2, 0x9f, 0x73, 0x51, 0x07,
+	0xea, 0x61, 0xe4, 0x05, 0x91, 0xc7, 0x4e, 0xad, 0x86, 0x40, 0x64, 0x34, 0xfa, 0x04, 0x36, 0x59,
+	0x44, 0x1c, 0x3a, 0x71, 0x02, 0x9f, 0xd1, 0x13, 0x66, 0x81, 0x58, 0xf7, 0x7a, 0x71, 0xdd, 0x03,
+	0x0e, 0xd8, 0x91, 0x72, 0xdb, 0x67, 0xd1, 0x29, 0xde, 0x60, 0x05, 0x56, 0xe7, 0x2e, 0x54, 0xf9,
+	0xd6, 0xd0, 0x6b, 0x60, 0x46, 0x89, 0x3f, 0xc9, 0xee, 0xc3, 0x88, 0x12, 0x7f, 0xe8, 0x22, 0x04,
+	0x55, 0xae, 0x58, 0x75, 0x19, 0xe2, 0xbb, 0xf3, 0x11, 0x5c, 0x5c, 0x9a, 0x15, 0xb5, 0x41, 0x7f,
+	0x46, 0x4f, 0xd5, 0x60, 0xfe, 0x89, 0x2e, 0x81, 0xf1, 0x9c, 0xcc, 0x92, 0x74, 0xac, 0x24, 0x1e,
+	0x56, 0xee, 0x6b, 0xdd, 0xbb, 0x60, 0x4a, 0x35, 0xa3, 0x26, 0xd4, 0xbe, 0xea, 0x0f, 0x0f, 0x86,
+	0xa3, 0xcf, 0xda, 0xaf, 0x70, 0x02, 0x1f, 0x8e, 0x46, 0x9c, 0xd0, 0xd0, 0x26, 0x34, 0x76, 0xf6,
+	0x9e, 0xec, 0xef, 0xda, 0x07, 0xf6, 0xa0, 0x5d, 0xe9, 0xfe, 0xb5, 0x02, 0x86, 0xfd, 0x9c, 0xeb,
+	0xf9, 0x06, 0x54, 0xd9, 0x69, 0x48, 0x2d, 0xad, 0x7c, 0x77, 0x42, 0xd8, 0x3b, 0x38, 0x0d, 0x29,
+	0x16, 0x72, 0xbe, 0x3c, 0x3f, 0xc2, 0x20, 0x5d, 0x5e, 0x10, 0xe8, 0x36, 0xd4, 0xf9, 0x19, 0xc6,
+	0x21, 0x75, 0x2c, 0x5d, 0xdc, 0xe8, 0xc5, 0xa2, 0x19, 0xf5, 0xb8, 0x00, 0x67, 0x90, 0x92, 0xf6,
+	0xab, 0x0b, 0xda, 0x1f, 0x2c, 0x6a, 0xdf, 0x10, 0xda, 0x7f, 0x73, 0x61, 0x47, 0x67, 0xe8, 0xff,
+	0x85, 0x95, 0xf9, 0x10, 0xaa, 0xfc, 0xd4, 0xa8, 0x05, 0x30, 0xda, 0x1b, 0xd8, 0x13, 0x6c, 0xf7,
+	0x07, 0x5f, 0xb7, 0x5f, 0x41, 0x17, 0x61, 0x53, 0xd0, 0x7b, 0x78, 0xff, 0x71, 0x7f, 0x64, 0x0f,
+	0xda, 0x1a, 0xba, 0x00, 0x4d, 0x7c, 0x38, 0x9a, 0x1c, 0xee, 0x0f, 0xfa, 0x52, 0xab, 0xbf, 0xd5,
+	0xa0, 0xf1, 0x59, 0x44, 0xc2, 0x63, 0x71, 0xd8, 0x9b, 0xa9, 0x7f, 0x69, 0x5b, 0xfa, 0x6a, 0xc5,
+	0x2c, 0x3a, 0x59, 0x65, 0xbd, 0x93, 0xad, 0x30, 0x70, 0xfd, 0x4c, 0x03, 0x5f, 0x50, 0x71, 0xf7,
+	0x26, 0x6c, 0x3e, 0xa1, 0x8c, 0xb8, 0x84, 0x91, 0x2f, 0xf9, 0x81, 0xd1, 0x65, 0x30, 0xc5, 0xc9,
+	0xe5, 0x1e, 0x1b, 0x58, 0x51, 0xdd, 0x5f, 0x5d, 0x81, 0x2a, 0xdf, 0x26, 0x7a, 0x17, 0xaa, 0x31,
+	0xbf, 0x5b, 0x6d, 0xdd, 0xdd, 0x0a, 0x31, 0xba, 0x95, 0x85, 0x80, 0x8a, 0x30, 0xa3, 0x57, 0xcb,
+	0xc0, 0x72, 0x0c, 0xb8, 0x03, 0x75, 0xc2, 0x18, 0x9d, 0x87, 0x2c, 0x0d, 0x3d, 0x65, 0x38, 0xa6,
+	0x71, 0x32, 0x63, 0x38, 0x03, 0xf1, 0x38, 0x16, 0x33, 0x12, 0xa9, 0x38, 0x56, 0x95, 0x71, 0x4c,
+	0x71, 0xfa, 0x0c, 0xbd, 0x09, 0xcd, 0xa7, 0x9e, 0xef, 0xc5, 0xc7, 0x52, 0x6e, 0x08, 0x39, 0xa4,
+	0xac, 0x3e, 0x43, 0xef, 0x83, 0xe9, 0xf9, 0x61, 0xc2, 0x62, 0xcb, 0x14, 0xcb, 0x59, 0xa5, 0xe5,
+	0x86, 0x42, 0x24, 0x6d, 0x49, 0xe1, 0xd0, 0xdb, 0x60, 0x38, 0x33, 0xe2, 0xcd, 0xad, 0x9a, 0x38,
+	0xf7, 0x66, 0x3a, 0x60, 0x87, 0x33, 0xb1, 0x94, 0xf1, 0x6d, 0xf9, 0x01, 0x9b, 0x1c, 0xd1, 0xa7,
+	0x41, 0x44, 0x45, 0xb8, 0x69, 0xe0, 0x86, 0x1f, 0xb0, 0x4f, 0x04, 0x03, 0x5d, 0x85, 0x7a, 0x44,
+	0x89, 0x7b, 0xca, 0xf7, 0xd4, 0x10, 0xc2, 0x9a, 0xa0, 0xfb, 0x0c, 0xdd, 0xe5, 0x77, 0x14, 0x4c,
+	0x23, 0x1a, 0xc7, 0x16, 0x88, 0x15, 0x5e, 0x2b, 0x6d, 0x69, 0x5f, 0x09, 0x71, 0x06, 0x43, 0x77,
+	0xa1, 0x76, 0xec, 0xc5, 0x2c, 0x88, 0x4e, 0xad, 0xa6, 0x38, 0xc4, 0x95, 0xd2, 0x88, 0x83, 0x88,
+	0xf8, 0xb1, 0xc7, 0xbc, 0xc0, 0xc7, 0x29, 0xae, 0xf3, 0x3b, 0x80, 0xaa, 0x30, 0x44, 0x1e, 0x74,
+	0xc8, 0x9c, 0x2a, 0xfb, 0x17, 0xdf, 0xc8, 0x82, 0x5a, 0x94, 0xf8, 0xcc, 0x9b, 0xa7, 0x2e, 0x90,
+	0x92, 0xe8, 0x43, 0xa8, 0xcf, 0x95, 0x91, 0x58, 0x7a, 0xd9, 0x05, 0xb3, 0x6b, 0xef, 0xa5, 0x66,
+	0x24, 0xd5, 0x96, 0x0d, 0x40, 0xf7, 0xc0, 0x88, 0x28, 0x8b, 0x4e, 0x55, 0xbe, 0xb8, 0xb6, 0x3c,
+	0x12, 0x73, 0xb1, 0x1c, 0x26, 0xa1, 0xe8, 0x26, 0xe8, 0x73, 0x12, 0x5a, 0xc6, 0x0a, 0x45, 0xc8,
+	0xb5, 0x48, 0x88, 0x39, 0x02, 0xfd, 0x00, 0xea, 0x24, 0x0c, 0xa3, 0xe0, 0x39, 0x99, 0xa9, 0xf4,
+	0xd1, 0x59, 0x46, 0xf7, 0x15, 0x02, 0x67, 0x58, 0x3e, 0x2e, 0xa6, 0x33, 0xea, 0xb0, 0x20, 0xb2,
+	0x6a, 0xeb, 0xc6, 0x8d, 0x15, 0x02, 0x67, 0x58, 0xf4, 0x08, 0x1a, 0x11, 0x8d, 0x83, 0x24, 0x72,
+	0x68, 0x6c, 0xd5, 0xc5, 0x81, 0xb6, 0x56, 0x1d, 0x48, 0x41, 0xe4, 0xa1, 0xf2, 0x21, 0xdf, 0x95,
+	0x6b, 0x3a, 0x7f, 0xd0, 0xc0, 0x10, 0xaa, 0x40, 0x6f, 0xc1, 0x06, 0xf7, 0xec, 0xcc, 0x25, 0x34,
+	0x81, 0x6c, 0xce, 0xc9, 0x49, 0x5f, 0xb1, 0xd0, 0xdb, 0xb0, 0xe9, 0xf9, 0x1e, 0xf3, 0xc8, 0x6c,
+	0xe2, 0xd2, 0x19, 0x39, 0x55, 0x57, 0xb6, 0xa1, 0x98, 0x03, 0xce, 0x43, 0x6f, 0x00, 0xcc, 0x93,
+	0x19, 0xf3, 0xc2, 0x99, 0x47, 0x23, 0x11, 0x1c, 0x34, 0x5c, 0xe0, 0xa0, 0xd7, 0xa1, 0xc1, 0xd7,
+	0x91, 0x13, 0x48, 0x27, 0xaa, 0xcf, 0xc9, 0x89, 0x1c, 0x7c, 0x19, 0xcc, 0x9f, 0x7b, 0x8c, 0xd1,
+	0x48, 0x5c, 0x83, 0x86, 0x15, 0xd5, 0xb9, 0x0a, 0xfa, 0x13, 0x12, 0x72, 0x0b, 0x0a, 0x9e, 0xd3,
+	0x28, 0xb5, 0x20, 0xfe, 0xdd, 0x79, 0x07, 0xea, 0xa9, 0xae, 0xb9, 0x35, 0x71, 0xdb, 0x09, 0x12,
+	0xa6, 0x20, 0x29, 0xd9, 0xf9, 0x93, 0x0e, 0xf5, 0x54, 0xb5, 0x68, 0xc4, 0x8f, 0xca, 0x9c, 0xe3,
+	0xc9, 0x8c, 0x1c, 0xd1, 0x59, 0x1a, 0x18, 0x6f, 0xad, 0xbf, 0x8c, 0xde, 0x13, 0x0e, 0xdf, 0x15,
+	0x68, 0xa9, 0xde, 0xe6, 0x3c, 0xe7, 0xa0, 0x31, 0x5c, 0x94, 0xf3, 0xd1, 0x93, 0x90, 0x7b, 0x89,
+	0x17, 0xf8, 0x69, 0x10, 0xbd, 0xf1, 0x1d, 0x93, 0x62, 0xfa, 0x8b, 0xc4, 0x8b, 0xe8, 0x9c, 0xfa,
+	0x0c, 0xb7, 0xc5, 0x04, 0x76, 0x3e, 0xbe, 0xf3, 0x67, 0x0d, 0x9a, 0x05, 0xc4, 0x8a, 0xe4, 0xf1,
+	0x39, 0xd4, 0x83, 0x90, 0x46, 0x84, 0xdb, 0x93, 0x8c, 0x77, 0x77, 0xfe, 0xb7, 0xd5, 0x7a, 0x7b,
+	0x6a, 0x18, 0xce, 0x26, 0x28, 0x84, 0x60, 0xbd, 0x14, 0x82, 0x1f, 0x41, 0x3d, 0x45, 0x23, 0x13,
+	0x2a, 0xc3, 0x51, 0xfb, 0x15, 0x04, 0x60, 0x8e, 0xf6, 0x0e, 0x26, 0xc3, 0x51, 0x5b, 0xe3, 0xdf,
+	0xf6, 0x4f, 0x86, 0xe3, 0x83, 0x71, 0xbb, 0x82, 0x10, 0xb4, 0x06, 0x7b, 0xf6, 0x78, 0xc2, 0x85,
+	0x82, 0xd9, 0xd6, 0x3b, 0x8f, 0xa0, 0xbd, 0xa8, 0xbc, 0xf3, 0xe4, 0xc1, 0x0e, 0xce, 0x73, 0xc5,
+	0xba, 0xc1, 0xb7, 0x8a, 0x83, 0x0b, 0xae, 0x5b, 0xca, 0x31, 0xc5, 0x39, 0xbf, 0x00, 0xc8, 0xdd,
+	0x7f, 0xc5, 0x84, 0xb7, 0xcb, 0x13, 0x5e, 0x59, 0x13, 0x3d, 0x8a, 0x53, 0xfe, 0x08, 0x5a, 0x65,
+	0x07, 0x3c, 0xeb, 0x90, 0x46, 0x71, 0xf4, 0xb7, 0x3a, 0x98, 0x32, 0xdd, 0xa0, 0x47, 0x00, 0x4e,
+	0xe0, 0x3b, 0xb3, 0x84, 0x5b, 0x81, 0xaa, 0x86, 0xde, 0x58, 0x91, 0x97, 0x7a, 0x3b, 0x19, 0x0a,
+	0x17, 0x46, 0xa0, 0x1f, 0x17, 0xc2, 0xa6, 0x34, 0xc1, 0xb7, 0x56, 0x8d, 0x5e, 0x17, 0x38, 0x2f,
+	0x83, 0x19, 0x24, 0x2c, 0x4c, 0x98, 0xf0, 0xdc, 0x0d, 0xac, 0xa8, 0x17, 0xce, 0x7d, 0x57, 0xa1,
+	0x2e, 0xb2, 0x15, 0xaf, 0x44, 0x4d, 0xe9, 0x9a, 0x82, 0x1e, 0xba, 0x5c, 0x44, 0xa6, 0xd4, 0x67,
+	0x5c, 0x54, 0x93, 0x22, 0x41, 0x0f, 0x5d, 0x1e, 0xb9, 0x8e, 0x83, 0x98, 0x89, 0xac, 0x21, 0x13,
+	0x5b, 0x46, 0xbf, 0x0c, 0xc3, 0xe8, 0xde, 0x07, 0xc8, 0xd5, 0x8a, 0xea, 0x50, 0x1d, 0xed, 0x8d,
+	0x6c, 0x59, 0xc2, 0x8e, 0x0f, 0x77, 0x76, 0xec, 0xf1, 0xb8, 0xad, 0x71, 0xf6, 0xa7, 0xfd, 0xe1,
+	0x6e, 0xbb, 0x82, 0x1a, 0x60, 0xd8, 0x18, 0xef, 0xe1, 0xb6, 0xde, 0xf9, 0xb7, 0x06, 0xf5, 0x34,
+	0x5d, 0xf2, 0x30, 0x14, 0xd2, 0xc8, 0xe1, 0xcf, 0x0b, 0x4d, 0x84, 0xb1, 0x94, 0xe4, 0x92, 0x39,
+	0x8d, 0x63, 0x32, 0xcd, 0xd2, 0x9d, 0x22, 0xd1, 0x47, 0x4b, 0xe9, 0xee, 0xed, 0x95, 0xb9, 0x78,
+	0xed, 0xcd, 0x5d, 0x07, 0x48, 0x42, 0x97, 0x94, 0x6f, 0x48, 0x71, 0xfa, 0xec, 0xa5, 0xf8, 0xd1,
+	0x5f, 0x2a, 0x00, 0x79, 0xc6, 0x47, 0xef, 0x97, 0x4a, 0xf8, 0x6b, 0x6b, 0x0a, 0x83, 0x62, 0x31,
+	0xdf, 0x82, 0x4a, 0xf6, 0x22, 0xac, 0x10, 0xa1, 0x1e, 0x95, 0x7f, 0x54, 0x55, 0x99, 0x92, 0x25,
+	0x23, 0xa9, 0x96, 0x8d, 0xa4, 0x68, 0x5a, 0x46, 0xd9, 0xb4, 0xca, 0xce, 0x64, 0x9e, 0xd7, 0x99,
+	0xba, 0x81, 0x2a, 0xc2, 0x73, 0x4b, 0x68, 0x80, 0x21, 0x2b, 0x71, 0x8d, 0x1b, 0xc5, 0xce, 0x6e,
+	0x7f, 0xf8, 0x84, 0x97, 0xdc, 0xa2, 0x06, 0xb7, 0xc7, 0x3b, 0x8f, 0xed, 0xc1, 0xe1, 0xae, 0x3d,
+	0x68, 0xeb, 0x68, 0x03, 0xea, 0x9f, 0x0e, 0x47, 0xc3, 0xf1, 0x63, 0x7b, 0xd0, 0xae, 0x72, 0x2a,
+	0x2b, 0xd8, 0x0d, 0x3e, 0x12, 0xdb, 0x07, 0x78, 0x68, 0x0f, 0xda, 0xa6, 0xb0, 0xad, 0xcf, 0x87,
+	0xfb, 0xfb, 0xf6, 0xa0, 0x5d, 0xeb, 0x3c, 0x80, 0x66, 0xa1, 0x0e, 0x3c, 0x2b, 0x86, 0x6c, 0x14,
+	0x6d, 0xf7, 0x28, 0x7b, 0x7d, 0x95, 0xec, 0x36, 0x7d, 0x87, 0x69, 0xf9, 0xd6, 0x2b, 0xc5, 0x27,
+	0x99, 0x5e, 0x7e, 0x92, 0x55, 0x8b, 0xfb, 0x31, 0xb8, 0x2c, 0x3f, 0x94, 0xd9, 0xfd, 0x9b, 0x0e,
+	0x55, 0xfe, 0x1a, 0xe0, 0x61, 0x42, 0x06, 0x3b, 0xb5, 0x37, 0x45, 0xa1, 0x2d, 0x68, 0xba, 0x34,
+	0x66, 0x9e, 0x4f, 0xf8, 0x55, 0xab, 0x9b, 0x2d, 0xb2, 0xd0, 0x07, 0xd0, 0x70, 0x02, 0xdf, 0x15,
+	0xa6, 0xa0, 0x9e, 0x6a, 0x97, 0x8b, 0x0f, 0x8d, 0xde, 0x4e, 0x2a, 0xc5, 0x39, 0xb0, 0xf3, 0xf7,
+	0x0a, 0x34, 0x32, 0x01, 0xfa, 0x18, 0x9a, 0xf9, 0x25, 0xc9, 0xf4, 0x7d, 0xf6, 0xbd, 0x16, 0x87,
+	0xa0, 0x8f, 0x97, 0xa2, 0xe4, 0x3b, 0xab, 0x37, 0xb1, 0xd6, 0xdd, 0x1e, 0x16, 0x02, 0x25, 0x1f,
+	0xdf, 0x5d, 0x33, 0x7e, 0x4f, 0x80, 0x54, 0x59, 0x2f, 0x47, 0x70, 0xed, 0xf9, 0x74, 0x4a, 0x18,
+	0x15, 0xa6, 0x5c, 0xc7, 0x8a, 0xea, 0x7c, 0x78, 0xb6, 0x8f, 0xae, 0x4f, 0x94, 0x0f, 0xa0, 0x59,
+	0x58, 0xeb, 0x5c, 0x6f, 0xcd, 0xdf, 0x54, 0xb2, 0xf4, 0xf3, 0x60, 0x45, 0xfa, 0xb9, 0x9a, 0x1e,
+	0xed, 0xbb, 0x33, 0xcf, 0xfd, 0x25, 0x9d, 0x5e, 0x5b, 0x18, 0x78, 0xce, 0xa4, 0xf3, 0x52, 0x42,
+	0xfc, 0xed, 0x73, 0x85, 0xf8, 0xee, 0x75, 0xa8, 0x61, 0xf5, 0x20, 0x59, 0xf1, 0x7c, 0xe9, 0xfe,
+	0x43, 0x07, 0xa3, 0xcf, 0xe3, 0xd0, 0x52, 0xd3, 0xeb, 0x16, 0xd4, 0xd5, 0x4b, 0x26, 0x2d, 0x05,
+	0x2f, 0x14, 0xfa, 0x37, 0x9c, 0x8f, 0x33, 0x00, 0xba, 0x0b, 0xa6, 0x2a, 0x45, 0xa5, 0x31, 0x65,
+	0x1a, 0x17, 0x73, 0xf7, 0x8a, 0x85, 0xa7, 0x02, 0x96, 0x52, 0x63, 0xb5, 0x9c, 0x1a, 0xb9, 0x9a,
+	0x42, 0x15, 0x0c, 0x0d, 0xcc, 0x3f, 0x79, 0x60, 0x7d, 0x4e, 0xa3, 0x2c, 0x0a, 0x36, 0x70, 0x4a,
+	0x2e, 0x24, 0xf6, 0xda, 0x62, 0x62, 0x7f, 0x17, 0x5a, 0x33, 0x12, 0xb3, 0xc9, 0x31, 0x25, 0x11,
+	0x3b, 0xa2, 0x84, 0xa9, 0x3c, 0xbc, 0xc9, 0xb9, 0x8f, 0x53, 0x26, 0xdf, 0x8d, 0x43, 0x42, 0xe2,
+	0x14, 0x9e, 0x18, 0x29, 0x8d, 0xde, 0x83, 0x9a, 0x93, 0x44, 0xa2, 0xd9, 0x26, 0x1f, 0x99, 0xa8,
+	0x7c, 0xba, 0xaf, 0x82, 0xe8, 0x19, 0x4e, 0x21, 0x9d, 0x7d, 0xa8, 0x72, 0xc6, 0x39, 0x1a, 0x57,
+	0x0b, 0x47, 0xd0, 0x17, 0x8e, 0xc0, 0x1d, 0xe3, 0xff, 0x2c, 0x3e, 0xbb, 0x1f, 0x42, 0x6d, 0x37,
+	0x98, 0xee, 0x7a, 0x3e, 0x45, 0xd7, 0xa0, 0x21, 0xee, 0x8a, 0x91, 0x79, 0xa8, 0x06, 0xe7, 0x0c,
+	0xbe, 0x2d, 0xd1, 0x2b, 0x52, 0xdb, 0xe2, 0xdf, 0xdd, 0x7f, 0x69, 0x50, 0xfb, 0x8a, 0x1e, 0x1d,
+	0x07, 0xc1, 0xb3, 0x25, 0xeb, 0x68, 0x83, 0x9e, 0x44, 0x33, 0x05, 0xe7, 0x9f, 0x22, 0xa2, 0x52,
+	0x27, 0xa2, 0xe9, 0x01, 0x14, 0x85, 0x6e, 0x83, 0x49, 0x79, 0xc7, 0x49, 0xb6, 0x3e, 0x5b, 0xb9,
+	0x85, 0xab, 0xa9, 0x65, 0x3f, 0x0a, 0x2b, 0xd0, 0x42, 0xaf, 0xd5, 0x58, 0xe8, 0xb5, 0x76, 0x7f,
+	0x96, 0xb6, 0xdb, 0x72, 0xc3, 0x6f, 0x01, 0xf0, 0xee, 0x11, 0xb7, 0x77, 0xd1, 0x4d, 0xba, 0x08,
+	0x9b, 0x9c, 0x16, 0xce, 0x60, 0x0f, 0x44, 0x72, 0x6b, 0xc3, 0x86, 0xea, 0x41, 0xc9, 0xa4, 0xa5,
+	0xa3, 0xcb, 0x80, 0xfa, 0xfb, 0xfb, 0x78, 0xef, 0xcb, 0xfe, 0xee, 0x04, 0xdb, 0x5f, 0x1c, 0xda,
+	0x63, 0x91, 0x3c, 0xba, 0xbf, 0xae, 0xc0, 0xc6, 0x28, 0x60, 0xde, 0x53, 0xcf, 0x91, 0xe1, 0x7e,
+	0xd9, 0x2d, 0x0c, 0xb1, 0x53, 0xf5, 0x60, 0x59, 0x73, 0x1a, 0x89, 0x29, 0xd8, 0x80, 0xbe, 0xca,
+	0x06, 0xaa, 0x05, 0x1b, 0x28, 0x54, 0x0e, 0x46, 0xb9, 0x72, 0x78, 0xc1, 0x1a, 0x80, 0xbf, 0x8b,
+	0x9f, 0x12, 0x6f, 0x46, 0xdd, 0x89, 0xec, 0xa2, 0xd5, 0xc4, 0xf3, 0xa8, 0x29, 0x79, 0x7c, 0x7c,
+	0xcc, 0xab, 0xdf, 0xc0, 0x11, 0x06, 0xec, 0x4e, 0x32, 0x0f, 0x81, 0x94, 0xd5, 0x67, 0xdd, 0x5f,
+	0xea, 0x50, 0x1f, 0xd0, 0x99, 0xf7, 0x9c, 0x46, 0xa7, 0xfc, 0x8a, 0xbe, 0x91, 0xa7, 0xcd, 0xad,
+	0xbb, 0xa1, 0x38, 0x43, 0x17, 0xdd, 0x87, 0x0d, 0xbf, 0xa0, 0x41, 0x15, 0xd8, 0x2e, 0xe5, 0x3b,
+	0xce, 0x65, 0xb8, 0x84, 0x44, 0x77, 0xb2, 0xee, 0x97, 0x2e, 0x4e, 0x99, 0xbd, 0x5b, 0xd2, 0xa5,
+	0x17, 0x3b, 0x60, 0x9d, 0x42, 0x07, 0x4c, 0xf5, 0xe8, 0x48, 0xe1, 0xad, 0x1f, 0xd1, 0x38, 0x0c,
+	0xfc, 0x98, 0x77, 0x42, 0x5d, 0xaa, 0xd4, 0xba, 0x91, 0x32, 0x77, 0xb8, 0xd6, 0x2f, 0x81, 0x41,
+	0xa3, 0x28, 0x88, 0x54, 0x50, 0x91, 0xc4, 0x82, 0x0d, 0xd6, 0x16, 0xfb, 0xfd, 0xe5, 0x42, 0xb5,
+	0xbe, 0x50, 0xa8, 0xa2, 0x1b, 0x70, 0xc1, 0xa7, 0x27, 0x2c, 0x6d, 0x44, 0xe4, 0x6d, 0xab, 0x4d,
+	0xce, 0x56, 0xbd, 0x88, 0x3e, 0xeb, 0xbe, 0x5f, 0xec, 0x36, 0xef, 0xdb, 0xa3, 0x81, 0xec, 0x36,
+	0x6f, 0x42, 0x63, 0x60, 0xef, 0x0e, 0xbf, 0xb4, 0xb1, 0xb0, 0x66, 0x00, 0x53, 0x59, 0x76, 0xa5,
+	0xfb, 0xfb, 0x0a, 0x40, 0x3f, 0x71, 0x3d, 0x26, 0x5d, 0x60, 0xd1, 0x34, 0x17, 0x8b, 0xd1, 0x4b,
+	0x60, 0x10, 0xd1, 0xab, 0x51, 0xc6, 0x27, 0x08, 0x6e, 0x7c, 0x21, 0xa5, 0x51, 0x6a, 0x7c, 0xfc,
+	0x9b, 0x7b, 0x73, 0x14, 0x3a, 0xca, 0xdb, 0xf8, 0x67, 0xc1, 0x72, 0xcd, 0xa2, 0xe5, 0x5a, 0x50,
+	0x8b, 0x93, 0xf9, 0x9c, 0x44, 0xa7, 0xe9, 0x4b, 0x47, 0x91, 0xe8, 0x03, 0xa8, 0x05, 0x09, 0x73,
+	0x02, 0xf5, 0xd0, 0x69, 0xe5, 0xad, 0xa1, 0x7c, 0xc7, 0xbd, 0x3d, 0x89, 0xc0, 0x29, 0x34, 0xd7,
+	0x7f, 0xa3, 0xa0, 0xff, 0xee, 0x43, 0xa8, 0x29, 0x64, 0xc1, 0xcd, 0x79, 0x25, 0x97, 0xb9, 0x74,
+	0x49, 0x2f, 0xfc, 0x7b, 0x60, 0x8f, 0x84, 0x63, 0x77, 0xbf, 0xd5, 0xc0, 0x10, 0xdd, 0xc5, 0x25,
+	0xf5, 0xfc, 0x70, 0x29, 0xbd, 0xbf, 0x5e, 0x6a, 0x47, 0xae, 0xcd, 0xee, 0xc5, 0xd2, 0x5d, 0x2f,
+	0x95, 0xee, 0x2f, 0x25, 0xc1, 0xff, 0x47, 0x07, 0x83, 0x1b, 0x46, 0xcc, 0x3b, 0x4d, 0xfc, 0x12,
+	0x9c, 0x20, 0x51, 0x0f, 0x31, 0x5d, 0xa4, 0xdc, 0x1d, 0x4e, 0xa3, 0x07, 0xd0, 0xe4, 0xfe, 0x2c,
+	0xa5, 0xb1, 0x9a, 0x3d, 0xeb, 0xc8, 0x8a, 0x09, 0x44, 0x74, 0x10, 0xe8, 0x18, 0x83, 0x9f, 0x7d,
+	0xa3, 0xaf, 0xe1, 0x52, 0xe2, 0xc7, 0xce, 0x31, 0x75, 0x93, 0x19, 0x39, 0x9a, 0x65, 0x73, 0xe8,
+	0xe5, 0x8e, 0x8f, 0x9c, 0xe3, 0xb0, 0x88, 0x94, 0x13, 0x48, 0x05, 0xbd, 0x9a, 0x2c, 0x4b, 0x3a,
+	0xff, 0xd4, 0x00, 0xf2, 0x55, 0xb9, 0x13, 0x7e, 0x43, 0x3c, 0xe6, 0xf9, 0xd3, 0xd2, 0x29, 0x36,
+	0x14, 0x53, 0x9e, 0xe4, 0x4d, 0x68, 0xca, 0x06, 0xaf, 0x84, 0x54, 0x04, 0x04, 0x04, 0x4b, 0x02,
+	0xb8, 0x2b, 0x27, 0xbe, 0x9f, 0xcf, 0xa2, 0xcb, 0x59, 0x14, 0x53, 0x82, 0x6e, 0xc2, 0x05, 0x27,
+	0x98, 0x87, 0x33, 0xca, 0xfd, 0x52, 0xc2, 0xaa, 0x02, 0xd6, 0xca, 0xd8, 0xd9, 0x6c, 0xf1, 0x33,
+	0x2f, 0x0c, 0x33, 0x98, 0x21, 0x67, 0x53, 0xcc, 0x6c, 0x36, 0x75, 0xb8, 0x0c, 0x66, 0xca, 0xd9,
+	0x32, 0xb6, 0x00, 0x76, 0x3e, 0x05, 0x6b, 0x9d, 0x86, 0xce, 0xca, 0xd4, 0x7a, 0xe1, 0xd6, 0x3f,
+	0xd9, 0xfe, 0xe9, 0x8d, 0xa9, 0xc7, 0x8e, 0x93, 0xa3, 0x9e, 0x13, 0xcc, 0xef, 0x4c, 0x69, 0x10,
+	0x4d, 0xe9, 0x9c, 0x38, 0xe9, 0xff, 0x95, 0xf9, 0x5f, 0x97, 0x47, 0xa6, 0xf8, 0xd3, 0xf2, 0xfb,
+	0xff, 0x1d, 0x00, 0x7d, 0x77, 0x87, 0xd0, 0xcf, 0x1c, 0x00, 0x00,
 }
@@ -0,0 +1,93 @@
+package adagio
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// MetadataApprover is the result metadata key which contains the approver
+	// of an approval node
+	MetadataApprover = "approval.approver"
+	// MetadataComment is the result metadata key which contains the comment
+	// supplied with the approval or rejection of an approval node
+	MetadataComment = "approval.comment"
+)
+
+// IsApproval returns true if the node is an approval node which is resolved
+// by an approval or rejection rather than being claimed and executed
+func IsApproval(node *Node) bool {
+	return node.Spec.Approval != nil
+}
+
+// AwaitingApproval returns true if the node is a ready approval node
+func AwaitingApproval(node *Node) bool {
+	return IsApproval(node) && node.Status == Node_READY
+}
+
+// ApprovalResult constructs the result of an approval node which is successful
+// when approved and a failure when rejected. The approver and comment are
+// stored as metadata and the comment is used as the output
+func ApprovalResult(approved bool, approver, comment string) *Node_Result {
+	conclusion := Node_Result_FAIL
+	if approved {
+		conclusion = Node_Result_SUCCESS
+	}
+
+	return &Node_Result{
+		Conclusion: conclusion,
+		Metadata: map[string]*MetadataValue{
+			MetadataApprover: {Values: []string{approver}},
+			MetadataComment:  {Values: []string{comment}},
+		},
+		Output: []byte(comment),
+	}
+}
+
+// ApprovalExpiredResult constructs the failed result of an approval node
+// which expired before it was approved or rejected
+func ApprovalExpiredResult() *Node_Result {
+	return &Node_Result{
+		Conclusion: Node_Result_FAIL,
+		Output:     []byte(ErrApprovalExpired.Error()),
+	}
+}
+
+// ApprovalExpired returns true if the node is awaiting approval and its timeout
+// has elapsed since it became ready (as recorded by its started at timestamp)
+func ApprovalExpired(node *Node, now time.Time) bool {
+	if !AwaitingApproval(node) || node.Spec.Approval.Timeout == "" {
+		return false
+	}
+
+	timeout, err := time.ParseDuration(node.Spec.Approval.Timeout)
+	if err != nil {
+		return false
+	}
+
+	startedAt, err := time.Parse(time.RFC3339Nano, node.StartedAt)
+	if err != nil {
+		return false
+	}
+
+	return !now.Before(startedAt.Add(timeout))
+}
+
+func validateApprovals(run *Run) error {
+	for _, node := range run.Nodes {
+		if !IsApproval(node) || node.Spec.Approval.Timeout == "" {
+			continue
+		}
+
+		timeout, err := time.ParseDuration(node.Spec.Approval.Timeout)
+		if err != nil {
+			return fmt.Errorf("approval node %q: timeout: %w", node.Spec.Name, err)
+		}
+
+		if timeout <= 0 {
+			return fmt.Errorf("approval node %q: timeout must be positive", node.Spec.Name)
+		}
+	}
+
+	return nil
+}
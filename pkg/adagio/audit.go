@@ -0,0 +1,22 @@
+package adagio
+
+import (
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// NewAuditEvent constructs an audit event for a call to the named RPC
+// initializing its ID and the time at which it was made
+func NewAuditEvent(rpc string) *AuditEvent {
+	mu.Lock()
+	defer mu.Unlock()
+
+	now := time.Now().UTC()
+
+	return &AuditEvent{
+		Id:  ulid.MustNew(ulid.Timestamp(now), entropy).String(),
+		At:  now.Format(time.RFC3339Nano),
+		Rpc: rpc,
+	}
+}
@@ -0,0 +1,188 @@
+package adagio
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// IsResolved returns true once the node has been completed or skipped
+// and will therefore not progress any further
+func IsResolved(node *Node) bool {
+	return node.Status == Node_COMPLETED || node.Status == Node_SKIPPED
+}
+
+// EdgeSatisfied returns true if any edge from source to destination within the run
+// is satisfied by the latest result of the source node.
+// An edge without a condition is satisfied by a successful result. Edges from
+// nodes which have been skipped or completed without an attempt are never satisfied
+func EdgeSatisfied(run *Run, source, destination *Node) bool {
+	for _, edge := range run.Edges {
+		if edge.Source != source.Spec.Name || edge.Destination != destination.Spec.Name {
+			continue
+		}
+
+		if edgeSatisfied(edge, source) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IncomingSatisfied returns true if any of the incoming edges of the node
+// within the run are satisfied.
+// This is a helper function for repository implementations to use once all
+// incoming nodes are resolved in order to decide whether the node is made ready
+// or skipped
+func IncomingSatisfied(run *Run, node *Node) bool {
+	for _, edge := range run.Edges {
+		if edge.Destination != node.Spec.Name {
+			continue
+		}
+
+		source, err := run.GetNodeByName(edge.Source)
+		if err != nil {
+			continue
+		}
+
+		if edgeSatisfied(edge, source) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func edgeSatisfied(edge *Edge, source *Node) (satisfied bool) {
+	if source.Status != Node_COMPLETED {
+		return false
+	}
+
+	VisitLatestAttempt(source, func(result *Node_Result) {
+		if edge.Condition == nil {
+			satisfied = result.Conclusion == Node_Result_SUCCESS
+			return
+		}
+
+		satisfied = ConditionMatches(edge.Condition, result)
+	})
+
+	return
+}
+
+// ConditionMatches returns true if the node result satisfies the condition.
+// The result conclusion must be one of the conditions conclusions (success when none are supplied).
+// Every metadata key must contain the associated value and every output path must
+// resolve to the associated value. JSON strings are compared unquoted, all other values
+// are compared in their compact JSON form. Negate inverts the metadata and output comparisons
+func ConditionMatches(condition *Edge_Condition, result *Node_Result) bool {
+	if !conclusionMatches(condition.Conclusions, result.Conclusion) {
+		return false
+	}
+
+	matches := true
+	for key, value := range condition.Metadata {
+		matches = matches && metadataContains(result.Metadata[key], value)
+	}
+
+	if len(condition.Output) > 0 {
+		var output interface{}
+		if err := json.Unmarshal(result.Output, &output); err != nil {
+			matches = false
+		}
+
+		for path, value := range condition.Output {
+			matches = matches && outputEquals(output, path, value)
+		}
+	}
+
+	return matches != condition.Negate
+}
+
+func conclusionMatches(conclusions []Node_Result_Conclusion, conclusion Node_Result_Conclusion) bool {
+	if len(conclusions) == 0 {
+		return conclusion == Node_Result_SUCCESS
+	}
+
+	for _, c := range conclusions {
+		if c == conclusion {
+			return true
+		}
+	}
+
+	return false
+}
+
+func metadataContains(metadata *MetadataValue, value string) bool {
+	if metadata == nil {
+		return false
+	}
+
+	for _, v := range metadata.Values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func outputEquals(output interface{}, path, expected string) bool {
+	for _, part := range strings.Split(path, ".") {
+		switch v := output.(type) {
+		case map[string]interface{}:
+			field, ok := v[part]
+			if !ok {
+				return false
+			}
+
+			output = field
+		case []interface{}:
+			index, err := strconv.Atoi(part)
+			if err != nil || index < 0 || index >= len(v) {
+				return false
+			}
+
+			output = v[index]
+		default:
+			return false
+		}
+	}
+
+	if str, ok := output.(string); ok {
+		return str == expected
+	}
+
+	data, err := json.Marshal(output)
+	if err != nil {
+		return false
+	}
+
+	return string(data) == expected
+}
+
+func validateConditions(run *Run) error {
+	for _, edge := range run.Edges {
+		if edge.Condition == nil {
+			continue
+		}
+
+		for _, conclusion := range edge.Condition.Conclusions {
+			if conclusion == Node_Result_NONE {
+				return fmt.Errorf("edge %q -> %q: condition conclusion cannot be none", edge.Source, edge.Destination)
+			}
+		}
+
+		for path := range edge.Condition.Output {
+			for _, part := range strings.Split(path, ".") {
+				if part == "" {
+					return fmt.Errorf("edge %q -> %q: condition output path %q is invalid", edge.Source, edge.Destination, path)
+				}
+			}
+		}
+	}
+
+	return nil
+}
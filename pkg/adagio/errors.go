@@ -1,6 +1,10 @@
 package adagio
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
 
 var (
 	// ErrMissingNode is returned when a node is not found
@@ -9,4 +13,62 @@ var (
 	ErrNodeNotReady = errors.New("node not ready")
 	// ErrRunDoesNotExist is returned when a run is referenced which does not exist
 	ErrRunDoesNotExist = errors.New("run does not exist")
+	// ErrNodeRequiresApproval is returned when a claim is made on an approval node
+	ErrNodeRequiresApproval = errors.New("node requires approval")
+	// ErrNodeNotAwaitingApproval is returned when an approval is made on a node
+	// which is not a ready approval node
+	ErrNodeNotAwaitingApproval = errors.New("node not awaiting approval")
+	// ErrApprovalExpired is returned when an approval is made after it has expired
+	ErrApprovalExpired = errors.New("approval expired")
+	// ErrRunCancelled is the output of the nodes which were unresolved when their run was cancelled
+	ErrRunCancelled = errors.New("run cancelled")
+	// ErrNodeScheduled is returned when a claim is made on a rescheduled node
+	// before the time at which it can next be claimed
+	ErrNodeScheduled = errors.New("node scheduled")
+	// ErrNodeUnplaceable is returned when a run contains a node which no
+	// registered agent can claim
+	ErrNodeUnplaceable = errors.New("no agent can claim node")
+	// ErrConcurrencyLimit is returned when a claim is made on a node which would
+	// exceed the max parallelism of its run or the slots of a resource pool
+	ErrConcurrencyLimit = errors.New("concurrency limit reached")
+	// ErrAgentDoesNotExist is returned when an agent is referenced which does not exist
+	ErrAgentDoesNotExist = errors.New("agent does not exist")
+	// ErrClaimNotHeld is returned when progress is reported for a node
+	// which is not running under the provided claim
+	ErrClaimNotHeld = errors.New("claim not held")
+	// ErrInvalidWebhook is returned when a webhook is created or updated with
+	// a malformed URL or without any known events
+	ErrInvalidWebhook = errors.New("invalid webhook")
+	// ErrWebhookDoesNotExist is returned when a webhook is referenced which does not exist
+	ErrWebhookDoesNotExist = errors.New("webhook does not exist")
+	// ErrDeliveryExists is returned when a delivery is created for a notification
+	// which has already been delivered (or is being delivered) to the webhook
+	ErrDeliveryExists = errors.New("delivery already exists")
+	// ErrDeliveryDoesNotExist is returned when a delivery is referenced which does not exist
+	ErrDeliveryDoesNotExist = errors.New("delivery does not exist")
+	// ErrInvalidNamespace is returned when a namespace is referenced whose name
+	// is not a lower case DNS label
+	ErrInvalidNamespace = errors.New("invalid namespace")
+	// ErrNamespaceNotServed is returned when a namespace is referenced which
+	// is not one of the namespaces served by the control plane
+	ErrNamespaceNotServed = errors.New("namespace not served")
+	// ErrRunQuotaExceeded is returned when a run is started in a namespace
+	// which already has its quota of concurrent runs
+	ErrRunQuotaExceeded = errors.New("run quota exceeded")
 )
+
+// ScheduledError is returned when a claim is made on a rescheduled node before
+// the time at which it can next be claimed. It wraps ErrNodeScheduled
+type ScheduledError struct {
+	NotBefore time.Time
+}
+
+// Error returns the error message including the time at which the node can be claimed
+func (e *ScheduledError) Error() string {
+	return fmt.Sprintf("%v until %s", ErrNodeScheduled, e.NotBefore.Format(time.RFC3339Nano))
+}
+
+// Unwrap returns ErrNodeScheduled
+func (e *ScheduledError) Unwrap() error {
+	return ErrNodeScheduled
+}
@@ -0,0 +1,97 @@
+package adagio
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ResourcePools is a set of named global resource pools
+// and the number of slots each pool provides
+type ResourcePools map[string]int
+
+// Validate returns an error given the run contains a node which requires slots
+// from an unknown resource pool or more slots than the pool provides
+func (p ResourcePools) Validate(run *Run) error {
+	for _, node := range run.Nodes {
+		for pool, slots := range node.Spec.Resources {
+			size, ok := p[pool]
+			if !ok {
+				return fmt.Errorf("node %q: unknown resource pool %q", node.Spec.Name, pool)
+			}
+
+			if int(slots) > size {
+				return fmt.Errorf("node %q: requires %d slots of resource pool %q which has %d", node.Spec.Name, slots, pool, size)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Acquire returns an error wrapping ErrConcurrencyLimit given the slots required
+// by the node spec are not available once the slots in use are accounted for
+func (p ResourcePools) Acquire(spec *Node_Spec, used map[string]int) error {
+	for pool, slots := range spec.Resources {
+		if used[pool]+int(slots) > p[pool] {
+			return fmt.Errorf("resource pool %q: %w", pool, ErrConcurrencyLimit)
+		}
+	}
+
+	return nil
+}
+
+// ResourcesUsed returns the number of slots of each resource pool
+// occupied by the running nodes of the provided runs
+func ResourcesUsed(runs ...*Run) map[string]int {
+	used := map[string]int{}
+	for _, run := range runs {
+		for _, node := range run.Nodes {
+			if node.Status != Node_RUNNING {
+				continue
+			}
+
+			for pool, slots := range node.Spec.Resources {
+				used[pool] += int(slots)
+			}
+		}
+	}
+
+	return used
+}
+
+// CheckParallelism returns an error wrapping ErrConcurrencyLimit given the run
+// has a max parallelism and at least that many nodes are already running
+func CheckParallelism(run *Run) error {
+	if run.MaxParallelism < 1 {
+		return nil
+	}
+
+	running := 0
+	for _, node := range run.Nodes {
+		if node.Status == Node_RUNNING {
+			running++
+		}
+	}
+
+	if running >= int(run.MaxParallelism) {
+		return fmt.Errorf("run max parallelism %d: %w", run.MaxParallelism, ErrConcurrencyLimit)
+	}
+
+	return nil
+}
+
+func validateLimits(run *Run) error {
+	if run.MaxParallelism < 0 {
+		return errors.New("max parallelism must not be negative")
+	}
+
+	for _, node := range run.Nodes {
+		for pool, slots := range node.Spec.Resources {
+			if slots < 1 {
+				return fmt.Errorf("node %q: resource pool %q: slots must be greater than zero", node.Spec.Name, pool)
+			}
+		}
+	}
+
+	return nil
+}
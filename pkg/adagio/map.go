@@ -0,0 +1,112 @@
+package adagio
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// Expansion is the result of expanding a map node into
+// a set of instance nodes and the edges which connect them
+type Expansion struct {
+	Nodes  []*Node
+	Edges  []*Edge
+	Result *Node_Result
+}
+
+// IsMap returns true if the node is a map node which is expanded
+// into instances once ready rather than being executed
+func IsMap(node *Node) bool {
+	return node.Spec.Map != nil
+}
+
+// Expand expands a ready map node into one instance node per element of the
+// JSON array found in the output of the node the map is over.
+// Each instance is a copy of the map nodes specification named "<name>[<index>]".
+// It inherits the map nodes inputs, except the input from the node the map is over
+// is replaced with the instances element (JSON strings are unquoted).
+// Instances are connected to the map nodes sources (other than the node the map
+// is over) and destinations, retaining any edge conditions, and are returned in the ready state.
+// The result contains the names of the instances as a JSON array when successful.
+// Given the input cannot be expanded the result is an error and no instances are returned.
+// This is a helper function for repository implementations to use when a map node
+// becomes ready
+func Expand(run *Run, node *Node) *Expansion {
+	var (
+		over  = node.Spec.Map.Over
+		items []json.RawMessage
+		names = []string{}
+	)
+
+	data, ok := node.Inputs[over]
+	if !ok {
+		return expansionError(fmt.Errorf("map: input %q not found", over))
+	}
+
+	if err := json.Unmarshal(data, &items); err != nil {
+		return expansionError(fmt.Errorf("map: input %q is not a JSON array: %w", over, err))
+	}
+
+	expansion := &Expansion{}
+	for i, item := range items {
+		spec := proto.Clone(node.Spec).(*Node_Spec)
+		spec.Name = fmt.Sprintf("%s[%d]", node.Spec.Name, i)
+		spec.Map = nil
+
+		inputs := map[string][]byte{over: itemValue(item)}
+		for name, input := range node.Inputs {
+			if name != over {
+				inputs[name] = input
+			}
+		}
+
+		expansion.Nodes = append(expansion.Nodes, &Node{
+			Spec:   spec,
+			Status: Node_READY,
+			Inputs: inputs,
+		})
+
+		for _, edge := range run.Edges {
+			if edge.Destination == node.Spec.Name && edge.Source != over {
+				expansion.Edges = append(expansion.Edges, &Edge{Source: edge.Source, Destination: spec.Name, Condition: edge.Condition})
+			}
+
+			if edge.Source == node.Spec.Name {
+				expansion.Edges = append(expansion.Edges, &Edge{Source: spec.Name, Destination: edge.Destination, Condition: edge.Condition})
+			}
+		}
+
+		names = append(names, spec.Name)
+	}
+
+	output, err := json.Marshal(names)
+	if err != nil {
+		return expansionError(err)
+	}
+
+	expansion.Result = &Node_Result{
+		Conclusion: Node_Result_SUCCESS,
+		Output:     output,
+	}
+
+	return expansion
+}
+
+func expansionError(err error) *Expansion {
+	return &Expansion{
+		Result: &Node_Result{
+			Conclusion: Node_Result_ERROR,
+			Output:     []byte(err.Error()),
+		},
+	}
+}
+
+func itemValue(item json.RawMessage) []byte {
+	var str string
+	if err := json.Unmarshal(item, &str); err == nil {
+		return []byte(str)
+	}
+
+	return item
+}
@@ -0,0 +1,31 @@
+package adagio
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// DefaultNamespace is the namespace of calls which do not name one
+const DefaultNamespace = "default"
+
+var namespacePattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// Namespace returns the provided namespace or the DefaultNamespace given it is empty
+func Namespace(name string) string {
+	if name == "" {
+		return DefaultNamespace
+	}
+
+	return name
+}
+
+// ValidateNamespace returns an error wrapping ErrInvalidNamespace given the name is
+// not a lower case DNS label (at most 63 alphanumeric characters or hyphens which
+// neither begins nor ends with a hyphen)
+func ValidateNamespace(name string) error {
+	if len(name) > 63 || !namespacePattern.MatchString(name) {
+		return fmt.Errorf("%w: %q must be a lower case DNS label", ErrInvalidNamespace, name)
+	}
+
+	return nil
+}
@@ -1,6 +1,12 @@
 package adagio
 
-import "strings"
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"time"
+)
 
 // RetryCondition is a key used in the node spec retry map
 type RetryCondition string
@@ -15,21 +21,85 @@ const (
 )
 
 // CanRetry returns true if the node can be retried
-func CanRetry(node *Node) (canRetry bool) {
+// Map and approval nodes are never retried as they are not executed
+func CanRetry(node *Node) bool {
+	retry, count, ok := latestRetry(node)
+
+	return ok && count < retry.MaxAttempts
+}
+
+// RetryDelay returns the delay before the next attempt of a node which can be retried.
+// The delay is the retries initial delay multiplied by its multiplier once for each
+// previous retry of the same condition, capped at the max delay and then reduced by
+// a random fraction of up to the configured jitter
+func RetryDelay(node *Node) time.Duration {
+	retry, count, ok := latestRetry(node)
+	if !ok || retry.InitialDelay == "" {
+		return 0
+	}
+
+	delay := backoff(retry, count)
+	if retry.Jitter > 0 {
+		delay -= time.Duration(rand.Float64() * retry.Jitter * float64(delay))
+	}
+
+	return delay
+}
+
+// ScheduleRetry prepares a node which can be retried for its next attempt.
+// Given the node has a retry delay it cannot be claimed again until the
+// delay has elapsed from now
+func ScheduleRetry(node *Node, now time.Time) {
+	node.StartedAt = ""
+	node.FinishedAt = ""
+
+	if delay := RetryDelay(node); delay > 0 {
+		node.NotBefore = now.Add(delay).Format(time.RFC3339Nano)
+	}
+}
+
+// backoff returns the delay without jitter before the retry following
+// count attempts with the same conclusion
+func backoff(retry *Node_Spec_Retry, count int32) time.Duration {
+	// durations are validated when the run is constructed
+	initial, _ := time.ParseDuration(retry.InitialDelay)
+
+	multiplier := retry.Multiplier
+	if multiplier == 0 {
+		multiplier = 1
+	}
+
+	delay := float64(initial) * math.Pow(multiplier, float64(count-1))
+
+	if retry.MaxDelay != "" {
+		max, _ := time.ParseDuration(retry.MaxDelay)
+		if delay > float64(max) {
+			return max
+		}
+	}
+
+	return time.Duration(delay)
+}
+
+// latestRetry returns the retry configured for the conclusion of the latest attempt
+// and the number of attempts made with that conclusion
+func latestRetry(node *Node) (retry *Node_Spec_Retry, count int32, ok bool) {
+	if IsMap(node) || IsApproval(node) {
+		return nil, 0, false
+	}
+
 	VisitLatestAttempt(node, func(result *Node_Result) {
 		// check for retries
 		retryKey := strings.ToLower(result.Conclusion.String())
-		if retry, ok := node.Spec.Retry[retryKey]; ok {
-			var retryCount int32
+		if retry, ok = node.Spec.Retry[retryKey]; !ok {
+			return
+		}
 
-			// count number of existing attempts
-			for _, r := range node.Attempts {
-				if r.Conclusion == result.Conclusion {
-					retryCount++
-				}
+		// count number of existing attempts
+		for _, r := range node.Attempts {
+			if r.Conclusion == result.Conclusion {
+				count++
 			}
-
-			canRetry = retryCount < retry.MaxAttempts
 		}
 	})
 
@@ -45,3 +115,95 @@ func VisitLatestAttempt(node *Node, fn func(*Node_Result)) {
 
 	fn(node.Attempts[len(node.Attempts)-1])
 }
+
+// CurrentAttempt returns the number (starting at 1) of the attempt the node is
+// running, or otherwise of its latest recorded attempt
+func CurrentAttempt(node *Node) int32 {
+	attempts := int32(len(node.Attempts))
+	if node.Status == Node_RUNNING || attempts < 1 {
+		return attempts + 1
+	}
+
+	return attempts
+}
+
+// RecordTransition appends a transition of the provided type at the provided time
+// to the history of the node. Transitions made under a claim record its ID and the
+// agent which made it. A finished transition records the attempt which concluded
+// and its conclusion, while the others record the attempt being awaited or made
+func RecordTransition(node *Node, typ Node_Transition_Type, at time.Time, claim *Claim) {
+	transition := &Node_Transition{
+		Type:    typ,
+		At:      at.Format(time.RFC3339Nano),
+		Attempt: int32(len(node.Attempts)) + 1,
+	}
+
+	switch typ {
+	case Node_Transition_FINISHED:
+		transition.Attempt--
+
+		VisitLatestAttempt(node, func(result *Node_Result) {
+			transition.Conclusion = result.Conclusion
+		})
+	case Node_Transition_SKIPPED:
+		transition.Attempt = 0
+	}
+
+	if claim != nil {
+		transition.AgentId = claim.AgentId
+		transition.ClaimId = claim.Id
+	}
+
+	node.History = append(node.History, transition)
+}
+
+// CheckSchedule returns a ScheduledError given the node has been rescheduled
+// and the time at which it can next be claimed is after now
+func CheckSchedule(node *Node, now time.Time) error {
+	if node.NotBefore == "" {
+		return nil
+	}
+
+	notBefore, err := time.Parse(time.RFC3339Nano, node.NotBefore)
+	if err != nil {
+		return err
+	}
+
+	if now.Before(notBefore) {
+		return &ScheduledError{NotBefore: notBefore}
+	}
+
+	return nil
+}
+
+func validateRetries(run *Run) error {
+	for _, node := range run.Nodes {
+		for condition, retry := range node.Spec.Retry {
+			if retry.InitialDelay != "" {
+				if delay, err := time.ParseDuration(retry.InitialDelay); err != nil {
+					return fmt.Errorf("node %q: retry %q: initial delay: %w", node.Spec.Name, condition, err)
+				} else if delay < 0 {
+					return fmt.Errorf("node %q: retry %q: initial delay must not be negative", node.Spec.Name, condition)
+				}
+			}
+
+			if retry.MaxDelay != "" {
+				if delay, err := time.ParseDuration(retry.MaxDelay); err != nil {
+					return fmt.Errorf("node %q: retry %q: max delay: %w", node.Spec.Name, condition, err)
+				} else if delay < 0 {
+					return fmt.Errorf("node %q: retry %q: max delay must not be negative", node.Spec.Name, condition)
+				}
+			}
+
+			if retry.Multiplier < 0 {
+				return fmt.Errorf("node %q: retry %q: multiplier must not be negative", node.Spec.Name, condition)
+			}
+
+			if retry.Jitter < 0 || retry.Jitter > 1 {
+				return fmt.Errorf("node %q: retry %q: jitter must be between 0 and 1", node.Spec.Name, condition)
+			}
+		}
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package adagio
 
 import (
 	"errors"
+	"fmt"
 	"math/rand"
 	"sync"
 	"time"
@@ -15,11 +16,33 @@ var (
 	mu      sync.Mutex
 )
 
+// RunOption is a functional option for a Run constructed via NewRun
+type RunOption func(*Run)
+
+// RunOptions is a slice of RunOption types
+type RunOptions []RunOption
+
+// Apply calls each option in turn on the provided Run
+func (o RunOptions) Apply(run *Run) {
+	for _, opt := range o {
+		opt(run)
+	}
+}
+
+// WithParent configures the run as a child of the node identified
+// by name within the run identified by runID
+func WithParent(runID, node string) RunOption {
+	return func(run *Run) {
+		run.Parent = &Run_Link{RunId: runID, Node: node}
+	}
+}
+
 // NewRun converts a graph specification into a new run instance
 // This is a convention and helper function for repository implementations to use to
 // correctly adapt a new graph spec into a run. It validates that the graph has
-// no cycles and initializes states, timestamps and IDs appropriately
-func NewRun(spec *GraphSpec) (run *Run, err error) {
+// no cycles, that map nodes, edge conditions, approvals and limits are well formed and initializes
+// states, timestamps and IDs appropriately
+func NewRun(spec *GraphSpec, opts ...RunOption) (run *Run, err error) {
 	func() {
 		mu.Lock()
 		defer mu.Unlock()
@@ -30,15 +53,44 @@ func NewRun(spec *GraphSpec) (run *Run, err error) {
 			CreatedAt: now.Format(time.RFC3339Nano),
 			Edges:     spec.Edges,
 			Nodes:     buildNodes(spec.Nodes),
+			// run inherits the parallelism limit and priority of the spec
+			MaxParallelism: spec.MaxParallelism,
+			Priority:       spec.Priority,
 		}
 	}()
 
+	RunOptions(opts).Apply(run)
+
 	graph := GraphFrom(run)
 
 	if err = validateGraph(graph); err != nil {
 		return
 	}
 
+	if err = validateMaps(run); err != nil {
+		return
+	}
+
+	if err = validateConditions(run); err != nil {
+		return
+	}
+
+	if err = validateApprovals(run); err != nil {
+		return
+	}
+
+	if err = validateRetries(run); err != nil {
+		return
+	}
+
+	if err = validateSelectors(run); err != nil {
+		return
+	}
+
+	if err = validateLimits(run); err != nil {
+		return
+	}
+
 	err = setInitialNodeStates(graph, run.Nodes)
 
 	return
@@ -55,6 +107,53 @@ func (run *Run) GetNodeByName(name string) (*Node, error) {
 	return nil, errors.New("graph: node not found")
 }
 
+// Priority returns the effective priority of the node within the run
+// which is the sum of the priority of the run and that of the node
+func Priority(run *Run, node *Node) int32 {
+	return run.Priority + node.Spec.Priority
+}
+
+// RunCompleted returns true given every node of the run is resolved
+func RunCompleted(run *Run) bool {
+	for _, node := range run.Nodes {
+		if !IsResolved(node) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// RunSucceeded returns true given every node of the run which was not
+// skipped succeeded on its latest attempt
+func RunSucceeded(run *Run) bool {
+	for _, node := range run.Nodes {
+		if node.Status == Node_SKIPPED {
+			continue
+		}
+
+		succeeded := false
+		VisitLatestAttempt(node, func(attempt *Node_Result) {
+			succeeded = attempt.Conclusion == Node_Result_SUCCESS
+		})
+
+		if !succeeded {
+			return false
+		}
+	}
+
+	return true
+}
+
+// CancelledResult constructs the errored result of a node which
+// was unresolved when its run was cancelled
+func CancelledResult() *Node_Result {
+	return &Node_Result{
+		Conclusion: Node_Result_ERROR,
+		Output:     []byte(ErrRunCancelled.Error()),
+	}
+}
+
 func buildNodes(specs []*Node_Spec) (nodes []*Node) {
 	for _, spec := range specs {
 		nodes = append(nodes, &Node{
@@ -73,6 +172,25 @@ func validateGraph(graph *graph.Graph) error {
 	return nil
 }
 
+func validateMaps(run *Run) error {
+	for _, node := range run.Nodes {
+		if !IsMap(node) {
+			continue
+		}
+
+		connected := false
+		for _, edge := range run.Edges {
+			connected = connected || (edge.Source == node.Spec.Map.Over && edge.Destination == node.Spec.Name)
+		}
+
+		if !connected {
+			return fmt.Errorf("map node %q must depend on the node it maps over %q", node.Spec.Name, node.Spec.Map.Over)
+		}
+	}
+
+	return nil
+}
+
 func setInitialNodeStates(graph *graph.Graph, nodes []*Node) error {
 	for _, node := range nodes {
 		incoming, err := graph.Incoming(node)
@@ -93,6 +211,8 @@ func setInitialNodeStates(graph *graph.Graph, nodes []*Node) error {
 
 // GraphFrom takes a run and builds a *graph.Graph from it which contains
 // helpful functions to traversing the runs graph structure
+// Expanded map instances are included given they have been appended
+// to the runs nodes and edges
 func GraphFrom(run *Run) *graph.Graph {
 	var (
 		graph  = graph.New()
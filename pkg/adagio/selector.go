@@ -0,0 +1,139 @@
+package adagio
+
+import "fmt"
+
+// SelectorMatches returns true if the provided labels satisfy the selector.
+// A nil selector matches any set of labels
+func SelectorMatches(selector *Node_Spec_Selector, labels map[string]string) bool {
+	if selector == nil {
+		return true
+	}
+
+	for key, value := range selector.MatchLabels {
+		if label, ok := labels[key]; !ok || label != value {
+			return false
+		}
+	}
+
+	for _, requirement := range selector.MatchExpressions {
+		if !requirementMatches(requirement, labels) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func requirementMatches(requirement *Node_Spec_Selector_Requirement, labels map[string]string) bool {
+	label, ok := labels[requirement.Key]
+
+	switch requirement.Operator {
+	case Node_Spec_Selector_Requirement_IN:
+		return ok && contains(requirement.Values, label)
+	case Node_Spec_Selector_Requirement_NOT_IN:
+		return !ok || !contains(requirement.Values, label)
+	case Node_Spec_Selector_Requirement_EXISTS:
+		return ok
+	case Node_Spec_Selector_Requirement_DOES_NOT_EXIST:
+		return !ok
+	default:
+		return false
+	}
+}
+
+// SupportsRuntime returns true if the agent has the named runtime
+func SupportsRuntime(agent *Agent, name string) bool {
+	for _, runtime := range agent.Runtimes {
+		if runtime.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CanClaim returns true if the agent supports the runtime of the node
+// and has labels which satisfy the nodes selector
+func CanClaim(agent *Agent, spec *Node_Spec) bool {
+	return SupportsRuntime(agent, spec.Runtime) && SelectorMatches(spec.Selector, agent.Labels)
+}
+
+// Unschedulable returns true if the node is ready to be claimed
+// but none of the provided agents can claim it
+func Unschedulable(node *Node, agents []*Agent) bool {
+	if node.Status != Node_READY || IsApproval(node) {
+		return false
+	}
+
+	for _, agent := range agents {
+		if CanClaim(agent, node.Spec) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// CheckPlacement returns an error wrapping ErrNodeUnplaceable given the spec
+// contains a node with a selector which none of the provided agents can claim.
+// Nodes without a selector and approval nodes (which are never claimed) are not checked
+func CheckPlacement(spec *GraphSpec, agents []*Agent) error {
+	for _, node := range spec.GetNodes() {
+		if node.Selector == nil || node.Approval != nil {
+			continue
+		}
+
+		placeable := false
+		for _, agent := range agents {
+			if CanClaim(agent, node) {
+				placeable = true
+				break
+			}
+		}
+
+		if !placeable {
+			return fmt.Errorf("node %q: %w", node.Name, ErrNodeUnplaceable)
+		}
+	}
+
+	return nil
+}
+
+func validateSelectors(run *Run) error {
+	for _, node := range run.Nodes {
+		if node.Spec.Selector == nil {
+			continue
+		}
+
+		for _, requirement := range node.Spec.Selector.MatchExpressions {
+			if requirement.Key == "" {
+				return fmt.Errorf("node %q: selector: requirement key must be provided", node.Spec.Name)
+			}
+
+			switch requirement.Operator {
+			case Node_Spec_Selector_Requirement_IN, Node_Spec_Selector_Requirement_NOT_IN:
+				if len(requirement.Values) == 0 {
+					return fmt.Errorf("node %q: selector: %s requirement on %q must have values", node.Spec.Name, requirement.Operator, requirement.Key)
+				}
+			case Node_Spec_Selector_Requirement_EXISTS, Node_Spec_Selector_Requirement_DOES_NOT_EXIST:
+				if len(requirement.Values) > 0 {
+					return fmt.Errorf("node %q: selector: %s requirement on %q must not have values", node.Spec.Name, requirement.Operator, requirement.Key)
+				}
+			default:
+				return fmt.Errorf("node %q: selector: unknown operator %s", node.Spec.Name, requirement.Operator)
+			}
+		}
+	}
+
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
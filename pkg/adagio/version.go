@@ -0,0 +1,5 @@
+package adagio
+
+// Version is the version of adagio reported by agents
+// It is overridden at build time via -ldflags "-X github.com/georgemac/adagio/pkg/adagio.Version=..."
+var Version = "dev"
@@ -0,0 +1,65 @@
+package adagio
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// NewWebhook constructs a webhook for the provided URL which is notified of the
+// provided events and whose payloads are signed using the provided secret.
+// It validates the webhook and initializes its ID and created at timestamp
+func NewWebhook(url, secret string, events ...Webhook_Event) (*Webhook, error) {
+	webhook := &Webhook{
+		Url:    url,
+		Secret: secret,
+		Events: events,
+	}
+
+	if err := ValidateWebhook(webhook); err != nil {
+		return nil, err
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	now := time.Now().UTC()
+	webhook.Id = ulid.MustNew(ulid.Timestamp(now), entropy).String()
+	webhook.CreatedAt = now.Format(time.RFC3339Nano)
+
+	return webhook, nil
+}
+
+// ValidateWebhook returns an error wrapping ErrInvalidWebhook given the URL of the
+// webhook is not an absolute http or https URL or it is not notified of any known event
+func ValidateWebhook(webhook *Webhook) error {
+	u, err := url.Parse(webhook.Url)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return fmt.Errorf("%w: url %q must be an absolute http or https URL", ErrInvalidWebhook, webhook.Url)
+	}
+
+	if len(webhook.Events) == 0 {
+		return fmt.Errorf("%w: at least one event is required", ErrInvalidWebhook)
+	}
+
+	for _, event := range webhook.Events {
+		if _, ok := Webhook_Event_name[int32(event)]; !ok || event == Webhook_NONE {
+			return fmt.Errorf("%w: unknown event %q", ErrInvalidWebhook, event)
+		}
+	}
+
+	return nil
+}
+
+// Notifies returns true given the webhook is notified of the provided event
+func Notifies(webhook *Webhook, event Webhook_Event) bool {
+	for _, e := range webhook.Events {
+		if e == event {
+			return true
+		}
+	}
+
+	return false
+}
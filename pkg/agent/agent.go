@@ -3,13 +3,19 @@ package agent
 import (
 	"context"
 	"errors"
-	"log"
+	"fmt"
 	"math/rand"
+	"os"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/georgemac/adagio/pkg/adagio"
+	"github.com/georgemac/adagio/pkg/logging"
+	"github.com/georgemac/adagio/pkg/metrics"
+	"github.com/georgemac/adagio/pkg/tracing"
+	"github.com/golang/protobuf/proto"
 	"github.com/oklog/ulid/v2"
 )
 
@@ -20,13 +26,18 @@ var (
 )
 
 // Repository is the minimal interface for a backing repository which can
-// notify of node related events, issue node claims and finalize the result
-// of executing a node
+// notify of node related events, issue node claims, reschedule claimed nodes,
+// record the progress and finalize the result of executing a node and record
+// the heartbeats of agents
 type Repository interface {
 	ClaimNode(ctx context.Context, runID, name string, claim *adagio.Claim) (*adagio.Node, bool, error)
 	FinishNode(ctx context.Context, runID, name string, result *adagio.Node_Result, claim *adagio.Claim) error
+	RescheduleNode(ctx context.Context, runID, name string, notBefore time.Time, claim *adagio.Claim) error
+	ReportProgress(ctx context.Context, runID, name string, progress *adagio.Node_Progress, claim *adagio.Claim) error
 	Subscribe(ctx context.Context, agent *adagio.Agent, events chan<- *adagio.Event, types ...adagio.Event_Type) error
 	UnsubscribeAll(context.Context, *adagio.Agent, chan<- *adagio.Event) error
+	Heartbeat(context.Context, *adagio.Agent) error
+	AppendLogs(ctx context.Context, runID, name string, attempt int32, lines []*adagio.LogLine) error
 }
 
 // RuntimeMap is a set of runtimes identified by name
@@ -86,7 +97,19 @@ type Pool struct {
 	repo     Repository
 	runtimes RuntimeMap
 
-	size int
+	size   int
+	labels map[string]string
+
+	// slots is a semaphore per concurrency limited runtime
+	slots         map[string]chan struct{}
+	limitInterval time.Duration
+
+	heartbeatInterval time.Duration
+	hangTimeout       time.Duration
+	logFlushInterval  time.Duration
+
+	metrics *metrics.Metrics
+	logger  logging.Logger
 
 	newClaimer func() Claimer
 }
@@ -94,9 +117,15 @@ type Pool struct {
 // NewPool constructs and configures a new node pool for execution
 func NewPool(repo Repository, runtimes RuntimeMap, opts ...Option) *Pool {
 	pool := &Pool{
-		repo:     repo,
-		runtimes: runtimes,
-		size:     1,
+		repo:              repo,
+		runtimes:          runtimes,
+		size:              1,
+		slots:             map[string]chan struct{}{},
+		limitInterval:     time.Second,
+		heartbeatInterval: 10 * time.Second,
+		logFlushInterval:  time.Second,
+		metrics:           metrics.New(),
+		logger:            logging.Default(),
 		newClaimer: func() Claimer {
 			entropy := ulid.Monotonic(rand.New(rand.NewSource(time.Now().UnixNano())), 0)
 
@@ -117,9 +146,11 @@ func NewPool(repo Repository, runtimes RuntimeMap, opts ...Option) *Pool {
 // of the supplied context
 func (p *Pool) Run(ctxt context.Context) {
 	var (
-		entropy  = ulid.Monotonic(rand.New(rand.NewSource(time.Now().UnixNano())), 0)
-		runtimes = []*adagio.Runtime{}
-		wg       sync.WaitGroup
+		entropy     = ulid.Monotonic(rand.New(rand.NewSource(time.Now().UnixNano())), 0)
+		runtimes    = []*adagio.Runtime{}
+		hostname, _ = os.Hostname()
+		startedAt   = time.Now().UTC().Format(time.RFC3339Nano)
+		wg          sync.WaitGroup
 	)
 
 	for runtime := range p.runtimes {
@@ -132,8 +163,15 @@ func (p *Pool) Run(ctxt context.Context) {
 
 	for i := 0; i < p.size; i++ {
 		agent := &adagio.Agent{
-			Id:       ulid.MustNew(ulid.Timestamp(time.Now().UTC()), entropy).String(),
-			Runtimes: runtimes,
+			Id:            ulid.MustNew(ulid.Timestamp(time.Now().UTC()), entropy).String(),
+			Runtimes:      runtimes,
+			Labels:        p.labels,
+			Hostname:      hostname,
+			Pid:           int32(os.Getpid()),
+			Version:       adagio.Version,
+			StartedAt:     startedAt,
+			LastHeartbeat: startedAt,
+			Capacity:      1,
 		}
 
 		wg.Add(1)
@@ -144,24 +182,66 @@ func (p *Pool) Run(ctxt context.Context) {
 				events  = make(chan *adagio.Event, 10)
 				claimer = p.newClaimer()
 				ctx     = context.Background()
+				logger  = p.logger.WithField(logging.AgentIDKey, agent.Id)
+				beats   = &heartbeat{repo: p.repo, agent: agent, logger: logger}
 			)
 
+			logger.Info("agent started")
+
 			p.repo.Subscribe(ctx, agent, events, adagio.Event_NODE_READY, adagio.Event_NODE_ORPHANED)
 
+			go beats.run(ctxt, p.heartbeatInterval)
+
+			// pending events are handled in order of priority
+			var pending queue
+
 			for {
-				select {
-				case event := <-events:
-					func(event *adagio.Event) {
-						ctx, cancel := context.WithCancel(ctx)
-						defer cancel()
-
-						if err := p.handleEvent(ctx, claimer, event); err != nil {
-							log.Println(err)
-						}
-					}(event)
-				case <-ctxt.Done():
-					return
+				// wait for an event given none are pending
+				if pending.Len() == 0 {
+					select {
+					case event := <-events:
+						pending.push(event)
+					case <-ctxt.Done():
+						return
+					}
+				}
+
+				// collect any other events which have already been delivered
+				// so that the highest priority of them is handled first
+			collect:
+				for {
+					select {
+					case event := <-events:
+						pending.push(event)
+					default:
+						break collect
+					}
 				}
+
+				func(event *adagio.Event) {
+					ctx, cancel := context.WithCancel(ctx)
+					defer cancel()
+
+					err := p.handleEvent(ctx, logger, claimer, beats, event)
+
+					if errors.Is(err, adagio.ErrNodeScheduled) {
+						// the repository announces the node again once it is ready
+						return
+					}
+
+					if errors.Is(err, adagio.ErrConcurrencyLimit) {
+						// redeliver the event in case a slot has been released by then
+						redeliver(ctxt, events, event, p.limitInterval)
+						return
+					}
+
+					if err != nil {
+						logger.WithFields(logging.Fields{
+							logging.RunIDKey: event.RunID,
+							logging.NodeKey:  event.NodeSpec.Name,
+						}).WithError(err).Error("handling event")
+					}
+				}(pending.pop())
 			}
 		}(agent)
 	}
@@ -169,37 +249,111 @@ func (p *Pool) Run(ctxt context.Context) {
 	wg.Wait()
 }
 
-func (p *Pool) handleEvent(ctx context.Context, claimer Claimer, event *adagio.Event) error {
+func (p *Pool) handleEvent(ctx context.Context, logger logging.Logger, claimer Claimer, beats *heartbeat, event *adagio.Event) error {
 	runtime, ok := p.runtimes[event.NodeSpec.Runtime]
 	if !ok {
 		return ErrRuntimeDoesNotExist
 	}
 
-	// construct a new claim
-	claim := claimer.NewClaim()
+	// leave ready nodes which select agents with other labels to matching agents
+	if event.Type == adagio.Event_NODE_READY && !adagio.SelectorMatches(event.NodeSpec.Selector, p.labels) {
+		return nil
+	}
+
+	if event.Type == adagio.Event_NODE_READY {
+		release, ok := p.acquire(event.NodeSpec.Runtime)
+		if !ok {
+			return fmt.Errorf("runtime %q: %w", event.NodeSpec.Runtime, adagio.ErrConcurrencyLimit)
+		}
+
+		defer release()
+	}
+
+	// continue the trace of the run from its root span
+	ctx = tracing.RunContext(ctx, event.TraceContext)
+
+	// construct a new claim on behalf of the agent
+	claim := proto.Clone(claimer.NewClaim()).(*adagio.Claim)
+	claim.AgentId = beats.agent.Id
 
 	node, claimed, err := p.repo.ClaimNode(ctx, event.RunID, event.NodeSpec.Name, claim)
 	if err != nil {
 		return err
 	}
 
+	p.metrics.ClaimAttempted(claimed)
+
 	if !claimed {
 		// node already claimed by other consumer
 		return nil
 	}
 
-	log.Printf("claimed run %q node %q\n", event.RunID, event.NodeSpec.Name)
+	logger = logger.WithFields(logging.Fields{
+		logging.RunIDKey:   event.RunID,
+		logging.NodeKey:    event.NodeSpec.Name,
+		logging.ClaimIDKey: claim.Id,
+	})
+
+	logger.WithField("event", strings.ToLower(event.Type.String())).Info("node claimed")
 
-	nodeResult := &adagio.Node_Result{}
+	beats.working(ctx, event.RunID, event.NodeSpec.Name)
+	defer beats.idle(ctx)
+
+	var (
+		nodeResult = &adagio.Node_Result{}
+		started    = time.Now()
+		elapsed    time.Duration
+	)
 
 	switch event.Type {
 	case adagio.Event_NODE_READY:
 		var (
 			result *adagio.Result
 			fn     = runtime.NewFunction()
+			report = newReporter(p.repo, event.RunID, event.NodeSpec.Name, claim)
+			logs   = newLogWriter(p.repo, logger, event.RunID, event.NodeSpec.Name, adagio.CurrentAttempt(node))
+
+			fnCtx, cancel = context.WithCancel(ctx)
 		)
 
-		if result, err = fn.Run(ctx, node); err == nil {
+		if p.hangTimeout > 0 {
+			go report.watch(fnCtx, p.hangTimeout, cancel)
+		}
+
+		flushed := logs.run(ctx, fnCtx.Done(), p.logFlushInterval)
+
+		spanCtx, span := tracing.StartNode(fnCtx, "node", event.RunID, event.NodeSpec.Name)
+		span.SetAttributes(
+			tracing.RuntimeKey.String(event.NodeSpec.Runtime),
+			tracing.AttemptKey.Int(int(adagio.CurrentAttempt(node))))
+
+		result, err = fn.Run(withLogger(logs.context(report.context(WithRunID(spanCtx, event.RunID))), logger), node)
+		elapsed = time.Since(started)
+
+		if err == nil {
+			span.SetAttributes(tracing.ConclusionKey.String(strings.ToLower(result.Conclusion.String())))
+		}
+
+		tracing.End(span, err)
+
+		cancel()
+
+		// ship any remaining lines once periodic flushing has stopped
+		// and before the node is concluded
+		<-flushed
+		logs.flush(ctx, true)
+
+		if report.isHung() {
+			result, err = nil, fmt.Errorf("node hung: no heartbeat within %v", p.hangTimeout)
+		}
+
+		if after, ok := RescheduleAfter(err); ok {
+			logger.WithField("after", after).Info("rescheduling node")
+
+			return p.repo.RescheduleNode(ctx, event.RunID, event.NodeSpec.Name, time.Now().Add(after), claim)
+		}
+
+		if err == nil {
 			nodeResult = &adagio.Node_Result{
 				Conclusion: adagio.Node_Result_Conclusion(result.Conclusion),
 				Metadata:   result.Metadata,
@@ -208,6 +362,8 @@ func (p *Pool) handleEvent(ctx context.Context, claimer Claimer, event *adagio.E
 		}
 
 	case adagio.Event_NODE_ORPHANED:
+		p.metrics.NodeOrphaned()
+
 		err = errors.New("node was orphaned")
 	}
 
@@ -216,7 +372,18 @@ func (p *Pool) handleEvent(ctx context.Context, claimer Claimer, event *adagio.E
 		nodeResult.Output = []byte(err.Error())
 	}
 
-	log.Printf("finising run %q node %q\n", event.RunID, event.NodeSpec.Name)
+	// attribute the attempt to the claim and agent which made it
+	nodeResult.StartedAt = started.UTC().Format(time.RFC3339Nano)
+	nodeResult.FinishedAt = time.Now().UTC().Format(time.RFC3339Nano)
+	nodeResult.ClaimId = claim.Id
+	nodeResult.AgentId = beats.agent.Id
+	nodeResult.Hostname = beats.agent.Hostname
+
+	if event.Type == adagio.Event_NODE_READY {
+		p.metrics.NodeExecuted(event.NodeSpec.Runtime, nodeResult.Conclusion, elapsed)
+	}
+
+	logger.WithField("conclusion", strings.ToLower(nodeResult.Conclusion.String())).Info("finishing node")
 
 	if err := p.repo.FinishNode(ctx, event.RunID, event.NodeSpec.Name, nodeResult, claim); err != nil {
 		return err
@@ -224,3 +391,30 @@ func (p *Pool) handleEvent(ctx context.Context, claimer Claimer, event *adagio.E
 
 	return nil
 }
+
+// acquire takes a slot of the runtimes concurrency limit without blocking and returns
+// a function which releases it. It returns false given all the slots are taken
+func (p *Pool) acquire(runtime string) (func(), bool) {
+	slots, ok := p.slots[runtime]
+	if !ok {
+		return func() {}, true
+	}
+
+	select {
+	case slots <- struct{}{}:
+		return func() { <-slots }, true
+	default:
+		return nil, false
+	}
+}
+
+// redeliver sends the event on the events channel once the delay has elapsed
+// without occupying the agent in the meantime
+func redeliver(ctx context.Context, events chan<- *adagio.Event, event *adagio.Event, after time.Duration) {
+	time.AfterFunc(after, func() {
+		select {
+		case events <- event:
+		case <-ctx.Done():
+		}
+	})
+}
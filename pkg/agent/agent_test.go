@@ -3,12 +3,23 @@ package agent
 import (
 	"context"
 	"errors"
+	"fmt"
+	"os"
+	"strings"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/georgemac/adagio/pkg/adagio"
+	"github.com/georgemac/adagio/pkg/logging"
+	"github.com/georgemac/adagio/pkg/tracing"
+	logtest "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 func TestPool_HappyPath_NODE_READY(t *testing.T) {
@@ -51,7 +62,7 @@ func TestPool_HappyPath_NODE_READY(t *testing.T) {
 			})
 		}
 
-		pool = NewPool(&repo, RuntimeMap(runtimes), WithAgentCount(5), WithClaimerFunc(claimFunc))
+		pool = NewPool(repo, RuntimeMap(runtimes), WithAgentCount(5), WithClaimerFunc(claimFunc))
 
 		done         = make(chan struct{})
 		ctxt, cancel = context.WithCancel(context.Background())
@@ -73,6 +84,9 @@ func TestPool_HappyPath_NODE_READY(t *testing.T) {
 		require.NotNil(t, call.agent)
 		require.NotNil(t, call.events)
 
+		// each agent executes one node at a time regardless of the size of its pool
+		assert.Equal(t, int32(1), call.agent.Capacity)
+
 		assert.Equal(t, []adagio.Event_Type{
 			adagio.Event_NODE_READY,
 			adagio.Event_NODE_ORPHANED,
@@ -97,7 +111,7 @@ func TestPool_HappyPath_NODE_READY(t *testing.T) {
 	require.Len(t, repo.finishCalls, 1)
 	assert.Equal(t, finishCall{"bar", "foo", &adagio.Node_Result{
 		Conclusion: adagio.Node_Result_SUCCESS,
-	}, claim}, repo.finishCalls[0])
+	}, claim}, unattributed(t, repo.finishCalls[0]))
 
 	// ensure runtime was invoked once
 	assert.Equal(t, uint64(1), runCalls)
@@ -142,7 +156,7 @@ func TestPool_Error_RuntimeDoesNotExist(t *testing.T) {
 				return claim
 			})
 		}
-		pool = NewPool(&repo, runtimes, WithAgentCount(5), WithClaimerFunc(claimFunc))
+		pool = NewPool(repo, runtimes, WithAgentCount(5), WithClaimerFunc(claimFunc))
 
 		done         = make(chan struct{})
 		ctxt, cancel = context.WithCancel(context.Background())
@@ -227,7 +241,7 @@ func TestPool_Error_RuntimeError(t *testing.T) {
 				return claim
 			})
 		}
-		pool = NewPool(&repo, runtimes, WithAgentCount(5), WithClaimerFunc(claimFunc))
+		pool = NewPool(repo, runtimes, WithAgentCount(5), WithClaimerFunc(claimFunc))
 
 		done         = make(chan struct{})
 		ctxt, cancel = context.WithCancel(context.Background())
@@ -273,7 +287,7 @@ func TestPool_Error_RuntimeError(t *testing.T) {
 	assert.Equal(t, finishCall{"bar", "foo", &adagio.Node_Result{
 		Output:     []byte("something went wrong"),
 		Conclusion: adagio.Node_Result_ERROR,
-	}, claim}, repo.finishCalls[0])
+	}, claim}, unattributed(t, repo.finishCalls[0]))
 
 	// ensure runtime was never invoked
 	assert.Equal(t, uint64(1), runCalls)
@@ -318,7 +332,7 @@ func TestPool_Error_NODE_ORPHANED(t *testing.T) {
 				return claim
 			})
 		}
-		pool = NewPool(&repo, runtimes, WithAgentCount(5), WithClaimerFunc(claimFunc))
+		pool = NewPool(repo, runtimes, WithAgentCount(5), WithClaimerFunc(claimFunc))
 
 		done         = make(chan struct{})
 		ctxt, cancel = context.WithCancel(context.Background())
@@ -364,8 +378,1088 @@ func TestPool_Error_NODE_ORPHANED(t *testing.T) {
 	assert.Equal(t, finishCall{"bar", "foo", &adagio.Node_Result{
 		Output:     []byte("node was orphaned"),
 		Conclusion: adagio.Node_Result_ERROR,
-	}, claim}, repo.finishCalls[0])
+	}, claim}, unattributed(t, repo.finishCalls[0]))
 
 	// ensure runtime was never invoked
 	assert.Equal(t, uint64(0), runCalls)
 }
+
+func TestPool_Reschedule(t *testing.T) {
+	var (
+		node = &adagio.Node{
+			Spec: &adagio.Node_Spec{
+				Name:    "foo",
+				Runtime: "sensor",
+			},
+		}
+
+		runtimes = map[string]Runtime{
+			"sensor": runtime{
+				name: "sensor",
+				newFunction: func() Function {
+					return function{
+						run: func(context.Context, *adagio.Node) (*adagio.Result, error) {
+							return nil, Reschedule(time.Minute)
+						},
+					}
+				},
+			},
+		}
+
+		repo = newRepository(1, node)
+
+		claim     = &adagio.Claim{Id: "claim"}
+		claimFunc = func() Claimer {
+			return ClaimerFunc(func() *adagio.Claim {
+				return claim
+			})
+		}
+		pool = NewPool(repo, runtimes, WithClaimerFunc(claimFunc))
+
+		done         = make(chan struct{})
+		ctxt, cancel = context.WithCancel(context.Background())
+		before       = time.Now()
+	)
+
+	go func() {
+		pool.Run(ctxt)
+		done <- struct{}{}
+	}()
+
+	repo.subscriptionCount.Wait()
+
+	repo.subscribeCalls[0].events <- &adagio.Event{
+		RunID:    "bar",
+		NodeSpec: node.Spec,
+		Type:     adagio.Event_NODE_READY,
+	}
+
+	// stop running
+	cancel()
+	<-done
+
+	// ensure node is rescheduled rather than finished
+	assert.Nil(t, repo.finishCalls)
+	require.Len(t, repo.rescheduleCalls, 1)
+
+	call := repo.rescheduleCalls[0]
+	assert.Equal(t, "bar", call.runID)
+	assert.Equal(t, "foo", call.name)
+	assert.Equal(t, claim, call.claim)
+	assert.False(t, call.notBefore.Before(before.Add(time.Minute)))
+}
+
+func TestPool_Scheduled(t *testing.T) {
+	var (
+		node = &adagio.Node{
+			Spec: &adagio.Node_Spec{
+				Name:    "foo",
+				Runtime: "test",
+			},
+		}
+
+		runtimes = map[string]Runtime{
+			"test": runtime{
+				name: "test",
+				newFunction: func() Function {
+					return function{
+						run: func(context.Context, *adagio.Node) (*adagio.Result, error) {
+							return &adagio.Result{Conclusion: adagio.Result_SUCCESS}, nil
+						},
+					}
+				},
+			},
+		}
+
+		repo = newRepository(1, node)
+
+		claim     = &adagio.Claim{Id: "claim"}
+		claimFunc = func() Claimer {
+			return ClaimerFunc(func() *adagio.Claim {
+				return claim
+			})
+		}
+		pool = NewPool(repo, runtimes, WithClaimerFunc(claimFunc))
+
+		done         = make(chan struct{})
+		ctxt, cancel = context.WithCancel(context.Background())
+	)
+
+	// node cannot be claimed for the next 50ms
+	repo.scheduled = map[string]time.Time{"foo": time.Now().Add(50 * time.Millisecond)}
+
+	go func() {
+		pool.Run(ctxt)
+		done <- struct{}{}
+	}()
+
+	repo.subscriptionCount.Wait()
+
+	repo.subscribeCalls[0].events <- &adagio.Event{
+		RunID:    "bar",
+		NodeSpec: node.Spec,
+		Type:     adagio.Event_NODE_READY,
+	}
+
+	// wait beyond the time the node is scheduled for
+	time.Sleep(200 * time.Millisecond)
+
+	// stop running
+	cancel()
+	<-done
+
+	// ensure the event is not redelivered as the repository
+	// announces the node again once it has been made ready
+	assert.Equal(t, claims(1, "bar", "foo", claim), repo.claimCalls)
+	assert.Len(t, repo.finishCalls, 0)
+}
+
+func TestPool_Selector(t *testing.T) {
+	var (
+		node = &adagio.Node{
+			Spec: &adagio.Node_Spec{
+				Name:    "foo",
+				Runtime: "test",
+			},
+		}
+
+		runtimes = map[string]Runtime{
+			"test": runtime{
+				name: "test",
+				newFunction: func() Function {
+					return function{
+						run: func(context.Context, *adagio.Node) (*adagio.Result, error) {
+							return &adagio.Result{Conclusion: adagio.Result_SUCCESS}, nil
+						},
+					}
+				},
+			},
+		}
+
+		repo   = newRepository(1, node)
+		labels = map[string]string{"zone": "a"}
+
+		claim     = &adagio.Claim{Id: "claim"}
+		claimFunc = func() Claimer {
+			return ClaimerFunc(func() *adagio.Claim {
+				return claim
+			})
+		}
+		pool = NewPool(repo, runtimes, WithClaimerFunc(claimFunc), WithLabels(labels))
+
+		done         = make(chan struct{})
+		ctxt, cancel = context.WithCancel(context.Background())
+	)
+
+	go func() {
+		pool.Run(ctxt)
+		done <- struct{}{}
+	}()
+
+	repo.subscriptionCount.Wait()
+
+	call := repo.subscribeCalls[0]
+
+	// ensure agent is registered with its labels
+	assert.Equal(t, labels, call.agent.Labels)
+
+	// node selecting another zone is left for other agents
+	call.events <- &adagio.Event{
+		RunID: "bar",
+		NodeSpec: &adagio.Node_Spec{
+			Name:     "baz",
+			Runtime:  "test",
+			Selector: &adagio.Node_Spec_Selector{MatchLabels: map[string]string{"zone": "b"}},
+		},
+		Type: adagio.Event_NODE_READY,
+	}
+
+	// node selecting this zone is claimed
+	call.events <- &adagio.Event{
+		RunID: "bar",
+		NodeSpec: &adagio.Node_Spec{
+			Name:     "foo",
+			Runtime:  "test",
+			Selector: &adagio.Node_Spec_Selector{MatchLabels: labels},
+		},
+		Type: adagio.Event_NODE_READY,
+	}
+
+	// wait for the matching node to be finished
+	deadline := time.Now().Add(5 * time.Second)
+	for repo.finishCount() < 1 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// stop running
+	cancel()
+	<-done
+
+	// ensure only the matching node is claimed
+	assert.Equal(t, claims(1, "bar", "foo", claim), repo.claimCalls)
+	require.Len(t, repo.finishCalls, 1)
+}
+
+func TestPool_RuntimeConcurrency(t *testing.T) {
+	var (
+		foo = &adagio.Node{Spec: &adagio.Node_Spec{Name: "foo", Runtime: "test"}}
+		bar = &adagio.Node{Spec: &adagio.Node_Spec{Name: "bar", Runtime: "test"}}
+
+		running, maxRunning int32
+
+		runtimes = map[string]Runtime{
+			"test": runtime{
+				name: "test",
+				newFunction: func() Function {
+					return function{
+						run: func(context.Context, *adagio.Node) (*adagio.Result, error) {
+							n := atomic.AddInt32(&running, 1)
+							defer atomic.AddInt32(&running, -1)
+
+							for {
+								max := atomic.LoadInt32(&maxRunning)
+								if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+									break
+								}
+							}
+
+							time.Sleep(50 * time.Millisecond)
+
+							return &adagio.Result{Conclusion: adagio.Result_SUCCESS}, nil
+						},
+					}
+				},
+			},
+		}
+
+		repo = newRepository(2, foo, bar)
+		pool = NewPool(repo, runtimes,
+			WithAgentCount(2),
+			WithRuntimeConcurrency("test", 1),
+			WithLimitInterval(10*time.Millisecond))
+
+		done         = make(chan struct{})
+		ctxt, cancel = context.WithCancel(context.Background())
+	)
+
+	go func() {
+		pool.Run(ctxt)
+		done <- struct{}{}
+	}()
+
+	repo.subscriptionCount.Wait()
+
+	// each agent is sent a different node
+	for i, node := range []*adagio.Node{foo, bar} {
+		repo.subscribeCalls[i].events <- &adagio.Event{
+			RunID:    "baz",
+			NodeSpec: node.Spec,
+			Type:     adagio.Event_NODE_READY,
+		}
+	}
+
+	// wait for both nodes to be finished
+	deadline := time.Now().Add(5 * time.Second)
+	for repo.finishCount() < 2 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// stop running
+	cancel()
+	<-done
+
+	require.Len(t, repo.finishCalls, 2)
+
+	// ensure the nodes were not run at the same time
+	assert.Equal(t, int32(1), maxRunning)
+}
+
+func TestPool_Redeliver_ConcurrencyLimit(t *testing.T) {
+	var (
+		node = &adagio.Node{
+			Spec: &adagio.Node_Spec{
+				Name:    "foo",
+				Runtime: "test",
+			},
+		}
+
+		runtimes = map[string]Runtime{
+			"test": runtime{
+				name: "test",
+				newFunction: func() Function {
+					return function{
+						run: func(context.Context, *adagio.Node) (*adagio.Result, error) {
+							return &adagio.Result{Conclusion: adagio.Result_SUCCESS}, nil
+						},
+					}
+				},
+			},
+		}
+
+		repo = newRepository(1, node)
+
+		claim     = &adagio.Claim{Id: "claim"}
+		claimFunc = func() Claimer {
+			return ClaimerFunc(func() *adagio.Claim {
+				return claim
+			})
+		}
+		pool = NewPool(repo, runtimes, WithClaimerFunc(claimFunc), WithLimitInterval(10*time.Millisecond))
+
+		done         = make(chan struct{})
+		ctxt, cancel = context.WithCancel(context.Background())
+	)
+
+	// node claims are rejected twice by the repository
+	repo.limited = map[string]int{"foo": 2}
+
+	go func() {
+		pool.Run(ctxt)
+		done <- struct{}{}
+	}()
+
+	repo.subscriptionCount.Wait()
+
+	repo.subscribeCalls[0].events <- &adagio.Event{
+		RunID:    "bar",
+		NodeSpec: node.Spec,
+		Type:     adagio.Event_NODE_READY,
+	}
+
+	// wait for the redelivered event to be claimed and finished
+	deadline := time.Now().Add(5 * time.Second)
+	for repo.finishCount() < 1 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// stop running
+	cancel()
+	<-done
+
+	// ensure the rejected claims and the final claim are attempted
+	assert.Equal(t, claims(3, "bar", "foo", claim), repo.claimCalls)
+	require.Len(t, repo.finishCalls, 1)
+}
+
+func TestPool_Priority(t *testing.T) {
+	var (
+		started = make(chan struct{})
+		release = make(chan struct{})
+
+		runtimes = map[string]Runtime{
+			"test": runtime{
+				name: "test",
+				newFunction: func() Function {
+					return function{
+						run: func(_ context.Context, node *adagio.Node) (*adagio.Result, error) {
+							if node.Spec.Name == "first" {
+								// hold the agent until the other events are delivered
+								close(started)
+								<-release
+							}
+
+							return &adagio.Result{Conclusion: adagio.Result_SUCCESS}, nil
+						},
+					}
+				},
+			},
+		}
+
+		nodes []*adagio.Node
+		names = []string{"first", "low", "high", "mid"}
+	)
+
+	for _, name := range names {
+		nodes = append(nodes, &adagio.Node{Spec: &adagio.Node_Spec{Name: name, Runtime: "test"}})
+	}
+
+	var (
+		repo = newRepository(1, nodes...)
+		pool = NewPool(repo, runtimes)
+
+		done         = make(chan struct{})
+		ctxt, cancel = context.WithCancel(context.Background())
+	)
+
+	go func() {
+		pool.Run(ctxt)
+		done <- struct{}{}
+	}()
+
+	repo.subscriptionCount.Wait()
+
+	events := repo.subscribeCalls[0].events
+
+	events <- &adagio.Event{RunID: "bar", NodeSpec: nodes[0].Spec, Type: adagio.Event_NODE_READY}
+
+	<-started
+
+	for i, priority := range []int32{1, 10, 5} {
+		events <- &adagio.Event{RunID: "bar", NodeSpec: nodes[i+1].Spec, Type: adagio.Event_NODE_READY, Priority: priority}
+	}
+
+	close(release)
+
+	// wait for all the nodes to be finished
+	deadline := time.Now().Add(5 * time.Second)
+	for repo.finishCount() < len(nodes) && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// stop running
+	cancel()
+	<-done
+
+	claimed := make([]string, 0, len(repo.claimCalls))
+	for _, call := range repo.claimCalls {
+		claimed = append(claimed, call.name)
+	}
+
+	// ensure the pending nodes are claimed in order of priority
+	assert.Equal(t, []string{"first", "high", "mid", "low"}, claimed)
+}
+
+func TestPool_Priority_Agents(t *testing.T) {
+	var (
+		started = make(chan struct{}, 2)
+		release = make(chan struct{})
+
+		runtimes = map[string]Runtime{
+			"test": runtime{
+				name: "test",
+				newFunction: func() Function {
+					return function{
+						run: func(_ context.Context, node *adagio.Node) (*adagio.Result, error) {
+							if strings.HasPrefix(node.Spec.Name, "first") {
+								// hold each agent until the other events are delivered
+								started <- struct{}{}
+								<-release
+							}
+
+							return &adagio.Result{Conclusion: adagio.Result_SUCCESS}, nil
+						},
+					}
+				},
+			},
+		}
+
+		nodes []*adagio.Node
+		names = []string{"first-0", "first-1", "low", "high", "mid"}
+	)
+
+	for _, name := range names {
+		nodes = append(nodes, &adagio.Node{Spec: &adagio.Node_Spec{Name: name, Runtime: "test"}})
+	}
+
+	var (
+		repo = newRepository(2, nodes...)
+		pool = NewPool(repo, runtimes, WithAgentCount(2))
+
+		done         = make(chan struct{})
+		ctxt, cancel = context.WithCancel(context.Background())
+	)
+
+	go func() {
+		pool.Run(ctxt)
+		done <- struct{}{}
+	}()
+
+	repo.subscriptionCount.Wait()
+
+	// occupy both agents
+	for i, call := range repo.subscribeCalls {
+		call.events <- &adagio.Event{RunID: "bar", NodeSpec: nodes[i].Spec, Type: adagio.Event_NODE_READY}
+	}
+
+	<-started
+	<-started
+
+	// each agent is delivered every ready node
+	for _, call := range repo.subscribeCalls {
+		for i, priority := range []int32{1, 10, 5} {
+			call.events <- &adagio.Event{RunID: "bar", NodeSpec: nodes[i+2].Spec, Type: adagio.Event_NODE_READY, Priority: priority}
+		}
+	}
+
+	close(release)
+
+	// wait for all the nodes to be finished
+	deadline := time.Now().Add(5 * time.Second)
+	for repo.finishCount() < len(nodes) && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// stop running
+	cancel()
+	<-done
+
+	// the first attempt to claim each node is the one which succeeds
+	var (
+		claimed []string
+		seen    = map[string]bool{}
+	)
+
+	for _, call := range repo.claimCalls[2:] {
+		if !seen[call.name] {
+			seen[call.name] = true
+			claimed = append(claimed, call.name)
+		}
+	}
+
+	// ensure the nodes pending with both agents are claimed in order of priority
+	assert.Equal(t, []string{"high", "mid", "low"}, claimed)
+}
+
+func TestPool_Heartbeat(t *testing.T) {
+	var (
+		node = &adagio.Node{
+			Spec: &adagio.Node_Spec{
+				Name:    "foo",
+				Runtime: "test",
+			},
+		}
+
+		runtimes = map[string]Runtime{
+			"test": runtime{
+				name: "test",
+				newFunction: func() Function {
+					return function{
+						run: func(context.Context, *adagio.Node) (*adagio.Result, error) {
+							return &adagio.Result{Conclusion: adagio.Result_SUCCESS}, nil
+						},
+					}
+				},
+			},
+		}
+
+		repo = newRepository(1, node)
+		pool = NewPool(repo, runtimes, WithHeartbeatInterval(10*time.Millisecond))
+
+		done         = make(chan struct{})
+		ctxt, cancel = context.WithCancel(context.Background())
+	)
+
+	go func() {
+		pool.Run(ctxt)
+		done <- struct{}{}
+	}()
+
+	repo.subscriptionCount.Wait()
+
+	call := repo.subscribeCalls[0]
+
+	// ensure agent is registered with the details of its process
+	hostname, _ := os.Hostname()
+	assert.Equal(t, hostname, call.agent.Hostname)
+	assert.Equal(t, int32(os.Getpid()), call.agent.Pid)
+	assert.Equal(t, adagio.Version, call.agent.Version)
+	assert.Equal(t, int32(1), call.agent.Capacity)
+	assert.NotEmpty(t, call.agent.StartedAt)
+
+	call.events <- &adagio.Event{
+		RunID:    "bar",
+		NodeSpec: node.Spec,
+		Type:     adagio.Event_NODE_READY,
+	}
+
+	// wait for the node to be finished and a periodic heartbeat to follow
+	deadline := time.Now().Add(5 * time.Second)
+	for repo.finishCount() < 1 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+
+	// stop running
+	cancel()
+	<-done
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	var working, idle bool
+	for _, agent := range repo.heartbeatCalls {
+		assert.Equal(t, call.agent.Id, agent.Id)
+		assert.NotEmpty(t, agent.LastHeartbeat)
+
+		if current := agent.Current; current != nil {
+			working = working || (current.RunId == "bar" && current.Node == "foo")
+			continue
+		}
+
+		idle = true
+	}
+
+	// ensure the agent reported both the node it was running and being idle
+	assert.True(t, working, "expected heartbeat with current work")
+	assert.True(t, idle, "expected heartbeat without current work")
+
+	// ensure the claims made identify the agent
+	require.NotEmpty(t, repo.claimAgents)
+	for _, agentID := range repo.claimAgents {
+		assert.Equal(t, call.agent.Id, agentID)
+	}
+}
+
+func TestPool_ReportProgress(t *testing.T) {
+	var (
+		node = &adagio.Node{
+			Spec: &adagio.Node_Spec{
+				Name:    "foo",
+				Runtime: "test",
+			},
+		}
+
+		runtimes = map[string]Runtime{
+			"test": runtime{
+				name: "test",
+				newFunction: func() Function {
+					return function{
+						run: func(ctx context.Context, _ *adagio.Node) (*adagio.Result, error) {
+							if err := ReportProgress(ctx, &adagio.Node_Progress{Percent: 50, Message: "halfway"}); err != nil {
+								return nil, err
+							}
+
+							return &adagio.Result{Conclusion: adagio.Result_SUCCESS}, nil
+						},
+					}
+				},
+			},
+		}
+
+		repo = newRepository(1, node)
+
+		claim     = &adagio.Claim{Id: "claim"}
+		claimFunc = func() Claimer {
+			return ClaimerFunc(func() *adagio.Claim {
+				return claim
+			})
+		}
+		pool = NewPool(repo, runtimes, WithClaimerFunc(claimFunc))
+
+		done         = make(chan struct{})
+		ctxt, cancel = context.WithCancel(context.Background())
+	)
+
+	go func() {
+		pool.Run(ctxt)
+		done <- struct{}{}
+	}()
+
+	repo.subscriptionCount.Wait()
+
+	repo.subscribeCalls[0].events <- &adagio.Event{
+		RunID:    "bar",
+		NodeSpec: node.Spec,
+		Type:     adagio.Event_NODE_READY,
+	}
+
+	// wait for the node to be finished
+	deadline := time.Now().Add(5 * time.Second)
+	for repo.finishCount() < 1 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// stop running
+	cancel()
+	<-done
+
+	// ensure progress is reported for the claimed node
+	require.Len(t, repo.progressCalls, 1)
+
+	call := repo.progressCalls[0]
+	assert.Equal(t, "bar", call.runID)
+	assert.Equal(t, "foo", call.name)
+	assert.Equal(t, claim, call.claim)
+	assert.Equal(t, float64(50), call.progress.Percent)
+	assert.Equal(t, "halfway", call.progress.Message)
+	assert.NotEmpty(t, call.progress.UpdatedAt)
+
+	require.Len(t, repo.finishCalls, 1)
+	assert.Equal(t, adagio.Node_Result_SUCCESS, repo.finishCalls[0].result.Conclusion)
+}
+
+func TestPool_HangTimeout(t *testing.T) {
+	var (
+		node = &adagio.Node{
+			Spec: &adagio.Node_Spec{
+				Name:    "foo",
+				Runtime: "test",
+			},
+		}
+
+		runtimes = map[string]Runtime{
+			"test": runtime{
+				name: "test",
+				newFunction: func() Function {
+					return function{
+						run: func(ctx context.Context, _ *adagio.Node) (*adagio.Result, error) {
+							// send a single heartbeat and then hang
+							KeepAlive(ctx)
+
+							<-ctx.Done()
+
+							return nil, ctx.Err()
+						},
+					}
+				},
+			},
+		}
+
+		repo = newRepository(1, node)
+		pool = NewPool(repo, runtimes, WithHangTimeout(20*time.Millisecond))
+
+		done         = make(chan struct{})
+		ctxt, cancel = context.WithCancel(context.Background())
+	)
+
+	go func() {
+		pool.Run(ctxt)
+		done <- struct{}{}
+	}()
+
+	repo.subscriptionCount.Wait()
+
+	repo.subscribeCalls[0].events <- &adagio.Event{
+		RunID:    "bar",
+		NodeSpec: node.Spec,
+		Type:     adagio.Event_NODE_READY,
+	}
+
+	// wait for the hung node to be finished
+	deadline := time.Now().Add(5 * time.Second)
+	for repo.finishCount() < 1 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// stop running
+	cancel()
+	<-done
+
+	// ensure the hung node is concluded with an error
+	require.Len(t, repo.finishCalls, 1)
+	assert.Equal(t, adagio.Node_Result_ERROR, repo.finishCalls[0].result.Conclusion)
+	assert.Equal(t, "node hung: no heartbeat within 20ms", string(repo.finishCalls[0].result.Output))
+}
+
+func TestPool_LogWriter(t *testing.T) {
+	var (
+		node = &adagio.Node{
+			Spec: &adagio.Node_Spec{
+				Name:    "foo",
+				Runtime: "test",
+			},
+			Status: adagio.Node_RUNNING,
+		}
+
+		runtimes = map[string]Runtime{
+			"test": runtime{
+				name: "test",
+				newFunction: func() Function {
+					return function{
+						run: func(ctx context.Context, _ *adagio.Node) (*adagio.Result, error) {
+							fmt.Fprint(LogWriter(ctx), "first line\nsecond ")
+							fmt.Fprint(LogWriter(ctx), "line\nunterminated")
+
+							return &adagio.Result{Conclusion: adagio.Result_SUCCESS}, nil
+						},
+					}
+				},
+			},
+		}
+
+		repo = newRepository(1, node)
+		pool = NewPool(repo, runtimes, WithLogFlushInterval(time.Hour))
+
+		done         = make(chan struct{})
+		ctxt, cancel = context.WithCancel(context.Background())
+	)
+
+	go func() {
+		pool.Run(ctxt)
+		done <- struct{}{}
+	}()
+
+	repo.subscriptionCount.Wait()
+
+	repo.subscribeCalls[0].events <- &adagio.Event{
+		RunID:    "bar",
+		NodeSpec: node.Spec,
+		Type:     adagio.Event_NODE_READY,
+	}
+
+	// wait for the node to be finished
+	deadline := time.Now().Add(5 * time.Second)
+	for repo.finishCount() < 1 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// stop running
+	cancel()
+	<-done
+
+	// ensure the lines are shipped for the first attempt once the function returns
+	require.Len(t, repo.logCalls, 1)
+
+	call := repo.logCalls[0]
+	assert.Equal(t, "bar", call.runID)
+	assert.Equal(t, "foo", call.name)
+	assert.Equal(t, int32(1), call.attempt)
+
+	var lines []string
+	for _, line := range call.lines {
+		assert.NotEmpty(t, line.Timestamp)
+		lines = append(lines, line.Text)
+	}
+
+	assert.Equal(t, []string{"first line", "second line", "unterminated"}, lines)
+}
+
+func TestPool_LogWriter_SlowAppend(t *testing.T) {
+	var (
+		node = &adagio.Node{
+			Spec: &adagio.Node_Spec{
+				Name:    "foo",
+				Runtime: "test",
+			},
+			Status: adagio.Node_RUNNING,
+		}
+
+		expected []string
+
+		runtimes = map[string]Runtime{
+			"test": runtime{
+				name: "test",
+				newFunction: func() Function {
+					return function{
+						run: func(ctx context.Context, _ *adagio.Node) (*adagio.Result, error) {
+							for _, line := range expected {
+								fmt.Fprintln(LogWriter(ctx), line)
+								time.Sleep(time.Millisecond)
+							}
+
+							return &adagio.Result{Conclusion: adagio.Result_SUCCESS}, nil
+						},
+					}
+				},
+			},
+		}
+
+		repo = newRepository(1, node)
+		pool = NewPool(repo, runtimes, WithLogFlushInterval(time.Millisecond))
+
+		done         = make(chan struct{})
+		ctxt, cancel = context.WithCancel(context.Background())
+	)
+
+	for i := 0; i < 50; i++ {
+		expected = append(expected, fmt.Sprintf("line %d", i))
+	}
+
+	// flushes are still in flight when the function returns
+	repo.logDelay = 20 * time.Millisecond
+
+	go func() {
+		pool.Run(ctxt)
+		done <- struct{}{}
+	}()
+
+	repo.subscriptionCount.Wait()
+
+	repo.subscribeCalls[0].events <- &adagio.Event{
+		RunID:    "bar",
+		NodeSpec: node.Spec,
+		Type:     adagio.Event_NODE_READY,
+	}
+
+	// wait for the node to be finished
+	deadline := time.Now().Add(5 * time.Second)
+	for repo.finishCount() < 1 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// stop running
+	cancel()
+	<-done
+
+	// ensure every line is shipped in order across the chunks
+	require.True(t, len(repo.logCalls) > 1, "expected lines to be shipped in several chunks")
+
+	var lines []string
+	for _, call := range repo.logCalls {
+		for _, line := range call.lines {
+			lines = append(lines, line.Text)
+		}
+	}
+
+	assert.Equal(t, expected, lines)
+}
+
+func TestPool_Tracing(t *testing.T) {
+	var (
+		spans    = tracetest.NewSpanRecorder()
+		provider = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))
+		previous = otel.GetTracerProvider()
+	)
+
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(previous)
+
+	var (
+		node = &adagio.Node{
+			Spec: &adagio.Node_Spec{
+				Name:    "foo",
+				Runtime: "test",
+			},
+			Status: adagio.Node_RUNNING,
+		}
+
+		runtimes = map[string]Runtime{
+			"test": runtime{
+				name: "test",
+				newFunction: func() Function {
+					return function{
+						run: func(context.Context, *adagio.Node) (*adagio.Result, error) {
+							return &adagio.Result{Conclusion: adagio.Result_SUCCESS}, nil
+						},
+					}
+				},
+			},
+		}
+
+		repo = newRepository(1, node)
+		pool = NewPool(repo, runtimes)
+		run  = &adagio.Run{Id: "bar"}
+
+		done         = make(chan struct{})
+		ctxt, cancel = context.WithCancel(context.Background())
+	)
+
+	// record the trace context of the run as a repository would
+	tracing.StartRun(context.Background(), run)
+
+	go func() {
+		pool.Run(ctxt)
+		done <- struct{}{}
+	}()
+
+	repo.subscriptionCount.Wait()
+
+	repo.subscribeCalls[0].events <- &adagio.Event{
+		RunID:        run.Id,
+		NodeSpec:     node.Spec,
+		Type:         adagio.Event_NODE_READY,
+		TraceContext: run.TraceContext,
+	}
+
+	// wait for the node to be finished
+	deadline := time.Now().Add(5 * time.Second)
+	for repo.finishCount() < 1 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// stop running
+	cancel()
+	<-done
+
+	ended := spans.Ended()
+	require.Len(t, ended, 2)
+
+	var (
+		root    = ended[0]
+		attempt = ended[1]
+	)
+
+	// the node attempt continues the trace of the run
+	assert.Equal(t, "node", attempt.Name())
+	assert.Equal(t, root.SpanContext().TraceID(), attempt.SpanContext().TraceID())
+	assert.Equal(t, root.SpanContext().SpanID(), attempt.Parent().SpanID())
+	assert.Subset(t, attempt.Attributes(), []attribute.KeyValue{
+		tracing.RunIDKey.String("bar"),
+		tracing.NodeKey.String("foo"),
+		tracing.RuntimeKey.String("test"),
+		tracing.AttemptKey.Int(1),
+		tracing.ConclusionKey.String("success"),
+	})
+}
+
+func TestPool_Logger(t *testing.T) {
+	var (
+		node = &adagio.Node{
+			Spec: &adagio.Node_Spec{
+				Name:    "foo",
+				Runtime: "test",
+			},
+			Status: adagio.Node_RUNNING,
+		}
+
+		runtimes = map[string]Runtime{
+			"test": runtime{
+				name: "test",
+				newFunction: func() Function {
+					return function{
+						run: func(ctx context.Context, _ *adagio.Node) (*adagio.Result, error) {
+							Logger(ctx).Info("from function")
+
+							return &adagio.Result{Conclusion: adagio.Result_SUCCESS}, nil
+						},
+					}
+				},
+			},
+		}
+
+		logger, hook = logtest.NewNullLogger()
+
+		repo = newRepository(1, node)
+		pool = NewPool(repo, runtimes, WithLogger(logger), WithClaimerFunc(func() Claimer {
+			return ClaimerFunc(func() *adagio.Claim { return &adagio.Claim{Id: "claim"} })
+		}))
+
+		done         = make(chan struct{})
+		ctxt, cancel = context.WithCancel(context.Background())
+	)
+
+	go func() {
+		pool.Run(ctxt)
+		done <- struct{}{}
+	}()
+
+	repo.subscriptionCount.Wait()
+
+	repo.subscribeCalls[0].events <- &adagio.Event{
+		RunID:    "bar",
+		NodeSpec: node.Spec,
+		Type:     adagio.Event_NODE_READY,
+	}
+
+	// wait for the node to be finished
+	deadline := time.Now().Add(5 * time.Second)
+	for repo.finishCount() < 1 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// stop running
+	cancel()
+	<-done
+
+	messages := map[string]logging.Fields{}
+	for _, entry := range hook.AllEntries() {
+		messages[entry.Message] = logging.Fields(entry.Data)
+	}
+
+	require.Contains(t, messages, "agent started")
+	assert.NotEmpty(t, messages["agent started"][logging.AgentIDKey])
+
+	// messages relating to the node carry the run, node, agent and claim
+	for _, message := range []string{"node claimed", "from function", "finishing node"} {
+		require.Contains(t, messages, message)
+
+		fields := messages[message]
+		assert.Equal(t, "bar", fields[logging.RunIDKey], message)
+		assert.Equal(t, "foo", fields[logging.NodeKey], message)
+		assert.Equal(t, "claim", fields[logging.ClaimIDKey], message)
+		assert.Equal(t, messages["agent started"][logging.AgentIDKey], fields[logging.AgentIDKey], message)
+	}
+
+	assert.Equal(t, "success", messages["finishing node"]["conclusion"])
+}
@@ -0,0 +1,46 @@
+package agent
+
+import (
+	"context"
+
+	"github.com/georgemac/adagio/pkg/logging"
+)
+
+type contextKey int
+
+// keys of the values carried on the context passed to Function.Run
+const (
+	runIDKey contextKey = iota
+	reporterKey
+	logWriterKey
+	loggerKey
+)
+
+// WithRunID returns a copy of the provided context which carries
+// the ID of the run a node being executed belongs to
+func WithRunID(ctx context.Context, runID string) context.Context {
+	return context.WithValue(ctx, runIDKey, runID)
+}
+
+// RunIDFromContext returns the run ID carried by the context
+// The Pool sets this on the context passed to Function.Run
+func RunIDFromContext(ctx context.Context) (string, bool) {
+	runID, ok := ctx.Value(runIDKey).(string)
+	return runID, ok
+}
+
+// Logger returns the logger carried by the context. The Pool carries a logger
+// with the run ID, node name, agent ID and claim ID of the node being executed
+// as fields on the context passed to Function.Run. Given the context carries
+// no logger the messages logged are discarded
+func Logger(ctx context.Context) logging.Logger {
+	if logger, ok := ctx.Value(loggerKey).(logging.Logger); ok {
+		return logger
+	}
+
+	return logging.Discard()
+}
+
+func withLogger(ctx context.Context, logger logging.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey, logger)
+}
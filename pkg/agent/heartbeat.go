@@ -0,0 +1,73 @@
+package agent
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/georgemac/adagio/pkg/adagio"
+	"github.com/georgemac/adagio/pkg/logging"
+	"github.com/golang/protobuf/proto"
+)
+
+// heartbeat publishes the liveness of an agent and the node
+// it is currently executing to the repository
+type heartbeat struct {
+	repo   Repository
+	agent  *adagio.Agent
+	logger logging.Logger
+
+	mu      sync.Mutex
+	current *adagio.Agent_Work
+}
+
+// working records the node being executed by the agent and publishes it
+func (h *heartbeat) working(ctx context.Context, runID, name string) {
+	h.mu.Lock()
+	h.current = &adagio.Agent_Work{
+		RunId:     runID,
+		Node:      name,
+		StartedAt: time.Now().UTC().Format(time.RFC3339Nano),
+	}
+	h.mu.Unlock()
+
+	h.beat(ctx)
+}
+
+// idle clears the node being executed by the agent and publishes it
+func (h *heartbeat) idle(ctx context.Context) {
+	h.mu.Lock()
+	h.current = nil
+	h.mu.Unlock()
+
+	h.beat(ctx)
+}
+
+// beat publishes a snapshot of the agent with the current time as its last heartbeat
+func (h *heartbeat) beat(ctx context.Context) {
+	agent := proto.Clone(h.agent).(*adagio.Agent)
+	agent.LastHeartbeat = time.Now().UTC().Format(time.RFC3339Nano)
+
+	h.mu.Lock()
+	agent.Current = h.current
+	h.mu.Unlock()
+
+	if err := h.repo.Heartbeat(ctx, agent); err != nil {
+		h.logger.WithError(err).Warn("publishing heartbeat")
+	}
+}
+
+// run publishes a heartbeat on the provided interval until the context is cancelled
+func (h *heartbeat) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			h.beat(ctx)
+		}
+	}
+}
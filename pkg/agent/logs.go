@@ -0,0 +1,136 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"github.com/georgemac/adagio/pkg/adagio"
+	"github.com/georgemac/adagio/pkg/logging"
+)
+
+// LogWriter returns a writer for the logs of the node being executed.
+// Each line written is timestamped and shipped to the repository in chunks.
+// The Pool carries the writer on the context passed to Function.Run. Given the
+// context carries no such writer the lines written are discarded
+func LogWriter(ctx context.Context) io.Writer {
+	if w, ok := ctx.Value(logWriterKey).(*logWriter); ok {
+		return w
+	}
+
+	return ioutil.Discard
+}
+
+// logWriter buffers the lines written for an attempt of a node
+// and ships them to the repository on flush
+type logWriter struct {
+	repo        Repository
+	logger      logging.Logger
+	runID, name string
+	attempt     int32
+
+	mu      sync.Mutex
+	partial []byte
+	lines   []*adagio.LogLine
+
+	// flushMu serializes flushes so that chunks are appended in the order written
+	flushMu sync.Mutex
+}
+
+func newLogWriter(repo Repository, logger logging.Logger, runID, name string, attempt int32) *logWriter {
+	return &logWriter{
+		repo:    repo,
+		logger:  logger,
+		runID:   runID,
+		name:    name,
+		attempt: attempt,
+	}
+}
+
+// context returns a copy of the context which carries the writer
+func (w *logWriter) context(ctx context.Context) context.Context {
+	return context.WithValue(ctx, logWriterKey, w)
+}
+
+// Write splits the provided data into lines and timestamps each complete line
+// Incomplete lines are held until they are completed or the writer is flushed
+func (w *logWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	data := append(w.partial, p...)
+	for {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			break
+		}
+
+		w.append(string(data[:i]))
+
+		data = data[i+1:]
+	}
+
+	w.partial = append([]byte(nil), data...)
+
+	return len(p), nil
+}
+
+func (w *logWriter) append(text string) {
+	w.lines = append(w.lines, &adagio.LogLine{
+		Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
+		Text:      text,
+	})
+}
+
+// flush ships the complete lines to the repository
+// Given final is true any incomplete line is shipped too
+func (w *logWriter) flush(ctx context.Context, final bool) {
+	w.flushMu.Lock()
+	defer w.flushMu.Unlock()
+
+	w.mu.Lock()
+	if final && len(w.partial) > 0 {
+		w.append(string(w.partial))
+		w.partial = nil
+	}
+
+	lines := w.lines
+	w.lines = nil
+	w.mu.Unlock()
+
+	if len(lines) == 0 {
+		return
+	}
+
+	if err := w.repo.AppendLogs(ctx, w.runID, w.name, w.attempt, lines); err != nil {
+		w.logger.WithError(err).Warn("shipping logs")
+	}
+}
+
+// run flushes the writer on the provided interval until stop is closed
+// Lines are shipped using ctx so that a flush in flight when stop is closed
+// is not aborted. The returned channel is closed once the writer has stopped
+func (w *logWriter) run(ctx context.Context, stop <-chan struct{}, interval time.Duration) <-chan struct{} {
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				w.flush(ctx, false)
+			}
+		}
+	}()
+
+	return done
+}
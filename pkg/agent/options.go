@@ -1,5 +1,12 @@
 package agent
 
+import (
+	"time"
+
+	"github.com/georgemac/adagio/pkg/logging"
+	"github.com/georgemac/adagio/pkg/metrics"
+)
+
 // Option is a functional option for the Pool type
 type Option func(*Pool)
 
@@ -27,3 +34,68 @@ func WithClaimerFunc(fn func() Claimer) Option {
 		p.newClaimer = fn
 	}
 }
+
+// WithLabels configures the labels of the agents in the pool
+// Agents only claim ready nodes with a selector which matches the labels
+func WithLabels(labels map[string]string) Option {
+	return func(p *Pool) {
+		p.labels = labels
+	}
+}
+
+// WithRuntimeConcurrency limits the number of nodes of the named runtime
+// which the agents in the pool run at once
+func WithRuntimeConcurrency(runtime string, limit int) Option {
+	return func(p *Pool) {
+		p.slots[runtime] = make(chan struct{}, limit)
+	}
+}
+
+// WithLimitInterval configures the interval after which a node which could not be
+// claimed due to a concurrency limit is attempted again (defaults to 1s)
+func WithLimitInterval(interval time.Duration) Option {
+	return func(p *Pool) {
+		p.limitInterval = interval
+	}
+}
+
+// WithHeartbeatInterval configures the interval on which agents publish
+// their heartbeat to the repository (defaults to 10s)
+func WithHeartbeatInterval(interval time.Duration) Option {
+	return func(p *Pool) {
+		p.heartbeatInterval = interval
+	}
+}
+
+// WithHangTimeout configures the duration within which a function which has sent
+// a heartbeat (see KeepAlive and ReportProgress) must send another. Otherwise, the
+// node is deemed hung, its context is cancelled and it is concluded with an error
+func WithHangTimeout(timeout time.Duration) Option {
+	return func(p *Pool) {
+		p.hangTimeout = timeout
+	}
+}
+
+// WithLogFlushInterval configures the interval on which the lines written to the
+// LogWriter of a node being executed are shipped to the repository (defaults to 1s)
+func WithLogFlushInterval(interval time.Duration) Option {
+	return func(p *Pool) {
+		p.logFlushInterval = interval
+	}
+}
+
+// WithMetrics configures the metrics on which the claims made, the
+// orphaned nodes handled and the duration of executions are recorded
+func WithMetrics(m *metrics.Metrics) Option {
+	return func(p *Pool) {
+		p.metrics = m
+	}
+}
+
+// WithLogger configures the logger on which the agents in the pool log the nodes
+// they claim and finish (defaults to info level logfmt on stderr)
+func WithLogger(logger logging.Logger) Option {
+	return func(p *Pool) {
+		p.logger = logger
+	}
+}
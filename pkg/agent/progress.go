@@ -0,0 +1,114 @@
+package agent
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/georgemac/adagio/pkg/adagio"
+)
+
+// ReportProgress records the progress of the node being executed with the
+// repository and counts as a heartbeat from the function executing it.
+// The Pool carries what is required to report progress on the context passed
+// to Function.Run. Given the context carries no such reporter it is a no-op
+func ReportProgress(ctx context.Context, progress *adagio.Node_Progress) error {
+	r, ok := ctx.Value(reporterKey).(*reporter)
+	if !ok {
+		return nil
+	}
+
+	r.keepAlive()
+
+	progress.UpdatedAt = time.Now().UTC().Format(time.RFC3339Nano)
+
+	return r.repo.ReportProgress(ctx, r.runID, r.name, progress, r.claim)
+}
+
+// KeepAlive records a heartbeat from the function executing the node without
+// reporting progress. Once a function has sent a heartbeat (via either KeepAlive or
+// ReportProgress) it must continue to do so within the hang timeout configured on
+// the Pool, otherwise the node is deemed hung and the context is cancelled
+func KeepAlive(ctx context.Context) {
+	if r, ok := ctx.Value(reporterKey).(*reporter); ok {
+		r.keepAlive()
+	}
+}
+
+// reporter reports the progress of a claimed node and watches
+// for the heartbeats of the function executing it
+type reporter struct {
+	repo        Repository
+	runID, name string
+	claim       *adagio.Claim
+
+	alive chan struct{}
+
+	mu   sync.Mutex
+	hung bool
+}
+
+func newReporter(repo Repository, runID, name string, claim *adagio.Claim) *reporter {
+	return &reporter{
+		repo:  repo,
+		runID: runID,
+		name:  name,
+		claim: claim,
+		alive: make(chan struct{}, 1),
+	}
+}
+
+// context returns a copy of the context which carries the reporter
+func (r *reporter) context(ctx context.Context) context.Context {
+	return context.WithValue(ctx, reporterKey, r)
+}
+
+func (r *reporter) keepAlive() {
+	select {
+	case r.alive <- struct{}{}:
+	default:
+	}
+}
+
+// watch calls cancel given the function stops sending heartbeats for longer than
+// the timeout once it has sent its first. It returns once the context is cancelled
+func (r *reporter) watch(ctx context.Context, timeout time.Duration, cancel func()) {
+	// functions opt in to hang detection by sending a heartbeat
+	select {
+	case <-ctx.Done():
+		return
+	case <-r.alive:
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.alive:
+			if !timer.Stop() {
+				<-timer.C
+			}
+
+			timer.Reset(timeout)
+		case <-timer.C:
+			r.mu.Lock()
+			r.hung = true
+			r.mu.Unlock()
+
+			cancel()
+
+			return
+		}
+	}
+}
+
+// isHung returns true given the function missed a heartbeat
+func (r *reporter) isHung() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.hung
+}
@@ -0,0 +1,56 @@
+package agent
+
+import (
+	"container/heap"
+
+	"github.com/georgemac/adagio/pkg/adagio"
+)
+
+// queue is a priority queue of events ordered by descending priority
+// and then by the order in which the events were pushed.
+// Each agent orders only the events already delivered to it. Priority is not enforced
+// when nodes are claimed, so an idle agent claims a lower priority node announced
+// before a higher priority one regardless of the events pending with other agents
+type queue struct {
+	items []queued
+	seq   uint64
+}
+
+type queued struct {
+	event *adagio.Event
+	seq   uint64
+}
+
+func (q *queue) Len() int { return len(q.items) }
+
+func (q *queue) Less(i, j int) bool {
+	if q.items[i].event.Priority != q.items[j].event.Priority {
+		return q.items[i].event.Priority > q.items[j].event.Priority
+	}
+
+	return q.items[i].seq < q.items[j].seq
+}
+
+func (q *queue) Swap(i, j int) { q.items[i], q.items[j] = q.items[j], q.items[i] }
+
+func (q *queue) Push(x interface{}) {
+	q.items = append(q.items, queued{x.(*adagio.Event), q.seq})
+	q.seq++
+}
+
+func (q *queue) Pop() interface{} {
+	var (
+		n    = len(q.items)
+		item = q.items[n-1]
+	)
+
+	q.items = q.items[:n-1]
+
+	return item.event
+}
+
+// push adds the event to the queue
+func (q *queue) push(event *adagio.Event) { heap.Push(q, event) }
+
+// pop removes and returns the highest priority event
+func (q *queue) pop() *adagio.Event { return heap.Pop(q).(*adagio.Event) }
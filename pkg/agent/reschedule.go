@@ -0,0 +1,34 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Reschedule returns an error which a Function can return to signal to the
+// Pool that the claim on the node should be released and the node claimed
+// again once the provided delay has elapsed. No attempt is recorded and no
+// agent is occupied while the node waits
+func Reschedule(after time.Duration) error {
+	return &rescheduleError{after: after}
+}
+
+type rescheduleError struct {
+	after time.Duration
+}
+
+func (e *rescheduleError) Error() string {
+	return fmt.Sprintf("reschedule after %v", e.after)
+}
+
+// RescheduleAfter returns the delay after which the node should be claimed
+// again given the provided error was constructed using Reschedule
+func RescheduleAfter(err error) (time.Duration, bool) {
+	var reschedule *rescheduleError
+	if errors.As(err, &reschedule) {
+		return reschedule.after, true
+	}
+
+	return 0, false
+}
@@ -2,9 +2,15 @@ package agent
 
 import (
 	"context"
+	"os"
 	"sync"
+	"testing"
+	"time"
 
 	"github.com/georgemac/adagio/pkg/adagio"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type runtime struct {
@@ -30,22 +36,30 @@ type repository struct {
 	// expectation of the number of subscriptions
 	subscriptionCount sync.WaitGroup
 	// return values
-	nodes map[string]*adagio.Node
+	nodes     map[string]*adagio.Node
+	scheduled map[string]time.Time
+	// number of claims rejected per node due to a concurrency limit
+	limited map[string]int
+	// duration each call to append logs takes (aborted given the context is cancelled)
+	logDelay time.Duration
 	// calls
-	claimCalls     []claimCall
-	finishCalls    []finishCall
-	subscribeCalls []subscribeCall
+	claimCalls      []claimCall
+	claimAgents     []string
+	finishCalls     []finishCall
+	rescheduleCalls []rescheduleCall
+	subscribeCalls  []subscribeCall
+	heartbeatCalls  []*adagio.Agent
+	progressCalls   []progressCall
+	logCalls        []logCall
 }
 
-func newRepository(subscriptionCount int, nodes ...*adagio.Node) repository {
-	wg := sync.WaitGroup{}
-	wg.Add(subscriptionCount)
-
-	repo := repository{
-		nodes:             map[string]*adagio.Node{},
-		subscriptionCount: wg,
+func newRepository(subscriptionCount int, nodes ...*adagio.Node) *repository {
+	repo := &repository{
+		nodes: map[string]*adagio.Node{},
 	}
 
+	repo.subscriptionCount.Add(subscriptionCount)
+
 	for _, node := range nodes {
 		repo.nodes[node.Spec.Name] = node
 	}
@@ -58,6 +72,15 @@ type claimCall struct {
 	claim       *adagio.Claim
 }
 
+// unowned returns a copy of the claim without the ID of the agent which made it
+// so that calls can be compared with the claims constructed by a test claimer
+func unowned(claim *adagio.Claim) *adagio.Claim {
+	claim = proto.Clone(claim).(*adagio.Claim)
+	claim.AgentId = ""
+
+	return claim
+}
+
 func claims(count int, runID, name string, claim *adagio.Claim) (calls []claimCall) {
 	calls = make([]claimCall, 0, count)
 	for i := 0; i < count; i++ {
@@ -71,8 +94,20 @@ func (r *repository) ClaimNode(_ context.Context, runID string, name string, cla
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	// track claim called
-	r.claimCalls = append(r.claimCalls, claimCall{runID, name, claim})
+	// track claim called and the agent which made it
+	r.claimCalls = append(r.claimCalls, claimCall{runID, name, unowned(claim)})
+	r.claimAgents = append(r.claimAgents, claim.AgentId)
+
+	// node cannot be claimed until scheduled time
+	if notBefore, ok := r.scheduled[name]; ok && time.Now().Before(notBefore) {
+		return nil, false, &adagio.ScheduledError{NotBefore: notBefore}
+	}
+
+	// node cannot be claimed while limited
+	if r.limited[name] > 0 {
+		r.limited[name]--
+		return nil, false, adagio.ErrConcurrencyLimit
+	}
 
 	// get node if claimable
 	node, ok := r.nodes[name]
@@ -89,11 +124,61 @@ type finishCall struct {
 	claim       *adagio.Claim
 }
 
+// unattributed asserts the result of the finish call is attributed to its claim,
+// the agent and host which made the attempt and the times at which it began and
+// concluded. It returns a copy of the call with the attribution removed
+func unattributed(t *testing.T, call finishCall) finishCall {
+	t.Helper()
+
+	hostname, _ := os.Hostname()
+
+	result := proto.Clone(call.result).(*adagio.Node_Result)
+	assert.Equal(t, call.claim.Id, result.ClaimId)
+	assert.NotEmpty(t, result.AgentId)
+	assert.Equal(t, hostname, result.Hostname)
+
+	startedAt, err := time.Parse(time.RFC3339Nano, result.StartedAt)
+	require.Nil(t, err)
+
+	finishedAt, err := time.Parse(time.RFC3339Nano, result.FinishedAt)
+	require.Nil(t, err)
+
+	assert.False(t, finishedAt.Before(startedAt), "attempt finished before it started")
+
+	result.StartedAt, result.FinishedAt = "", ""
+	result.ClaimId, result.AgentId, result.Hostname = "", "", ""
+	call.result = result
+
+	return call
+}
+
+func (r *repository) finishCount() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.finishCalls)
+}
+
 func (r *repository) FinishNode(_ context.Context, runID string, name string, result *adagio.Node_Result, claim *adagio.Claim) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	r.finishCalls = append(r.finishCalls, finishCall{runID, name, result, claim})
+	r.finishCalls = append(r.finishCalls, finishCall{runID, name, result, unowned(claim)})
+
+	return nil
+}
+
+type rescheduleCall struct {
+	runID, name string
+	notBefore   time.Time
+	claim       *adagio.Claim
+}
+
+func (r *repository) RescheduleNode(_ context.Context, runID string, name string, notBefore time.Time, claim *adagio.Claim) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.rescheduleCalls = append(r.rescheduleCalls, rescheduleCall{runID, name, notBefore, unowned(claim)})
 
 	return nil
 }
@@ -120,3 +205,50 @@ func (r *repository) Subscribe(_ context.Context, agent *adagio.Agent, events ch
 
 	return nil
 }
+
+func (r *repository) Heartbeat(_ context.Context, agent *adagio.Agent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.heartbeatCalls = append(r.heartbeatCalls, agent)
+
+	return nil
+}
+
+type progressCall struct {
+	runID, name string
+	progress    *adagio.Node_Progress
+	claim       *adagio.Claim
+}
+
+func (r *repository) ReportProgress(_ context.Context, runID string, name string, progress *adagio.Node_Progress, claim *adagio.Claim) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.progressCalls = append(r.progressCalls, progressCall{runID, name, progress, unowned(claim)})
+
+	return nil
+}
+
+type logCall struct {
+	runID, name string
+	attempt     int32
+	lines       []*adagio.LogLine
+}
+
+func (r *repository) AppendLogs(ctx context.Context, runID string, name string, attempt int32, lines []*adagio.LogLine) error {
+	if r.logDelay > 0 {
+		select {
+		case <-time.After(r.logDelay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.logCalls = append(r.logCalls, logCall{runID, name, attempt, lines})
+
+	return nil
+}
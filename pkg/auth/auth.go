@@ -0,0 +1,173 @@
+// Package auth authenticates and authorizes the callers of the control plane
+//
+// Callers present either a bearer token in the "authorization" metadata, which is
+// verified by an Authenticator (a static token file or JWTs signed by a key in a
+// local JWKS file), or a client certificate verified during the mutual TLS handshake.
+// The certificates of proxies such as the HTTP gateway only authenticate the transport,
+// so calls made over their connections must present a bearer token.
+// The identity of an authenticated caller is carried on the context of the call.
+//
+// Authenticated callers are authorized by a Policy which binds their subject to a
+// Role (viewer, operator or admin) per namespace.
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// MethodToken identifies callers authenticated by a static token
+	MethodToken = "token"
+	// MethodJWT identifies callers authenticated by a JWT
+	MethodJWT = "jwt"
+	// MethodCertificate identifies callers authenticated by a client certificate
+	MethodCertificate = "certificate"
+
+	authorizationKey = "authorization"
+	bearerPrefix     = "bearer "
+)
+
+var (
+	// ErrInvalidToken is returned when a bearer token can not be authenticated
+	ErrInvalidToken = errors.New("invalid token")
+)
+
+// Identity is an authenticated caller
+type Identity struct {
+	Subject string
+	// Method by which the caller was authenticated
+	Method string
+}
+
+type identityKey struct{}
+
+// WithIdentity returns a context carrying the provided identity
+func WithIdentity(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// FromContext returns the identity carried by the context if any
+func FromContext(ctx context.Context) (*Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(*Identity)
+	return identity, ok
+}
+
+// Authenticator is a type which authenticates bearer tokens
+type Authenticator interface {
+	Authenticate(token string) (*Identity, error)
+}
+
+// Authenticators is a set of Authenticator which authenticates a token
+// given any one of them authenticates it
+type Authenticators []Authenticator
+
+// Authenticate returns the identity returned by the first authenticator which
+// authenticates the token, otherwise the error of the last authenticator
+func (a Authenticators) Authenticate(token string) (identity *Identity, err error) {
+	err = ErrInvalidToken
+	for _, authenticator := range a {
+		if identity, err = authenticator.Authenticate(token); err == nil {
+			return
+		}
+	}
+
+	return nil, err
+}
+
+// UnaryServerInterceptor returns an interceptor which authenticates each unary
+// call (see Authenticate) and carries the identity of the caller on its context
+func UnaryServerInterceptor(authenticator Authenticator, transports ...string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := Authenticate(ctx, authenticator, transports...)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns an interceptor which authenticates each streaming
+// call (see Authenticate) and carries the identity of the caller on its context
+func StreamServerInterceptor(authenticator Authenticator, transports ...string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := Authenticate(stream.Context(), authenticator, transports...)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, serverStream{stream, ctx})
+	}
+}
+
+// Authenticate returns a context carrying the identity of the caller of an incoming call.
+// A bearer token is authenticated by the authenticator (which can be nil given only client
+// certificates are accepted). Otherwise the subject common name of a client certificate verified
+// during the TLS handshake identifies the caller, unless it is one of the transport subjects.
+// These are the common names of proxies (e.g. the HTTP gateway) whose certificates only
+// authenticate the connection and never the calls forwarded over it. It returns an
+// Unauthenticated status error given the caller can not be authenticated
+func Authenticate(ctx context.Context, authenticator Authenticator, transports ...string) (context.Context, error) {
+	if token, ok := bearerToken(ctx); ok {
+		if authenticator == nil {
+			return nil, status.Error(codes.Unauthenticated, "token authentication is not enabled")
+		}
+
+		identity, err := authenticator.Authenticate(token)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+
+		return WithIdentity(ctx, identity), nil
+	}
+
+	if p, ok := peer.FromContext(ctx); ok {
+		if info, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(info.State.VerifiedChains) > 0 && len(info.State.VerifiedChains[0]) > 0 {
+			subject := info.State.VerifiedChains[0][0].Subject.CommonName
+			for _, transport := range transports {
+				if subject == transport {
+					return nil, status.Errorf(codes.Unauthenticated, "a bearer token is required on calls forwarded by %q", subject)
+				}
+			}
+
+			return WithIdentity(ctx, &Identity{
+				Subject: subject,
+				Method:  MethodCertificate,
+			}), nil
+		}
+	}
+
+	return nil, status.Error(codes.Unauthenticated, "a bearer token or client certificate is required")
+}
+
+func bearerToken(ctx context.Context) (string, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", false
+	}
+
+	for _, value := range md.Get(authorizationKey) {
+		if len(value) > len(bearerPrefix) && strings.EqualFold(value[:len(bearerPrefix)], bearerPrefix) {
+			return strings.TrimSpace(value[len(bearerPrefix):]), true
+		}
+	}
+
+	return "", false
+}
+
+// serverStream overrides the context of a server stream
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s serverStream) Context() context.Context { return s.ctx }
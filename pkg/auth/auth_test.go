@@ -0,0 +1,306 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func Test_ReadTokens(t *testing.T) {
+	tokens, err := ReadTokens(strings.NewReader("# operators\nabc, alice\n\ndef,bob\n"))
+	require.Nil(t, err)
+
+	assert.Equal(t, Tokens{{Token: "abc", Subject: "alice"}, {Token: "def", Subject: "bob"}}, tokens)
+
+	identity, err := tokens.Authenticate("def")
+	require.Nil(t, err)
+	assert.Equal(t, &Identity{Subject: "bob", Method: MethodToken}, identity)
+
+	_, err = tokens.Authenticate("ghi")
+	assert.Equal(t, ErrInvalidToken, err)
+
+	_, err = ReadTokens(strings.NewReader("abc\n"))
+	assert.EqualError(t, err, "line 1: malformed token expected token,subject")
+}
+
+func Test_JWTVerifier(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err)
+
+	jwks, err := json.Marshal(map[string]interface{}{
+		"keys": []map[string]string{
+			{
+				"kty": "RSA",
+				"kid": "rsa",
+				"n":   encode(rsaKey.N.Bytes()),
+				"e":   encode(big.NewInt(int64(rsaKey.E)).Bytes()),
+			},
+			{
+				"kty": "EC",
+				"kid": "ec",
+				"crv": "P-256",
+				"x":   encode(ecKey.X.Bytes()),
+				"y":   encode(ecKey.Y.Bytes()),
+			},
+		},
+	})
+	require.Nil(t, err)
+
+	now := time.Now()
+
+	verifier, err := NewJWTVerifier(jwks, WithIssuer("issuer"), WithAudience("adagio"))
+	require.Nil(t, err)
+
+	verifier.now = func() time.Time { return now }
+
+	valid := func() map[string]interface{} {
+		return map[string]interface{}{
+			"sub": "alice",
+			"iss": "issuer",
+			"aud": []string{"other", "adagio"},
+			"exp": now.Add(time.Hour).Unix(),
+		}
+	}
+
+	for _, test := range []struct {
+		name   string
+		token  string
+		reason string
+	}{
+		{
+			name:  "a valid RS256 token",
+			token: sign(t, "RS256", "rsa", rsaKey, valid()),
+		},
+		{
+			name:  "a valid ES256 token",
+			token: sign(t, "ES256", "ec", ecKey, valid()),
+		},
+		{
+			name:   "a token signed by an unknown key",
+			token:  sign(t, "RS256", "other", rsaKey, valid()),
+			reason: `unknown key "other"`,
+		},
+		{
+			name:   "a token signed by a different key",
+			token:  sign(t, "ES256", "ec", mustECKey(t), valid()),
+			reason: "invalid signature",
+		},
+		{
+			name:   "a token with an algorithm which does not match the key",
+			token:  sign(t, "ES256", "rsa", ecKey, valid()),
+			reason: `algorithm "ES256" does not match key`,
+		},
+		{
+			name:   "an unsigned token",
+			token:  sign(t, "none", "rsa", nil, valid()),
+			reason: `unsupported algorithm "none"`,
+		},
+		{
+			name:   "an expired token",
+			token:  sign(t, "RS256", "rsa", rsaKey, with(valid(), "exp", now.Add(-time.Hour).Unix())),
+			reason: "token has expired",
+		},
+		{
+			name:   "a token without an expiry",
+			token:  sign(t, "RS256", "rsa", rsaKey, with(valid(), "exp", nil)),
+			reason: "expiry is required",
+		},
+		{
+			name:   "a token which is not yet valid",
+			token:  sign(t, "RS256", "rsa", rsaKey, with(valid(), "nbf", now.Add(time.Hour).Unix())),
+			reason: "token is not yet valid",
+		},
+		{
+			name:   "a token from another issuer",
+			token:  sign(t, "RS256", "rsa", rsaKey, with(valid(), "iss", "other")),
+			reason: `unexpected issuer "other"`,
+		},
+		{
+			name:   "a token for another audience",
+			token:  sign(t, "RS256", "rsa", rsaKey, with(valid(), "aud", "other")),
+			reason: "unexpected audience",
+		},
+		{
+			name:   "a token without a subject",
+			token:  sign(t, "RS256", "rsa", rsaKey, with(valid(), "sub", nil)),
+			reason: "subject is required",
+		},
+		{
+			name:   "a malformed token",
+			token:  "abc.def",
+			reason: "invalid token",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			identity, err := verifier.Authenticate(test.token)
+			if test.reason != "" {
+				require.NotNil(t, err)
+				assert.True(t, errors.Is(err, ErrInvalidToken))
+				assert.Contains(t, err.Error(), test.reason)
+				return
+			}
+
+			require.Nil(t, err)
+			assert.Equal(t, &Identity{Subject: "alice", Method: MethodJWT}, identity)
+		})
+	}
+}
+
+func Test_Authenticate(t *testing.T) {
+	tokens := Tokens{{Token: "abc", Subject: "alice"}}
+
+	for _, test := range []struct {
+		name          string
+		authenticator Authenticator
+		md            metadata.MD
+		// common name of the verified client certificate of the connection
+		certificate string
+		transports  []string
+		identity    *Identity
+		message     string
+	}{
+		{
+			name:          "a valid bearer token",
+			authenticator: tokens,
+			md:            metadata.Pairs("authorization", "Bearer abc"),
+			identity:      &Identity{Subject: "alice", Method: MethodToken},
+		},
+		{
+			name:          "an invalid bearer token",
+			authenticator: Authenticators{tokens},
+			md:            metadata.Pairs("authorization", "bearer def"),
+			message:       "invalid token",
+		},
+		{
+			name:    "a bearer token when token authentication is not enabled",
+			md:      metadata.Pairs("authorization", "Bearer abc"),
+			message: "token authentication is not enabled",
+		},
+		{
+			name:          "neither a bearer token or client certificate",
+			authenticator: tokens,
+			md:            metadata.Pairs("authorization", "Basic abc"),
+			message:       "a bearer token or client certificate is required",
+		},
+		{
+			name:          "a client certificate",
+			authenticator: tokens,
+			certificate:   "bob",
+			identity:      &Identity{Subject: "bob", Method: MethodCertificate},
+		},
+		{
+			name:          "a call forwarded by a gateway without a bearer token",
+			authenticator: tokens,
+			certificate:   "gateway",
+			transports:    []string{"gateway"},
+			message:       `a bearer token is required on calls forwarded by "gateway"`,
+		},
+		{
+			name:          "a call forwarded by a gateway with a bearer token",
+			authenticator: tokens,
+			md:            metadata.Pairs("authorization", "Bearer abc"),
+			certificate:   "gateway",
+			transports:    []string{"gateway"},
+			identity:      &Identity{Subject: "alice", Method: MethodToken},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), test.md)
+			if test.certificate != "" {
+				ctx = peer.NewContext(ctx, &peer.Peer{AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{
+					VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: test.certificate}}}},
+				}}})
+			}
+
+			ctx, err := Authenticate(ctx, test.authenticator, test.transports...)
+			if test.message != "" {
+				assert.Equal(t, codes.Unauthenticated, status.Code(err))
+				assert.Equal(t, test.message, status.Convert(err).Message())
+				return
+			}
+
+			require.Nil(t, err)
+
+			identity, ok := FromContext(ctx)
+			require.True(t, ok)
+			assert.Equal(t, test.identity, identity)
+		})
+	}
+}
+
+func encode(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func with(claims map[string]interface{}, key string, value interface{}) map[string]interface{} {
+	if value == nil {
+		delete(claims, key)
+		return claims
+	}
+
+	claims[key] = value
+	return claims
+}
+
+func mustECKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err)
+
+	return key
+}
+
+func sign(t *testing.T, alg, kid string, key crypto.Signer, claims map[string]interface{}) string {
+	t.Helper()
+
+	header, err := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	require.Nil(t, err)
+
+	payload, err := json.Marshal(claims)
+	require.Nil(t, err)
+
+	signed := encode(header) + "." + encode(payload)
+	digest := sha256.Sum256([]byte(signed))
+
+	var signature []byte
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		signature, err = rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+		require.Nil(t, err)
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+		require.Nil(t, err)
+
+		signature = make([]byte, 64)
+		r.FillBytes(signature[:32])
+		s.FillBytes(signature[32:])
+	}
+
+	return signed + "." + encode(signature)
+}
@@ -0,0 +1,20 @@
+package auth
+
+import (
+	"context"
+
+	"google.golang.org/grpc/credentials"
+)
+
+var _ credentials.PerRPCCredentials = BearerToken("")
+
+// BearerToken is a token presented in the "authorization" metadata of each call
+type BearerToken string
+
+// GetRequestMetadata returns the authorization metadata for the token
+func (t BearerToken) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{authorizationKey: "Bearer " + string(t)}, nil
+}
+
+// RequireTransportSecurity returns true as tokens must not be sent in the clear
+func (t BearerToken) RequireTransportSecurity() bool { return true }
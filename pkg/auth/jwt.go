@@ -0,0 +1,327 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"strings"
+	"time"
+
+	// register the hashes used by the supported algorithms
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+)
+
+var _ Authenticator = (*JWTVerifier)(nil)
+
+// JWTVerifier authenticates bearer tokens which are JWTs signed by one of a set of keys
+// read from a JSON Web Key Set. The RS256, RS384, RS512, ES256, ES384 and ES512 signing
+// algorithms are supported. The "sub" claim of a valid JWT identifies the caller
+type JWTVerifier struct {
+	keys     map[string]crypto.PublicKey
+	issuer   string
+	audience string
+	leeway   time.Duration
+	now      func() time.Time
+}
+
+// JWTOption is a functional option for the JWTVerifier
+type JWTOption func(*JWTVerifier)
+
+// WithIssuer requires the "iss" claim of verified JWTs match the issuer
+func WithIssuer(issuer string) JWTOption {
+	return func(v *JWTVerifier) {
+		v.issuer = issuer
+	}
+}
+
+// WithAudience requires the "aud" claim of verified JWTs contain the audience
+func WithAudience(audience string) JWTOption {
+	return func(v *JWTVerifier) {
+		v.audience = audience
+	}
+}
+
+// WithLeeway configures the clock skew tolerated when validating the "exp" and "nbf" claims
+func WithLeeway(leeway time.Duration) JWTOption {
+	return func(v *JWTVerifier) {
+		v.leeway = leeway
+	}
+}
+
+// LoadJWKS returns a JWTVerifier for the keys in the JSON Web Key Set file at path
+func LoadJWKS(path string, opts ...JWTOption) (*JWTVerifier, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	verifier, err := NewJWTVerifier(data, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("jwks file %q: %w", path, err)
+	}
+
+	return verifier, nil
+}
+
+// NewJWTVerifier returns a JWTVerifier for the keys in the JSON encoded JSON Web Key Set
+func NewJWTVerifier(jwks []byte, opts ...JWTOption) (*JWTVerifier, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+
+	if err := json.Unmarshal(jwks, &set); err != nil {
+		return nil, err
+	}
+
+	verifier := &JWTVerifier{
+		keys:   map[string]crypto.PublicKey{},
+		leeway: time.Minute,
+		now:    time.Now,
+	}
+
+	for i, key := range set.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+
+		public, err := key.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("key %d (%q): %w", i, key.Kid, err)
+		}
+
+		verifier.keys[key.Kid] = public
+	}
+
+	if len(verifier.keys) == 0 {
+		return nil, errors.New("no signing keys found")
+	}
+
+	for _, opt := range opts {
+		opt(verifier)
+	}
+
+	return verifier, nil
+}
+
+// Authenticate verifies the signature and claims of the JWT and returns the identity of its subject
+func (v *JWTVerifier) Authenticate(token string) (*Identity, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidToken
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("%w: malformed header", ErrInvalidToken)
+	}
+
+	key, ok := v.keys[header.Kid]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown key %q", ErrInvalidToken, header.Kid)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed signature", ErrInvalidToken)
+	}
+
+	if err := verify(header.Alg, key, parts[0]+"."+parts[1], signature); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	var claims struct {
+		Subject   string   `json:"sub"`
+		Issuer    string   `json:"iss"`
+		Audience  audience `json:"aud"`
+		ExpiresAt *int64   `json:"exp"`
+		NotBefore *int64   `json:"nbf"`
+	}
+
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("%w: malformed claims", ErrInvalidToken)
+	}
+
+	now := v.now()
+
+	switch {
+	case claims.Subject == "":
+		return nil, fmt.Errorf("%w: subject is required", ErrInvalidToken)
+	case claims.ExpiresAt == nil:
+		return nil, fmt.Errorf("%w: expiry is required", ErrInvalidToken)
+	case now.After(time.Unix(*claims.ExpiresAt, 0).Add(v.leeway)):
+		return nil, fmt.Errorf("%w: token has expired", ErrInvalidToken)
+	case claims.NotBefore != nil && now.Before(time.Unix(*claims.NotBefore, 0).Add(-v.leeway)):
+		return nil, fmt.Errorf("%w: token is not yet valid", ErrInvalidToken)
+	case v.issuer != "" && claims.Issuer != v.issuer:
+		return nil, fmt.Errorf("%w: unexpected issuer %q", ErrInvalidToken, claims.Issuer)
+	case v.audience != "" && !claims.Audience.contains(v.audience):
+		return nil, fmt.Errorf("%w: unexpected audience", ErrInvalidToken)
+	}
+
+	return &Identity{Subject: claims.Subject, Method: MethodJWT}, nil
+}
+
+// verify checks the signature of the signed content using the key and algorithm
+func verify(alg string, key crypto.PublicKey, signed string, signature []byte) error {
+	var hash crypto.Hash
+	switch alg {
+	case "RS256", "ES256":
+		hash = crypto.SHA256
+	case "RS384", "ES384":
+		hash = crypto.SHA384
+	case "RS512", "ES512":
+		hash = crypto.SHA512
+	default:
+		return fmt.Errorf("unsupported algorithm %q", alg)
+	}
+
+	h := hash.New()
+	h.Write([]byte(signed))
+	digest := h.Sum(nil)
+
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		if alg[0] != 'R' {
+			return fmt.Errorf("algorithm %q does not match key", alg)
+		}
+
+		return rsa.VerifyPKCS1v15(key, hash, digest, signature)
+	case *ecdsa.PublicKey:
+		size := (key.Curve.Params().BitSize + 7) / 8
+		if alg[0] != 'E' || len(signature) != 2*size {
+			return fmt.Errorf("algorithm %q does not match key", alg)
+		}
+
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(key, digest, r, s) {
+			return errors.New("invalid signature")
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("unsupported key type %T", key)
+}
+
+// jwk is a JSON Web Key
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	// RSA
+	N string `json:"n"`
+	E string `json:"e"`
+	// EC
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeInt(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("modulus: %w", err)
+		}
+
+		e, err := decodeInt(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("exponent: %w", err)
+		}
+
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, errors.New("exponent too large")
+		}
+
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+
+		x, err := decodeInt(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("x: %w", err)
+		}
+
+		y, err := decodeInt(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("y: %w", err)
+		}
+
+		if !curve.IsOnCurve(x, y) {
+			return nil, errors.New("point is not on curve")
+		}
+
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+}
+
+// audience is the "aud" claim which is either a single string or an array of strings
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+
+	return json.Unmarshal(data, (*[]string)(a))
+}
+
+func (a audience) contains(v string) bool {
+	for _, aud := range a {
+		if aud == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+func decodeInt(v string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(v)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) == 0 {
+		return nil, errors.New("empty value")
+	}
+
+	return new(big.Int).SetBytes(data), nil
+}
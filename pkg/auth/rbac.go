@@ -0,0 +1,228 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/georgemac/adagio/pkg/adagio"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Any is the wildcard matching every subject or namespace of a binding
+const Any = "*"
+
+// Role is a set of permissions on the control plane of a namespace.
+// Each role holds every permission of the roles it is greater than
+type Role int
+
+const (
+	// RoleNone holds no permissions
+	RoleNone Role = iota
+	// RoleViewer may list and inspect
+	RoleViewer
+	// RoleOperator may additionally start runs and resolve approvals
+	RoleOperator
+	// RoleAdmin may additionally manage webhooks and view the audit log
+	RoleAdmin
+)
+
+var roleNames = [...]string{"none", "viewer", "operator", "admin"}
+
+// ParseRole parses a lower case role name ("viewer"|"operator"|"admin")
+func ParseRole(v string) (Role, error) {
+	for role, name := range roleNames {
+		if name == v {
+			return Role(role), nil
+		}
+	}
+
+	return RoleNone, fmt.Errorf("unknown role %q", v)
+}
+
+func (r Role) String() string {
+	if r < 0 || int(r) >= len(roleNames) {
+		return fmt.Sprintf("Role(%d)", int(r))
+	}
+
+	return roleNames[r]
+}
+
+// UnmarshalText parses the role name (see ParseRole)
+func (r *Role) UnmarshalText(text []byte) (err error) {
+	*r, err = ParseRole(string(text))
+	return
+}
+
+// Binding grants a role to a subject in a namespace. Either of the subject
+// or namespace can be Any to match every authenticated subject or namespace
+type Binding struct {
+	Subject   string `toml:"subject"`
+	Namespace string `toml:"namespace"`
+	Role      Role   `toml:"role"`
+}
+
+// Policy maps the subjects of authenticated callers to roles per namespace
+type Policy struct {
+	bindings []Binding
+}
+
+// NewPolicy returns a policy which grants the roles of the provided bindings
+func NewPolicy(bindings ...Binding) *Policy {
+	return &Policy{bindings: bindings}
+}
+
+// LoadPolicy reads the policy from the "rbac" table of a TOML config file.
+// It returns nil given the file does not define an "rbac" table
+func LoadPolicy(path string) (*Policy, error) {
+	fi, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer fi.Close()
+
+	policy, err := ReadPolicy(fi)
+	if err != nil {
+		return nil, fmt.Errorf("policy %q: %w", path, err)
+	}
+
+	return policy, nil
+}
+
+// ReadPolicy parses a policy from a TOML document of the form below.
+// It returns nil given the document does not define an "rbac" table
+//
+//	[[rbac.binding]]
+//	subject = "alice"
+//	namespace = "*"
+//	role = "admin"
+func ReadPolicy(r io.Reader) (*Policy, error) {
+	var config struct {
+		RBAC *struct {
+			Bindings []Binding `toml:"binding"`
+		} `toml:"rbac"`
+	}
+
+	if _, err := toml.DecodeReader(r, &config); err != nil {
+		return nil, err
+	}
+
+	if config.RBAC == nil {
+		return nil, nil
+	}
+
+	for i, binding := range config.RBAC.Bindings {
+		if binding.Subject == "" || binding.Namespace == "" || binding.Role == RoleNone {
+			return nil, fmt.Errorf("binding %d: subject, namespace and role are required", i)
+		}
+	}
+
+	return NewPolicy(config.RBAC.Bindings...), nil
+}
+
+// Role returns the greatest role bound to the subject in the namespace
+func (p *Policy) Role(subject, namespace string) (role Role) {
+	for _, binding := range p.bindings {
+		if (binding.Subject == Any || binding.Subject == subject) &&
+			(binding.Namespace == Any || binding.Namespace == namespace) &&
+			binding.Role > role {
+			role = binding.Role
+		}
+	}
+
+	return
+}
+
+// Authorize returns a PermissionDenied status error given the identity carried by the
+// context is not bound to at least the required role in the namespace. Callers which
+// have not been authenticated hold no role
+func (p *Policy) Authorize(ctx context.Context, namespace string, required Role) error {
+	identity, ok := FromContext(ctx)
+	if !ok || identity.Subject == "" {
+		return status.Errorf(codes.PermissionDenied, "%s role required in namespace %q: caller is not authenticated", required, namespace)
+	}
+
+	if role := p.Role(identity.Subject, namespace); role < required {
+		return status.Errorf(codes.PermissionDenied, "%s role required in namespace %q: %q holds %s", required, namespace, identity.Subject, role)
+	}
+
+	return nil
+}
+
+// Namespace returns the namespace named by the request or the default
+// namespace given the request does not name one
+func Namespace(req interface{}) string {
+	if namespaced, ok := req.(interface{ GetNamespace() string }); ok {
+		return adagio.Namespace(namespaced.GetNamespace())
+	}
+
+	return adagio.DefaultNamespace
+}
+
+// DeniedFunc is called with each call denied by an authorizing interceptor
+// along with its request and the PermissionDenied error returned to the caller
+type DeniedFunc func(ctx context.Context, method string, req interface{}, err error)
+
+// AuthorizeUnaryServerInterceptor returns an interceptor which authorizes each unary call
+// given the role the permissions require for its full method name (RoleAdmin when absent)
+// in the namespace of its request. Denied calls are passed to each of the denied functions
+func AuthorizeUnaryServerInterceptor(policy *Policy, permissions map[string]Role, denied ...DeniedFunc) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := authorize(ctx, policy, permissions, info.FullMethod, req, denied); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// AuthorizeStreamServerInterceptor returns an interceptor which authorizes each streaming
+// call on receipt of each of its requests (see AuthorizeUnaryServerInterceptor)
+func AuthorizeStreamServerInterceptor(policy *Policy, permissions map[string]Role, denied ...DeniedFunc) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, authorizingStream{stream, func(req interface{}) error {
+			return authorize(stream.Context(), policy, permissions, info.FullMethod, req, denied)
+		}})
+	}
+}
+
+func authorize(ctx context.Context, policy *Policy, permissions map[string]Role, method string, req interface{}, denied []DeniedFunc) error {
+	required, ok := permissions[method]
+	if !ok {
+		required = RoleAdmin
+	}
+
+	err := policy.Authorize(ctx, Namespace(req), required)
+	if err != nil {
+		for _, fn := range denied {
+			fn(ctx, method, req, err)
+		}
+	}
+
+	return err
+}
+
+// authorizingStream authorizes each message received on a server stream
+type authorizingStream struct {
+	grpc.ServerStream
+	authorize func(interface{}) error
+}
+
+func (s authorizingStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	return s.authorize(m)
+}
+
+// MethodName returns the name of the RPC of a full method name (e.g. "Start")
+func MethodName(fullMethod string) string {
+	return fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+}
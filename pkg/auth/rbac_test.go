@@ -0,0 +1,145 @@
+package auth
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const policyTOML = `
+log-level = "debug"
+
+[[rbac.binding]]
+subject = "alice"
+namespace = "*"
+role = "admin"
+
+[[rbac.binding]]
+subject = "bob"
+namespace = "default"
+role = "operator"
+
+[[rbac.binding]]
+subject = "*"
+namespace = "default"
+role = "viewer"
+`
+
+type namespaced string
+
+func (n namespaced) GetNamespace() string { return string(n) }
+
+func Test_ReadPolicy(t *testing.T) {
+	policy, err := ReadPolicy(strings.NewReader(policyTOML))
+	require.Nil(t, err)
+
+	for _, test := range []struct {
+		subject   string
+		namespace string
+		role      Role
+	}{
+		{"alice", "default", RoleAdmin},
+		{"alice", "other", RoleAdmin},
+		{"bob", "default", RoleOperator},
+		{"bob", "other", RoleNone},
+		{"carol", "default", RoleViewer},
+		{"carol", "other", RoleNone},
+	} {
+		assert.Equal(t, test.role, policy.Role(test.subject, test.namespace), "%s in %s", test.subject, test.namespace)
+	}
+
+	policy, err = ReadPolicy(strings.NewReader(`log-level = "debug"`))
+	require.Nil(t, err)
+	assert.Nil(t, policy)
+
+	_, err = ReadPolicy(strings.NewReader("[[rbac.binding]]\nsubject = \"alice\"\nnamespace = \"*\"\nrole = \"owner\"\n"))
+	assert.Contains(t, err.Error(), `unknown role "owner"`)
+
+	_, err = ReadPolicy(strings.NewReader("[[rbac.binding]]\nsubject = \"alice\"\nrole = \"admin\"\n"))
+	assert.EqualError(t, err, "binding 0: subject, namespace and role are required")
+}
+
+func Test_AuthorizeUnaryServerInterceptor(t *testing.T) {
+	var (
+		policy = NewPolicy(
+			Binding{Subject: "alice", Namespace: "default", Role: RoleOperator},
+			Binding{Subject: "alice", Namespace: "other", Role: RoleViewer},
+		)
+		permissions = map[string]Role{"/svc/Start": RoleOperator, "/svc/List": RoleViewer}
+		handler     = func(context.Context, interface{}) (interface{}, error) { return "ok", nil }
+	)
+
+	for _, test := range []struct {
+		name     string
+		identity *Identity
+		method   string
+		req      interface{}
+		message  string
+	}{
+		{
+			name:     "an operator may start in the default namespace",
+			identity: &Identity{Subject: "alice"},
+			method:   "/svc/Start",
+			req:      namespaced(""),
+		},
+		{
+			name:     "a viewer may list in a namespace",
+			identity: &Identity{Subject: "alice"},
+			method:   "/svc/List",
+			req:      namespaced("other"),
+		},
+		{
+			name:     "a viewer may not start in a namespace",
+			identity: &Identity{Subject: "alice"},
+			method:   "/svc/Start",
+			req:      namespaced("other"),
+			message:  `operator role required in namespace "other": "alice" holds viewer`,
+		},
+		{
+			name:     "a method without a permission requires an admin",
+			identity: &Identity{Subject: "alice"},
+			method:   "/svc/Delete",
+			req:      namespaced("default"),
+			message:  `admin role required in namespace "default": "alice" holds operator`,
+		},
+		{
+			name:    "an unauthenticated caller holds no role",
+			method:  "/svc/List",
+			req:     namespaced("default"),
+			message: `viewer role required in namespace "default": caller is not authenticated`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				ctx    = context.Background()
+				denied []string
+			)
+
+			if test.identity != nil {
+				ctx = WithIdentity(ctx, test.identity)
+			}
+
+			interceptor := AuthorizeUnaryServerInterceptor(policy, permissions, func(_ context.Context, method string, _ interface{}, _ error) {
+				denied = append(denied, MethodName(method))
+			})
+
+			resp, err := interceptor(ctx, test.req, &grpc.UnaryServerInfo{FullMethod: test.method}, handler)
+			if test.message != "" {
+				assert.Equal(t, codes.PermissionDenied, status.Code(err))
+				assert.Equal(t, test.message, status.Convert(err).Message())
+				assert.Equal(t, []string{MethodName(test.method)}, denied)
+				return
+			}
+
+			require.Nil(t, err)
+			assert.Equal(t, "ok", resp)
+			assert.Empty(t, denied)
+		})
+	}
+}
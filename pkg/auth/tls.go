@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+)
+
+// ServerTLSConfig returns the TLS configuration for a server presenting the certificate
+// and key read from the provided PEM files. Given a client CA file the server requires
+// clients present a certificate signed by one of its certificates (mutual TLS)
+func ServerTLSConfig(certFile, keyFile, clientCAFile string) (*tls.Config, error) {
+	if certFile == "" || keyFile == "" {
+		return nil, errors.New("both a certificate and key are required")
+	}
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if clientCAFile != "" {
+		if config.ClientCAs, err = loadCertPool(clientCAFile); err != nil {
+			return nil, err
+		}
+
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return config, nil
+}
+
+// ClientTLSConfig returns the TLS configuration for a client which verifies the server
+// using the certificates in the CA file (or the system roots when empty). Given a
+// certificate and key the client presents them to the server (mutual TLS)
+func ClientTLSConfig(caFile, certFile, keyFile, serverName string) (*tls.Config, error) {
+	config := &tls.Config{
+		ServerName: serverName,
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if caFile != "" {
+		pool, err := loadCertPool(caFile)
+		if err != nil {
+			return nil, err
+		}
+
+		config.RootCAs = pool
+	}
+
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
+
+func loadCertPool(path string) (*x509.CertPool, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no certificates found in %q", path)
+	}
+
+	return pool, nil
+}
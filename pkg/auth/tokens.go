@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"bufio"
+	"crypto/subtle"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+var _ Authenticator = (Tokens)(nil)
+
+// Tokens is a static set of bearer tokens and the subjects they identify
+type Tokens []Token
+
+// Token is a static bearer token and the subject it identifies
+type Token struct {
+	Token   string
+	Subject string
+}
+
+// LoadTokens reads a static token file in which each line is a token and the
+// subject it identifies separated by a comma (e.g. "s3cr3t,alice"). Empty lines
+// and lines beginning with "#" are ignored
+func LoadTokens(path string) (Tokens, error) {
+	fi, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer fi.Close()
+
+	tokens, err := ReadTokens(fi)
+	if err != nil {
+		return nil, fmt.Errorf("token file %q: %w", path, err)
+	}
+
+	return tokens, nil
+}
+
+// ReadTokens parses static tokens from the reader (see LoadTokens)
+func ReadTokens(r io.Reader) (tokens Tokens, err error) {
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		parts := strings.SplitN(text, ",", 2)
+		if len(parts) < 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+			return nil, fmt.Errorf("line %d: malformed token expected token,subject", line)
+		}
+
+		tokens = append(tokens, Token{
+			Token:   strings.TrimSpace(parts[0]),
+			Subject: strings.TrimSpace(parts[1]),
+		})
+	}
+
+	return tokens, scanner.Err()
+}
+
+// Authenticate returns the identity of the subject of the matching static token
+func (t Tokens) Authenticate(token string) (*Identity, error) {
+	var identity *Identity
+
+	// compare against every token in constant time to not leak which matched
+	for _, candidate := range t {
+		if subtle.ConstantTimeCompare([]byte(candidate.Token), []byte(token)) == 1 && identity == nil {
+			identity = &Identity{Subject: candidate.Subject, Method: MethodToken}
+		}
+	}
+
+	if identity == nil {
+		return nil, ErrInvalidToken
+	}
+
+	return identity, nil
+}
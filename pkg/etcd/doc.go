@@ -3,16 +3,35 @@
 //
 // Keyspace Design (etcd internals)
 //
+// Every key below is prefixed by the list of the repository ("default" unless
+// configured via ForList), e.g. v0/default/runs/<run-id>. adagiod constructs a
+// repository for each adagio namespace whose list is the name of the namespace.
+//
 // Namespaces:
-// v0/runs/   : runs namespace
-// v0/nodes/  : nodes namespace
-// v0/states/ : states namespace
+// v0/runs/       : runs namespace
+// v0/nodes/      : nodes namespace
+// v0/states/     : states namespace
+// v0/children/   : child run links namespace
+// v0/expansions/ : expanded map nodes namespace
+// v0/limits/     : concurrency limit slots namespace
+// v0/logs/       : node logs namespace
+// v0/webhooks/   : webhooks namespace
+// v0/deliveries/ : webhook deliveries namespace
+// v0/audit/      : audit log namespace
 //
 // Objects:
-// v0/agents/<agent-id>                       : Agent{} serialized agent object (leased)
-// v0/runs/<run-id>                           : Run{}   serialized run object
-// v0/nodes/<run-id>/node/<name>              : Node{}  serialized node object
-// v0/states/<state>/run/<run-id>/node/<name> : ""      empty string to identify state
+// v0/agents/<agent-id>                                      : Agent{} serialized agent object (leased)
+// v0/runs/<run-id>                                          : Run{}   serialized run object
+// v0/nodes/<run-id>/node/<name>                             : Node{}  serialized node object
+// v0/states/<state>/run/<run-id>/node/<name>                : ""      empty string to identify state
+// v0/children/<run-id>/run/<child-run-id>                   : name of the node which started the child run
+// v0/expansions/<run-id>/node/<name>                        : serialized specs and edges of a map nodes instances
+// v0/limits/runs/<run-id>/slot/<n>                          : ID of the claim occupying a slot of a runs max parallelism (leased)
+// v0/limits/pools/<pool>/slot/<n>                           : ID of the claim occupying a slot of a resource pool (leased)
+// v0/logs/<run-id>/node/<name>/attempt/<n>/<chunk-id>       : []LogLine{} serialized chunk of lines (ULID chunk IDs order the chunks)
+// v0/webhooks/<webhook-id>                                  : Webhook{}  serialized webhook object
+// v0/deliveries/<webhook-id>/notification/<notification-id> : Delivery{} serialized delivery object (created once per notification)
+// v0/audit/<event-id>                                       : AuditEvent{} serialized audit event (never replaced, ULID IDs order the log)
 //
-// States: waiting, ready, running, completed
+// States: waiting, ready, running, completed, skipped, scheduled
 package etcd
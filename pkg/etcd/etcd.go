@@ -5,7 +5,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
+	"io"
+	"math/rand"
 	"path"
 	"strings"
 	"sync"
@@ -13,8 +14,10 @@ import (
 
 	"github.com/georgemac/adagio/pkg/adagio"
 	"github.com/georgemac/adagio/pkg/agent"
-	"github.com/georgemac/adagio/pkg/graph"
+	"github.com/georgemac/adagio/pkg/logging"
+	"github.com/georgemac/adagio/pkg/metrics"
 	"github.com/georgemac/adagio/pkg/service/controlplane"
+	"github.com/georgemac/adagio/pkg/tracing"
 	"github.com/oklog/ulid"
 	"go.etcd.io/etcd/clientv3"
 	"go.etcd.io/etcd/clientv3/namespace"
@@ -26,13 +29,26 @@ var (
 
 	minULID = ulid.MustNew(0, zeroReader{})
 	maxULID = ulid.MustNew(ulid.MaxTime(), oneReader{})
+
+	errTooManyConflicts = errors.New("transaction conflicted too many times")
 )
 
+// maxTxnAttempts bounds the number of attempts made at a transaction which
+// keeps conflicting with concurrent updates to the keys it compares
+const maxTxnAttempts = 10
+
 const (
-	runsPrefix   = "runs/"
-	statesPrefix = "states/"
-	agentsPrefix = "agents/"
-	nodesPrefix  = "nodes/"
+	runsPrefix       = "runs/"
+	statesPrefix     = "states/"
+	agentsPrefix     = "agents/"
+	nodesPrefix      = "nodes/"
+	childrenPrefix   = "children/"
+	expansionsPrefix = "expansions/"
+	limitsPrefix     = "limits/"
+	logsPrefix       = "logs/"
+	webhooksPrefix   = "webhooks/"
+	deliveriesPrefix = "deliveries/"
+	auditPrefix      = "audit/"
 )
 
 // Repository is the etcd backed implementation of an adagio Repository type (control plane and agent)
@@ -49,11 +65,18 @@ type Repository struct {
 
 	namespace string
 	list      string
+	pools     adagio.ResourcePools
+	metrics   *metrics.Metrics
+	logger    logging.Logger
 	now       func() time.Time
 
 	ttl     time.Duration
 	leases  map[string]func()
 	leaseMu sync.Mutex
+
+	// entropy for the monotonic IDs of log chunks
+	entropy   io.Reader
+	entropyMu sync.Mutex
 }
 
 // New constructs and configure a new repository service from the provided etcd client
@@ -67,15 +90,19 @@ func New(kv clientv3.KV, watcher clientv3.Watcher, leaser clientv3.Lease, opts .
 		now:           func() time.Time { return time.Now().UTC() },
 		namespace:     "v0",
 		list:          "default",
+		pools:         adagio.ResourcePools{},
+		metrics:       metrics.New(),
+		logger:        logging.Default(),
 		ttl:           10 * time.Second,
 		leases:        map[string]func(){},
+		entropy:       ulid.Monotonic(rand.New(rand.NewSource(time.Now().UnixNano())), 0),
 	}
 
 	Options(opts).Apply(r)
 
 	fullNS := path.Join(r.namespace, r.list) + "/"
 
-	r.kv = namespace.NewKV(r.kv, fullNS)
+	r.kv = tracedKV{namespace.NewKV(r.kv, fullNS)}
 	r.watcher = namespace.NewWatcher(r.watcher, fullNS)
 	r.leaser = namespace.NewLease(r.leaser, fullNS)
 
@@ -98,12 +125,14 @@ func (r *Repository) Stats(ctx context.Context) (*adagio.Stats, error) {
 
 	stats.RunCount = resp.Count
 
+	rev := resp.Header.Revision
+
 	for status := range adagio.Node_Status_name {
 		resp, err := r.kv.Get(ctx,
 			nodesInStateKey(adagio.Node_Status(status)),
 			clientv3.WithPrefix(),
 			clientv3.WithCountOnly(),
-			clientv3.WithRev(resp.Header.Revision))
+			clientv3.WithRev(rev))
 		if err != nil {
 			return nil, err
 		}
@@ -113,25 +142,86 @@ func (r *Repository) Stats(ctx context.Context) (*adagio.Stats, error) {
 			stats.NodeCounts.WaitingCount = resp.Count
 		case adagio.Node_READY:
 			stats.NodeCounts.ReadyCount = resp.Count
+
+			if stats.UnschedulableCounts, err = r.unschedulableCounts(ctx, rev); err != nil {
+				return nil, err
+			}
 		case adagio.Node_RUNNING:
 			stats.NodeCounts.RunningCount = resp.Count
 		case adagio.Node_COMPLETED:
 			stats.NodeCounts.CompletedCount = resp.Count
+		case adagio.Node_SKIPPED:
+			stats.NodeCounts.SkippedCount = resp.Count
+		case adagio.Node_SCHEDULED:
+			stats.NodeCounts.ScheduledCount = resp.Count
 		}
 	}
 
 	return stats, nil
 }
 
+// unschedulableCounts counts the ready nodes which no registered agent can claim by runtime
+func (r *Repository) unschedulableCounts(ctx context.Context, rev int64) (map[string]int64, error) {
+	agents, err := r.ListAgents(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.kv.Get(ctx, nodesInStateKey(adagio.Node_READY), clientv3.WithPrefix(), clientv3.WithKeysOnly(), clientv3.WithRev(rev))
+	if err != nil {
+		return nil, err
+	}
+
+	counts := map[string]int64{}
+	for _, kv := range resp.Kvs {
+		// states/<state>/run/<run_id>/node/<name>
+		keyParts := strings.Split(string(kv.Key), "/")
+		if len(keyParts) < 6 {
+			continue
+		}
+
+		nodeResp, err := r.kv.Get(ctx, nodeKey(keyParts[3], keyParts[5]), clientv3.WithRev(rev))
+		if err != nil {
+			return nil, err
+		}
+
+		if len(nodeResp.Kvs) < 1 {
+			continue
+		}
+
+		var node adagio.Node
+		if err := json.Unmarshal(nodeResp.Kvs[0].Value, &node); err != nil {
+			return nil, err
+		}
+
+		if adagio.Unschedulable(&node, agents) {
+			counts[node.Spec.Runtime]++
+		}
+	}
+
+	return counts, nil
+}
+
 // StartRun takes a graph specification and instantiates it within etcd an returns the resulting Run
 // representation
-func (r *Repository) StartRun(ctx context.Context, spec *adagio.GraphSpec) (run *adagio.Run, err error) {
-	run, err = adagio.NewRun(spec)
+func (r *Repository) StartRun(ctx context.Context, spec *adagio.GraphSpec, opts ...adagio.RunOption) (run *adagio.Run, err error) {
+	ctx, span := tracing.Start(ctx, "etcd.StartRun")
+	defer func() { tracing.End(span, err) }()
+
+	run, err = adagio.NewRun(spec, opts...)
 	if err != nil {
 		return
 	}
 
-	data, err := marshalRun(run.CreatedAt, spec.Nodes, run.Edges)
+	span.SetAttributes(tracing.RunIDKey.String(run.Id))
+
+	if err = r.pools.Validate(run); err != nil {
+		return nil, err
+	}
+
+	tracing.StartRun(ctx, run)
+
+	data, err := marshalRun(run.CreatedAt, spec.Nodes, run.Edges, run.Parent, run.MaxParallelism, run.Priority, run.TraceContext)
 	if err != nil {
 		return nil, err
 	}
@@ -147,6 +237,10 @@ func (r *Repository) StartRun(ctx context.Context, spec *adagio.GraphSpec) (run
 	)
 
 	for _, node := range run.Nodes {
+		if node.Status == adagio.Node_READY {
+			r.markReady(node)
+		}
+
 		nodeData, err := json.Marshal(node)
 		if err != nil {
 			return nil, err
@@ -162,6 +256,11 @@ func (r *Repository) StartRun(ctx context.Context, spec *adagio.GraphSpec) (run
 		ops = append(ops, put, putState)
 	}
 
+	if parent := run.Parent; parent != nil {
+		// link the new run as a child of its parent run
+		ops = append(ops, clientv3.OpPut(childKey(parent.RunId, run.Id), parent.Node))
+	}
+
 	resp, err := r.kv.Txn(ctx).
 		If(cmps...).
 		Then(ops...).
@@ -172,8 +271,13 @@ func (r *Repository) StartRun(ctx context.Context, spec *adagio.GraphSpec) (run
 
 	if !resp.Succeeded {
 		err = errors.New("duplicate run already created")
+		return
 	}
 
+	r.metrics.RunStarted()
+
+	r.logger.WithField(logging.RunIDKey, run.Id).Debug("run started")
+
 	return
 }
 
@@ -269,15 +373,32 @@ func (r *Repository) ListRuns(ctx context.Context, req controlplane.ListRequest)
 // ClaimNode attempts to claim a node identified by name for a specified run ID and providing a unique claim
 // Given the node is found and the claim is successful the node is returned and the claimed boolean with be true
 func (r *Repository) ClaimNode(ctx context.Context, runID, name string, claim *adagio.Claim) (node *adagio.Node, claimed bool, err error) {
+	ctx, span := tracing.StartNode(ctx, "etcd.ClaimNode", runID, name)
+	defer func() {
+		span.SetAttributes(tracing.ClaimedKey.Bool(claimed))
+		tracing.End(span, err)
+	}()
+
 	defer func() {
 		if err != nil {
 			err = fmt.Errorf("error claiming node: %w", err)
 		}
 	}()
 
+	err = r.retryOnConflict(ctx, "claim_node", func() (conflicted bool, err error) {
+		node, claimed, conflicted, err = r.claimNode(ctx, runID, name, claim)
+		return
+	})
+
+	return
+}
+
+// claimNode makes a single attempt at claiming a node. Given the claim transaction fails
+// because the slots it requires were taken concurrently conflicted is true
+func (r *Repository) claimNode(ctx context.Context, runID, name string, claim *adagio.Claim) (node *adagio.Node, claimed, conflicted bool, err error) {
 	run, err := r.getRun(ctx, runID)
 	if err != nil {
-		return nil, false, err
+		return nil, false, false, err
 	}
 
 	node, err = run.GetNodeByName(name)
@@ -286,18 +407,43 @@ func (r *Repository) ClaimNode(ctx context.Context, runID, name string, claim *a
 	}
 
 	if node.Status == adagio.Node_WAITING {
-		return nil, false, adagio.ErrNodeNotReady
+		return nil, false, false, adagio.ErrNodeNotReady
+	}
+
+	if adagio.IsApproval(node) {
+		return nil, false, false, adagio.ErrNodeRequiresApproval
+	}
+
+	// scheduled nodes are claimed once they have been promoted to ready
+	if node.Status == adagio.Node_SCHEDULED {
+		return nil, false, false, adagio.CheckSchedule(node, r.now())
 	}
 
 	// node must be either ready or in none state
 	if node.Status != adagio.Node_READY && node.Status != adagio.Node_NONE {
-		return nil, false, nil
+		return nil, false, false, nil
+	}
+
+	slots, err := r.freeSlots(ctx, run, node)
+	if err != nil {
+		return nil, false, false, err
 	}
 
+	logger := r.logger.WithFields(logging.Fields{
+		logging.RunIDKey:   runID,
+		logging.NodeKey:    name,
+		logging.ClaimIDKey: claim.Id,
+	})
+
 	// construct a lease which is kept-alive
-	leaseID, err := r.lease(claim.Id)
+	leaseID, err := r.lease(claim.Id, logger)
 	if err != nil {
-		return nil, false, err
+		return nil, false, false, err
+	}
+
+	// the node was orphaned by the agent which held the previous claim
+	if node.Status == adagio.Node_NONE {
+		adagio.RecordTransition(node, adagio.Node_Transition_ORPHANED, r.now(), node.Claim)
 	}
 
 	// set claim on node
@@ -311,7 +457,13 @@ func (r *Repository) ClaimNode(ctx context.Context, runID, name string, claim *a
 
 	cmps, ops, err := r.transition(runID, node, adagio.Node_RUNNING, nil, nil, clientv3.WithLease(leaseID))
 	if err != nil {
-		return nil, false, err
+		return nil, false, false, err
+	}
+
+	// occupy the free slots for the lifetime of the claim
+	for _, key := range slots {
+		cmps = append(cmps, clientv3.Compare(clientv3.Version(key), "=", 0))
+		ops = append(ops, clientv3.OpPut(key, claim.Id, clientv3.WithLease(leaseID)))
 	}
 
 	resp, err := r.kv.Txn(ctx).
@@ -319,14 +471,70 @@ func (r *Repository) ClaimNode(ctx context.Context, runID, name string, claim *a
 		Then(ops...).
 		Commit()
 	if err != nil {
-		return nil, false, err
+		return nil, false, false, err
 	}
 
 	if !resp.Succeeded {
-		return nil, false, nil
+		// slots may have been taken by another claim in the meantime
+		// so attempt the claim again against the latest state
+		return nil, false, len(slots) > 0, nil
+	}
+
+	logger.Debug("node claimed")
+
+	return node, resp.Succeeded, false, nil
+}
+
+// freeSlots returns the keys of the free slots required to claim the node, one slot
+// of the runs max parallelism and those of each resource pool the node requires.
+// Given not enough slots are free an error wrapping ErrConcurrencyLimit is returned
+func (r *Repository) freeSlots(ctx context.Context, run *adagio.Run, node *adagio.Node) (keys []string, err error) {
+	if max := run.MaxParallelism; max > 0 {
+		free, err := r.free(ctx, runSlotsKey(run.Id), int(max), 1)
+		if err != nil {
+			return nil, fmt.Errorf("run max parallelism %d: %w", max, err)
+		}
+
+		keys = append(keys, free...)
+	}
+
+	for pool, slots := range node.Spec.Resources {
+		free, err := r.free(ctx, poolSlotsKey(pool), r.pools[pool], int(slots))
+		if err != nil {
+			return nil, fmt.Errorf("resource pool %q: %w", pool, err)
+		}
+
+		keys = append(keys, free...)
+	}
+
+	return
+}
+
+// free returns the keys of n unoccupied slots beneath the prefix of a limit with the provided size
+func (r *Repository) free(ctx context.Context, prefix string, size, n int) ([]string, error) {
+	resp, err := r.kv.Get(ctx, prefix, clientv3.WithPrefix(), clientv3.WithKeysOnly())
+	if err != nil {
+		return nil, err
+	}
+
+	occupied := map[string]struct{}{}
+	for _, kv := range resp.Kvs {
+		occupied[string(kv.Key)] = struct{}{}
+	}
+
+	var keys []string
+	for i := 0; i < size && len(keys) < n; i++ {
+		key := fmt.Sprintf("%s%d", prefix, i)
+		if _, ok := occupied[key]; !ok {
+			keys = append(keys, key)
+		}
 	}
 
-	return node, resp.Succeeded, nil
+	if len(keys) < n {
+		return nil, adagio.ErrConcurrencyLimit
+	}
+
+	return keys, nil
 }
 
 func (r *Repository) complete(runID string, node *adagio.Node, cmps []clientv3.Cmp, ops []clientv3.Op) ([]clientv3.Cmp, []clientv3.Op, error) {
@@ -353,16 +561,38 @@ func (r *Repository) transition(runID string, node *adagio.Node, toStatus adagio
 	node.Status = toStatus
 
 	switch toStatus {
+	case adagio.Node_READY:
+		r.markReady(node)
+
 	case adagio.Node_RUNNING:
-		node.StartedAt = r.now().Format(time.RFC3339Nano)
+		now := r.now()
 
-	case adagio.Node_COMPLETED:
+		// rescheduled nodes retain the time at which they were first started
+		if node.NotBefore == "" || node.StartedAt == "" {
+			node.StartedAt = now.Format(time.RFC3339Nano)
+		}
+
+		node.NotBefore = ""
+
+		// progress is reported afresh for each claim
+		node.Progress = nil
+
+		adagio.RecordTransition(node, adagio.Node_Transition_CLAIMED, now, node.Claim)
+
+	case adagio.Node_COMPLETED, adagio.Node_SKIPPED:
 		now := r.now()
 		if node.StartedAt == "" {
 			node.StartedAt = now.Format(time.RFC3339Nano)
 		}
 
 		node.FinishedAt = now.Format(time.RFC3339Nano)
+
+		if toStatus == adagio.Node_SKIPPED {
+			adagio.RecordTransition(node, adagio.Node_Transition_SKIPPED, now, nil)
+			break
+		}
+
+		adagio.RecordTransition(node, adagio.Node_Transition_FINISHED, now, node.Claim)
 	}
 
 	data, err := json.Marshal(node)
@@ -398,6 +628,21 @@ func (r *Repository) transition(runID string, node *adagio.Node, toStatus adagio
 		), nil
 }
 
+// markReady records the time at which the node became ready
+// and for approval nodes the time they began awaiting approval
+func (r *Repository) markReady(node *adagio.Node) {
+	at := r.now()
+	now := at.Format(time.RFC3339Nano)
+
+	node.ReadyAt = now
+
+	adagio.RecordTransition(node, adagio.Node_Transition_READY, at, nil)
+
+	if adagio.IsApproval(node) {
+		node.StartedAt = now
+	}
+}
+
 func (r *Repository) nodeIsOrphaned(runID string, node *adagio.Node) []clientv3.Cmp {
 	return []clientv3.Cmp{
 		// ensure node does not exist in any state
@@ -405,6 +650,8 @@ func (r *Repository) nodeIsOrphaned(runID string, node *adagio.Node) []clientv3.
 		r.statusDoesNotExist(runID, node, adagio.Node_READY),
 		r.statusDoesNotExist(runID, node, adagio.Node_RUNNING),
 		r.statusDoesNotExist(runID, node, adagio.Node_COMPLETED),
+		r.statusDoesNotExist(runID, node, adagio.Node_SKIPPED),
+		r.statusDoesNotExist(runID, node, adagio.Node_SCHEDULED),
 	}
 }
 
@@ -413,13 +660,15 @@ func (r *Repository) statusDoesNotExist(runID string, node *adagio.Node, status
 	return clientv3.Compare(clientv3.Version(statusKey), "=", 0)
 }
 
-func (r *Repository) lease(claimID string) (clientv3.LeaseID, error) {
+func (r *Repository) lease(claimID string, logger logging.Logger) (clientv3.LeaseID, error) {
 	// store lease keep-alive cancel func
 	ctx, cancel := context.WithCancel(context.Background())
 
 	// grant lease in seconds
 	leaseResp, err := r.leaser.Grant(ctx, int64(r.ttl/time.Second))
 	if err != nil {
+		cancel()
+
 		return 0, err
 	}
 
@@ -430,7 +679,7 @@ func (r *Repository) lease(claimID string) (clientv3.LeaseID, error) {
 
 		// revoke lease
 		if _, err := r.leaser.Revoke(context.Background(), leaseResp.ID); err != nil {
-			log.Println(claimID, err)
+			logger.WithError(err).Warn("revoking lease")
 			return
 		}
 	}
@@ -442,15 +691,15 @@ func (r *Repository) lease(claimID string) (clientv3.LeaseID, error) {
 
 		resps, err := r.leaser.KeepAlive(ctx, leaseResp.ID)
 		if err != nil {
-			log.Println(err)
+			logger.WithError(err).Error("keeping lease alive")
 			return
 		}
 
 		for resp := range resps {
-			log.Println("keep-alive", resp.ID)
+			logger.WithField("lease_id", resp.ID).Debug("lease kept alive")
 		}
 
-		log.Println("finished keep-alive for", claimID)
+		logger.Debug("finished keeping lease alive")
 	}()
 
 	return leaseResp.ID, nil
@@ -466,256 +715,1212 @@ func (r *Repository) cancelLease(claimID string) {
 	r.leaseMu.Unlock()
 }
 
+// retryOnConflict calls attempt until it no longer reports a conflicting transaction.
+// It gives up once the context is done or after maxTxnAttempts attempts
+func (r *Repository) retryOnConflict(ctx context.Context, operation string, attempt func() (conflicted bool, err error)) error {
+	for i := 0; i < maxTxnAttempts; i++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		conflicted, err := attempt()
+		if err != nil || !conflicted {
+			return err
+		}
+
+		r.metrics.TxnConflict(operation)
+	}
+
+	return errTooManyConflicts
+}
+
 // FinishNode records a result for a node identified by name for a specified run ID and given a unique and active claim
 func (r *Repository) FinishNode(ctx context.Context, runID, name string, result *adagio.Node_Result, claim *adagio.Claim) (err error) {
+	ctx, span := tracing.StartNode(ctx, "etcd.FinishNode", runID, name)
+	defer func() { tracing.End(span, err) }()
+
 	defer func() {
 		if err != nil {
 			err = fmt.Errorf("error finishing node: %w", err)
 		}
 	}()
 
-	run, err := r.getRun(ctx, runID)
-	if err != nil {
-		return err
-	}
+	logger := r.logger.WithFields(logging.Fields{
+		logging.RunIDKey:   runID,
+		logging.NodeKey:    name,
+		logging.ClaimIDKey: claim.Id,
+	})
 
-	node, err := run.GetNodeByName(name)
-	if err != nil {
-		return err
-	}
+	err = r.retryOnConflict(ctx, "finish_node", func() (bool, error) {
+		run, err := r.getRun(ctx, runID)
+		if err != nil {
+			return false, err
+		}
 
-	if node.Status != adagio.Node_RUNNING {
-		return errors.New("attempt to finish non-running node")
-	}
+		node, err := run.GetNodeByName(name)
+		if err != nil {
+			return false, err
+		}
 
-	// append result to list of attempts
-	node.Attempts = append(node.Attempts, result)
+		if node.Status != adagio.Node_RUNNING {
+			// the run may have been cancelled while the node was running
+			r.cancelLease(claim.Id)
 
-	var (
-		cmps []clientv3.Cmp
-		ops  []clientv3.Op
-	)
+			return false, errors.New("attempt to finish non-running node")
+		}
 
-	if result.Conclusion == adagio.Node_Result_SUCCESS {
-		cmps, ops, err = r.handleSuccess(ctx, run, node, result)
+		succeeded, err := r.finish(ctx, run, node, result)
 		if err != nil {
-			return err
+			r.cancelLease(claim.Id)
+
+			return false, err
 		}
-	} else {
-		cmps, ops, err = r.handleFailure(ctx, run, node, result)
-		if err != nil {
-			return err
+
+		if !succeeded {
+			logger.Debug("conflict finishing node, retrying")
+
+			return true, nil
 		}
-	}
 
-	resp, err := r.kv.Txn(ctx).
-		If(cmps...).
-		Then(ops...).
-		Commit()
-	if err != nil {
-		r.cancelLease(claim.Id)
+		// failed nodes which are retried after a delay are promoted once it has passed
+		r.promoteAfter(runID, node)
 
+		return false, nil
+	})
+	if err != nil {
 		return err
 	}
 
-	if !resp.Succeeded {
-		return r.FinishNode(ctx, run.Id, node.Spec.Name, result, claim)
-	}
-
 	r.cancelLease(claim.Id)
 
+	logger.Debug("node finished")
+
 	return nil
 }
 
-func (r *Repository) handleSuccess(ctx context.Context, run *adagio.Run, node *adagio.Node, result *adagio.Node_Result) ([]clientv3.Cmp, []clientv3.Op, error) {
-	cmps, ops, err := r.complete(run.Id, node, nil, nil)
-	if err != nil {
-		return nil, nil, err
-	}
+// RescheduleNode releases the claim on a running node and returns it to the ready state
+// without recording an attempt. The node cannot be claimed again until notBefore
+func (r *Repository) RescheduleNode(ctx context.Context, runID, name string, notBefore time.Time, claim *adagio.Claim) (err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("error rescheduling node: %w", err)
+		}
+	}()
 
-	graph := adagio.GraphFrom(run)
+	err = r.retryOnConflict(ctx, "reschedule_node", func() (bool, error) {
+		run, err := r.getRun(ctx, runID)
+		if err != nil {
+			return false, err
+		}
 
-	outgoing, err := graph.Outgoing(node)
-	if err != nil {
-		return nil, nil, err
-	}
+		node, err := run.GetNodeByName(name)
+		if err != nil {
+			return false, err
+		}
 
-	for o := range outgoing {
-		out := o.(*adagio.Node)
+		if node.Status != adagio.Node_RUNNING {
+			r.cancelLease(claim.Id)
 
-		if out.Status > adagio.Node_WAITING {
-			continue
+			return false, errors.New("attempt to reschedule non-running node")
 		}
 
-		isReady := true
+		node.NotBefore = notBefore.Format(time.RFC3339Nano)
 
-		incoming, err := graph.Incoming(out)
+		adagio.RecordTransition(node, adagio.Node_Transition_RESCHEDULED, r.now(), claim)
+
+		cmps, ops, err := r.transition(runID, node, r.scheduledStatus(node), nil, nil)
 		if err != nil {
-			return nil, nil, err
+			return false, err
 		}
 
-		for v := range incoming {
-			in := v.(*adagio.Node)
+		resp, err := r.kv.Txn(ctx).
+			If(cmps...).
+			Then(ops...).
+			Commit()
+		if err != nil {
+			return false, err
+		}
 
-			// target node is ready if all incoming nodes
-			// are completed
-			isReady = isReady && in.Status == adagio.Node_COMPLETED
+		if resp.Succeeded {
+			r.promoteAfter(runID, node)
+		}
 
-			if in == node {
-				// we have already considered the finishing node
-				continue
-			}
+		return !resp.Succeeded, nil
+	})
+	if err != nil {
+		return err
+	}
 
-			if in.Status == adagio.Node_NONE {
-				cmps = append(cmps, r.nodeIsOrphaned(run.Id, in)...)
-				continue
-			}
+	r.cancelLease(claim.Id)
 
-			// ensure node in state key is created
-			currentKey := nodeInStateKey(run.Id, statusToString(in.Status), in.Spec.Name)
-			cmps = append(cmps, clientv3.Compare(clientv3.Version(currentKey), ">", 0))
+	return nil
+}
+
+// ReportProgress records the progress of a node running under the provided claim
+func (r *Repository) ReportProgress(ctx context.Context, runID, name string, progress *adagio.Node_Progress, claim *adagio.Claim) (err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("error reporting progress: %w", err)
 		}
+	}()
 
-		// if all nodes are now completed
-		// then the outgoing target is ready
-		if isReady {
-			cmps, ops, err = r.transition(run.Id, out, adagio.Node_READY, cmps, ops)
-			if err != nil {
-				return nil, nil, err
-			}
+	var (
+		nodeKey    = nodeKey(runID, name)
+		runningKey = nodeInStateKey(runID, statusToString(adagio.Node_RUNNING), name)
+	)
+
+	return r.retryOnConflict(ctx, "report_progress", func() (bool, error) {
+		resp, err := r.kv.Get(ctx, nodeKey)
+		if err != nil {
+			return false, err
 		}
-	}
 
-	return cmps, ops, nil
-}
+		if len(resp.Kvs) < 1 {
+			return false, adagio.ErrMissingNode
+		}
 
-func (r *Repository) handleFailure(ctx context.Context, run *adagio.Run, node *adagio.Node, result *adagio.Node_Result) ([]clientv3.Cmp, []clientv3.Op, error) {
-	if adagio.CanRetry(node) {
-		// put node back into the ready state to be attempted again
-		return r.transition(run.Id, node, adagio.Node_READY, nil, nil)
-	}
+		node := &adagio.Node{}
+		if err := json.Unmarshal(resp.Kvs[0].Value, node); err != nil {
+			return false, err
+		}
 
-	cmps, ops, err := r.complete(run.Id, node, nil, nil)
-	if err != nil {
-		return nil, nil, err
-	}
+		// check the node is running at the same revision
+		running, err := r.kv.Get(ctx, runningKey, clientv3.WithCountOnly(), clientv3.WithRev(resp.Header.Revision))
+		if err != nil {
+			return false, err
+		}
 
-	if err := adagio.GraphFrom(run).WalkFrom(node, func(gnode graph.Node) error {
-		var (
-			node, _ = gnode.(*adagio.Node)
-			err     error
-		)
+		if running.Count < 1 || node.Claim.GetId() != claim.Id {
+			return false, adagio.ErrClaimNotHeld
+		}
 
-		// complete outgoing nodes with nil result to signify
-		// no attempt has been made
-		cmps, ops, err = r.complete(run.Id, node, cmps, ops)
+		node.Progress = progress
+
+		data, err := json.Marshal(node)
 		if err != nil {
-			return err
+			return false, err
 		}
 
-		return nil
-	}); err != nil {
-		return nil, nil, err
-	}
+		txn, err := r.kv.Txn(ctx).
+			If(
+				// ensure node is still running
+				clientv3.Compare(clientv3.Version(runningKey), ">", 0),
+				// ensure node has not been updated since it was read
+				clientv3.Compare(clientv3.ModRevision(nodeKey), "=", resp.Kvs[0].ModRevision),
+			).
+			Then(clientv3.OpPut(nodeKey, string(data))).
+			Commit()
+		if err != nil {
+			return false, err
+		}
 
-	return cmps, ops, nil
+		return !txn.Succeeded, nil
+	})
 }
 
-// Subscribe registers the agent as a subscriber and sends events regarding node readiness and orphanage
-// on the provided events channel
-func (r *Repository) Subscribe(ctx context.Context, a *adagio.Agent, events chan<- *adagio.Event, typ ...adagio.Event_Type) error {
-	r.mu.Lock()
+// AppendLogs appends lines to the logs of an attempt of a node
+// Each call stores the lines as a chunk beneath a monotonically increasing key
+func (r *Repository) AppendLogs(ctx context.Context, runID, name string, attempt int32, lines []*adagio.LogLine) error {
+	data, err := json.Marshal(lines)
+	if err != nil {
+		return err
+	}
+
+	r.entropyMu.Lock()
+	id := ulid.MustNew(ulid.Timestamp(r.now()), r.entropy)
+	r.entropyMu.Unlock()
+
+	var (
+		nodeKey = nodeKey(runID, name)
+		key     = logsKey(runID, name, attempt) + id.String()
+	)
+
+	resp, err := r.kv.Txn(ctx).
+		If(clientv3.Compare(clientv3.Version(nodeKey), ">", 0)).
+		Then(clientv3.OpPut(key, string(data))).
+		Commit()
+	if err != nil {
+		return fmt.Errorf("error appending logs: %w", err)
+	}
+
+	if !resp.Succeeded {
+		return fmt.Errorf("error appending logs: node %q: %w", name, adagio.ErrMissingNode)
+	}
+
+	return nil
+}
+
+// ReadLogs returns the lines of the logs of an attempt of a node from the provided offset
+func (r *Repository) ReadLogs(ctx context.Context, runID, name string, attempt int32, offset int) (lines []*adagio.LogLine, err error) {
+	resp, err := r.kv.Get(ctx, logsKey(runID, name, attempt), clientv3.WithPrefix(), clientv3.WithSort(clientv3.SortByKey, clientv3.SortAscend))
+	if err != nil {
+		return nil, fmt.Errorf("error reading logs: %w", err)
+	}
+
+	for _, kv := range resp.Kvs {
+		var chunk []*adagio.LogLine
+		if err := json.Unmarshal(kv.Value, &chunk); err != nil {
+			return nil, fmt.Errorf("error reading logs: %w", err)
+		}
+
+		lines = append(lines, chunk...)
+	}
+
+	if offset >= len(lines) {
+		return nil, nil
+	}
+
+	return lines[offset:], nil
+}
+
+// ResolveApproval completes a ready approval node with the provided result.
+// Given the approval has expired the node is failed and ErrApprovalExpired is returned
+func (r *Repository) ResolveApproval(ctx context.Context, runID, name string, result *adagio.Node_Result) (err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("error resolving approval: %w", err)
+		}
+	}()
+
+	var expired bool
+	err = r.retryOnConflict(ctx, "resolve_approval", func() (bool, error) {
+		run, err := r.getRun(ctx, runID)
+		if err != nil {
+			return false, err
+		}
+
+		node, err := run.GetNodeByName(name)
+		if err != nil {
+			return false, err
+		}
+
+		if !adagio.AwaitingApproval(node) {
+			return false, adagio.ErrNodeNotAwaitingApproval
+		}
+
+		expired = adagio.ApprovalExpired(node, r.now())
+		if expired {
+			result = adagio.ApprovalExpiredResult()
+		}
+
+		succeeded, err := r.finish(ctx, run, node, result)
+		if err != nil {
+			return false, err
+		}
+
+		return !succeeded, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if expired {
+		return adagio.ErrApprovalExpired
+	}
+
+	return nil
+}
+
+// ExpireApprovals fails all the approval nodes whose approval has expired
+func (r *Repository) ExpireApprovals(ctx context.Context) error {
+	resp, err := r.kv.Get(ctx, nodesInStateKey(adagio.Node_READY), clientv3.WithPrefix(), clientv3.WithKeysOnly())
+	if err != nil {
+		return err
+	}
+
+	for _, kv := range resp.Kvs {
+		keyParts := strings.Split(string(kv.Key), "/")
+		if len(keyParts) < 6 {
+			continue
+		}
+
+		run, err := r.getRun(ctx, keyParts[3])
+		if err != nil {
+			return err
+		}
+
+		node, err := run.GetNodeByName(keyParts[5])
+		if err != nil {
+			return err
+		}
+
+		if !adagio.ApprovalExpired(node, r.now()) {
+			continue
+		}
+
+		// a failed transaction suggests the node has been resolved
+		// concurrently so it is left for any subsequent call
+		if _, err := r.finish(ctx, run, node, adagio.ApprovalExpiredResult()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CancelRun completes every unresolved node of the run identified by id with an
+// errored result so that none of them are claimed. The child runs of the run are
+// cancelled in turn
+func (r *Repository) CancelRun(ctx context.Context, id string) error {
+	if err := r.cancel(ctx, id); err != nil {
+		return fmt.Errorf("error cancelling run: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) cancel(ctx context.Context, id string) error {
+	var (
+		run *adagio.Run
+		// leases of the claims on the running nodes which hold their slots
+		leases []clientv3.LeaseID
+	)
+
+	err := r.retryOnConflict(ctx, "cancel_run", func() (_ bool, err error) {
+		run, err = r.getRun(ctx, id)
+		if err != nil {
+			return false, err
+		}
+
+		var (
+			cmps      []clientv3.Cmp
+			ops       []clientv3.Op
+			cancelled []*adagio.Node
+		)
+
+		leases = leases[:0]
+
+		for _, node := range run.Nodes {
+			if adagio.IsResolved(node) {
+				continue
+			}
+
+			if node.Status == adagio.Node_RUNNING {
+				resp, err := r.kv.Get(ctx, nodeInStateKey(run.Id, statusToString(adagio.Node_RUNNING), node.Spec.Name))
+				if err != nil {
+					return false, err
+				}
+
+				for _, kv := range resp.Kvs {
+					leases = append(leases, clientv3.LeaseID(kv.Lease))
+				}
+			}
+
+			node.Attempts = append(node.Attempts, adagio.CancelledResult())
+
+			if cmps, ops, err = r.complete(run.Id, node, cmps, ops); err != nil {
+				return false, err
+			}
+
+			cancelled = append(cancelled, node)
+		}
+
+		if len(cancelled) == 0 {
+			return false, nil
+		}
+
+		resp, err := r.kv.Txn(ctx).
+			If(cmps...).
+			Then(ops...).
+			Commit()
+		if err != nil {
+			return false, err
+		}
+
+		if resp.Succeeded {
+			r.metrics.NodeFinished(run, cancelled...)
+		}
+
+		return !resp.Succeeded, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// the claims on the cancelled nodes can no longer be finished so their leases
+	// are revoked in order to release the slots they hold
+	for _, lease := range leases {
+		// the lease has already been revoked given the claim was released concurrently
+		if _, err := r.leaser.Revoke(ctx, lease); err != nil {
+			r.logger.WithError(err).WithField(logging.RunIDKey, id).Warn("revoking lease")
+		}
+	}
+
+	for _, child := range run.Children {
+		if err := r.cancel(ctx, child.RunId); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PromoteScheduled makes ready all the scheduled nodes whose delay has passed.
+// Nodes are otherwise promoted by the repository which scheduled them, this
+// ensures they are promoted given that repository has since gone away
+func (r *Repository) PromoteScheduled(ctx context.Context) error {
+	resp, err := r.kv.Get(ctx, nodesInStateKey(adagio.Node_SCHEDULED), clientv3.WithPrefix(), clientv3.WithKeysOnly())
+	if err != nil {
+		return err
+	}
+
+	for _, kv := range resp.Kvs {
+		keyParts := strings.Split(string(kv.Key), "/")
+		if len(keyParts) < 6 {
+			continue
+		}
+
+		if err := r.promote(ctx, keyParts[3], keyParts[5]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// scheduledStatus returns the status of a node being put back to be attempted again.
+// Nodes which cannot be claimed until a later time are scheduled, otherwise they are ready
+func (r *Repository) scheduledStatus(node *adagio.Node) adagio.Node_Status {
+	if adagio.CheckSchedule(node, r.now()) != nil {
+		return adagio.Node_SCHEDULED
+	}
+
+	return adagio.Node_READY
+}
+
+// promoteAfter promotes the node once the time it is not to be claimed before has passed
+// given it has been scheduled
+func (r *Repository) promoteAfter(runID string, node *adagio.Node) {
+	var scheduled *adagio.ScheduledError
+	if node.Status != adagio.Node_SCHEDULED || !errors.As(adagio.CheckSchedule(node, r.now()), &scheduled) {
+		return
+	}
+
+	name := node.Spec.Name
+
+	time.AfterFunc(scheduled.NotBefore.Sub(r.now()), func() {
+		if err := r.promote(context.Background(), runID, name); err != nil {
+			r.logger.WithFields(logging.Fields{
+				logging.RunIDKey: runID,
+				logging.NodeKey:  name,
+			}).WithError(err).Error("promoting scheduled node")
+		}
+	})
+}
+
+// promote transitions a scheduled node to ready given the time it is not to be claimed
+// before has passed. Nodes promoted concurrently are left as they are
+func (r *Repository) promote(ctx context.Context, runID, name string) error {
+	return r.retryOnConflict(ctx, "promote_node", func() (bool, error) {
+		run, err := r.getRun(ctx, runID)
+		if err != nil {
+			return false, err
+		}
+
+		node, err := run.GetNodeByName(name)
+		if err != nil {
+			return false, err
+		}
+
+		if node.Status != adagio.Node_SCHEDULED || adagio.CheckSchedule(node, r.now()) != nil {
+			return false, nil
+		}
+
+		cmps, ops, err := r.transition(runID, node, adagio.Node_READY, nil, nil)
+		if err != nil {
+			return false, err
+		}
+
+		resp, err := r.kv.Txn(ctx).
+			If(cmps...).
+			Then(ops...).
+			Commit()
+		if err != nil {
+			return false, err
+		}
+
+		return !resp.Succeeded, nil
+	})
+}
+
+// finish appends the result to the nodes attempts and attempts to complete it and
+// progress its outgoing nodes. It returns false if the transaction did not succeed
+func (r *Repository) finish(ctx context.Context, run *adagio.Run, node *adagio.Node, result *adagio.Node_Result) (bool, error) {
+	// append result to list of attempts
+	node.Attempts = append(node.Attempts, result)
+
+	var (
+		cmps []clientv3.Cmp
+		ops  []clientv3.Op
+		err  error
+	)
+
+	if result.Conclusion == adagio.Node_Result_SUCCESS {
+		cmps, ops, err = r.handleSuccess(ctx, run, node, result)
+		if err != nil {
+			return false, err
+		}
+	} else {
+		cmps, ops, err = r.handleFailure(ctx, run, node, result)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	resp, err := r.kv.Txn(ctx).
+		If(cmps...).
+		Then(ops...).
+		Commit()
+	if err != nil {
+		return false, err
+	}
+
+	if resp.Succeeded {
+		r.metrics.NodeFinished(run, node)
+	}
+
+	return resp.Succeeded, nil
+}
+
+func (r *Repository) handleSuccess(ctx context.Context, run *adagio.Run, node *adagio.Node, result *adagio.Node_Result) ([]clientv3.Cmp, []clientv3.Op, error) {
+	cmps, ops, err := r.complete(run.Id, node, nil, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return r.readyOutgoing(run, node, map[*adagio.Node]struct{}{node: {}}, cmps, ops)
+}
+
+// readyOutgoing progresses the outgoing nodes of the provided node given all of
+// their incoming nodes are resolved. Pending is the set of nodes which have
+// already been transitioned as part of the transaction being built
+func (r *Repository) readyOutgoing(run *adagio.Run, node *adagio.Node, pending map[*adagio.Node]struct{}, cmps []clientv3.Cmp, ops []clientv3.Op) ([]clientv3.Cmp, []clientv3.Op, error) {
+	outgoing, err := adagio.GraphFrom(run).Outgoing(node)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nodes := make([]*adagio.Node, 0, len(outgoing))
+	for o := range outgoing {
+		nodes = append(nodes, o.(*adagio.Node))
+	}
+
+	return r.resolve(run, nodes, pending, cmps, ops)
+}
+
+// resolve transitions each of the waiting nodes whose incoming nodes are all resolved
+// into the ready state, given any of their incoming edges are satisfied, or otherwise
+// into the skipped state
+func (r *Repository) resolve(run *adagio.Run, nodes []*adagio.Node, pending map[*adagio.Node]struct{}, cmps []clientv3.Cmp, ops []clientv3.Op) ([]clientv3.Cmp, []clientv3.Op, error) {
+	graph := adagio.GraphFrom(run)
+
+	for _, out := range nodes {
+		if out.Status > adagio.Node_WAITING {
+			continue
+		}
+
+		isResolved := true
+
+		incoming, err := graph.Incoming(out)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for v := range incoming {
+			in := v.(*adagio.Node)
+
+			// target node can progress if all incoming nodes
+			// are resolved
+			isResolved = isResolved && adagio.IsResolved(in)
+
+			if _, ok := pending[in]; ok {
+				// we have already considered the pending node
+				continue
+			}
+
+			if in.Status == adagio.Node_NONE {
+				cmps = append(cmps, r.nodeIsOrphaned(run.Id, in)...)
+				continue
+			}
+
+			// ensure node in state key is created
+			currentKey := nodeInStateKey(run.Id, statusToString(in.Status), in.Spec.Name)
+			cmps = append(cmps, clientv3.Compare(clientv3.Version(currentKey), ">", 0))
+		}
+
+		if !isResolved {
+			continue
+		}
+
+		if !adagio.IncomingSatisfied(run, out) {
+			// no incoming edge is satisfied so skip the node
+			// and descend into its outgoing nodes
+			cmps, ops, err = r.transition(run.Id, out, adagio.Node_SKIPPED, cmps, ops)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			pending[out] = struct{}{}
+
+			cmps, ops, err = r.readyOutgoing(run, out, pending, cmps, ops)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			continue
+		}
+
+		if adagio.IsMap(out) {
+			cmps, ops, err = r.expand(run, out, pending, cmps, ops)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			continue
+		}
+
+		cmps, ops, err = r.transition(run.Id, out, adagio.Node_READY, cmps, ops)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		pending[out] = struct{}{}
+	}
+
+	return cmps, ops, nil
+}
+
+// expand completes a ready map node and creates its instances in the ready state
+func (r *Repository) expand(run *adagio.Run, node *adagio.Node, pending map[*adagio.Node]struct{}, cmps []clientv3.Cmp, ops []clientv3.Op) ([]clientv3.Cmp, []clientv3.Op, error) {
+	// refresh inputs as the node which readied the map node
+	// will have only just completed within this transaction
+	if err := r.setInputs(run, node); err != nil {
+		return nil, nil, err
+	}
+
+	expansion := adagio.Expand(run, node)
+
+	node.Attempts = append(node.Attempts, expansion.Result)
+
+	cmps, ops, err := r.complete(run.Id, node, cmps, ops)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pending[node] = struct{}{}
+
+	if expansion.Result.Conclusion != adagio.Node_Result_SUCCESS {
+		return r.completeDescendants(run, node, pending, cmps, ops)
+	}
+
+	data, err := marshalExpansion(expansion)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key := expansionKey(run.Id, node.Spec.Name)
+	cmps = append(cmps, clientv3.Compare(clientv3.Version(key), "=", 0))
+	ops = append(ops, clientv3.OpPut(key, string(data)))
+
+	for _, instance := range expansion.Nodes {
+		r.markReady(instance)
+
+		instanceData, err := json.Marshal(instance)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var (
+			key      = nodeKey(run.Id, instance.Spec.Name)
+			stateKey = nodeInStateKey(run.Id, statusToString(instance.Status), instance.Spec.Name)
+		)
+
+		cmps = append(cmps, clientv3.Compare(clientv3.Version(key), "=", 0))
+		ops = append(ops, clientv3.OpPut(key, string(instanceData)), clientv3.OpPut(stateKey, ""))
+
+		pending[instance] = struct{}{}
+	}
+
+	run.Nodes = append(run.Nodes, expansion.Nodes...)
+	run.Edges = append(run.Edges, expansion.Edges...)
+
+	if len(expansion.Nodes) > 0 {
+		return cmps, ops, nil
+	}
+
+	// the map produced no instances so its destinations may now be ready
+	return r.readyOutgoing(run, node, pending, cmps, ops)
+}
+
+func (r *Repository) handleFailure(ctx context.Context, run *adagio.Run, node *adagio.Node, result *adagio.Node_Result) ([]clientv3.Cmp, []clientv3.Op, error) {
+	if adagio.CanRetry(node) {
+		// put node back into the ready state to be attempted again
+		// once any retry delay has elapsed
+		now := r.now()
+
+		adagio.RecordTransition(node, adagio.Node_Transition_FINISHED, now, node.Claim)
+		adagio.RecordTransition(node, adagio.Node_Transition_RETRIED, now, nil)
+		adagio.ScheduleRetry(node, now)
+
+		return r.transition(run.Id, node, r.scheduledStatus(node), nil, nil)
+	}
+
+	cmps, ops, err := r.complete(run.Id, node, nil, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return r.completeDescendants(run, node, map[*adagio.Node]struct{}{node: {}}, cmps, ops)
+}
+
+// completeDescendants completes the outgoing nodes of the provided node with no result,
+// descending into their outgoing nodes in turn. Outgoing nodes connected via an edge
+// satisfied by the nodes result are instead resolved
+func (r *Repository) completeDescendants(run *adagio.Run, node *adagio.Node, pending map[*adagio.Node]struct{}, cmps []clientv3.Cmp, ops []clientv3.Op) ([]clientv3.Cmp, []clientv3.Op, error) {
+	outgoing, err := adagio.GraphFrom(run).Outgoing(node)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var satisfied []*adagio.Node
+	for o := range outgoing {
+		out := o.(*adagio.Node)
+
+		if adagio.EdgeSatisfied(run, node, out) {
+			satisfied = append(satisfied, out)
+			continue
+		}
+
+		if adagio.IsResolved(out) {
+			continue
+		}
+
+		// complete outgoing nodes with nil result to signify
+		// no attempt has been made
+		cmps, ops, err = r.complete(run.Id, out, cmps, ops)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		pending[out] = struct{}{}
+
+		cmps, ops, err = r.completeDescendants(run, out, pending, cmps, ops)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return r.resolve(run, satisfied, pending, cmps, ops)
+}
+
+// Subscribe registers the agent as a subscriber and sends events regarding node readiness and orphanage
+// on the provided events channel
+func (r *Repository) Subscribe(ctx context.Context, a *adagio.Agent, events chan<- *adagio.Event, typ ...adagio.Event_Type) error {
+	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	// create agent record
+	// create agent record
+
+	// construct a lease for the agent
+	leaseID, err := r.lease(a.Id, r.logger.WithField(logging.AgentIDKey, a.Id))
+	if err != nil {
+		return err
+	}
+
+	// persist agent in keyspace
+	agentData, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+
+	if _, err := r.kv.Put(ctx, agentKey(a), string(agentData), clientv3.WithLease(leaseID)); err != nil {
+		return err
+	}
+
+	// begin subscription
+
+	ch := make(chan struct{})
+
+	r.subscriptions[events] = ch
+
+	go func() {
+		defer r.cancelLease(a.Id)
+
+		var (
+			// construct a new context for this operation
+			// as the subscription will be cancelled via
+			// an unsubscribe
+			ctx    = context.Background()
+			logger = r.logger.WithField(logging.AgentIDKey, a.Id)
+			opts   = []clientv3.OpOption{clientv3.WithPrefix()}
+			filter = filter{
+				agent:    a,
+				orphaned: !types(typ).contains(adagio.Event_NODE_ORPHANED),
+				ready:    !types(typ).contains(adagio.Event_NODE_READY),
+			}
+		)
+
+		// consume existing ready nodes
+		resp, err := r.kv.Get(ctx, nodesInStateKey(adagio.Node_READY), clientv3.WithPrefix())
+		if err != nil {
+			logger.WithError(err).Error("listing ready nodes")
+			goto Watch
+		}
+
+		// handle ready node events
+		for _, kv := range resp.Kvs {
+			r.handleKeyEvent(ctx, events, keyEvent{kv.Key, keyCreated}, filter, clientv3.WithRev(resp.Header.Revision))
+		}
+
+		// set the watch responses to return a revision higher than the response
+		// to reduce the chance we observe the same ready node twice
+		opts = append(opts, clientv3.WithRev(resp.Header.Revision+1))
+
+	Watch:
+		// watch for new events
+		watch := r.watcher.Watch(ctx, statesPrefix, opts...)
+		for {
+			var resp clientv3.WatchResponse
+			select {
+			case <-ch:
+				return
+			case resp = <-watch:
+			}
+
+			if resp.Err() != nil {
+				logger.WithError(resp.Err()).Error("watching node states")
+				continue
+			}
+
+			for _, ev := range resp.Events {
+				kev := keyEvent{
+					Key: ev.Kv.Key,
+				}
+
+				if ev.IsCreate() {
+					kev.Type = keyCreated
+				}
+
+				// todo(george): import issues with etcd returning old urls from types
+				// defined in new url scheme makes importing other etcd v3 libs to break
+				// until then I am just going to compare to the literal 1 value which means
+				// DELETE operation
+				if ev.Type == 1 {
+					kev.Type = keyDeleted
+				}
+
+				r.handleKeyEvent(ctx, events, kev, filter, clientv3.WithRev(resp.Header.Revision))
+			}
+		}
+	}()
+
+	return nil
+}
+
+type keyEvent struct {
+	Key  []byte
+	Type keyEventType
+}
+
+type keyEventType int
+
+const (
+	keyUnknown keyEventType = iota
+	keyCreated
+	keyDeleted
+)
+
+type filter struct {
+	agent    *adagio.Agent
+	orphaned bool
+	ready    bool
+}
+
+func (r *Repository) handleKeyEvent(ctx context.Context, dest chan<- *adagio.Event, ev keyEvent, filter filter, opts ...clientv3.OpOption) {
+	keyParts := strings.Split(string(ev.Key), "/")
+	if len(keyParts) < 6 {
+		return
+	}
+
+	status := stringToStatus(keyParts[1])
+	switch status {
+	// we're only interested in actions on ready and running keys
+	case adagio.Node_READY, adagio.Node_RUNNING:
+	default:
+		return
+	}
+
+	logger := r.logger.WithFields(logging.Fields{
+		logging.AgentIDKey: filter.agent.Id,
+		logging.RunIDKey:   keyParts[3],
+		logging.NodeKey:    keyParts[5],
+	})
+
+	run, err := r.getRun(ctx, keyParts[3], opts...)
+	if err != nil {
+		logger.WithError(err).Error("handling node event")
+		return
+	}
+
+	node, err := run.GetNodeByName(keyParts[5])
+	if err != nil {
+		logger.WithError(err).Error("handling node event")
+		return
+	}
+
+	// only send events for nodes the subscribed agent has the runtime for
+	if !adagio.SupportsRuntime(filter.agent, node.Spec.Runtime) {
+		return
+	}
+
+	switch ev.Type {
+	case keyCreated:
+		// if a ready status key has been created and the subscription contains a
+		// node ready type then send a node ready event
+		// approval nodes are not announced as they are not claimed by agents
+		if status == adagio.Node_READY && !filter.ready && !adagio.IsApproval(node) {
+			dest <- &adagio.Event{
+				Type:         adagio.Event_NODE_READY,
+				RunID:        keyParts[3],
+				NodeSpec:     node.Spec,
+				Priority:     adagio.Priority(run, node),
+				TraceContext: run.TraceContext,
+			}
+		}
+	case keyDeleted:
+		// given the deletion of a running key where no other state key for the
+		// node exists (this is where GetNodeByName returns a node with a NONE status)
+		if status == adagio.Node_RUNNING && node.Status == adagio.Node_NONE && !filter.orphaned {
+			dest <- &adagio.Event{
+				Type:         adagio.Event_NODE_ORPHANED,
+				RunID:        keyParts[3],
+				NodeSpec:     node.Spec,
+				Priority:     adagio.Priority(run, node),
+				TraceContext: run.TraceContext,
+			}
+		}
+	}
+
+	return
+}
 
-	// construct a lease for the agent
-	leaseID, err := r.lease(a.Id)
+func (r *Repository) getRun(ctx context.Context, id string, ops ...clientv3.OpOption) (*adagio.Run, error) {
+	run := &adagio.Run{Id: id}
+
+	resp, err := r.kv.Get(ctx, runKey(run), ops...)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// persist agent in keyspace
-	agentData, err := json.Marshal(a)
+	if len(resp.Kvs) < 1 {
+		return nil, errors.New("run not found")
+	}
+
+	// initially read out node configuration
+	if err := unmarshalRun(resp.Kvs[0].Value, run); err != nil {
+		return nil, err
+	}
+
+	// given no options are supplied then ensure the rest of
+	// the run is read at the same revision as the run itself
+	if len(ops) < 1 {
+		ops = append(ops, clientv3.WithRev(resp.Header.Revision))
+	}
+
+	// add the nodes and edges of any expanded map nodes
+	if err := r.expansionsForRun(ctx, run, ops...); err != nil {
+		return nil, err
+	}
+
+	// re-hydrate current node states
+	if err := r.nodesForRun(ctx, run, ops...); err != nil {
+		return nil, err
+	}
+
+	// fetch links to any child runs
+	if err := r.childrenForRun(ctx, run, ops...); err != nil {
+		return nil, err
+	}
+
+	// check if all node states in order to derive run state
+	var (
+		runRunning   = false
+		runCompleted = true
+	)
+
+	for _, node := range run.Nodes {
+		runRunning = runRunning || (node.Status > adagio.Node_WAITING)
+		runCompleted = runCompleted && adagio.IsResolved(node)
+	}
+
+	if runRunning {
+		run.Status = adagio.Run_RUNNING
+	}
+
+	if runCompleted {
+		run.Status = adagio.Run_COMPLETED
+	}
+
+	return run, nil
+}
+
+func (r *Repository) nodesForRun(ctx context.Context, run *adagio.Run, ops ...clientv3.OpOption) error {
+	// ensure all calls use with prefix
+	ops = append(ops, clientv3.WithPrefix())
+
+	var (
+		prefix    = allNodesKey(run)
+		nodes     = map[string]*adagio.Node{}
+		resp, err = r.kv.Get(ctx, prefix, ops...)
+	)
+
 	if err != nil {
 		return err
 	}
 
-	if _, err := r.kv.Put(ctx, agentKey(a), string(agentData), clientv3.WithLease(leaseID)); err != nil {
-		return err
+	// given no options are supplied then enforce all nodes header revision
+	// is used for subsequent calls
+	// < 2 because we added with prefix
+	if len(ops) < 2 {
+		ops = append(ops, clientv3.WithRev(resp.Header.Revision))
 	}
 
-	// begin subscription
+	// create mapping for existing nodes in-order
+	// to replace with deserialized ones
+	for _, node := range run.Nodes {
+		nodes[node.Spec.Name] = node
+	}
 
-	ch := make(chan struct{})
+	for _, kv := range resp.Kvs {
+		node := &adagio.Node{}
+		if err := json.Unmarshal(kv.Value, node); err != nil {
+			return err
+		}
 
-	r.subscriptions[events] = ch
+		// check status key exists at store revision for each node
+		resp, err := r.kv.Get(ctx, nodeInStateKey(run.Id, statusToString(node.Status), node.Spec.Name), ops...)
+		if err != nil {
+			return err
+		}
 
-	go func() {
-		defer r.cancelLease(a.Id)
+		if len(resp.Kvs) < 1 {
+			// node not found with expected status suggests node
+			// has been orphaned so we mark it with none status
+			node.Status = adagio.Node_NONE
+		}
 
-		var (
-			// construct a new context for this operation
-			// as the subscription will be cancelled via
-			// an unsubscribe
-			ctx    = context.Background()
-			opts   = []clientv3.OpOption{clientv3.WithPrefix()}
-			filter = filter{
-				orphaned: !types(typ).contains(adagio.Event_NODE_ORPHANED),
-				ready:    !types(typ).contains(adagio.Event_NODE_READY),
-			}
-		)
+		if dst, ok := nodes[node.Spec.Name]; ok {
+			// swap existing node with new de-serialized version
+			*dst = *node
+		}
+	}
 
-		// consume existing ready nodes
-		resp, err := r.kv.Get(ctx, nodesInStateKey(adagio.Node_READY), clientv3.WithPrefix())
-		if err != nil {
-			log.Println(err)
-			goto Watch
+	for _, node := range run.Nodes {
+		if err = r.setInputs(run, node); err != nil {
+			return err
 		}
+	}
 
-		// handle ready node events
-		for _, kv := range resp.Kvs {
-			r.handleKeyEvent(ctx, events, keyEvent{kv.Key, keyCreated}, filter, clientv3.WithRev(resp.Header.Revision))
+	return nil
+}
+
+func (r *Repository) expansionsForRun(ctx context.Context, run *adagio.Run, ops ...clientv3.OpOption) error {
+	resp, err := r.kv.Get(ctx, allExpansionsKey(run), append(ops, clientv3.WithPrefix())...)
+	if err != nil {
+		return err
+	}
+
+	for _, kv := range resp.Kvs {
+		if err := unmarshalExpansion(kv.Value, run); err != nil {
+			return err
 		}
+	}
 
-		// set the watch responses to return a revision higher than the response
-		// to reduce the chance we observe the same ready node twice
-		opts = append(opts, clientv3.WithRev(resp.Header.Revision+1))
+	return nil
+}
 
-	Watch:
-		// watch for new events
-		watch := r.watcher.Watch(ctx, statesPrefix, opts...)
-		for {
-			var resp clientv3.WatchResponse
-			select {
-			case <-ch:
+func (r *Repository) childrenForRun(ctx context.Context, run *adagio.Run, ops ...clientv3.OpOption) error {
+	prefix := allChildrenKey(run)
+
+	resp, err := r.kv.Get(ctx, prefix, append(ops, clientv3.WithPrefix())...)
+	if err != nil {
+		return err
+	}
+
+	for _, kv := range resp.Kvs {
+		run.Children = append(run.Children, &adagio.Run_Link{
+			RunId: strings.TrimPrefix(string(kv.Key), prefix),
+			Node:  string(kv.Value),
+		})
+	}
+
+	return nil
+}
+
+func (r *Repository) setInputs(run *adagio.Run, node *adagio.Node) error {
+	// for each incoming node fetch their outputs
+	incoming, err := adagio.GraphFrom(run).Incoming(node)
+	if err != nil {
+		return err
+	}
+
+	for ini := range incoming {
+		in := ini.(*adagio.Node)
+
+		adagio.VisitLatestAttempt(in, func(result *adagio.Node_Result) {
+			if result.Conclusion != adagio.Node_Result_SUCCESS {
 				return
-			case resp = <-watch:
 			}
 
-			if resp.Err() != nil {
-				log.Println(resp.Err())
+			if node.Inputs == nil {
+				node.Inputs = map[string][]byte{}
+			}
+
+			node.Inputs[in.Spec.Name] = result.Output
+		})
+	}
+
+	return nil
+}
+
+// WatchRuns sends a run updated event on the provided channel for each run whose
+// node states change, until the context is cancelled. Unlike Subscribe it does not
+// register an agent
+func (r *Repository) WatchRuns(ctx context.Context, events chan<- *adagio.Event) error {
+	// watch from the current revision so that no update after the call returns is missed
+	resp, err := r.kv.Get(ctx, statesPrefix, clientv3.WithPrefix(), clientv3.WithCountOnly())
+	if err != nil {
+		return fmt.Errorf("error watching runs: %w", err)
+	}
+
+	watch := r.watcher.Watch(ctx, statesPrefix, clientv3.WithPrefix(), clientv3.WithRev(resp.Header.Revision+1))
+
+	go func() {
+		for resp := range watch {
+			if err := resp.Err(); err != nil {
+				r.logger.WithError(err).Error("watching run states")
 				continue
 			}
 
+			// a transition creates and deletes keys of the same run in one revision
+			updated := map[string]struct{}{}
 			for _, ev := range resp.Events {
-				kev := keyEvent{
-					Key: ev.Kv.Key,
+				keyParts := strings.Split(string(ev.Kv.Key), "/")
+				if len(keyParts) < 6 {
+					continue
 				}
 
-				if ev.IsCreate() {
-					kev.Type = keyCreated
+				if _, ok := updated[keyParts[3]]; ok {
+					continue
 				}
 
-				// todo(george): import issues with etcd returning old urls from types
-				// defined in new url scheme makes importing other etcd v3 libs to break
-				// until then I am just going to compare to the literal 1 value which means
-				// DELETE operation
-				if ev.Type == 1 {
-					kev.Type = keyDeleted
-				}
+				updated[keyParts[3]] = struct{}{}
 
-				r.handleKeyEvent(ctx, events, kev, filter, clientv3.WithRev(resp.Header.Revision))
+				select {
+				case events <- &adagio.Event{Type: adagio.Event_RUN_UPDATED, RunID: keyParts[3]}:
+				case <-ctx.Done():
+					return
+				}
 			}
 		}
 	}()
@@ -723,227 +1928,301 @@ func (r *Repository) Subscribe(ctx context.Context, a *adagio.Agent, events chan
 	return nil
 }
 
-type keyEvent struct {
-	Key  []byte
-	Type keyEventType
-}
+// UnsubscribeAll unsubscribes the provided agent and channel as a listener
+func (r *Repository) UnsubscribeAll(ctx context.Context, a *adagio.Agent, ch chan<- *adagio.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// delete agent key before the
+	_, err := r.kv.Delete(ctx, agentKey(a))
+	if err != nil {
+		return err
+	}
+
+	// release agent key lease when possible
+	r.cancelLease(a.Id)
+
+	if signal, ok := r.subscriptions[ch]; ok {
+		signal <- struct{}{}
+
+		delete(r.subscriptions, ch)
+	}
+
+	return nil
+}
+
+// Heartbeat replaces the record of a subscribed agent with the provided agent
+// retaining the lease of its subscription
+func (r *Repository) Heartbeat(ctx context.Context, a *adagio.Agent) error {
+	data, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+
+	key := agentKey(a)
+
+	resp, err := r.kv.Txn(ctx).
+		If(clientv3.Compare(clientv3.Version(key), ">", 0)).
+		Then(clientv3.OpPut(key, string(data), clientv3.WithIgnoreLease())).
+		Commit()
+	if err != nil {
+		return err
+	}
+
+	if !resp.Succeeded {
+		return fmt.Errorf("agent %q: %w", a.Id, adagio.ErrAgentDoesNotExist)
+	}
+
+	return nil
+}
+
+// CreateWebhook stores the provided webhook given no webhook exists with the same ID
+func (r *Repository) CreateWebhook(ctx context.Context, webhook *adagio.Webhook) error {
+	data, err := json.Marshal(webhook)
+	if err != nil {
+		return err
+	}
 
-type keyEventType int
+	key := webhookKey(webhook.Id)
 
-const (
-	keyUnknown keyEventType = iota
-	keyCreated
-	keyDeleted
-)
+	resp, err := r.kv.Txn(ctx).
+		If(clientv3.Compare(clientv3.Version(key), "=", 0)).
+		Then(clientv3.OpPut(key, string(data))).
+		Commit()
+	if err != nil {
+		return err
+	}
 
-type filter struct {
-	orphaned bool
-	ready    bool
+	if !resp.Succeeded {
+		return fmt.Errorf("webhook %q already exists", webhook.Id)
+	}
+
+	return nil
 }
 
-func (r *Repository) handleKeyEvent(ctx context.Context, dest chan<- *adagio.Event, ev keyEvent, filter filter, opts ...clientv3.OpOption) {
-	keyParts := strings.Split(string(ev.Key), "/")
-	if len(keyParts) < 6 {
-		return
+// InspectWebhook returns the webhook for the provided ID
+func (r *Repository) InspectWebhook(ctx context.Context, id string) (*adagio.Webhook, error) {
+	resp, err := r.kv.Get(ctx, webhookKey(id))
+	if err != nil {
+		return nil, err
 	}
 
-	status := stringToStatus(keyParts[1])
-	switch status {
-	// we're only interested in actions on ready and running keys
-	case adagio.Node_READY, adagio.Node_RUNNING:
-	default:
-		return
+	if len(resp.Kvs) < 1 {
+		return nil, fmt.Errorf("webhook %q: %w", id, adagio.ErrWebhookDoesNotExist)
 	}
 
-	run, err := r.getRun(ctx, keyParts[3], opts...)
-	if err != nil {
-		log.Println(keyParts[3], err)
-		return
+	var webhook adagio.Webhook
+	if err := json.Unmarshal(resp.Kvs[0].Value, &webhook); err != nil {
+		return nil, err
 	}
 
-	node, err := run.GetNodeByName(keyParts[5])
+	return &webhook, nil
+}
+
+// ListWebhooks returns the webhooks recorded within etcd ordered by ID
+func (r *Repository) ListWebhooks(ctx context.Context) (webhooks []*adagio.Webhook, err error) {
+	resp, err := r.kv.Get(ctx, webhooksPrefix, clientv3.WithPrefix(), clientv3.WithSort(clientv3.SortByKey, clientv3.SortAscend))
 	if err != nil {
-		log.Println(err)
-		return
+		return nil, err
 	}
 
-	switch ev.Type {
-	case keyCreated:
-		// if a ready status key has been created and the subscription contains a
-		// node ready type then send a node ready event
-		if status == adagio.Node_READY && !filter.ready {
-			dest <- &adagio.Event{
-				Type:     adagio.Event_NODE_READY,
-				RunID:    keyParts[3],
-				NodeSpec: node.Spec,
-			}
-		}
-	case keyDeleted:
-		// given the deletion of a running key where no other state key for the
-		// node exists (this is where GetNodeByName returns a node with a NONE status)
-		if status == adagio.Node_RUNNING && node.Status == adagio.Node_NONE && !filter.orphaned {
-			dest <- &adagio.Event{
-				Type:     adagio.Event_NODE_ORPHANED,
-				RunID:    keyParts[3],
-				NodeSpec: node.Spec,
-			}
+	for _, kv := range resp.Kvs {
+		var webhook adagio.Webhook
+		if err := json.Unmarshal(kv.Value, &webhook); err != nil {
+			return nil, err
 		}
+
+		webhooks = append(webhooks, &webhook)
 	}
 
 	return
 }
 
-func (r *Repository) getRun(ctx context.Context, id string, ops ...clientv3.OpOption) (*adagio.Run, error) {
-	run := &adagio.Run{Id: id}
-
-	resp, err := r.kv.Get(ctx, runKey(run), ops...)
+// UpdateWebhook replaces the recorded webhook with the provided webhook
+func (r *Repository) UpdateWebhook(ctx context.Context, webhook *adagio.Webhook) error {
+	data, err := json.Marshal(webhook)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	if len(resp.Kvs) < 1 {
-		return nil, errors.New("run not found")
-	}
+	key := webhookKey(webhook.Id)
 
-	// initially read out node configuration
-	if err := unmarshalRun(resp.Kvs[0].Value, run); err != nil {
-		return nil, err
+	resp, err := r.kv.Txn(ctx).
+		If(clientv3.Compare(clientv3.Version(key), ">", 0)).
+		Then(clientv3.OpPut(key, string(data))).
+		Commit()
+	if err != nil {
+		return err
 	}
 
-	// re-hydrate current node states
-	if err := r.nodesForRun(ctx, run, ops...); err != nil {
-		return nil, err
+	if !resp.Succeeded {
+		return fmt.Errorf("webhook %q: %w", webhook.Id, adagio.ErrWebhookDoesNotExist)
 	}
 
-	// check if all node states in order to derive run state
-	var (
-		runRunning   = false
-		runCompleted = true
-	)
+	return nil
+}
 
-	for _, node := range run.Nodes {
-		runRunning = runRunning || (node.Status > adagio.Node_WAITING)
-		runCompleted = runCompleted && (node.Status == adagio.Node_COMPLETED)
-	}
+// DeleteWebhook removes the webhook for the provided ID along with its deliveries
+func (r *Repository) DeleteWebhook(ctx context.Context, id string) error {
+	key := webhookKey(id)
 
-	if runRunning {
-		run.Status = adagio.Run_RUNNING
+	resp, err := r.kv.Txn(ctx).
+		If(clientv3.Compare(clientv3.Version(key), ">", 0)).
+		Then(clientv3.OpDelete(key), clientv3.OpDelete(allDeliveriesKey(id), clientv3.WithPrefix())).
+		Commit()
+	if err != nil {
+		return err
 	}
 
-	if runCompleted {
-		run.Status = adagio.Run_COMPLETED
+	if !resp.Succeeded {
+		return fmt.Errorf("webhook %q: %w", id, adagio.ErrWebhookDoesNotExist)
 	}
 
-	return run, nil
+	return nil
 }
 
-func (r *Repository) nodesForRun(ctx context.Context, run *adagio.Run, ops ...clientv3.OpOption) error {
-	// ensure all calls use with prefix
-	ops = append(ops, clientv3.WithPrefix())
+// CreateDelivery stores the provided delivery given the webhook exists and no
+// delivery of the same notification has been made to the webhook
+// It ensures a notification is delivered once when several control planes share the cluster
+func (r *Repository) CreateDelivery(ctx context.Context, delivery *adagio.Delivery) error {
+	data, err := json.Marshal(delivery)
+	if err != nil {
+		return err
+	}
 
 	var (
-		prefix    = allNodesKey(run)
-		nodes     = map[string]*adagio.Node{}
-		resp, err = r.kv.Get(ctx, prefix, ops...)
+		webhook = webhookKey(delivery.WebhookId)
+		key     = deliveryKey(delivery.WebhookId, delivery.Notification.Id)
 	)
 
+	resp, err := r.kv.Txn(ctx).
+		If(clientv3.Compare(clientv3.Version(webhook), ">", 0),
+			clientv3.Compare(clientv3.Version(key), "=", 0)).
+		Then(clientv3.OpPut(key, string(data))).
+		Else(clientv3.OpGet(webhook, clientv3.WithKeysOnly())).
+		Commit()
 	if err != nil {
 		return err
 	}
 
-	// given no options are supplied then enforce all nodes header revision
-	// is used for subsequent calls
-	// < 2 because we added with prefix
-	if len(ops) < 2 {
-		ops = append(ops, clientv3.WithRev(resp.Header.Revision))
+	if !resp.Succeeded {
+		if len(resp.Responses[0].GetResponseRange().Kvs) < 1 {
+			return fmt.Errorf("webhook %q: %w", delivery.WebhookId, adagio.ErrWebhookDoesNotExist)
+		}
+
+		return fmt.Errorf("notification %q: %w", delivery.Notification.Id, adagio.ErrDeliveryExists)
 	}
 
-	// create mapping for existing nodes in-order
-	// to replace with deserialized ones
-	for _, node := range run.Nodes {
-		nodes[node.Spec.Name] = node
+	return nil
+}
+
+// UpdateDelivery replaces the recorded delivery with the provided delivery
+func (r *Repository) UpdateDelivery(ctx context.Context, delivery *adagio.Delivery) error {
+	data, err := json.Marshal(delivery)
+	if err != nil {
+		return err
 	}
 
-	for _, kv := range resp.Kvs {
-		node := &adagio.Node{}
-		if err := json.Unmarshal(kv.Value, node); err != nil {
-			return err
-		}
+	key := deliveryKey(delivery.WebhookId, delivery.Notification.Id)
 
-		// check status key exists at store revision for each node
-		resp, err := r.kv.Get(ctx, nodeInStateKey(run.Id, statusToString(node.Status), node.Spec.Name), ops...)
-		if err != nil {
-			return err
-		}
+	resp, err := r.kv.Txn(ctx).
+		If(clientv3.Compare(clientv3.Version(key), ">", 0)).
+		Then(clientv3.OpPut(key, string(data))).
+		Commit()
+	if err != nil {
+		return err
+	}
 
-		if len(resp.Kvs) < 1 {
-			// node not found with expected status suggests node
-			// has been orphaned so we mark it with none status
-			node.Status = adagio.Node_NONE
-		}
+	if !resp.Succeeded {
+		return fmt.Errorf("notification %q: %w", delivery.Notification.Id, adagio.ErrDeliveryDoesNotExist)
+	}
 
-		if dst, ok := nodes[node.Spec.Name]; ok {
-			// swap existing node with new de-serialized version
-			*dst = *node
-		}
+	return nil
+}
+
+// ListDeliveries returns the deliveries made to the webhook for the provided ID
+// ordered by notification ID
+func (r *Repository) ListDeliveries(ctx context.Context, webhookID string) (deliveries []*adagio.Delivery, err error) {
+	resp, err := r.kv.Txn(ctx).
+		If(clientv3.Compare(clientv3.Version(webhookKey(webhookID)), ">", 0)).
+		Then(clientv3.OpGet(allDeliveriesKey(webhookID), clientv3.WithPrefix(), clientv3.WithSort(clientv3.SortByKey, clientv3.SortAscend))).
+		Commit()
+	if err != nil {
+		return nil, err
 	}
 
-	for _, node := range run.Nodes {
-		if err = r.setInputs(run, node); err != nil {
-			return err
+	if !resp.Succeeded {
+		return nil, fmt.Errorf("webhook %q: %w", webhookID, adagio.ErrWebhookDoesNotExist)
+	}
+
+	for _, kv := range resp.Responses[0].GetResponseRange().Kvs {
+		var delivery adagio.Delivery
+		if err := json.Unmarshal(kv.Value, &delivery); err != nil {
+			return nil, err
 		}
+
+		deliveries = append(deliveries, &delivery)
 	}
 
-	return nil
+	return
 }
 
-func (r *Repository) setInputs(run *adagio.Run, node *adagio.Node) error {
-	// for each incoming node fetch their outputs
-	incoming, err := adagio.GraphFrom(run).Incoming(node)
+// AppendAuditEvent appends the provided event to the audit log keyed by its ID
+// An event is never replaced once it has been appended
+func (r *Repository) AppendAuditEvent(ctx context.Context, event *adagio.AuditEvent) error {
+	data, err := json.Marshal(event)
 	if err != nil {
 		return err
 	}
 
-	for ini := range incoming {
-		in := ini.(*adagio.Node)
-
-		adagio.VisitLatestAttempt(in, func(result *adagio.Node_Result) {
-			if result.Conclusion != adagio.Node_Result_SUCCESS {
-				return
-			}
+	key := auditPrefix + event.Id
 
-			if node.Inputs == nil {
-				node.Inputs = map[string][]byte{}
-			}
+	resp, err := r.kv.Txn(ctx).
+		If(clientv3.Compare(clientv3.Version(key), "=", 0)).
+		Then(clientv3.OpPut(key, string(data))).
+		Commit()
+	if err != nil {
+		return err
+	}
 
-			node.Inputs[in.Spec.Name] = result.Output
-		})
+	if !resp.Succeeded {
+		return fmt.Errorf("audit event %q already exists", event.Id)
 	}
 
 	return nil
 }
 
-// UnsubscribeAll unsubscribes the provided agent and channel as a listener
-func (r *Repository) UnsubscribeAll(ctx context.Context, a *adagio.Agent, ch chan<- *adagio.Event) error {
-	r.mu.Lock()
-	defer r.mu.Unlock()
+// ListAuditEvents returns the events of the audit log from the most recently appended
+// given a set of provided predicates
+func (r *Repository) ListAuditEvents(ctx context.Context, req controlplane.AuditListRequest) (events []*adagio.AuditEvent, err error) {
+	opts := []clientv3.OpOption{clientv3.WithPrefix(), clientv3.WithSort(clientv3.SortByKey, clientv3.SortDescend)}
 
-	// delete agent key before the
-	_, err := r.kv.Delete(ctx, agentKey(a))
+	// events can only be limited by etcd when they are not filtered
+	if req.Limit != nil && req.RunID == "" {
+		opts = append(opts, clientv3.WithLimit(int64(*req.Limit)))
+	}
+
+	resp, err := r.kv.Get(ctx, auditPrefix, opts...)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// release agent key lease when possible
-	r.cancelLease(a.Id)
+	for _, kv := range resp.Kvs {
+		if req.Limit != nil && uint64(len(events)) >= *req.Limit {
+			break
+		}
 
-	if signal, ok := r.subscriptions[ch]; ok {
-		signal <- struct{}{}
+		var event adagio.AuditEvent
+		if err := json.Unmarshal(kv.Value, &event); err != nil {
+			return nil, err
+		}
 
-		delete(r.subscriptions, ch)
+		if req.RunID == "" || event.RunId == req.RunID {
+			events = append(events, &event)
+		}
 	}
 
-	return nil
+	return
 }
 
 func agentKey(agent *adagio.Agent) string {
@@ -962,6 +2241,46 @@ func nodeKey(runID, name string) string {
 	return fmt.Sprintf("%s%s/node/%s", nodesPrefix, runID, name)
 }
 
+func allChildrenKey(run *adagio.Run) string {
+	return fmt.Sprintf("%s%s/run/", childrenPrefix, run.Id)
+}
+
+func childKey(parentID, childID string) string {
+	return fmt.Sprintf("%s%s/run/%s", childrenPrefix, parentID, childID)
+}
+
+func allExpansionsKey(run *adagio.Run) string {
+	return fmt.Sprintf("%s%s/node/", expansionsPrefix, run.Id)
+}
+
+func expansionKey(runID, name string) string {
+	return fmt.Sprintf("%s%s/node/%s", expansionsPrefix, runID, name)
+}
+
+func logsKey(runID, name string, attempt int32) string {
+	return fmt.Sprintf("%s%s/node/%s/attempt/%d/", logsPrefix, runID, name, attempt)
+}
+
+func webhookKey(id string) string {
+	return webhooksPrefix + id
+}
+
+func allDeliveriesKey(webhookID string) string {
+	return fmt.Sprintf("%s%s/notification/", deliveriesPrefix, webhookID)
+}
+
+func deliveryKey(webhookID, notificationID string) string {
+	return allDeliveriesKey(webhookID) + notificationID
+}
+
+func runSlotsKey(runID string) string {
+	return fmt.Sprintf("%sruns/%s/slot/", limitsPrefix, runID)
+}
+
+func poolSlotsKey(pool string) string {
+	return fmt.Sprintf("%spools/%s/slot/", limitsPrefix, pool)
+}
+
 func nodesInStateKey(status adagio.Node_Status) string {
 	return statesPrefix + statusToString(status)
 }
@@ -971,9 +2290,13 @@ func nodeInStateKey(runID, state, name string) string {
 }
 
 type run struct {
-	CreatedAt time.Time           `json:"created_at"`
-	Specs     []*adagio.Node_Spec `json:"specs"`
-	Edges     []*adagio.Edge      `json:"edges"`
+	CreatedAt      time.Time           `json:"created_at"`
+	Specs          []*adagio.Node_Spec `json:"specs"`
+	Edges          []*adagio.Edge      `json:"edges"`
+	Parent         *adagio.Run_Link    `json:"parent,omitempty"`
+	MaxParallelism int32               `json:"max_parallelism,omitempty"`
+	Priority       int32               `json:"priority,omitempty"`
+	TraceContext   map[string]string   `json:"trace_context,omitempty"`
 }
 
 func unmarshalRun(data []byte, dst *adagio.Run) error {
@@ -984,6 +2307,10 @@ func unmarshalRun(data []byte, dst *adagio.Run) error {
 
 	dst.CreatedAt = run.CreatedAt.Format(time.RFC3339Nano)
 	dst.Edges = run.Edges
+	dst.Parent = run.Parent
+	dst.MaxParallelism = run.MaxParallelism
+	dst.Priority = run.Priority
+	dst.TraceContext = run.TraceContext
 
 	// create an initial specification with zeroed node state
 	// which will be replaced when nodes fetched and de-serialized
@@ -994,10 +2321,10 @@ func unmarshalRun(data []byte, dst *adagio.Run) error {
 	return nil
 }
 
-func marshalRun(createdAt string, spec []*adagio.Node_Spec, edges []*adagio.Edge) ([]byte, error) {
+func marshalRun(createdAt string, spec []*adagio.Node_Spec, edges []*adagio.Edge, parent *adagio.Run_Link, maxParallelism, priority int32, traceContext map[string]string) ([]byte, error) {
 	var (
 		createdAtT, err = time.Parse(time.RFC3339Nano, createdAt)
-		run             = run{createdAtT, spec, edges}
+		run             = run{createdAtT, spec, edges, parent, maxParallelism, priority, traceContext}
 	)
 	if err != nil {
 		return nil, err
@@ -1006,6 +2333,36 @@ func marshalRun(createdAt string, spec []*adagio.Node_Spec, edges []*adagio.Edge
 	return json.Marshal(&run)
 }
 
+type expansion struct {
+	Specs []*adagio.Node_Spec `json:"specs"`
+	Edges []*adagio.Edge      `json:"edges"`
+}
+
+func unmarshalExpansion(data []byte, dst *adagio.Run) error {
+	var expansion expansion
+	if err := json.Unmarshal(data, &expansion); err != nil {
+		return err
+	}
+
+	dst.Edges = append(dst.Edges, expansion.Edges...)
+
+	// instances are hydrated along with the rest of the runs nodes
+	for _, spec := range expansion.Specs {
+		dst.Nodes = append(dst.Nodes, &adagio.Node{Spec: spec})
+	}
+
+	return nil
+}
+
+func marshalExpansion(exp *adagio.Expansion) ([]byte, error) {
+	expansion := expansion{Edges: exp.Edges}
+	for _, node := range exp.Nodes {
+		expansion.Specs = append(expansion.Specs, node.Spec)
+	}
+
+	return json.Marshal(&expansion)
+}
+
 func statusToString(status adagio.Node_Status) string {
 	return strings.ToLower(status.String())
 }
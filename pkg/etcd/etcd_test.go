@@ -32,7 +32,7 @@ func Test_Run_RepositoryTestHarness(t *testing.T) {
 	}()
 
 	var (
-		repo     = New(cli.KV, cli.Watcher, cli.Lease, WithNamespace("adagio-test/"))
+		repo     = New(cli.KV, cli.Watcher, cli.Lease, WithNamespace("adagio-test/"), WithResourcePools(repository.ExampleResourcePools))
 		orphaner = repository.OrphanFunc(func(c *adagio.Claim) {
 			repo.cancelLease(c.Id)
 		})
@@ -1,5 +1,11 @@
 package etcd
 
+import (
+	"github.com/georgemac/adagio/pkg/adagio"
+	"github.com/georgemac/adagio/pkg/logging"
+	"github.com/georgemac/adagio/pkg/metrics"
+)
+
 // Option is a functional option for repository
 type Option func(*Repository)
 
@@ -28,3 +34,28 @@ func WithNamespace(ns string) Option {
 		r.namespace = ns
 	}
 }
+
+// WithResourcePools configures the named global resource pools from which
+// nodes can require slots. Every repository sharing the same etcd cluster
+// and list should be configured with the same pools
+func WithResourcePools(pools adagio.ResourcePools) Option {
+	return func(r *Repository) {
+		r.pools = pools
+	}
+}
+
+// WithMetrics configures the metrics on which the runs started and completed,
+// the nodes retried and the transaction conflicts encountered are recorded
+func WithMetrics(m *metrics.Metrics) Option {
+	return func(r *Repository) {
+		r.metrics = m
+	}
+}
+
+// WithLogger configures the logger on which errors watching and leasing
+// keys are logged (defaults to info level logfmt on stderr)
+func WithLogger(logger logging.Logger) Option {
+	return func(r *Repository) {
+		r.logger = logger
+	}
+}
@@ -0,0 +1,63 @@
+package etcd
+
+import (
+	"context"
+
+	"github.com/georgemac/adagio/pkg/tracing"
+	"go.etcd.io/etcd/clientv3"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracedKV is a clientv3.KV which records a span for each committed transaction
+type tracedKV struct {
+	clientv3.KV
+}
+
+func (kv tracedKV) Txn(ctx context.Context) clientv3.Txn {
+	return &tracedTxn{Txn: kv.KV.Txn(ctx), ctx: ctx}
+}
+
+type tracedTxn struct {
+	clientv3.Txn
+	ctx context.Context
+
+	cmps, thens, elses int
+}
+
+func (t *tracedTxn) If(cs ...clientv3.Cmp) clientv3.Txn {
+	t.cmps += len(cs)
+	t.Txn = t.Txn.If(cs...)
+	return t
+}
+
+func (t *tracedTxn) Then(ops ...clientv3.Op) clientv3.Txn {
+	t.thens += len(ops)
+	t.Txn = t.Txn.Then(ops...)
+	return t
+}
+
+func (t *tracedTxn) Else(ops ...clientv3.Op) clientv3.Txn {
+	t.elses += len(ops)
+	t.Txn = t.Txn.Else(ops...)
+	return t
+}
+
+func (t *tracedTxn) Commit() (resp *clientv3.TxnResponse, err error) {
+	_, span := tracing.Start(t.ctx, "etcd.Txn",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "etcd"),
+			attribute.Int("etcd.txn.compares", t.cmps),
+			attribute.Int("etcd.txn.ops", t.thens+t.elses),
+		))
+	defer func() {
+		if resp != nil {
+			span.SetAttributes(attribute.Bool("etcd.txn.succeeded", resp.Succeeded))
+		}
+
+		tracing.End(span, err)
+	}()
+
+	return t.Txn.Commit()
+}
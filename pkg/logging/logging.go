@@ -0,0 +1,67 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Field keys used consistently across adagio log messages
+const (
+	RunIDKey     = "run_id"
+	NodeKey      = "node"
+	AgentIDKey   = "agent_id"
+	ClaimIDKey   = "claim_id"
+	WebhookIDKey = "webhook_id"
+	NamespaceKey = "namespace"
+)
+
+// Logger is the structured and levelled logger injected into the
+// agent pool, repositories and control plane
+type Logger = logrus.FieldLogger
+
+// Fields is a set of fields attached to a log message
+type Fields = logrus.Fields
+
+// New constructs a logger which writes messages at or above the named level
+// ("debug"|"info"|"warn"|"error") to w in the named format ("logfmt"|"json")
+func New(w io.Writer, format, level string) (Logger, error) {
+	lvl, err := logrus.ParseLevel(level)
+	if err != nil {
+		return nil, fmt.Errorf("logging: %w", err)
+	}
+
+	logger := logrus.New()
+	logger.SetOutput(w)
+	logger.SetLevel(lvl)
+
+	switch format {
+	case "logfmt":
+		logger.SetFormatter(&logrus.TextFormatter{DisableColors: true, FullTimestamp: true})
+	case "json":
+		logger.SetFormatter(&logrus.JSONFormatter{})
+	default:
+		return nil, fmt.Errorf("logging: unexpected format %q expected one of [logfmt|json]", format)
+	}
+
+	return logger, nil
+}
+
+// Default returns the logger used when none is configured which writes
+// messages at info level and above to stderr in logfmt
+func Default() Logger {
+	logger := logrus.New()
+	logger.SetFormatter(&logrus.TextFormatter{DisableColors: true, FullTimestamp: true})
+
+	return logger
+}
+
+// Discard returns a logger which drops every message
+func Discard() Logger {
+	logger := logrus.New()
+	logger.SetOutput(ioutil.Discard)
+
+	return logger
+}
@@ -0,0 +1,51 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_New(t *testing.T) {
+	t.Run("json messages at or above the level are written with their fields", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+
+		logger, err := New(buf, "json", "info")
+		require.Nil(t, err)
+
+		logger.WithField(RunIDKey, "run").Debug("dropped")
+		logger.WithFields(Fields{RunIDKey: "run", NodeKey: "foo"}).Info("written")
+
+		var message map[string]interface{}
+		require.Nil(t, json.Unmarshal(buf.Bytes(), &message))
+
+		assert.Equal(t, "written", message["msg"])
+		assert.Equal(t, "info", message["level"])
+		assert.Equal(t, "run", message[RunIDKey])
+		assert.Equal(t, "foo", message[NodeKey])
+	})
+
+	t.Run("logfmt messages", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+
+		logger, err := New(buf, "logfmt", "debug")
+		require.Nil(t, err)
+
+		logger.WithField(ClaimIDKey, "claim").Debug("written")
+
+		assert.Contains(t, buf.String(), "level=debug msg=written claim_id=claim")
+	})
+
+	t.Run("unexpected level", func(t *testing.T) {
+		_, err := New(&bytes.Buffer{}, "json", "loud")
+		assert.NotNil(t, err)
+	})
+
+	t.Run("unexpected format", func(t *testing.T) {
+		_, err := New(&bytes.Buffer{}, "xml", "info")
+		assert.NotNil(t, err)
+	})
+}
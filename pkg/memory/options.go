@@ -0,0 +1,44 @@
+package memory
+
+import (
+	"github.com/georgemac/adagio/pkg/adagio"
+	"github.com/georgemac/adagio/pkg/logging"
+	"github.com/georgemac/adagio/pkg/metrics"
+)
+
+// Option is a functional option for the in-memory repository
+type Option func(*Repository)
+
+// Options is a slice of Option
+type Options []Option
+
+// Apply calls each option on r in turn
+func (o Options) Apply(r *Repository) {
+	for _, opt := range o {
+		opt(r)
+	}
+}
+
+// WithResourcePools configures the named global resource pools
+// from which nodes can require slots
+func WithResourcePools(pools adagio.ResourcePools) Option {
+	return func(r *Repository) {
+		r.pools = pools
+	}
+}
+
+// WithMetrics configures the metrics on which the runs started
+// and completed and the nodes retried are recorded
+func WithMetrics(m *metrics.Metrics) Option {
+	return func(r *Repository) {
+		r.metrics = m
+	}
+}
+
+// WithLogger configures the logger on which the runs started and the
+// nodes claimed and finished are logged at debug level
+func WithLogger(logger logging.Logger) Option {
+	return func(r *Repository) {
+		r.logger = logger
+	}
+}
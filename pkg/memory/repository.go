@@ -2,6 +2,7 @@ package memory
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"sort"
@@ -11,7 +12,11 @@ import (
 	"github.com/georgemac/adagio/pkg/adagio"
 	"github.com/georgemac/adagio/pkg/agent"
 	"github.com/georgemac/adagio/pkg/graph"
+	"github.com/georgemac/adagio/pkg/logging"
+	"github.com/georgemac/adagio/pkg/metrics"
 	"github.com/georgemac/adagio/pkg/service/controlplane"
+	"github.com/georgemac/adagio/pkg/tracing"
+	"github.com/golang/protobuf/proto"
 )
 
 var (
@@ -21,7 +26,17 @@ var (
 )
 
 type (
-	listenerSet map[adagio.Event_Type][]chan<- *adagio.Event
+	listenerSet map[adagio.Event_Type][]listener
+
+	listener struct {
+		agent  *adagio.Agent
+		events chan<- *adagio.Event
+	}
+
+	watcher struct {
+		ctx    context.Context
+		events chan<- *adagio.Event
+	}
 
 	runState struct {
 		run    *adagio.Run
@@ -34,29 +49,51 @@ type (
 // It adheres to the repository test harness
 type Repository struct {
 	agents map[string]*adagio.Agent
-	runs   map[string]runState
+	runs   map[string]*runState
 	claims map[string]struct {
 		run  *adagio.Run
 		node *adagio.Node
 	}
+	logs map[string][]*adagio.LogLine
+
+	webhooks map[string]*adagio.Webhook
+	// deliveries keyed by webhook ID and then notification ID
+	deliveries map[string]map[string]*adagio.Delivery
+
+	audit []*adagio.AuditEvent
 
 	listeners listenerSet
+	watchers  []watcher
 	mu        sync.Mutex
 
-	now func() time.Time
+	pools   adagio.ResourcePools
+	metrics *metrics.Metrics
+	logger  logging.Logger
+	now     func() time.Time
 }
 
 // New constructs and configures a new in memory repository
-func New() *Repository {
-	return &Repository{
+func New(opts ...Option) *Repository {
+	r := &Repository{
 		agents: map[string]*adagio.Agent{},
-		runs:   map[string]runState{},
+		runs:   map[string]*runState{},
 		claims: map[string]struct {
 			run  *adagio.Run
 			node *adagio.Node
 		}{},
-		listeners: listenerSet{},
+		logs:       map[string][]*adagio.LogLine{},
+		webhooks:   map[string]*adagio.Webhook{},
+		deliveries: map[string]map[string]*adagio.Delivery{},
+		listeners:  listenerSet{},
+		pools:      adagio.ResourcePools{},
+		metrics:    metrics.New(),
+		logger:     logging.Default(),
+		now:        time.Now,
 	}
+
+	Options(opts).Apply(r)
+
+	return r
 }
 
 // Stats returns counts of runs and nodes in their respective states
@@ -64,7 +101,15 @@ func (r *Repository) Stats(context.Context) (*adagio.Stats, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	nodeCounts := &adagio.Stats_NodeCounts{}
+	var (
+		nodeCounts          = &adagio.Stats_NodeCounts{}
+		unschedulableCounts = map[string]int64{}
+		agents              = make([]*adagio.Agent, 0, len(r.agents))
+	)
+
+	for _, agent := range r.agents {
+		agents = append(agents, agent)
+	}
 
 	for _, runState := range r.runs {
 		for _, node := range runState.lookup {
@@ -73,31 +118,59 @@ func (r *Repository) Stats(context.Context) (*adagio.Stats, error) {
 				nodeCounts.WaitingCount++
 			case adagio.Node_READY:
 				nodeCounts.ReadyCount++
+
+				if adagio.Unschedulable(node, agents) {
+					unschedulableCounts[node.Spec.Runtime]++
+				}
 			case adagio.Node_RUNNING:
 				nodeCounts.RunningCount++
 			case adagio.Node_COMPLETED:
 				nodeCounts.CompletedCount++
+			case adagio.Node_SKIPPED:
+				nodeCounts.SkippedCount++
+			case adagio.Node_SCHEDULED:
+				nodeCounts.ScheduledCount++
 			}
 		}
 	}
 
 	return &adagio.Stats{
-		RunCount:   int64(len(r.runs)),
-		NodeCounts: nodeCounts,
+		RunCount:            int64(len(r.runs)),
+		NodeCounts:          nodeCounts,
+		UnschedulableCounts: unschedulableCounts,
 	}, nil
 }
 
 // StartRun instantiates a run from a provided graph specification
-func (r *Repository) StartRun(_ context.Context, spec *adagio.GraphSpec) (run *adagio.Run, err error) {
+func (r *Repository) StartRun(ctx context.Context, spec *adagio.GraphSpec, opts ...adagio.RunOption) (run *adagio.Run, err error) {
+	ctx, span := tracing.Start(ctx, "memory.StartRun")
+	defer func() { tracing.End(span, err) }()
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	run, err = adagio.NewRun(spec)
+	run, err = adagio.NewRun(spec, opts...)
 	if err != nil {
 		return
 	}
 
-	state := runState{
+	span.SetAttributes(tracing.RunIDKey.String(run.Id))
+
+	if err = r.pools.Validate(run); err != nil {
+		return nil, err
+	}
+
+	if parent := run.Parent; parent != nil {
+		// link the new run as a child of its parent run
+		if state, ok := r.runs[parent.RunId]; ok {
+			state.run.Children = append(state.run.Children, &adagio.Run_Link{
+				RunId: run.Id,
+				Node:  parent.Node,
+			})
+		}
+	}
+
+	state := &runState{
 		run:    run,
 		lookup: map[string]*adagio.Node{},
 		graph:  adagio.GraphFrom(run),
@@ -105,14 +178,22 @@ func (r *Repository) StartRun(_ context.Context, spec *adagio.GraphSpec) (run *a
 
 	r.runs[run.Id] = state
 
+	tracing.StartRun(ctx, run)
+
+	r.metrics.RunStarted()
+
+	r.logger.WithField(logging.RunIDKey, run.Id).Debug("run started")
+
 	for _, node := range run.Nodes {
 		state.lookup[node.Spec.Name] = node
 
 		if node.Status == adagio.Node_READY {
-			r.notifyReady(run, node)
+			r.ready(run, node)
 		}
 	}
 
+	r.updated(run)
+
 	return
 }
 
@@ -136,7 +217,7 @@ func (r *Repository) InspectRun(_ context.Context, id string) (*adagio.Run, erro
 
 	for _, node := range run.Nodes {
 		runRunning = runRunning || (node.Status > adagio.Node_WAITING)
-		runCompleted = runCompleted && (node.Status == adagio.Node_COMPLETED)
+		runCompleted = runCompleted && adagio.IsResolved(node)
 	}
 
 	if runRunning {
@@ -147,7 +228,8 @@ func (r *Repository) InspectRun(_ context.Context, id string) (*adagio.Run, erro
 		run.Status = adagio.Run_COMPLETED
 	}
 
-	return run, nil
+	// the run continues to be updated once returned
+	return proto.Clone(run).(*adagio.Run), nil
 }
 
 // ListAgents returns a set of subscribed agents
@@ -179,7 +261,7 @@ func (r *Repository) ListRuns(_ context.Context, req controlplane.ListRequest) (
 		return runs[i].Id > runs[j].Id
 	})
 
-	if req.Start != nil || req.Finish != nil {
+	if len(runs) > 0 && (req.Start != nil || req.Finish != nil) {
 		var (
 			min            int
 			max            = len(runs)
@@ -234,7 +316,13 @@ func (r *Repository) ListRuns(_ context.Context, req controlplane.ListRequest) (
 }
 
 // ClaimNode attempts to make a claim for a node
-func (r *Repository) ClaimNode(_ context.Context, runID, name string, claim *adagio.Claim) (*adagio.Node, bool, error) {
+func (r *Repository) ClaimNode(ctx context.Context, runID, name string, claim *adagio.Claim) (_ *adagio.Node, claimed bool, err error) {
+	_, span := tracing.StartNode(ctx, "memory.ClaimNode", runID, name)
+	defer func() {
+		span.SetAttributes(tracing.ClaimedKey.Bool(claimed))
+		tracing.End(span, err)
+	}()
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -252,36 +340,187 @@ func (r *Repository) ClaimNode(_ context.Context, runID, name string, claim *ada
 		return nil, false, fmt.Errorf("in-memory repository: node %q: %w", name, adagio.ErrNodeNotReady)
 	}
 
+	if adagio.IsApproval(node) {
+		return nil, false, fmt.Errorf("in-memory repository: node %q: %w", name, adagio.ErrNodeRequiresApproval)
+	}
+
+	// scheduled nodes are claimed once they have been promoted to ready
+	if node.Status == adagio.Node_SCHEDULED {
+		if err := adagio.CheckSchedule(node, r.now()); err != nil {
+			return nil, false, fmt.Errorf("in-memory repository: node %q: %w", name, err)
+		}
+
+		return nil, false, nil
+	}
+
 	// node already claimed
 	if node.Status > adagio.Node_READY {
 		return nil, false, nil
 	}
 
+	if err := r.checkLimits(state, node); err != nil {
+		return nil, false, fmt.Errorf("in-memory repository: node %q: %w", name, err)
+	}
+
+	now := r.now()
+
+	// the node was orphaned by the agent which held the previous claim
+	if node.Status == adagio.Node_NONE {
+		adagio.RecordTransition(node, adagio.Node_Transition_ORPHANED, now, node.Claim)
+	}
+
 	// update node state to running
 	node.Status = adagio.Node_RUNNING
-	node.StartedAt = r.now().Format(time.RFC3339Nano)
 	node.Claim = claim
 
+	adagio.RecordTransition(node, adagio.Node_Transition_CLAIMED, now, claim)
+
+	// rescheduled nodes retain the time at which they were first started
+	if node.NotBefore == "" || node.StartedAt == "" {
+		node.StartedAt = now.Format(time.RFC3339Nano)
+	}
+
+	node.NotBefore = ""
+
+	// progress is reported afresh for each claim
+	node.Progress = nil
+
 	r.claims[claim.Id] = struct {
 		run  *adagio.Run
 		node *adagio.Node
 	}{state.run, node}
 
+	r.updated(state.run)
+
+	r.logger.WithFields(logging.Fields{
+		logging.RunIDKey:   runID,
+		logging.NodeKey:    name,
+		logging.ClaimIDKey: claim.Id,
+	}).Debug("node claimed")
+
 	return node, true, nil
 }
 
-func (r *Repository) notifyReady(run *adagio.Run, node *adagio.Node) {
-	for _, ch := range r.listeners[adagio.Event_NODE_READY] {
+// checkLimits ensures claiming the node would not exceed the max parallelism
+// of its run or the slots of any of the resource pools it requires
+func (r *Repository) checkLimits(state *runState, node *adagio.Node) error {
+	if err := adagio.CheckParallelism(state.run); err != nil {
+		return err
+	}
+
+	if len(node.Spec.Resources) == 0 {
+		return nil
+	}
+
+	runs := make([]*adagio.Run, 0, len(r.runs))
+	for _, state := range r.runs {
+		runs = append(runs, state.run)
+	}
+
+	return r.pools.Acquire(node.Spec, adagio.ResourcesUsed(runs...))
+}
+
+// ready transitions the node into the ready state, records the time at which
+// it became ready and notifies listeners.
+// Approval nodes are not announced, instead the time at which they began
+// awaiting approval is recorded
+func (r *Repository) ready(run *adagio.Run, node *adagio.Node) {
+	at := r.now()
+	now := at.Format(time.RFC3339Nano)
+
+	node.Status = adagio.Node_READY
+	node.ReadyAt = now
+
+	adagio.RecordTransition(node, adagio.Node_Transition_READY, at, nil)
+
+	if adagio.IsApproval(node) {
+		node.StartedAt = now
+		return
+	}
+
+	r.notify(&adagio.Event{
+		RunID:        run.Id,
+		NodeSpec:     node.Spec,
+		Type:         adagio.Event_NODE_READY,
+		Priority:     adagio.Priority(run, node),
+		TraceContext: run.TraceContext,
+	})
+}
+
+// schedule transitions the node into the scheduled state until the time it is not
+// to be claimed before, at which point it is promoted to ready. Given that time
+// has already passed the node is made ready straight away
+func (r *Repository) schedule(run *adagio.Run, node *adagio.Node) {
+	now := r.now()
+
+	var scheduled *adagio.ScheduledError
+	if !errors.As(adagio.CheckSchedule(node, now), &scheduled) {
+		r.ready(run, node)
+		return
+	}
+
+	node.Status = adagio.Node_SCHEDULED
+
+	time.AfterFunc(scheduled.NotBefore.Sub(now), func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		r.promote(run, node)
+	})
+}
+
+// promote makes a scheduled node ready given the time it is not
+// to be claimed before has passed
+func (r *Repository) promote(run *adagio.Run, node *adagio.Node) {
+	if node.Status != adagio.Node_SCHEDULED || adagio.CheckSchedule(node, r.now()) != nil {
+		return
+	}
+
+	r.ready(run, node)
+
+	r.updated(run)
+}
+
+// notify sends the event to each listener for the events type
+// whose agent supports the runtime of the events node
+func (r *Repository) notify(event *adagio.Event) {
+	for _, l := range r.listeners[event.Type] {
+		if !adagio.SupportsRuntime(l.agent, event.NodeSpec.Runtime) {
+			continue
+		}
+
 		select {
-		case ch <- &adagio.Event{RunID: run.Id, NodeSpec: node.Spec, Type: adagio.Event_NODE_READY}:
+		case l.events <- event:
 			// attempt to send
 		default:
 		}
 	}
 }
 
+// updated sends a run updated event for the run to each watcher. Unlike the events
+// of listeners they are not dropped, as a watcher acts on the latest state of the run
+// whichever order they are received in
+func (r *Repository) updated(run *adagio.Run) {
+	event := &adagio.Event{
+		RunID: run.Id,
+		Type:  adagio.Event_RUN_UPDATED,
+	}
+
+	for _, w := range r.watchers {
+		go func(w watcher) {
+			select {
+			case w.events <- event:
+			case <-w.ctx.Done():
+			}
+		}(w)
+	}
+}
+
 // FinishNode reports the result of a node run and readies any eligible outgoing nodes
-func (r *Repository) FinishNode(_ context.Context, runID, name string, result *adagio.Node_Result, claim *adagio.Claim) error {
+func (r *Repository) FinishNode(ctx context.Context, runID, name string, result *adagio.Node_Result, claim *adagio.Claim) (err error) {
+	_, span := tracing.StartNode(ctx, "memory.FinishNode", runID, name)
+	defer func() { tracing.End(span, err) }()
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -295,25 +534,276 @@ func (r *Repository) FinishNode(_ context.Context, runID, name string, result *a
 		return err
 	}
 
+	delete(r.claims, claim.Id)
+
+	// the run may have been cancelled while the node was running
+	if node.Status != adagio.Node_RUNNING {
+		return fmt.Errorf("in-memory repository: node %q: attempt to finish non-running node", name)
+	}
+
+	r.logger.WithFields(logging.Fields{
+		logging.RunIDKey:   runID,
+		logging.NodeKey:    name,
+		logging.ClaimIDKey: claim.Id,
+	}).Debug("node finished")
+
+	return r.finish(state, node, result)
+}
+
+// RescheduleNode releases the claim on a running node and returns it to the ready state
+// without recording an attempt. The node cannot be claimed again until notBefore
+func (r *Repository) RescheduleNode(_ context.Context, runID, name string, notBefore time.Time, claim *adagio.Claim) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	state, err := r.state(runID)
+	if err != nil {
+		return err
+	}
+
+	node, err := node(state, name)
+	if err != nil {
+		return err
+	}
+
+	if node.Status != adagio.Node_RUNNING {
+		return fmt.Errorf("in-memory repository: node %q: attempt to reschedule non-running node", name)
+	}
+
+	delete(r.claims, claim.Id)
+
+	node.NotBefore = notBefore.Format(time.RFC3339Nano)
+
+	adagio.RecordTransition(node, adagio.Node_Transition_RESCHEDULED, r.now(), claim)
+
+	r.schedule(state.run, node)
+
+	r.updated(state.run)
+
+	return nil
+}
+
+// ReportProgress records the progress of a node running under the provided claim
+func (r *Repository) ReportProgress(_ context.Context, runID, name string, progress *adagio.Node_Progress, claim *adagio.Claim) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	state, err := r.state(runID)
+	if err != nil {
+		return err
+	}
+
+	node, err := node(state, name)
+	if err != nil {
+		return err
+	}
+
+	if node.Status != adagio.Node_RUNNING || node.Claim.GetId() != claim.Id {
+		return fmt.Errorf("in-memory repository: node %q: %w", name, adagio.ErrClaimNotHeld)
+	}
+
+	node.Progress = progress
+
+	return nil
+}
+
+// AppendLogs appends lines to the logs of an attempt of a node
+func (r *Repository) AppendLogs(_ context.Context, runID, name string, attempt int32, lines []*adagio.LogLine) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	state, err := r.state(runID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := node(state, name); err != nil {
+		return err
+	}
+
+	key := logsKey(runID, name, attempt)
+
+	r.logs[key] = append(r.logs[key], lines...)
+
+	return nil
+}
+
+// ReadLogs returns the lines of the logs of an attempt of a node from the provided offset
+func (r *Repository) ReadLogs(_ context.Context, runID, name string, attempt int32, offset int) ([]*adagio.LogLine, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	state, err := r.state(runID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := node(state, name); err != nil {
+		return nil, err
+	}
+
+	lines := r.logs[logsKey(runID, name, attempt)]
+	if offset >= len(lines) {
+		return nil, nil
+	}
+
+	return append([]*adagio.LogLine(nil), lines[offset:]...), nil
+}
+
+// ResolveApproval completes a ready approval node with the provided result.
+// Given the approval has expired the node is failed and ErrApprovalExpired is returned
+func (r *Repository) ResolveApproval(_ context.Context, runID, name string, result *adagio.Node_Result) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	state, err := r.state(runID)
+	if err != nil {
+		return err
+	}
+
+	node, err := node(state, name)
+	if err != nil {
+		return err
+	}
+
+	if !adagio.AwaitingApproval(node) {
+		return fmt.Errorf("in-memory repository: node %q: %w", name, adagio.ErrNodeNotAwaitingApproval)
+	}
+
+	if adagio.ApprovalExpired(node, r.now()) {
+		if err := r.finish(state, node, adagio.ApprovalExpiredResult()); err != nil {
+			return err
+		}
+
+		return fmt.Errorf("in-memory repository: node %q: %w", name, adagio.ErrApprovalExpired)
+	}
+
+	return r.finish(state, node, result)
+}
+
+// ExpireApprovals fails all the approval nodes whose approval has expired
+func (r *Repository) ExpireApprovals(context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	for _, state := range r.runs {
+		for _, node := range state.run.Nodes {
+			if !adagio.ApprovalExpired(node, now) {
+				continue
+			}
+
+			if err := r.finish(state, node, adagio.ApprovalExpiredResult()); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// CancelRun completes every unresolved node of the run identified by id with an
+// errored result so that none of them are claimed. The child runs of the run are
+// cancelled in turn
+func (r *Repository) CancelRun(_ context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.cancel(id)
+}
+
+func (r *Repository) cancel(id string) error {
+	state, err := r.state(id)
+	if err != nil {
+		return err
+	}
+
+	var (
+		now       = r.now()
+		cancelled []*adagio.Node
+	)
+
+	for _, node := range state.run.Nodes {
+		if adagio.IsResolved(node) {
+			continue
+		}
+
+		if node.Status == adagio.Node_RUNNING {
+			delete(r.claims, node.Claim.GetId())
+		}
+
+		node.Status = adagio.Node_COMPLETED
+		node.FinishedAt = now.Format(time.RFC3339Nano)
+		node.Attempts = append(node.Attempts, adagio.CancelledResult())
+
+		adagio.RecordTransition(node, adagio.Node_Transition_FINISHED, now, node.Claim)
+
+		cancelled = append(cancelled, node)
+	}
+
+	if len(cancelled) > 0 {
+		r.metrics.NodeFinished(state.run, cancelled...)
+
+		r.logger.WithField(logging.RunIDKey, id).Debug("run cancelled")
+
+		r.updated(state.run)
+	}
+
+	for _, child := range state.run.Children {
+		if err := r.cancel(child.RunId); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PromoteScheduled makes ready all the scheduled nodes whose delay has passed
+func (r *Repository) PromoteScheduled(context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, state := range r.runs {
+		for _, node := range state.run.Nodes {
+			r.promote(state.run, node)
+		}
+	}
+
+	return nil
+}
+
+func (r *Repository) finish(state *runState, node *adagio.Node, result *adagio.Node_Result) error {
+	now := r.now()
+
 	node.Status = adagio.Node_COMPLETED
-	node.FinishedAt = r.now().Format(time.RFC3339Nano)
+	node.FinishedAt = now.Format(time.RFC3339Nano)
 	node.Attempts = append(node.Attempts, result)
 
+	adagio.RecordTransition(node, adagio.Node_Transition_FINISHED, now, node.Claim)
+
 	outgoing, err := state.graph.Outgoing(node)
 	if err != nil {
 		return fmt.Errorf("finishing node %q: %w", node, err)
 	}
 
-	delete(r.claims, claim.Id)
-
 	if result.Conclusion == adagio.Node_Result_SUCCESS {
-		return r.handleSuccess(state, node, outgoing, result)
+		err = r.handleSuccess(state, node, outgoing, result)
+	} else {
+		err = r.handleFailure(state, node, outgoing, result)
 	}
 
-	return r.handleFailure(state, node, outgoing, result)
+	if err != nil {
+		return err
+	}
+
+	r.metrics.NodeFinished(state.run, node)
+
+	r.updated(state.run)
+
+	return nil
 }
 
-func (r *Repository) handleSuccess(state runState, node *adagio.Node, outgoing map[graph.Node]struct{}, result *adagio.Node_Result) error {
+func (r *Repository) handleSuccess(state *runState, node *adagio.Node, outgoing map[graph.Node]struct{}, result *adagio.Node_Result) error {
 	for outi := range outgoing {
 		out := outi.(*adagio.Node)
 
@@ -323,6 +813,17 @@ func (r *Repository) handleSuccess(state runState, node *adagio.Node, outgoing m
 		}
 
 		out.Inputs[node.Spec.Name] = result.Output
+	}
+
+	return r.resolve(state, outgoing)
+}
+
+// resolve progresses each of the waiting nodes whose incoming nodes are all
+// resolved into the ready state, given any of their incoming edges are satisfied,
+// or otherwise into the skipped state
+func (r *Repository) resolve(state *runState, nodes map[graph.Node]struct{}) error {
+	for outi := range nodes {
+		out := outi.(*adagio.Node)
 
 		if out.Status > adagio.Node_WAITING {
 			// do not bother to manipulate outgoing nodes which are not waiting
@@ -331,45 +832,145 @@ func (r *Repository) handleSuccess(state runState, node *adagio.Node, outgoing m
 
 		incoming, err := state.graph.Incoming(out)
 		if err != nil {
-			return fmt.Errorf("finishing node %q: %w", node, err)
+			return fmt.Errorf("resolving node %q: %w", out, err)
 		}
 
-		// given all the incoming nodes into "out" are now completed
-		// then the waiting out node can be progressed to ready
-		ready := true
+		// given all the incoming nodes into "out" are now resolved
+		// then the waiting out node can be progressed
+		resolved := true
 		for in := range incoming {
-			ready = ready && in.(*adagio.Node).Status == adagio.Node_COMPLETED
+			resolved = resolved && adagio.IsResolved(in.(*adagio.Node))
+		}
+
+		if !resolved {
+			continue
 		}
 
-		if ready {
-			out.Status = adagio.Node_READY
+		if !adagio.IncomingSatisfied(state.run, out) {
+			if err := r.skip(state, out); err != nil {
+				return err
+			}
 
-			r.notifyReady(state.run, out)
+			continue
 		}
+
+		if adagio.IsMap(out) {
+			if err := r.expand(state, out); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		r.ready(state.run, out)
 	}
 
 	return nil
 }
 
-func (r *Repository) handleFailure(state runState, node *adagio.Node, src map[graph.Node]struct{}, result *adagio.Node_Result) error {
+// skip skips the node and resolves its outgoing nodes
+func (r *Repository) skip(state *runState, node *adagio.Node) error {
+	at := r.now()
+	now := at.Format(time.RFC3339Nano)
+
+	node.Status = adagio.Node_SKIPPED
+	node.StartedAt = now
+	node.FinishedAt = now
+
+	adagio.RecordTransition(node, adagio.Node_Transition_SKIPPED, at, nil)
+
+	outgoing, err := state.graph.Outgoing(node)
+	if err != nil {
+		return fmt.Errorf("skipping node %q: %w", node, err)
+	}
+
+	return r.resolve(state, outgoing)
+}
+
+// expand expands a ready map node into its instances and completes the map node
+func (r *Repository) expand(state *runState, node *adagio.Node) error {
+	var (
+		expansion = adagio.Expand(state.run, node)
+		at        = r.now()
+		now       = at.Format(time.RFC3339Nano)
+	)
+
+	node.Status = adagio.Node_COMPLETED
+	node.StartedAt = now
+	node.FinishedAt = now
+	node.Attempts = append(node.Attempts, expansion.Result)
+
+	adagio.RecordTransition(node, adagio.Node_Transition_FINISHED, at, nil)
+
+	// add instances and their edges to the run and rebuild the graph
+	state.run.Nodes = append(state.run.Nodes, expansion.Nodes...)
+	state.run.Edges = append(state.run.Edges, expansion.Edges...)
+	for _, instance := range expansion.Nodes {
+		state.lookup[instance.Spec.Name] = instance
+	}
+
+	state.graph = adagio.GraphFrom(state.run)
+
+	for _, instance := range expansion.Nodes {
+		r.ready(state.run, instance)
+	}
+
+	outgoing, err := state.graph.Outgoing(node)
+	if err != nil {
+		return fmt.Errorf("expanding node %q: %w", node, err)
+	}
+
+	if expansion.Result.Conclusion != adagio.Node_Result_SUCCESS {
+		return r.handleFailure(state, node, outgoing, expansion.Result)
+	}
+
+	// propagate the expansion result which readies destinations
+	// given the map produced no instances
+	return r.handleSuccess(state, node, outgoing, expansion.Result)
+}
+
+func (r *Repository) handleFailure(state *runState, node *adagio.Node, outgoing map[graph.Node]struct{}, result *adagio.Node_Result) error {
 	if adagio.CanRetry(node) {
 		// put node back into the ready state to be attempted again
-		node.Status = adagio.Node_READY
-		node.FinishedAt = ""
+		// once any retry delay has elapsed
+		now := r.now()
+
+		adagio.RecordTransition(node, adagio.Node_Transition_RETRIED, now, nil)
+		adagio.ScheduleRetry(node, now)
 
-		r.notifyReady(state.run, node)
+		r.schedule(state.run, node)
 
 		return nil
 	}
 
-	// no attempts remaining so progress outgoing nodes into
-	// completed but inconcluded state
+	return r.completeDescendants(state, node, outgoing)
+}
+
+// completeDescendants progresses outgoing nodes into the completed but inconcluded
+// state, descending into their outgoing nodes in turn. Outgoing nodes connected via
+// an edge satisfied by the nodes result are instead resolved
+func (r *Repository) completeDescendants(state *runState, node *adagio.Node, src map[graph.Node]struct{}) error {
+	satisfied := map[graph.Node]struct{}{}
+
 	for outi := range src {
 		out := outi.(*adagio.Node)
 
+		if adagio.EdgeSatisfied(state.run, node, out) {
+			satisfied[out] = struct{}{}
+			continue
+		}
+
+		if adagio.IsResolved(out) {
+			continue
+		}
+
+		now := r.now()
+
 		out.Status = adagio.Node_COMPLETED
-		out.StartedAt = r.now().Format(time.RFC3339Nano)
-		out.FinishedAt = r.now().Format(time.RFC3339Nano)
+		out.StartedAt = now.Format(time.RFC3339Nano)
+		out.FinishedAt = now.Format(time.RFC3339Nano)
+
+		adagio.RecordTransition(out, adagio.Node_Transition_FINISHED, now, nil)
 
 		outgoing, err := state.graph.Outgoing(out)
 		if err != nil {
@@ -377,15 +978,16 @@ func (r *Repository) handleFailure(state runState, node *adagio.Node, src map[gr
 		}
 
 		// descend into child nodes
-		if err := r.handleFailure(state, node, outgoing, result); err != nil {
+		if err := r.completeDescendants(state, out, outgoing); err != nil {
 			return err
 		}
 	}
 
-	return nil
+	return r.resolve(state, satisfied)
 }
 
 // Subscribe registers the provided channel to listen for the defined event types
+// Only events for nodes with a runtime supported by the agent are sent
 func (r *Repository) Subscribe(_ context.Context, agent *adagio.Agent, events chan<- *adagio.Event, types ...adagio.Event_Type) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -396,22 +998,50 @@ func (r *Repository) Subscribe(_ context.Context, agent *adagio.Agent, events ch
 		if typ == adagio.Event_NODE_READY {
 			for _, state := range r.runs {
 				for _, node := range state.lookup {
-					if node.Status == adagio.Node_READY {
+					if node.Status == adagio.Node_READY && !adagio.IsApproval(node) && adagio.SupportsRuntime(agent, node.Spec.Runtime) {
 						events <- &adagio.Event{
-							RunID:    state.run.Id,
-							NodeSpec: node.Spec,
-							Type:     adagio.Event_NODE_READY,
+							RunID:        state.run.Id,
+							NodeSpec:     node.Spec,
+							Type:         adagio.Event_NODE_READY,
+							Priority:     adagio.Priority(state.run, node),
+							TraceContext: state.run.TraceContext,
 						}
 					}
 				}
 			}
 		}
-		r.listeners[typ] = append(r.listeners[typ], events)
+		r.listeners[typ] = append(r.listeners[typ], listener{agent, events})
 	}
 
 	return nil
 }
 
+// WatchRuns sends a run updated event on the provided channel each time the state of
+// a node of a run changes, until the context is cancelled. Unlike Subscribe it does not
+// register an agent
+func (r *Repository) WatchRuns(ctx context.Context, events chan<- *adagio.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.watchers = append(r.watchers, watcher{ctx, events})
+
+	go func() {
+		<-ctx.Done()
+
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		for i, w := range r.watchers {
+			if w.events == events {
+				r.watchers = append(r.watchers[:i], r.watchers[i+1:]...)
+				break
+			}
+		}
+	}()
+
+	return nil
+}
+
 // UnsubscribeAll unsubscribes the channel for all event types
 func (r *Repository) UnsubscribeAll(_ context.Context, agent *adagio.Agent, events chan<- *adagio.Event) error {
 	r.mu.Lock()
@@ -419,11 +1049,11 @@ func (r *Repository) UnsubscribeAll(_ context.Context, agent *adagio.Agent, even
 
 	delete(r.agents, agent.Id)
 
-	for event, chans := range r.listeners {
-		for i, ch := range chans {
-			if ch == events {
+	for event, listeners := range r.listeners {
+		for i, l := range listeners {
+			if l.events == events {
 				// remove channel from listening map
-				r.listeners[event] = append(chans[0:i], chans[i+1:]...)
+				r.listeners[event] = append(listeners[0:i], listeners[i+1:]...)
 			}
 		}
 	}
@@ -431,16 +1061,193 @@ func (r *Repository) UnsubscribeAll(_ context.Context, agent *adagio.Agent, even
 	return nil
 }
 
-func (r *Repository) state(runID string) (runState, error) {
+// Heartbeat replaces the record of a subscribed agent with the provided agent
+func (r *Repository) Heartbeat(_ context.Context, agent *adagio.Agent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.agents[agent.Id]; !ok {
+		return fmt.Errorf("in-memory repository: agent %q: %w", agent.Id, adagio.ErrAgentDoesNotExist)
+	}
+
+	r.agents[agent.Id] = agent
+
+	return nil
+}
+
+// CreateWebhook stores the provided webhook
+func (r *Repository) CreateWebhook(_ context.Context, webhook *adagio.Webhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.webhooks[webhook.Id] = proto.Clone(webhook).(*adagio.Webhook)
+	r.deliveries[webhook.Id] = map[string]*adagio.Delivery{}
+
+	return nil
+}
+
+// InspectWebhook returns the webhook for the provided ID
+func (r *Repository) InspectWebhook(_ context.Context, id string) (*adagio.Webhook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	webhook, ok := r.webhooks[id]
+	if !ok {
+		return nil, fmt.Errorf("in-memory repository: webhook %q: %w", id, adagio.ErrWebhookDoesNotExist)
+	}
+
+	return proto.Clone(webhook).(*adagio.Webhook), nil
+}
+
+// ListWebhooks returns the stored webhooks ordered by ID
+func (r *Repository) ListWebhooks(context.Context) (webhooks []*adagio.Webhook, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, webhook := range r.webhooks {
+		webhooks = append(webhooks, proto.Clone(webhook).(*adagio.Webhook))
+	}
+
+	sort.Slice(webhooks, func(i, j int) bool {
+		return webhooks[i].Id < webhooks[j].Id
+	})
+
+	return
+}
+
+// UpdateWebhook replaces the stored webhook with the provided webhook
+func (r *Repository) UpdateWebhook(_ context.Context, webhook *adagio.Webhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.webhooks[webhook.Id]; !ok {
+		return fmt.Errorf("in-memory repository: webhook %q: %w", webhook.Id, adagio.ErrWebhookDoesNotExist)
+	}
+
+	r.webhooks[webhook.Id] = proto.Clone(webhook).(*adagio.Webhook)
+
+	return nil
+}
+
+// DeleteWebhook removes the webhook for the provided ID along with its deliveries
+func (r *Repository) DeleteWebhook(_ context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.webhooks[id]; !ok {
+		return fmt.Errorf("in-memory repository: webhook %q: %w", id, adagio.ErrWebhookDoesNotExist)
+	}
+
+	delete(r.webhooks, id)
+	delete(r.deliveries, id)
+
+	return nil
+}
+
+// CreateDelivery stores the provided delivery given the webhook exists and no
+// delivery of the same notification has been made to the webhook
+func (r *Repository) CreateDelivery(_ context.Context, delivery *adagio.Delivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deliveries, ok := r.deliveries[delivery.WebhookId]
+	if !ok {
+		return fmt.Errorf("in-memory repository: webhook %q: %w", delivery.WebhookId, adagio.ErrWebhookDoesNotExist)
+	}
+
+	if _, ok := deliveries[delivery.Notification.Id]; ok {
+		return fmt.Errorf("in-memory repository: notification %q: %w", delivery.Notification.Id, adagio.ErrDeliveryExists)
+	}
+
+	deliveries[delivery.Notification.Id] = proto.Clone(delivery).(*adagio.Delivery)
+
+	return nil
+}
+
+// UpdateDelivery replaces the stored delivery with the provided delivery
+func (r *Repository) UpdateDelivery(_ context.Context, delivery *adagio.Delivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deliveries, ok := r.deliveries[delivery.WebhookId]
+	if !ok {
+		return fmt.Errorf("in-memory repository: webhook %q: %w", delivery.WebhookId, adagio.ErrWebhookDoesNotExist)
+	}
+
+	if _, ok := deliveries[delivery.Notification.Id]; !ok {
+		return fmt.Errorf("in-memory repository: notification %q: %w", delivery.Notification.Id, adagio.ErrDeliveryDoesNotExist)
+	}
+
+	deliveries[delivery.Notification.Id] = proto.Clone(delivery).(*adagio.Delivery)
+
+	return nil
+}
+
+// ListDeliveries returns the deliveries made to the webhook for the provided ID
+// ordered by notification ID
+func (r *Repository) ListDeliveries(_ context.Context, webhookID string) (deliveries []*adagio.Delivery, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	webhookDeliveries, ok := r.deliveries[webhookID]
+	if !ok {
+		return nil, fmt.Errorf("in-memory repository: webhook %q: %w", webhookID, adagio.ErrWebhookDoesNotExist)
+	}
+
+	for _, delivery := range webhookDeliveries {
+		deliveries = append(deliveries, proto.Clone(delivery).(*adagio.Delivery))
+	}
+
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].Notification.Id < deliveries[j].Notification.Id
+	})
+
+	return
+}
+
+// AppendAuditEvent appends the provided event to the audit log
+func (r *Repository) AppendAuditEvent(_ context.Context, event *adagio.AuditEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.audit = append(r.audit, proto.Clone(event).(*adagio.AuditEvent))
+
+	return nil
+}
+
+// ListAuditEvents returns the events of the audit log from the most recently appended
+// given a set of provided predicates
+func (r *Repository) ListAuditEvents(_ context.Context, req controlplane.AuditListRequest) (events []*adagio.AuditEvent, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := len(r.audit) - 1; i >= 0; i-- {
+		if req.Limit != nil && uint64(len(events)) >= *req.Limit {
+			break
+		}
+
+		if event := r.audit[i]; req.RunID == "" || event.RunId == req.RunID {
+			events = append(events, proto.Clone(event).(*adagio.AuditEvent))
+		}
+	}
+
+	return
+}
+
+func (r *Repository) state(runID string) (*runState, error) {
 	state, ok := r.runs[runID]
 	if !ok {
-		return runState{}, fmt.Errorf("in-memory repository: run %q: %w", runID, adagio.ErrRunDoesNotExist)
+		return nil, fmt.Errorf("in-memory repository: run %q: %w", runID, adagio.ErrRunDoesNotExist)
 	}
 
 	return state, nil
 }
 
-func node(state runState, name string) (*adagio.Node, error) {
+func logsKey(runID, name string, attempt int32) string {
+	return fmt.Sprintf("%s/%s/%d", runID, name, attempt)
+}
+
+func node(state *runState, name string) (*adagio.Node, error) {
 	node, ok := state.lookup[name]
 	if !ok {
 		return nil, fmt.Errorf("in-memory repository: node %q: %w", name, adagio.ErrMissingNode)
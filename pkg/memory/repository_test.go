@@ -9,7 +9,7 @@ import (
 )
 
 func Test_Run_RepositoryTestHarness(t *testing.T) {
-	repo := New()
+	repo := New(WithResourcePools(repository.ExampleResourcePools))
 
 	repository.TestHarness(t, func(now func() time.Time) (repository.Repository, repository.Orphaner) {
 		repo.now = now
@@ -28,13 +28,13 @@ func Test_Run_RepositoryTestHarness(t *testing.T) {
 			c.node.Status = adagio.Node_NONE
 
 			// notify listens of orphan
-			for _, ch := range repo.listeners[adagio.Event_NODE_ORPHANED] {
-				select {
-				case ch <- &adagio.Event{RunID: c.run.Id, NodeSpec: c.node.Spec, Type: adagio.Event_NODE_ORPHANED}:
-					// attempt to send
-				default:
-				}
-			}
+			repo.notify(&adagio.Event{
+				RunID:        c.run.Id,
+				NodeSpec:     c.node.Spec,
+				Type:         adagio.Event_NODE_ORPHANED,
+				Priority:     adagio.Priority(c.run, c.node),
+				TraceContext: c.run.TraceContext,
+			})
 		})
 	})
 }
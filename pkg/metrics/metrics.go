@@ -0,0 +1,236 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/georgemac/adagio/pkg/adagio"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const namespace = "adagio"
+
+// Metrics is a set of prometheus collectors which describe the runs and nodes
+// processed by adagio repositories and agents. A single Metrics instance is
+// shared between the components of a process and registered once
+type Metrics struct {
+	runsStarted   prometheus.Counter
+	runsCompleted *prometheus.CounterVec
+	nodeDuration  *prometheus.HistogramVec
+	claimAttempts prometheus.Counter
+	claims        prometheus.Counter
+	orphaned      prometheus.Counter
+	retries       prometheus.Counter
+	conflicts     *prometheus.CounterVec
+}
+
+// New constructs a new set of unregistered metrics
+func New() *Metrics {
+	return &Metrics{
+		runsStarted: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "runs_started_total",
+			Help:      "Number of runs started.",
+		}),
+		runsCompleted: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "runs_completed_total",
+			Help:      "Number of runs completed by conclusion.",
+		}, []string{"conclusion"}),
+		nodeDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "node_duration_seconds",
+			Help:      "Duration of node executions by runtime and conclusion.",
+			Buckets:   prometheus.ExponentialBuckets(0.01, 4, 10),
+		}, []string{"runtime", "conclusion"}),
+		claimAttempts: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "claim_attempts_total",
+			Help:      "Number of attempts made by agents to claim nodes.",
+		}),
+		claims: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "claims_total",
+			Help:      "Number of successful node claims made by agents.",
+		}),
+		orphaned: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "nodes_orphaned_total",
+			Help:      "Number of orphaned nodes handled by agents.",
+		}),
+		retries: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "node_retries_total",
+			Help:      "Number of nodes returned to the ready state to be attempted again.",
+		}),
+		conflicts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "etcd_txn_conflicts_total",
+			Help:      "Number of etcd transactions which did not succeed and were retried by operation.",
+		}, []string{"operation"}),
+	}
+}
+
+// Register registers each of the metrics with the provided registerer
+func (m *Metrics) Register(reg prometheus.Registerer) error {
+	for _, c := range []prometheus.Collector{
+		m.runsStarted,
+		m.runsCompleted,
+		m.nodeDuration,
+		m.claimAttempts,
+		m.claims,
+		m.orphaned,
+		m.retries,
+		m.conflicts,
+	} {
+		if err := reg.Register(c); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RunStarted records that a run has been started
+func (m *Metrics) RunStarted() {
+	m.runsStarted.Inc()
+}
+
+// NodeFinished records the outcome of nodes being finished together given the state of
+// the run once the nodes have been finished. Nodes returned to the ready or scheduled state
+// are counted as retries and runs whose every node is resolved are counted as completed
+func (m *Metrics) NodeFinished(run *adagio.Run, nodes ...*adagio.Node) {
+	for _, node := range nodes {
+		if node.Status == adagio.Node_READY || node.Status == adagio.Node_SCHEDULED {
+			m.retries.Inc()
+		}
+	}
+
+	if !adagio.RunCompleted(run) {
+		return
+	}
+
+	m.runsCompleted.WithLabelValues(conclusion(run)).Inc()
+}
+
+// NodeExecuted records the duration of a node execution by its runtime and conclusion
+func (m *Metrics) NodeExecuted(runtime string, result adagio.Node_Result_Conclusion, duration time.Duration) {
+	m.nodeDuration.WithLabelValues(runtime, strings.ToLower(result.String())).Observe(duration.Seconds())
+}
+
+// ClaimAttempted records an attempt to claim a node and whether it was claimed
+func (m *Metrics) ClaimAttempted(claimed bool) {
+	m.claimAttempts.Inc()
+
+	if claimed {
+		m.claims.Inc()
+	}
+}
+
+// NodeOrphaned records that an orphaned node has been handled
+func (m *Metrics) NodeOrphaned() {
+	m.orphaned.Inc()
+}
+
+// TxnConflict records that an etcd transaction for the named operation did not succeed
+func (m *Metrics) TxnConflict(operation string) {
+	m.conflicts.WithLabelValues(operation).Inc()
+}
+
+// conclusion returns "success" given every node of the run which was not
+// skipped succeeded on its latest attempt, otherwise "failure"
+func conclusion(run *adagio.Run) string {
+	if !adagio.RunSucceeded(run) {
+		return "failure"
+	}
+
+	return "success"
+}
+
+// StatsRepository is a type which reports counts of runs and nodes
+type StatsRepository interface {
+	Stats(context.Context) (*adagio.Stats, error)
+}
+
+// StatsCollector is a prometheus collector which exposes the counts
+// reported by a repository as gauges on each collection
+type StatsCollector struct {
+	repo    StatsRepository
+	timeout time.Duration
+
+	runs          *prometheus.Desc
+	nodes         *prometheus.Desc
+	unschedulable *prometheus.Desc
+	errors        prometheus.Counter
+}
+
+// NewStatsCollector constructs a new StatsCollector for the provided repository
+// The labels are attached to each collected metric (e.g. the namespace of the repository)
+// so that the collectors of multiple repositories can be registered together
+func NewStatsCollector(repo StatsRepository, labels prometheus.Labels) *StatsCollector {
+	return &StatsCollector{
+		repo:    repo,
+		timeout: 5 * time.Second,
+		runs: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "runs"),
+			"Number of runs.",
+			nil, labels),
+		nodes: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "nodes"),
+			"Number of nodes by state.",
+			[]string{"state"}, labels),
+		unschedulable: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "unschedulable_nodes"),
+			"Number of ready nodes which no registered agent can claim by runtime.",
+			[]string{"runtime"}, labels),
+		errors: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace:   namespace,
+			Name:        "stats_errors_total",
+			Help:        "Number of errors encountered collecting stats from the repository.",
+			ConstLabels: labels,
+		}),
+	}
+}
+
+// Describe sends the descriptors of the collected metrics
+func (c *StatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.runs
+	ch <- c.nodes
+	ch <- c.unschedulable
+	c.errors.Describe(ch)
+}
+
+// Collect fetches the stats from the repository and sends them as gauges
+// Given the stats cannot be fetched only the error count is sent
+func (c *StatsCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	stats, err := c.repo.Stats(ctx)
+	if err != nil {
+		c.errors.Inc()
+		c.errors.Collect(ch)
+		return
+	}
+
+	c.errors.Collect(ch)
+
+	ch <- prometheus.MustNewConstMetric(c.runs, prometheus.GaugeValue, float64(stats.RunCount))
+
+	counts := stats.GetNodeCounts()
+	for state, count := range map[string]int64{
+		"waiting":   counts.GetWaitingCount(),
+		"ready":     counts.GetReadyCount(),
+		"running":   counts.GetRunningCount(),
+		"completed": counts.GetCompletedCount(),
+		"skipped":   counts.GetSkippedCount(),
+		"scheduled": counts.GetScheduledCount(),
+	} {
+		ch <- prometheus.MustNewConstMetric(c.nodes, prometheus.GaugeValue, float64(count), state)
+	}
+
+	for runtime, count := range stats.UnschedulableCounts {
+		ch <- prometheus.MustNewConstMetric(c.unschedulable, prometheus.GaugeValue, float64(count), runtime)
+	}
+}
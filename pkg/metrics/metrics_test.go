@@ -0,0 +1,237 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/georgemac/adagio/pkg/adagio"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Metrics_NodeFinished(t *testing.T) {
+	var (
+		succeeded = &adagio.Node{
+			Spec:     &adagio.Node_Spec{Name: "a"},
+			Status:   adagio.Node_COMPLETED,
+			Attempts: []*adagio.Node_Result{{Conclusion: adagio.Node_Result_SUCCESS}},
+		}
+		failed = &adagio.Node{
+			Spec:     &adagio.Node_Spec{Name: "b"},
+			Status:   adagio.Node_COMPLETED,
+			Attempts: []*adagio.Node_Result{{Conclusion: adagio.Node_Result_FAIL}},
+		}
+		skipped = &adagio.Node{
+			Spec:   &adagio.Node_Spec{Name: "c"},
+			Status: adagio.Node_SKIPPED,
+		}
+		retried = &adagio.Node{
+			Spec:     &adagio.Node_Spec{Name: "d"},
+			Status:   adagio.Node_READY,
+			Attempts: []*adagio.Node_Result{{Conclusion: adagio.Node_Result_ERROR}},
+		}
+		delayed = &adagio.Node{
+			Spec:     &adagio.Node_Spec{Name: "e"},
+			Status:   adagio.Node_SCHEDULED,
+			Attempts: []*adagio.Node_Result{{Conclusion: adagio.Node_Result_ERROR}},
+		}
+	)
+
+	for _, test := range []struct {
+		name      string
+		run       *adagio.Run
+		nodes     []*adagio.Node
+		retries   float64
+		successes float64
+		failures  float64
+	}{
+		{
+			name:      "a run whose nodes succeeded or were skipped",
+			run:       &adagio.Run{Nodes: []*adagio.Node{succeeded, skipped}},
+			nodes:     []*adagio.Node{succeeded},
+			successes: 1,
+		},
+		{
+			name:     "a run with a failed node",
+			run:      &adagio.Run{Nodes: []*adagio.Node{succeeded, failed}},
+			nodes:    []*adagio.Node{failed},
+			failures: 1,
+		},
+		{
+			name:    "a run with a node being retried",
+			run:     &adagio.Run{Nodes: []*adagio.Node{succeeded, retried}},
+			nodes:   []*adagio.Node{retried},
+			retries: 1,
+		},
+		{
+			name:    "a run with a node being retried after a delay",
+			run:     &adagio.Run{Nodes: []*adagio.Node{succeeded, delayed}},
+			nodes:   []*adagio.Node{delayed},
+			retries: 1,
+		},
+		{
+			name:    "a run whose nodes finished together",
+			run:     &adagio.Run{Nodes: []*adagio.Node{succeeded, failed, retried, delayed}},
+			nodes:   []*adagio.Node{retried, delayed},
+			retries: 2,
+		},
+		{
+			name:     "a run completed by nodes finished together",
+			run:      &adagio.Run{Nodes: []*adagio.Node{succeeded, failed, skipped}},
+			nodes:    []*adagio.Node{failed, skipped},
+			failures: 1,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			m := New()
+
+			m.NodeFinished(test.run, test.nodes...)
+
+			assert.Equal(t, test.retries, testutil.ToFloat64(m.retries))
+			assert.Equal(t, test.successes, testutil.ToFloat64(m.runsCompleted.WithLabelValues("success")))
+			assert.Equal(t, test.failures, testutil.ToFloat64(m.runsCompleted.WithLabelValues("failure")))
+		})
+	}
+}
+
+func Test_Metrics_ClaimAttempted(t *testing.T) {
+	m := New()
+
+	m.ClaimAttempted(true)
+	m.ClaimAttempted(false)
+	m.ClaimAttempted(false)
+
+	assert.Equal(t, float64(3), testutil.ToFloat64(m.claimAttempts))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.claims))
+}
+
+func Test_Metrics_Register(t *testing.T) {
+	var (
+		m        = New()
+		registry = prometheus.NewRegistry()
+	)
+
+	require.Nil(t, m.Register(registry))
+
+	m.RunStarted()
+	m.NodeExecuted("shell", adagio.Node_Result_SUCCESS, time.Second)
+	m.TxnConflict("finish_node")
+
+	families, err := registry.Gather()
+	require.Nil(t, err)
+
+	var names []string
+	for _, family := range families {
+		names = append(names, family.GetName())
+	}
+
+	assert.Equal(t, []string{
+		"adagio_claim_attempts_total",
+		"adagio_claims_total",
+		"adagio_etcd_txn_conflicts_total",
+		"adagio_node_duration_seconds",
+		"adagio_node_retries_total",
+		"adagio_nodes_orphaned_total",
+		"adagio_runs_started_total",
+	}, names)
+
+	// registering the same metrics twice is an error
+	assert.NotNil(t, m.Register(registry))
+}
+
+type statsRepository struct {
+	stats *adagio.Stats
+	err   error
+}
+
+func (s statsRepository) Stats(context.Context) (*adagio.Stats, error) {
+	return s.stats, s.err
+}
+
+func Test_StatsCollector(t *testing.T) {
+	t.Run("the stats of the repository are collected as gauges", func(t *testing.T) {
+		registry := prometheus.NewRegistry()
+		registry.MustRegister(NewStatsCollector(statsRepository{stats: &adagio.Stats{
+			RunCount: 2,
+			NodeCounts: &adagio.Stats_NodeCounts{
+				WaitingCount:   1,
+				ReadyCount:     2,
+				RunningCount:   3,
+				CompletedCount: 4,
+				SkippedCount:   5,
+				ScheduledCount: 6,
+			},
+			UnschedulableCounts: map[string]int64{"shell": 2},
+		}}, nil))
+
+		gauges := gather(t, registry)
+
+		assert.Equal(t, map[string]float64{
+			"adagio_runs":                                 2,
+			`adagio_nodes{state="waiting"}`:               1,
+			`adagio_nodes{state="ready"}`:                 2,
+			`adagio_nodes{state="running"}`:               3,
+			`adagio_nodes{state="completed"}`:             4,
+			`adagio_nodes{state="skipped"}`:               5,
+			`adagio_nodes{state="scheduled"}`:             6,
+			`adagio_unschedulable_nodes{runtime="shell"}`: 2,
+			"adagio_stats_errors_total":                   0,
+		}, gauges)
+	})
+
+	t.Run("errors fetching the stats are counted", func(t *testing.T) {
+		registry := prometheus.NewRegistry()
+		registry.MustRegister(NewStatsCollector(statsRepository{err: errors.New("unavailable")}, nil))
+
+		assert.Equal(t, map[string]float64{"adagio_stats_errors_total": 1}, gather(t, registry))
+	})
+
+	t.Run("the stats of multiple repositories are distinguished by their labels", func(t *testing.T) {
+		registry := prometheus.NewRegistry()
+		registry.MustRegister(NewStatsCollector(statsRepository{stats: &adagio.Stats{RunCount: 1}}, prometheus.Labels{"namespace": "default"}))
+		registry.MustRegister(NewStatsCollector(statsRepository{stats: &adagio.Stats{RunCount: 3}}, prometheus.Labels{"namespace": "team-a"}))
+
+		gauges := gather(t, registry)
+
+		assert.Equal(t, float64(1), gauges[`adagio_runs{namespace="default"}`])
+		assert.Equal(t, float64(3), gauges[`adagio_runs{namespace="team-a"}`])
+		assert.Contains(t, gauges, `adagio_nodes{namespace="team-a",state="waiting"}`)
+	})
+}
+
+// gather returns the value of each gathered metric keyed by its name and labels
+func gather(t *testing.T, registry *prometheus.Registry) map[string]float64 {
+	t.Helper()
+
+	families, err := registry.Gather()
+	require.Nil(t, err)
+
+	values := map[string]float64{}
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			var labels []string
+			for _, label := range metric.GetLabel() {
+				labels = append(labels, label.GetName()+"=\""+label.GetValue()+"\"")
+			}
+
+			key := family.GetName()
+			if len(labels) > 0 {
+				key += "{" + strings.Join(labels, ",") + "}"
+			}
+
+			switch {
+			case metric.GetGauge() != nil:
+				values[key] = metric.GetGauge().GetValue()
+			case metric.GetCounter() != nil:
+				values[key] = metric.GetCounter().GetValue()
+			}
+		}
+	}
+
+	return values
+}
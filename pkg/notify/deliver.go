@@ -0,0 +1,156 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/georgemac/adagio/pkg/adagio"
+	"github.com/georgemac/adagio/pkg/logging"
+	"github.com/golang/protobuf/jsonpb"
+)
+
+const (
+	// EventHeader is the header which contains the event of the posted notification
+	EventHeader = "X-Adagio-Event"
+	// DeliveryHeader is the header which contains the ID of the posted notification
+	DeliveryHeader = "X-Adagio-Delivery"
+	// SignatureHeader is the header which contains the signature of the posted notification
+	SignatureHeader = "X-Adagio-Signature"
+)
+
+var marshaler = jsonpb.Marshaler{OrigName: true}
+
+// start delivers the notification of the delivery to the webhook in the background
+func (n *Notifier) start(ctx context.Context, webhook *adagio.Webhook, delivery *adagio.Delivery) {
+	n.wg.Add(1)
+	go func() {
+		defer n.wg.Done()
+
+		n.deliver(ctx, webhook, delivery)
+	}()
+}
+
+// deliver posts the notification of the delivery to the webhook until it is accepted
+// or the maximum number of attempts have been made, recording each attempt on the delivery
+func (n *Notifier) deliver(ctx context.Context, webhook *adagio.Webhook, delivery *adagio.Delivery) {
+	logger := n.logger.WithFields(logging.Fields{
+		logging.WebhookIDKey: webhook.Id,
+		logging.RunIDKey:     delivery.Notification.RunId,
+		"notification":       delivery.Notification.Id,
+	})
+
+	for {
+		if nextAttemptAt, err := time.Parse(time.RFC3339Nano, delivery.NextAttemptAt); err == nil {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(nextAttemptAt.Sub(n.now())):
+			}
+		}
+
+		code, err := n.post(ctx, webhook, delivery.Notification)
+		if ctx.Err() != nil {
+			// leave the delivery pending to be resumed
+			return
+		}
+
+		now := n.now()
+		delivery.Attempts++
+		delivery.ResponseCode = int32(code)
+		delivery.UpdatedAt = now.Format(time.RFC3339Nano)
+		delivery.NextAttemptAt = ""
+		delivery.Error = ""
+
+		switch {
+		case err == nil:
+			delivery.Status = adagio.Delivery_DELIVERED
+		case delivery.Attempts >= n.maxAttempts:
+			delivery.Status = adagio.Delivery_FAILED
+			delivery.Error = err.Error()
+		default:
+			delivery.Error = err.Error()
+			delivery.NextAttemptAt = now.Add(n.backoff(delivery.Attempts)).Format(time.RFC3339Nano)
+		}
+
+		if err := n.repo.UpdateDelivery(ctx, delivery); err != nil {
+			logger.WithError(err).Error("notify: updating delivery")
+		}
+
+		switch delivery.Status {
+		case adagio.Delivery_DELIVERED:
+			logger.Debug("notification delivered")
+			return
+		case adagio.Delivery_FAILED:
+			logger.WithError(err).Warn("notification delivery failed")
+			return
+		}
+
+		logger.WithError(err).Debug("notification delivery attempt failed, retrying")
+	}
+}
+
+// post sends the signed notification to the webhook and returns the status code
+// of the response. It returns an error given the response is not a 2xx
+func (n *Notifier) post(ctx context.Context, webhook *adagio.Webhook, notification *adagio.Notification) (int, error) {
+	var body bytes.Buffer
+	if err := marshaler.Marshal(&body, notification); err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, webhook.Url, bytes.NewReader(body.Bytes()))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, notification.Event.String())
+	req.Header.Set(DeliveryHeader, notification.Id)
+	req.Header.Set(SignatureHeader, Sign(webhook.Secret, body.Bytes()))
+
+	resp, err := n.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return 0, err
+	}
+
+	defer resp.Body.Close()
+
+	io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %q", resp.Status)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// backoff returns the delay before the attempt following the provided attempt which
+// doubles the initial delay for each attempt made up to the maximum delay
+func (n *Notifier) backoff(attempts int32) time.Duration {
+	delay := n.initialDelay
+	for i := int32(1); i < attempts && delay < n.maxDelay; i++ {
+		delay *= 2
+	}
+
+	if delay > n.maxDelay {
+		return n.maxDelay
+	}
+
+	return delay
+}
+
+// Sign returns the signature of the body as sent in the signature header which is
+// "sha256=" followed by the hex encoded HMAC-SHA256 of the body keyed by the secret
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,322 @@
+// Package notify posts signed notifications of run and node events to webhooks
+//
+// The Notifier watches the repository for updates to the state of runs and derives
+// notifications from the state and history of their nodes. On starting it catches up
+// on the runs created within its lookback window. A delivery is created in the
+// repository for each notification and webhook notified of its event. As the repository
+// rejects a second delivery of the same notification to a webhook several notifiers can
+// share a repository without duplicating deliveries.
+//
+// Each notification is posted as JSON along with the following headers:
+//
+//	X-Adagio-Event:     the event (e.g. "RUN_FAILED")
+//	X-Adagio-Delivery:  the ID of the notification (stable across attempts)
+//	X-Adagio-Signature: "sha256=" followed by the hex encoded HMAC-SHA256 of the body keyed by the webhook secret
+//
+// Deliveries which fail are retried with an exponential backoff until the maximum
+// number of attempts has been made. Each attempt is recorded on the delivery.
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/georgemac/adagio/pkg/adagio"
+	"github.com/georgemac/adagio/pkg/logging"
+	"github.com/georgemac/adagio/pkg/service/controlplane"
+)
+
+// Repository is the store of the runs from which notifications are derived
+// and of the webhooks and deliveries to which they are posted
+type Repository interface {
+	WatchRuns(ctx context.Context, events chan<- *adagio.Event) error
+	ListRuns(context.Context, controlplane.ListRequest) ([]*adagio.Run, error)
+	InspectRun(ctx context.Context, id string) (*adagio.Run, error)
+	ListWebhooks(context.Context) ([]*adagio.Webhook, error)
+	ListDeliveries(ctx context.Context, webhookID string) ([]*adagio.Delivery, error)
+	CreateDelivery(context.Context, *adagio.Delivery) error
+	UpdateDelivery(context.Context, *adagio.Delivery) error
+}
+
+// Notifier derives notifications from the runs of a repository
+// and delivers them to the webhooks notified of their events
+type Notifier struct {
+	repo   Repository
+	client *http.Client
+	logger logging.Logger
+	now    func() time.Time
+
+	lookback     time.Duration
+	maxAttempts  int32
+	initialDelay time.Duration
+	maxDelay     time.Duration
+
+	// keys of the deliveries created for runs which are yet to complete
+	created map[string]struct{}
+
+	wg sync.WaitGroup
+}
+
+// New constructs and configures a new Notifier
+func New(repo Repository, opts ...Option) *Notifier {
+	n := &Notifier{
+		repo:         repo,
+		client:       &http.Client{Timeout: 10 * time.Second},
+		logger:       logging.Default(),
+		now:          func() time.Time { return time.Now().UTC() },
+		lookback:     24 * time.Hour,
+		maxAttempts:  5,
+		initialDelay: time.Second,
+		maxDelay:     time.Minute,
+		created:      map[string]struct{}{},
+	}
+
+	Options(opts).Apply(n)
+
+	return n
+}
+
+// Run resumes the pending deliveries of the repository, catches up on the runs created
+// within the lookback window and then notifies each run as the repository reports it updated
+// It blocks until the context is cancelled and any deliveries in flight have returned
+func (n *Notifier) Run(ctx context.Context) {
+	defer n.wg.Wait()
+
+	// watch before catching up so that no update in between is missed
+	events := make(chan *adagio.Event, 100)
+	if err := n.repo.WatchRuns(ctx, events); err != nil {
+		n.logger.WithError(err).Error("notify: watching runs")
+		return
+	}
+
+	if err := n.resume(ctx); err != nil {
+		n.logger.WithError(err).Error("notify: resuming deliveries")
+	}
+
+	if err := n.CatchUp(ctx); err != nil {
+		n.logger.WithError(err).Error("notify: catching up on runs")
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-events:
+			if err := n.Notify(ctx, event.RunID); err != nil {
+				n.logger.WithError(err).WithField(logging.RunIDKey, event.RunID).Error("notify: notifying run")
+			}
+		}
+	}
+}
+
+// CatchUp derives the notifications of every run created within the lookback window.
+// A delivery is created and started for each notification and webhook notified of
+// its event which has not already been delivered
+func (n *Notifier) CatchUp(ctx context.Context) error {
+	webhooks, err := n.repo.ListWebhooks(ctx)
+	if err != nil {
+		return err
+	}
+
+	since := n.now().Add(-n.lookback)
+
+	runs, err := n.repo.ListRuns(ctx, controlplane.ListRequest{Finish: &since})
+	if err != nil {
+		return err
+	}
+
+	for _, run := range runs {
+		if err := n.notify(ctx, webhooks, run); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Notify derives the notifications of the run identified by runID and creates and
+// starts a delivery for each which has not already been delivered
+func (n *Notifier) Notify(ctx context.Context, runID string) error {
+	webhooks, err := n.repo.ListWebhooks(ctx)
+	if err != nil {
+		return err
+	}
+
+	run, err := n.repo.InspectRun(ctx, runID)
+	if err != nil {
+		return err
+	}
+
+	return n.notify(ctx, webhooks, run)
+}
+
+// notify creates and starts the deliveries of the notifications of the run
+// Once the run has completed the record of its created deliveries is forgotten
+func (n *Notifier) notify(ctx context.Context, webhooks []*adagio.Webhook, run *adagio.Run) error {
+	notifications := Notifications(run)
+
+	for _, webhook := range webhooks {
+		for _, notification := range notifications {
+			if !Notifies(webhook, notification) {
+				continue
+			}
+
+			key := webhook.Id + " " + notification.Id
+			if _, ok := n.created[key]; ok {
+				continue
+			}
+
+			now := n.now().Format(time.RFC3339Nano)
+			delivery := &adagio.Delivery{
+				WebhookId:     webhook.Id,
+				Notification:  notification,
+				Status:        adagio.Delivery_PENDING,
+				CreatedAt:     now,
+				UpdatedAt:     now,
+				NextAttemptAt: now,
+			}
+
+			err := n.repo.CreateDelivery(ctx, delivery)
+			switch {
+			case err == nil:
+				n.start(ctx, webhook, delivery)
+			case errors.Is(err, adagio.ErrDeliveryExists), errors.Is(err, adagio.ErrWebhookDoesNotExist):
+				// delivered by another notifier or the webhook has since been deleted
+			default:
+				return fmt.Errorf("notify: creating delivery: %w", err)
+			}
+
+			n.created[key] = struct{}{}
+		}
+	}
+
+	if !adagio.RunCompleted(run) {
+		return nil
+	}
+
+	for key := range n.created {
+		if strings.Contains(key, " "+run.Id+"/") {
+			delete(n.created, key)
+		}
+	}
+
+	return nil
+}
+
+// resume starts the deliveries which are pending in the repository
+func (n *Notifier) resume(ctx context.Context) error {
+	webhooks, err := n.repo.ListWebhooks(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, webhook := range webhooks {
+		deliveries, err := n.repo.ListDeliveries(ctx, webhook.Id)
+		if err != nil {
+			return err
+		}
+
+		for _, delivery := range deliveries {
+			if delivery.Status == adagio.Delivery_PENDING {
+				n.start(ctx, webhook, delivery)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Notifies returns true given the webhook is notified of the event of the notification
+// and the event occurred after the webhook was created
+func Notifies(webhook *adagio.Webhook, notification *adagio.Notification) bool {
+	if !adagio.Notifies(webhook, notification.Event) {
+		return false
+	}
+
+	createdAt, err := time.Parse(time.RFC3339Nano, webhook.CreatedAt)
+	if err != nil {
+		return true
+	}
+
+	occurredAt, err := time.Parse(time.RFC3339Nano, notification.OccurredAt)
+	if err != nil {
+		return true
+	}
+
+	return !occurredAt.Before(createdAt)
+}
+
+// Notifications derives the notifications of the events which have occurred within
+// the run from the history of its nodes and, given it has completed, its conclusion
+func Notifications(run *adagio.Run) (notifications []*adagio.Notification) {
+	for _, node := range run.Nodes {
+		for _, transition := range node.History {
+			switch {
+			case transition.Type == adagio.Node_Transition_RETRIED:
+				// the retried attempt is the one before that being awaited
+				notification := nodeNotification(run, node, adagio.Webhook_NODE_RETRIED, transition.Attempt-1, transition.At)
+				if retried := int(notification.Attempt); retried > 0 && retried <= len(node.Attempts) {
+					notification.Conclusion = node.Attempts[retried-1].Conclusion
+				}
+
+				notifications = append(notifications, notification)
+			case transition.Type == adagio.Node_Transition_READY && adagio.IsApproval(node):
+				notifications = append(notifications, nodeNotification(run, node, adagio.Webhook_APPROVAL_REQUESTED, transition.Attempt, transition.At))
+			}
+		}
+	}
+
+	if !adagio.RunCompleted(run) {
+		return
+	}
+
+	var (
+		event      = adagio.Webhook_RUN_SUCCEEDED
+		occurredAt time.Time
+		failed     []string
+	)
+
+	for _, node := range run.Nodes {
+		if finishedAt, err := time.Parse(time.RFC3339Nano, node.FinishedAt); err == nil && finishedAt.After(occurredAt) {
+			occurredAt = finishedAt
+		}
+
+		if node.Status == adagio.Node_SKIPPED {
+			continue
+		}
+
+		succeeded := false
+		adagio.VisitLatestAttempt(node, func(attempt *adagio.Node_Result) {
+			succeeded = attempt.Conclusion == adagio.Node_Result_SUCCESS
+		})
+
+		if !succeeded {
+			event = adagio.Webhook_RUN_FAILED
+			failed = append(failed, node.Spec.Name)
+		}
+	}
+
+	return append(notifications, &adagio.Notification{
+		Id:          fmt.Sprintf("%s/%s", run.Id, strings.ToLower(event.String())),
+		Event:       event,
+		RunId:       run.Id,
+		FailedNodes: failed,
+		OccurredAt:  occurredAt.Format(time.RFC3339Nano),
+	})
+}
+
+func nodeNotification(run *adagio.Run, node *adagio.Node, event adagio.Webhook_Event, attempt int32, at string) *adagio.Notification {
+	return &adagio.Notification{
+		Id:         fmt.Sprintf("%s/%s/%s/%d", run.Id, node.Spec.Name, strings.ToLower(event.String()), attempt),
+		Event:      event,
+		RunId:      run.Id,
+		Node:       node.Spec.Name,
+		Attempt:    attempt,
+		OccurredAt: at,
+	}
+}
@@ -0,0 +1,257 @@
+package notify
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/georgemac/adagio/pkg/adagio"
+	"github.com/georgemac/adagio/pkg/logging"
+	"github.com/georgemac/adagio/pkg/memory"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type receiver struct {
+	mu            sync.Mutex
+	secret        string
+	statuses      []int
+	notifications []*adagio.Notification
+	valid         bool
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	body, _ := ioutil.ReadAll(req.Body)
+
+	r.valid = req.Header.Get(SignatureHeader) == Sign(r.secret, body)
+
+	status := http.StatusOK
+	if len(r.statuses) > 0 {
+		status, r.statuses = r.statuses[0], r.statuses[1:]
+	}
+
+	if status == http.StatusOK {
+		var notification adagio.Notification
+		if err := jsonpb.UnmarshalString(string(body), &notification); err == nil {
+			r.notifications = append(r.notifications, &notification)
+		}
+	}
+
+	w.WriteHeader(status)
+}
+
+// received returns the notifications accepted ordered by ID
+func (r *receiver) received() []*adagio.Notification {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sort.Slice(r.notifications, func(i, j int) bool {
+		return r.notifications[i].Id < r.notifications[j].Id
+	})
+
+	return r.notifications
+}
+
+func Test_Notifier_CatchUp(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		repo = memory.New(memory.WithLogger(logging.Discard()))
+		spec = &adagio.Node_Spec{
+			Name:    "a",
+			Runtime: "test",
+			Retry:   map[string]*adagio.Node_Spec_Retry{"fail": {MaxAttempts: 2}},
+		}
+	)
+
+	for _, test := range []struct {
+		name     string
+		events   []adagio.Webhook_Event
+		statuses []int
+		// expected notifications received ordered by ID
+		expected []*adagio.Notification
+		status   adagio.Delivery_Status
+		attempts int32
+	}{
+		{
+			name:   "a failed run and its retried node are notified",
+			events: []adagio.Webhook_Event{adagio.Webhook_RUN_FAILED, adagio.Webhook_NODE_RETRIED},
+			expected: []*adagio.Notification{
+				{Event: adagio.Webhook_NODE_RETRIED, Node: "a", Attempt: 1, Conclusion: adagio.Node_Result_FAIL},
+				{Event: adagio.Webhook_RUN_FAILED, FailedNodes: []string{"a"}},
+			},
+			status:   adagio.Delivery_DELIVERED,
+			attempts: 1,
+		},
+		{
+			name:     "events the webhook is not notified of are not delivered",
+			events:   []adagio.Webhook_Event{adagio.Webhook_RUN_SUCCEEDED, adagio.Webhook_APPROVAL_REQUESTED},
+			expected: nil,
+		},
+		{
+			name:     "a delivery which is not accepted is retried",
+			events:   []adagio.Webhook_Event{adagio.Webhook_RUN_FAILED},
+			statuses: []int{http.StatusInternalServerError, http.StatusBadGateway},
+			expected: []*adagio.Notification{
+				{Event: adagio.Webhook_RUN_FAILED, FailedNodes: []string{"a"}},
+			},
+			status:   adagio.Delivery_DELIVERED,
+			attempts: 3,
+		},
+		{
+			name:     "a delivery fails once the maximum attempts have been made",
+			events:   []adagio.Webhook_Event{adagio.Webhook_RUN_FAILED},
+			statuses: []int{500, 500, 500, 500},
+			status:   adagio.Delivery_FAILED,
+			attempts: 3,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			recv := &receiver{secret: "secret", statuses: test.statuses}
+
+			server := httptest.NewServer(recv)
+			defer server.Close()
+
+			webhook, err := adagio.NewWebhook(server.URL, recv.secret, test.events...)
+			require.Nil(t, err)
+
+			require.Nil(t, repo.CreateWebhook(ctx, webhook))
+			defer repo.DeleteWebhook(ctx, webhook.Id)
+
+			run, err := repo.StartRun(ctx, &adagio.GraphSpec{Nodes: []*adagio.Node_Spec{spec}})
+			require.Nil(t, err)
+
+			for i := 0; i < 2; i++ {
+				claim := &adagio.Claim{Id: "claim"}
+
+				_, claimed, err := repo.ClaimNode(ctx, run.Id, spec.Name, claim)
+				require.Nil(t, err)
+				require.True(t, claimed)
+
+				require.Nil(t, repo.FinishNode(ctx, run.Id, spec.Name, &adagio.Node_Result{Conclusion: adagio.Node_Result_FAIL}, claim))
+			}
+
+			notifier := New(repo,
+				WithMaxAttempts(3),
+				WithBackoff(time.Millisecond, 2*time.Millisecond),
+				WithLogger(logging.Discard()))
+
+			// catching up twice does not deliver the notifications again
+			require.Nil(t, notifier.CatchUp(ctx))
+			require.Nil(t, notifier.CatchUp(ctx))
+
+			notifier.wg.Wait()
+
+			received := recv.received()
+			require.Len(t, received, len(test.expected))
+
+			for i, expected := range test.expected {
+				assert.Equal(t, expected.Event, received[i].Event)
+				assert.Equal(t, run.Id, received[i].RunId)
+				assert.Equal(t, expected.Node, received[i].Node)
+				assert.Equal(t, expected.Attempt, received[i].Attempt)
+				assert.Equal(t, expected.Conclusion, received[i].Conclusion)
+				assert.Equal(t, expected.FailedNodes, received[i].FailedNodes)
+			}
+
+			if len(received) > 0 {
+				assert.True(t, recv.valid, "signature invalid")
+			}
+
+			deliveries, err := repo.ListDeliveries(ctx, webhook.Id)
+			require.Nil(t, err)
+
+			if test.attempts == 0 {
+				assert.Empty(t, deliveries)
+				return
+			}
+
+			for _, delivery := range deliveries {
+				assert.Equal(t, test.status, delivery.Status)
+				assert.Equal(t, test.attempts, delivery.Attempts)
+			}
+
+			if test.status == adagio.Delivery_FAILED {
+				require.Len(t, deliveries, 1)
+				assert.Equal(t, int32(500), deliveries[0].ResponseCode)
+				assert.Contains(t, deliveries[0].Error, "500")
+			}
+		})
+	}
+}
+
+func Test_Notifier_Run(t *testing.T) {
+	var (
+		repo = memory.New(memory.WithLogger(logging.Discard()))
+		spec = &adagio.Node_Spec{Name: "a", Runtime: "test"}
+		recv = &receiver{secret: "secret"}
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	server := httptest.NewServer(recv)
+	defer server.Close()
+
+	webhook, err := adagio.NewWebhook(server.URL, recv.secret, adagio.Webhook_RUN_SUCCEEDED)
+	require.Nil(t, err)
+
+	require.Nil(t, repo.CreateWebhook(ctx, webhook))
+
+	notifier := New(repo, WithLogger(logging.Discard()))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		notifier.Run(ctx)
+	}()
+
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	// the run is started once the notifier is running so is
+	// notified as the repository reports it updated
+	run, err := repo.StartRun(ctx, &adagio.GraphSpec{Nodes: []*adagio.Node_Spec{spec}})
+	require.Nil(t, err)
+
+	claim := &adagio.Claim{Id: "claim"}
+
+	_, claimed, err := repo.ClaimNode(ctx, run.Id, spec.Name, claim)
+	require.Nil(t, err)
+	require.True(t, claimed)
+
+	require.Nil(t, repo.FinishNode(ctx, run.Id, spec.Name, &adagio.Node_Result{Conclusion: adagio.Node_Result_SUCCESS}, claim))
+
+	require.Eventually(t, func() bool {
+		return len(recv.received()) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	received := recv.received()
+	require.Len(t, received, 1)
+	assert.Equal(t, adagio.Webhook_RUN_SUCCEEDED, received[0].Event)
+	assert.Equal(t, run.Id, received[0].RunId)
+}
+
+func Test_Notifier_backoff(t *testing.T) {
+	notifier := New(nil, WithBackoff(time.Second, 5*time.Second))
+
+	for attempts, expected := range map[int32]time.Duration{
+		1: time.Second,
+		2: 2 * time.Second,
+		3: 4 * time.Second,
+		4: 5 * time.Second,
+		9: 5 * time.Second,
+	} {
+		assert.Equal(t, expected, notifier.backoff(attempts), "attempts %d", attempts)
+	}
+}
@@ -0,0 +1,62 @@
+package notify
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/georgemac/adagio/pkg/logging"
+)
+
+// Option is a functional option for the Notifier
+type Option func(*Notifier)
+
+// Options is a slice of Option
+type Options []Option
+
+// Apply calls each option on n in turn
+func (o Options) Apply(n *Notifier) {
+	for _, opt := range o {
+		opt(n)
+	}
+}
+
+// WithLookback configures how long before the notifier starts runs must have been
+// created in order to be caught up on when it starts (defaults to 24h)
+func WithLookback(lookback time.Duration) Option {
+	return func(n *Notifier) {
+		n.lookback = lookback
+	}
+}
+
+// WithMaxAttempts configures the number of attempts made to post a
+// notification before its delivery is marked as failed (defaults to 5)
+func WithMaxAttempts(attempts int32) Option {
+	return func(n *Notifier) {
+		n.maxAttempts = attempts
+	}
+}
+
+// WithBackoff configures the delay before the first retry of a delivery which is
+// doubled for each subsequent retry up to the maximum delay (defaults to 1s and 1m)
+func WithBackoff(initial, max time.Duration) Option {
+	return func(n *Notifier) {
+		n.initialDelay = initial
+		n.maxDelay = max
+	}
+}
+
+// WithHTTPClient configures the client used to post notifications
+// (defaults to a client with a 10s timeout)
+func WithHTTPClient(client *http.Client) Option {
+	return func(n *Notifier) {
+		n.client = client
+	}
+}
+
+// WithLogger configures the logger on which failed deliveries and errors
+// watching the repository are logged (defaults to info level logfmt on stderr)
+func WithLogger(logger logging.Logger) Option {
+	return func(n *Notifier) {
+		n.logger = logger
+	}
+}
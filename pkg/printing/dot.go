@@ -6,7 +6,7 @@ import (
 	"github.com/georgemac/adagio/pkg/adagio"
 )
 
-var tableTmpl = `%s [shape=none, margin=0, label=<<table border="0" cellborder="1" cellspacing="0" cellpadding="4">
+var tableTmpl = `%q [shape=none, margin=0, label=<<table border="0" cellborder="1" cellspacing="0" cellpadding="4">
     <tr><td bgcolor="lightgrey">%s (attempts %d)</td></tr>
     <tr><td bgcolor="%s">%s</td></tr>
 </table>>];
@@ -50,7 +50,13 @@ func Dot(pbrun *adagio.Run) (fmt.Formatter, error) {
 		}
 
 		for _, edge := range pbrun.Edges {
-			fmt.Fprintf(w, "    %s -> %s;\n", edge.Source, edge.Destination)
+			if edge.Condition != nil {
+				// conditional edges are distinguished with a dashed line
+				fmt.Fprintf(w, "    %q -> %q [style=dashed];\n", edge.Source, edge.Destination)
+				continue
+			}
+
+			fmt.Fprintf(w, "    %q -> %q;\n", edge.Source, edge.Destination)
 		}
 
 		fmt.Fprint(w, "}")
@@ -15,7 +15,31 @@ type (
 	// Result is a printing package simplified representation of an adagio result
 	Result struct {
 		Conclusion string
+		Metadata   map[string][]string
 		Output     string
+		StartedAt  time.Time
+		FinishedAt time.Time
+		ClaimID    string
+		AgentID    string
+		Hostname   string
+	}
+
+	// Progress is a printing package simplified representation of an adagio node progress
+	Progress struct {
+		Percent   float64
+		Message   string
+		Metadata  map[string][]string
+		UpdatedAt time.Time
+	}
+
+	// Transition is a printing package simplified representation of an adagio node transition
+	Transition struct {
+		Type       string
+		At         time.Time
+		Attempt    int32
+		AgentID    string
+		ClaimID    string
+		Conclusion string
 	}
 
 	// Node is a printing package simplified representation of an adagio node
@@ -25,15 +49,27 @@ type (
 		Metadata   map[string][]string
 		Status     string
 		Attempts   []Result
+		ReadyAt    time.Time
 		StartedAt  time.Time
 		FinishedAt time.Time
+		NotBefore  time.Time
+		Progress   *Progress
 		Inputs     map[string]string
+		History    []Transition
+	}
+
+	// Link is a printing package simplified representation of an adagio run link
+	Link struct {
+		RunID string
+		Node  string
 	}
 
 	// Run is a printing package simplified representation of an adagio run
 	Run struct {
 		ID        string
 		CreatedAt time.Time
+		Parent    *Link
+		Children  []Link
 		Nodes     []Node
 	}
 )
@@ -49,11 +85,21 @@ func PBRunToRun(pbrun *adagio.Run) (Run, error) {
 		}
 	)
 
+	if parent := pbrun.Parent; parent != nil {
+		run.Parent = &Link{RunID: parent.RunId, Node: parent.Node}
+	}
+
+	for _, child := range pbrun.Children {
+		run.Children = append(run.Children, Link{RunID: child.RunId, Node: child.Node})
+	}
+
 	for _, node := range pbrun.Nodes {
 		var (
 			attempts      []Result
+			readyAt, _    = time.Parse(time.RFC3339, node.ReadyAt)
 			startedAt, _  = time.Parse(time.RFC3339, node.StartedAt)
 			finishedAt, _ = time.Parse(time.RFC3339, node.FinishedAt)
+			notBefore, _  = time.Parse(time.RFC3339, node.NotBefore)
 			status, err   = statusToString(node.Status)
 		)
 		if err != nil {
@@ -61,37 +107,67 @@ func PBRunToRun(pbrun *adagio.Run) (Run, error) {
 		}
 
 		for _, result := range node.Attempts {
+			var (
+				startedAt, _  = time.Parse(time.RFC3339, result.StartedAt)
+				finishedAt, _ = time.Parse(time.RFC3339, result.FinishedAt)
+			)
+
 			attempts = append(attempts, Result{
 				Conclusion: conclusionToString(result.Conclusion),
+				Metadata:   metadataToMap(result.Metadata),
 				Output:     string(result.Output),
+				StartedAt:  startedAt,
+				FinishedAt: finishedAt,
+				ClaimID:    result.ClaimId,
+				AgentID:    result.AgentId,
+				Hostname:   result.Hostname,
 			})
 		}
 
-		metadata := map[string][]string{}
-		for k, v := range node.Spec.Metadata {
-			var values []string
-
-			for _, value := range v.Values {
-				values = append(values, value)
-			}
-
-			metadata[k] = values
-		}
+		metadata := metadataToMap(node.Spec.Metadata)
 
 		inputs := map[string]string{}
 		for k, v := range node.Inputs {
 			inputs[k] = string(v)
 		}
 
+		var progress *Progress
+		if p := node.Progress; p != nil {
+			updatedAt, _ := time.Parse(time.RFC3339, p.UpdatedAt)
+			progress = &Progress{
+				Percent:   p.Percent,
+				Message:   p.Message,
+				Metadata:  metadataToMap(p.Metadata),
+				UpdatedAt: updatedAt,
+			}
+		}
+
+		var history []Transition
+		for _, transition := range node.History {
+			at, _ := time.Parse(time.RFC3339, transition.At)
+			history = append(history, Transition{
+				Type:       strings.ToLower(transition.Type.String()),
+				At:         at,
+				Attempt:    transition.Attempt,
+				AgentID:    transition.AgentId,
+				ClaimID:    transition.ClaimId,
+				Conclusion: conclusionToString(transition.Conclusion),
+			})
+		}
+
 		run.Nodes = append(run.Nodes, Node{
 			Name:       node.Spec.Name,
 			Runtime:    node.Spec.Runtime,
 			Metadata:   metadata,
 			Status:     status,
 			Attempts:   attempts,
+			ReadyAt:    readyAt,
 			StartedAt:  startedAt,
 			FinishedAt: finishedAt,
+			NotBefore:  notBefore,
+			Progress:   progress,
 			Inputs:     inputs,
+			History:    history,
 		})
 	}
 
@@ -130,11 +206,30 @@ func statusToString(state adagio.Node_Status) (string, error) {
 		return "running", nil
 	case adagio.Node_COMPLETED:
 		return "completed", nil
+	case adagio.Node_SKIPPED:
+		return "skipped", nil
+	case adagio.Node_SCHEDULED:
+		return "scheduled", nil
 	default:
 		return "", errors.New("status not recognized")
 	}
 }
 
+func metadataToMap(metadata map[string]*adagio.MetadataValue) map[string][]string {
+	m := map[string][]string{}
+	for k, v := range metadata {
+		var values []string
+
+		for _, value := range v.Values {
+			values = append(values, value)
+		}
+
+		m[k] = values
+	}
+
+	return m
+}
+
 func conclusionToString(conclusion adagio.Node_Result_Conclusion) string {
 	return strings.ToLower(conclusion.String())
 }
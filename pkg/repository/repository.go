@@ -12,31 +12,39 @@ import (
 
 	"github.com/georgemac/adagio/pkg/adagio"
 	"github.com/georgemac/adagio/pkg/agent"
+	"github.com/georgemac/adagio/pkg/notify"
 	"github.com/georgemac/adagio/pkg/service/controlplane"
+	"github.com/golang/protobuf/proto"
 	"github.com/kr/pretty"
 	"github.com/oklog/ulid/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+// runtime is the runtime of the harness nodes and the
+// runtime supported by the agents the harness subscribes
+const runtime = "harness"
+
 var (
-	a = &adagio.Node_Spec{Name: "a"}
-	b = &adagio.Node_Spec{Name: "b"}
-	c = &adagio.Node_Spec{Name: "c"}
-	d = &adagio.Node_Spec{Name: "d"}
-	e = &adagio.Node_Spec{Name: "e"}
-	f = &adagio.Node_Spec{Name: "f"}
-	g = &adagio.Node_Spec{Name: "g"}
+	a = &adagio.Node_Spec{Name: "a", Runtime: runtime}
+	b = &adagio.Node_Spec{Name: "b", Runtime: runtime}
+	c = &adagio.Node_Spec{Name: "c", Runtime: runtime}
+	d = &adagio.Node_Spec{Name: "d", Runtime: runtime}
+	e = &adagio.Node_Spec{Name: "e", Runtime: runtime}
+	f = &adagio.Node_Spec{Name: "f", Runtime: runtime}
+	g = &adagio.Node_Spec{Name: "g", Runtime: runtime}
 
 	h = &adagio.Node_Spec{
-		Name: "h",
+		Name:    "h",
+		Runtime: runtime,
 		Retry: map[string]*adagio.Node_Spec_Retry{
 			"error": {MaxAttempts: 2},
 		},
 	}
 
 	i = &adagio.Node_Spec{
-		Name: "i",
+		Name:    "i",
+		Runtime: runtime,
 		Retry: map[string]*adagio.Node_Spec_Retry{
 			"fail": {MaxAttempts: 2},
 		},
@@ -66,14 +74,25 @@ var (
 			{Source: f.Name, Destination: g.Name},
 		},
 	}
+
+	// ExampleResourcePools are the resource pools with which
+	// a repository under test must be configured
+	ExampleResourcePools = adagio.ResourcePools{"harness": 1}
 )
 
-// Repository is a combination of the controlplane and agent repository types
+// Repository is a combination of the controlplane, agent and notify repository types
 type Repository interface {
 	controlplane.Repository
 	agent.Repository
+	CancelRun(ctx context.Context, id string) error
+	WatchRuns(ctx context.Context, events chan<- *adagio.Event) error
+	CreateDelivery(context.Context, *adagio.Delivery) error
+	UpdateDelivery(context.Context, *adagio.Delivery) error
 }
 
+// compile time check to ensure the harness covers the notify.Repository
+var _ notify.Repository = (Repository)(nil)
+
 // Orphaner is a type which can trigger the orphaning of a node based on a claim
 type Orphaner interface {
 	Orphan(claim *adagio.Claim)
@@ -98,9 +117,27 @@ type Constructor func(func() time.Time) (Repository, Orphaner)
 func TestHarness(t *testing.T, repoFn Constructor) {
 	t.Helper()
 
-	repo, orphaner := repoFn(func() time.Time {
-		return when
-	})
+	var (
+		clockMu sync.Mutex
+		now     = when
+	)
+
+	clock := func() time.Time {
+		clockMu.Lock()
+		defer clockMu.Unlock()
+
+		return now
+	}
+
+	repo, orphaner := repoFn(clock)
+
+	// advance moves the repositories clock forward by the provided duration
+	advance := func(d time.Duration) {
+		clockMu.Lock()
+		defer clockMu.Unlock()
+
+		now = now.Add(d)
+	}
 
 	t.Run("a run is created", func(t *testing.T) {
 		var (
@@ -265,7 +302,7 @@ func TestHarness(t *testing.T, repoFn Constructor) {
 					"e": []byte("e"),
 					"f": []byte("f"),
 				}, success("g")),
-			}, stripClaims(runs[0].Nodes))
+			}, stripClaimsAndHistory(runs[0].Nodes))
 		})
 	})
 
@@ -378,7 +415,7 @@ func TestHarness(t *testing.T, repoFn Constructor) {
 				completed(g, map[string][]byte{
 					"f": []byte("f"),
 				}),
-			}, stripClaims(runs[0].Nodes))
+			}, stripClaimsAndHistory(runs[0].Nodes))
 		})
 	})
 
@@ -531,7 +568,7 @@ func TestHarness(t *testing.T, repoFn Constructor) {
 				completed(h, map[string][]byte{
 					"a": []byte("a"),
 				}, errorResult("h"), success("h")),
-			}, stripClaims(runs[0].Nodes))
+			}, stripClaimsAndHistory(runs[0].Nodes))
 		})
 	})
 
@@ -658,7 +695,7 @@ func TestHarness(t *testing.T, repoFn Constructor) {
 				completed(i, map[string][]byte{
 					"a": []byte("a"),
 				}, fail("i"), fail("i")),
-			}, stripClaims(runs[0].Nodes))
+			}, stripClaimsAndHistory(runs[0].Nodes))
 		})
 	})
 
@@ -677,7 +714,7 @@ func TestHarness(t *testing.T, repoFn Constructor) {
 		require.NotNil(t, run)
 
 		var (
-			agent  = &adagio.Agent{Id: "foo"}
+			agent  = &adagio.Agent{Id: "foo", Runtimes: []*adagio.Runtime{{Name: runtime}}}
 			events = make(chan *adagio.Event, 5)
 		)
 
@@ -712,11 +749,37 @@ func TestHarness(t *testing.T, repoFn Constructor) {
 			return
 		}
 
+		orphaned := claims[a.Name]
+
 		t.Run("the orphaned node", func(t *testing.T) {
 			// ensure orphaned node can be claimed again and has no results yet
 			claims = canClaim(ctx, t, repo, run, map[string]*adagio.Node{"a": running(a, nil)})
 		})
 
+		t.Run("the orphaning is recorded before the next claim", func(t *testing.T) {
+			run, err := repo.InspectRun(ctx, run.Id)
+			require.Nil(t, err)
+
+			node, err := run.GetNodeByName(a.Name)
+			require.Nil(t, err)
+
+			var types []adagio.Node_Transition_Type
+			for _, transition := range node.History {
+				types = append(types, transition.Type)
+			}
+
+			assert.Equal(t, []adagio.Node_Transition_Type{
+				adagio.Node_Transition_READY,
+				adagio.Node_Transition_CLAIMED,
+				adagio.Node_Transition_ORPHANED,
+				adagio.Node_Transition_CLAIMED,
+			}, types)
+
+			require.Len(t, node.History, 4)
+			assert.Equal(t, orphaned.Id, node.History[2].ClaimId)
+			assert.Equal(t, claims[a.Name].Id, node.History[3].ClaimId)
+		})
+
 		// can error the node
 		canFinish(ctx, t, repo, run, map[string]adagio.Node_Result_Conclusion{
 			"a": adagio.Node_Result_ERROR,
@@ -732,7 +795,7 @@ func TestHarness(t *testing.T, repoFn Constructor) {
 
 			assert.Equal(t, []*adagio.Node{
 				completed(a, nil, errorResult("a")),
-			}, stripClaims(runs[0].Nodes))
+			}, stripClaimsAndHistory(runs[0].Nodes))
 		})
 	})
 
@@ -745,6 +808,7 @@ func TestHarness(t *testing.T, repoFn Constructor) {
 			NodeCounts: &adagio.Stats_NodeCounts{
 				CompletedCount: 23,
 			},
+			UnschedulableCounts: map[string]int64{},
 		}, stats)
 	})
 
@@ -812,219 +876,1630 @@ func TestHarness(t *testing.T, repoFn Constructor) {
 			})
 		}
 	})
-}
 
-// TestLayer is used by the TestHarness to run a prebaked scenario of calls (claims and finishes)
-// and expect a state of the world
-type TestLayer struct {
-	Name        string
-	Repository  Repository
-	Run         *adagio.Run
-	Unclaimable []string
-	Claimable   map[string]*adagio.Node
-	Finish      map[string]adagio.Node_Result_Conclusion
-	Events      []*adagio.Event
-	RunStatus   adagio.Run_Status
-}
+	t.Run("a child run is linked to its parent", func(t *testing.T) {
+		var (
+			ctx         = context.Background()
+			parent, err = repo.StartRun(ctx, &adagio.GraphSpec{
+				Nodes: []*adagio.Node_Spec{a},
+			})
+		)
+		require.Nil(t, err)
 
-// Exec executes the test layer against the provided testing T given a context
-func (l *TestLayer) Exec(ctx context.Context, t *testing.T) {
-	t.Helper()
+		child, err := repo.StartRun(ctx, &adagio.GraphSpec{
+			Nodes: []*adagio.Node_Spec{b},
+		}, adagio.WithParent(parent.Id, a.Name))
+		require.Nil(t, err)
 
-	var (
-		agent     = &adagio.Agent{Id: "foo"}
-		events    = make(chan *adagio.Event, len(l.Events))
-		collected = make([]*adagio.Event, 0)
-		err       = l.Repository.Subscribe(ctx, agent, events, adagio.Event_NODE_READY)
-	)
-	require.Nil(t, err)
+		link := &adagio.Run_Link{RunId: parent.Id, Node: a.Name}
+		assert.Equal(t, link, child.Parent)
 
-	defer func() {
-		l.Repository.UnsubscribeAll(ctx, agent, events)
+		t.Run("the child references the parent", func(t *testing.T) {
+			run, err := repo.InspectRun(ctx, child.Id)
+			require.Nil(t, err)
 
-		close(events)
-	}()
+			assert.Equal(t, link, run.Parent)
+			assert.Empty(t, run.Children)
+		})
 
-	var claims map[string]*adagio.Claim
-	t.Run(l.Name, func(t *testing.T) {
-		canNotClaim(ctx, t, l.Repository, l.Run, l.Unclaimable...)
+		t.Run("the parent references the child", func(t *testing.T) {
+			run, err := repo.InspectRun(ctx, parent.Id)
+			require.Nil(t, err)
 
-		claims = canClaim(ctx, t, l.Repository, l.Run, l.Claimable)
+			assert.Nil(t, run.Parent)
+			assert.Equal(t, []*adagio.Run_Link{
+				{RunId: child.Id, Node: a.Name},
+			}, run.Children)
+		})
 	})
 
-	canFinish(ctx, t, l.Repository, l.Run, l.Finish, claims)
-
-	t.Run(fmt.Sprintf("the run is reported with a status of %q", l.RunStatus), func(t *testing.T) {
-		// check run reports expected status
-		run, err := l.Repository.InspectRun(ctx, l.Run.Id)
+	t.Run("a run with a map node", func(t *testing.T) {
+		var (
+			ctx = context.Background()
+			m   = &adagio.Node_Spec{
+				Name: "m",
+				Map:  &adagio.Node_Spec_Map{Over: a.Name},
+			}
+			// (a) --> (m) --> (c)
+			//          ^
+			//         /
+			// (b) ----
+			run, err = repo.StartRun(ctx, &adagio.GraphSpec{
+				Nodes: []*adagio.Node_Spec{a, b, m, c},
+				Edges: []*adagio.Edge{
+					{Source: a.Name, Destination: m.Name},
+					{Source: b.Name, Destination: m.Name},
+					{Source: m.Name, Destination: c.Name},
+				},
+			})
+		)
 		require.Nil(t, err)
-		require.Equal(t, l.RunStatus, run.Status)
-	})
-
-	for i := 0; i < len(l.Events); i++ {
-		select {
-		case event := <-events:
-			collected = append(collected, event)
-		case <-time.After(5 * time.Second):
-			fmt.Printf("collected so far %#v", collected)
-			t.Error("timeout collecting events")
-			return
-		}
-	}
 
-	sort.SliceStable(collected, func(i, j int) bool {
-		return collected[i].NodeSpec.Name < collected[j].NodeSpec.Name
-	})
+		var claims map[string]*adagio.Claim
+		t.Run("the root nodes are claimed", func(t *testing.T) {
+			claims = canClaim(ctx, t, repo, run, map[string]*adagio.Node{
+				"a": running(a, nil),
+				"b": running(b, nil),
+			})
+		})
 
-	if l.Events != nil {
-		if !assert.Equal(t, l.Events, collected) {
-			fmt.Println(pretty.Diff(l.Events, collected))
-		}
-	}
+		require.Nil(t, repo.FinishNode(ctx, run.Id, a.Name, &adagio.Node_Result{
+			Conclusion: adagio.Node_Result_SUCCESS,
+			Output:     []byte(`["x", {"y": 1}]`),
+		}, claims[a.Name]))
 
-	t.Run("the subscribed agent is listed", func(t *testing.T) {
-		agents, err := l.Repository.ListAgents(ctx)
-		require.Nil(t, err)
-		require.Len(t, agents, 1)
+		canFinish(ctx, t, repo, run, map[string]adagio.Node_Result_Conclusion{
+			"b": adagio.Node_Result_SUCCESS,
+		}, claims)
 
-		assert.Equal(t, agent, agents[0])
-	})
-}
+		var (
+			m0 = &adagio.Node_Spec{Name: "m[0]"}
+			m1 = &adagio.Node_Spec{Name: "m[1]"}
+		)
 
-func canNotClaim(ctx context.Context, t *testing.T, repo Repository, run *adagio.Run, names ...string) {
-	t.Helper()
+		t.Run("the map node is expanded", func(t *testing.T) {
+			run, err := repo.InspectRun(ctx, run.Id)
+			require.Nil(t, err)
 
-	t.Run("can not claim", func(t *testing.T) {
-		t.Parallel()
+			node, err := run.GetNodeByName(m.Name)
+			require.Nil(t, err)
 
-		for _, name := range names {
-			t.Run(fmt.Sprintf("node %q cannot be claimed because it is not ready", name), func(t *testing.T) {
-				func(name string) {
-					t.Parallel()
+			assert.Equal(t, adagio.Node_COMPLETED, node.Status)
+			assert.Equal(t, []*adagio.Node_Result{success(`["m[0]","m[1]"]`)}, node.Attempts)
 
-					_, _, err := repo.ClaimNode(ctx, run.Id, name, newClaim())
-					assert.True(t, errors.Is(err, adagio.ErrNodeNotReady), "error unexpected", err)
-				}(name)
-			})
-		}
-	})
-}
+			assert.Contains(t, run.Edges, &adagio.Edge{Source: b.Name, Destination: m0.Name})
+			assert.Contains(t, run.Edges, &adagio.Edge{Source: m1.Name, Destination: c.Name})
+		})
 
-func canClaim(ctx context.Context, t *testing.T, repo Repository, run *adagio.Run, nodes map[string]*adagio.Node) (claims map[string]*adagio.Claim) {
-	t.Helper()
+		t.Run("the reduce node is not ready", func(t *testing.T) {
+			canNotClaim(ctx, t, repo, run, c.Name)
+		})
 
-	var mu sync.Mutex
-	claims = map[string]*adagio.Claim{}
+		t.Run("the map node instances are claimed", func(t *testing.T) {
+			claims = canClaim(ctx, t, repo, run, map[string]*adagio.Node{
+				"m[0]": running(m0, map[string][]byte{
+					"a": []byte("x"),
+					"b": []byte("b"),
+				}),
+				"m[1]": running(m1, map[string][]byte{
+					"a": []byte(`{"y": 1}`),
+					"b": []byte("b"),
+				}),
+			})
+		})
 
-	t.Run("can claim", func(t *testing.T) {
-		t.Parallel()
+		canFinish(ctx, t, repo, run, map[string]adagio.Node_Result_Conclusion{
+			"m[0]": adagio.Node_Result_SUCCESS,
+			"m[1]": adagio.Node_Result_SUCCESS,
+		}, claims)
 
-		for name, node := range nodes {
-			t.Run(fmt.Sprintf("5 concurrent claim attempts for node %q", name), func(t *testing.T) {
-				func(name string, node *adagio.Node) {
-					t.Parallel()
+		t.Run("the reduce node receives all instance outputs", func(t *testing.T) {
+			claims = canClaim(ctx, t, repo, run, map[string]*adagio.Node{
+				"c": running(c, map[string][]byte{
+					"m":    []byte(`["m[0]","m[1]"]`),
+					"m[0]": []byte("m[0]"),
+					"m[1]": []byte("m[1]"),
+				}),
+			})
+		})
 
-					claimed, claim := attemptNClaims(ctx, t, repo, run, name, 5)
-					node.Claim = claim
+		canFinish(ctx, t, repo, run, map[string]adagio.Node_Result_Conclusion{
+			"c": adagio.Node_Result_SUCCESS,
+		}, claims)
 
-					t.Run("and it returns the correct node", func(t *testing.T) {
-						assert.Equal(t, node, claimed)
-					})
+		run, err = repo.InspectRun(ctx, run.Id)
+		require.Nil(t, err)
 
-					mu.Lock()
-					claims[name] = claim
-					mu.Unlock()
-				}(name, node)
-			})
-		}
+		assert.Equal(t, adagio.Run_COMPLETED, run.Status)
+		assert.Len(t, run.Nodes, 6)
 	})
 
-	return
-}
-
-func canFinish(ctx context.Context, t *testing.T, repo Repository, run *adagio.Run, names map[string]adagio.Node_Result_Conclusion, claims map[string]*adagio.Claim) {
-	t.Helper()
+	t.Run("a map node over an empty array", func(t *testing.T) {
+		var (
+			ctx = context.Background()
+			m   = &adagio.Node_Spec{
+				Name: "m",
+				Map:  &adagio.Node_Spec_Map{Over: a.Name},
+			}
+			// (a) --> (m) --> (c)
+			run, err = repo.StartRun(ctx, &adagio.GraphSpec{
+				Nodes: []*adagio.Node_Spec{a, m, c},
+				Edges: []*adagio.Edge{
+					{Source: a.Name, Destination: m.Name},
+					{Source: m.Name, Destination: c.Name},
+				},
+			})
+		)
+		require.Nil(t, err)
 
-	t.Run("can finish", func(t *testing.T) {
-		for name, conclusion := range names {
-			claim := claims[name]
-			require.NotNil(t, claim, "claim is missing")
+		var claims map[string]*adagio.Claim
+		t.Run("the root node is claimed", func(t *testing.T) {
+			claims = canClaim(ctx, t, repo, run, map[string]*adagio.Node{
+				"a": running(a, nil),
+			})
+		})
 
-			t.Run(fmt.Sprintf("node %q", name), func(t *testing.T) {
-				func(name string, conclusion adagio.Node_Result_Conclusion, claim *adagio.Claim) {
-					t.Parallel()
+		require.Nil(t, repo.FinishNode(ctx, run.Id, a.Name, &adagio.Node_Result{
+			Conclusion: adagio.Node_Result_SUCCESS,
+			Output:     []byte(`[]`),
+		}, claims[a.Name]))
 
-					assert.Nil(t, repo.FinishNode(ctx, run.Id, name, &adagio.Node_Result{
-						Conclusion: conclusion,
-						Output:     []byte(name),
-					}, claim))
-				}(name, conclusion, claim)
+		t.Run("the reduce node is ready", func(t *testing.T) {
+			canClaim(ctx, t, repo, run, map[string]*adagio.Node{
+				"c": running(c, map[string][]byte{
+					"m": []byte(`[]`),
+				}),
 			})
-		}
+		})
 	})
-}
-
-func attemptNClaims(ctx context.Context, t *testing.T, repo Repository, run *adagio.Run, name string, n int) (node *adagio.Node, claim *adagio.Claim) {
-	t.Helper()
 
-	t.Run("only one successful claim is made", func(t *testing.T) {
+	t.Run("a map node over an invalid output", func(t *testing.T) {
 		var (
-			wg    sync.WaitGroup
-			mu    sync.Mutex
-			count int32
+			ctx = context.Background()
+			m   = &adagio.Node_Spec{
+				Name: "m",
+				Map:  &adagio.Node_Spec_Map{Over: a.Name},
+			}
+			// (a) --> (m) --> (c)
+			run, err = repo.StartRun(ctx, &adagio.GraphSpec{
+				Nodes: []*adagio.Node_Spec{a, m, c},
+				Edges: []*adagio.Edge{
+					{Source: a.Name, Destination: m.Name},
+					{Source: m.Name, Destination: c.Name},
+				},
+			})
 		)
+		require.Nil(t, err)
 
-		for i := 0; i < n; i++ {
-			wg.Add(1)
-			go func() {
-				defer wg.Done()
-				var (
-					cclaim         = newClaim()
-					cnode, ok, err = repo.ClaimNode(ctx, run.Id, name, cclaim)
-				)
-				require.Nil(t, err)
-
-				if ok {
-					mu.Lock()
-					count++
-					node = cnode
-					claim = cclaim
-					mu.Unlock()
+		var claims map[string]*adagio.Claim
+		t.Run("the root node is claimed", func(t *testing.T) {
+			claims = canClaim(ctx, t, repo, run, map[string]*adagio.Node{
+				"a": running(a, nil),
+			})
+		})
 
-					return
-				}
+		require.Nil(t, repo.FinishNode(ctx, run.Id, a.Name, &adagio.Node_Result{
+			Conclusion: adagio.Node_Result_SUCCESS,
+			Output:     []byte(`not an array`),
+		}, claims[a.Name]))
 
-				require.Nil(t, cnode)
-			}()
-		}
+		run, err = repo.InspectRun(ctx, run.Id)
+		require.Nil(t, err)
 
-		wg.Wait()
+		assert.Equal(t, adagio.Run_COMPLETED, run.Status)
 
-		require.Equal(t, int32(1), count)
+		node, err := run.GetNodeByName(m.Name)
+		require.Nil(t, err)
+		require.Len(t, node.Attempts, 1)
+		assert.Equal(t, adagio.Node_Result_ERROR, node.Attempts[0].Conclusion)
 	})
 
-	return
-}
+	t.Run("a run with conditional edges", func(t *testing.T) {
+		var (
+			ctx     = context.Background()
+			deploy  = &adagio.Edge_Condition{Output: map[string]string{"deploy": "true"}}
+			abandon = &adagio.Edge_Condition{Output: map[string]string{"deploy": "true"}, Negate: true}
+			failed  = &adagio.Edge_Condition{Conclusions: []adagio.Node_Result_Conclusion{adagio.Node_Result_FAIL}}
+			//    ---(deploy)--> (b) --
+			//   /                     \
+			// (a) --(!deploy)-> (c) --> (d)
+			//   \
+			//    ---(failed)--> (e) --> (f)
+			run, err = repo.StartRun(ctx, &adagio.GraphSpec{
+				Nodes: []*adagio.Node_Spec{a, b, c, d, e, f},
+				Edges: []*adagio.Edge{
+					{Source: a.Name, Destination: b.Name, Condition: deploy},
+					{Source: a.Name, Destination: c.Name, Condition: abandon},
+					{Source: a.Name, Destination: e.Name, Condition: failed},
+					{Source: b.Name, Destination: d.Name},
+					{Source: c.Name, Destination: d.Name},
+					{Source: e.Name, Destination: f.Name},
+				},
+			})
+		)
+		require.Nil(t, err)
 
-func waiting(spec *adagio.Node_Spec) *adagio.Node {
-	return node(spec, adagio.Node_WAITING, nil)
-}
+		var claims map[string]*adagio.Claim
+		t.Run("the root node is claimed", func(t *testing.T) {
+			claims = canClaim(ctx, t, repo, run, map[string]*adagio.Node{
+				"a": running(a, nil),
+			})
+		})
 
-func ready(spec *adagio.Node_Spec, inputs map[string][]byte) *adagio.Node {
-	return node(spec, adagio.Node_READY, inputs)
-}
+		require.Nil(t, repo.FinishNode(ctx, run.Id, a.Name, &adagio.Node_Result{
+			Conclusion: adagio.Node_Result_SUCCESS,
+			Output:     []byte(`{"deploy": true}`),
+		}, claims[a.Name]))
 
-func running(spec *adagio.Node_Spec, inputs map[string][]byte, attempts ...*adagio.Node_Result) *adagio.Node {
-	n := node(spec, adagio.Node_RUNNING, inputs)
-	n.StartedAt = when.Format(time.RFC3339)
-	n.Attempts = attempts
-	return n
-}
+		t.Run("the unsatisfied branches are skipped", func(t *testing.T) {
+			run, err := repo.InspectRun(ctx, run.Id)
+			require.Nil(t, err)
+
+			for _, name := range []string{c.Name, e.Name, f.Name} {
+				node, err := run.GetNodeByName(name)
+				require.Nil(t, err)
+
+				assert.Equal(t, adagio.Node_SKIPPED, node.Status, name)
+				assert.Empty(t, node.Attempts, name)
+			}
+		})
+
+		t.Run("the satisfied branch is claimed", func(t *testing.T) {
+			claims = canClaim(ctx, t, repo, run, map[string]*adagio.Node{
+				"b": running(b, map[string][]byte{"a": []byte(`{"deploy": true}`)}),
+			})
+		})
+
+		canFinish(ctx, t, repo, run, map[string]adagio.Node_Result_Conclusion{
+			"b": adagio.Node_Result_SUCCESS,
+		}, claims)
+
+		t.Run("the join node is ready given one satisfied branch", func(t *testing.T) {
+			claims = canClaim(ctx, t, repo, run, map[string]*adagio.Node{
+				"d": running(d, map[string][]byte{"b": []byte("b")}),
+			})
+		})
+
+		canFinish(ctx, t, repo, run, map[string]adagio.Node_Result_Conclusion{
+			"d": adagio.Node_Result_SUCCESS,
+		}, claims)
+
+		run, err = repo.InspectRun(ctx, run.Id)
+		require.Nil(t, err)
+
+		assert.Equal(t, adagio.Run_COMPLETED, run.Status)
+	})
+
+	t.Run("a run with a conditional edge on failure", func(t *testing.T) {
+		var (
+			ctx    = context.Background()
+			failed = &adagio.Edge_Condition{Conclusions: []adagio.Node_Result_Conclusion{adagio.Node_Result_FAIL}}
+			//    ---(failed)--> (b)
+			//   /
+			// (a) ------------> (c)
+			run, err = repo.StartRun(ctx, &adagio.GraphSpec{
+				Nodes: []*adagio.Node_Spec{a, b, c},
+				Edges: []*adagio.Edge{
+					{Source: a.Name, Destination: b.Name, Condition: failed},
+					{Source: a.Name, Destination: c.Name},
+				},
+			})
+		)
+		require.Nil(t, err)
+
+		var claims map[string]*adagio.Claim
+		t.Run("the root node is claimed", func(t *testing.T) {
+			claims = canClaim(ctx, t, repo, run, map[string]*adagio.Node{
+				"a": running(a, nil),
+			})
+		})
+
+		canFinish(ctx, t, repo, run, map[string]adagio.Node_Result_Conclusion{
+			"a": adagio.Node_Result_FAIL,
+		}, claims)
+
+		t.Run("the failure branch is claimed", func(t *testing.T) {
+			claims = canClaim(ctx, t, repo, run, map[string]*adagio.Node{
+				"b": running(b, nil),
+			})
+		})
+
+		t.Run("the success branch is completed without an attempt", func(t *testing.T) {
+			run, err := repo.InspectRun(ctx, run.Id)
+			require.Nil(t, err)
+
+			node, err := run.GetNodeByName(c.Name)
+			require.Nil(t, err)
+
+			assert.Equal(t, adagio.Node_COMPLETED, node.Status)
+			assert.Empty(t, node.Attempts)
+		})
+	})
+
+	t.Run("a run with an invalid condition", func(t *testing.T) {
+		_, err := repo.StartRun(context.Background(), &adagio.GraphSpec{
+			Nodes: []*adagio.Node_Spec{a, b},
+			Edges: []*adagio.Edge{
+				{
+					Source:      a.Name,
+					Destination: b.Name,
+					Condition:   &adagio.Edge_Condition{Output: map[string]string{"deploy..now": "true"}},
+				},
+			},
+		})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("a run with an approval node", func(t *testing.T) {
+		var (
+			ctx  = context.Background()
+			gate = &adagio.Node_Spec{
+				Name:     "gate",
+				Approval: &adagio.Node_Spec_Approval{Timeout: "1h"},
+			}
+			// (a) --> (gate) --> (b)
+			run, err = repo.StartRun(ctx, &adagio.GraphSpec{
+				Nodes: []*adagio.Node_Spec{a, gate, b},
+				Edges: []*adagio.Edge{
+					{Source: a.Name, Destination: gate.Name},
+					{Source: gate.Name, Destination: b.Name},
+				},
+			})
+		)
+		require.Nil(t, err)
+
+		var claims map[string]*adagio.Claim
+		t.Run("the root node is claimed", func(t *testing.T) {
+			claims = canClaim(ctx, t, repo, run, map[string]*adagio.Node{
+				"a": running(a, nil),
+			})
+		})
+
+		canFinish(ctx, t, repo, run, map[string]adagio.Node_Result_Conclusion{
+			"a": adagio.Node_Result_SUCCESS,
+		}, claims)
+
+		t.Run("the approval node awaits approval", func(t *testing.T) {
+			_, _, err := repo.ClaimNode(ctx, run.Id, gate.Name, newClaim())
+			assert.True(t, errors.Is(err, adagio.ErrNodeRequiresApproval), "error unexpected", err)
+
+			run, err := repo.InspectRun(ctx, run.Id)
+			require.Nil(t, err)
+
+			node, err := run.GetNodeByName(gate.Name)
+			require.Nil(t, err)
+
+			assert.Equal(t, adagio.Node_READY, node.Status)
+			assert.Equal(t, when.Format(time.RFC3339Nano), node.StartedAt)
+		})
+
+		t.Run("a node which is not awaiting approval cannot be approved", func(t *testing.T) {
+			err := repo.ResolveApproval(ctx, run.Id, b.Name, adagio.ApprovalResult(true, "alice", "lgtm"))
+			assert.True(t, errors.Is(err, adagio.ErrNodeNotAwaitingApproval), "error unexpected", err)
+		})
+
+		approval := adagio.ApprovalResult(true, "alice", "lgtm")
+		require.Nil(t, repo.ResolveApproval(ctx, run.Id, gate.Name, approval))
+
+		t.Run("the approval is stored as the result", func(t *testing.T) {
+			run, err := repo.InspectRun(ctx, run.Id)
+			require.Nil(t, err)
+
+			node, err := run.GetNodeByName(gate.Name)
+			require.Nil(t, err)
+
+			assert.Equal(t, adagio.Node_COMPLETED, node.Status)
+			assert.Equal(t, []*adagio.Node_Result{approval}, node.Attempts)
+		})
+
+		t.Run("the approved branch is claimed", func(t *testing.T) {
+			canClaim(ctx, t, repo, run, map[string]*adagio.Node{
+				"b": running(b, map[string][]byte{"gate": []byte("lgtm")}),
+			})
+		})
+	})
+
+	t.Run("a run with a rejected approval node", func(t *testing.T) {
+		var (
+			ctx  = context.Background()
+			gate = &adagio.Node_Spec{
+				Name:     "gate",
+				Approval: &adagio.Node_Spec_Approval{},
+			}
+			// (gate) --> (b)
+			run, err = repo.StartRun(ctx, &adagio.GraphSpec{
+				Nodes: []*adagio.Node_Spec{gate, b},
+				Edges: []*adagio.Edge{
+					{Source: gate.Name, Destination: b.Name},
+				},
+			})
+		)
+		require.Nil(t, err)
+
+		require.Nil(t, repo.ResolveApproval(ctx, run.Id, gate.Name, adagio.ApprovalResult(false, "bob", "not today")))
+
+		run, err = repo.InspectRun(ctx, run.Id)
+		require.Nil(t, err)
+
+		assert.Equal(t, adagio.Run_COMPLETED, run.Status)
+
+		node, err := run.GetNodeByName(b.Name)
+		require.Nil(t, err)
+
+		assert.Empty(t, node.Attempts)
+	})
+
+	t.Run("approval nodes expire", func(t *testing.T) {
+		var (
+			ctx  = context.Background()
+			gate = &adagio.Node_Spec{
+				Name:     "gate",
+				Approval: &adagio.Node_Spec_Approval{Timeout: "1h"},
+			}
+			spec = &adagio.GraphSpec{
+				Nodes: []*adagio.Node_Spec{gate, b},
+				Edges: []*adagio.Edge{
+					{Source: gate.Name, Destination: b.Name},
+				},
+			}
+		)
+
+		first, err := repo.StartRun(ctx, spec)
+		require.Nil(t, err)
+
+		second, err := repo.StartRun(ctx, spec)
+		require.Nil(t, err)
+
+		require.Nil(t, repo.ExpireApprovals(ctx))
+
+		advance(2 * time.Hour)
+
+		t.Run("when approved after expiry", func(t *testing.T) {
+			err := repo.ResolveApproval(ctx, first.Id, gate.Name, adagio.ApprovalResult(true, "alice", "lgtm"))
+			assert.True(t, errors.Is(err, adagio.ErrApprovalExpired), "error unexpected", err)
+		})
+
+		t.Run("when expired approvals are failed", func(t *testing.T) {
+			require.Nil(t, repo.ExpireApprovals(ctx))
+		})
+
+		for _, run := range []*adagio.Run{first, second} {
+			run, err := repo.InspectRun(ctx, run.Id)
+			require.Nil(t, err)
+
+			assert.Equal(t, adagio.Run_COMPLETED, run.Status)
+
+			node, err := run.GetNodeByName(gate.Name)
+			require.Nil(t, err)
+
+			assert.Equal(t, []*adagio.Node_Result{adagio.ApprovalExpiredResult()}, node.Attempts)
+		}
+	})
+
+	t.Run("a cancelled run", func(t *testing.T) {
+		var (
+			ctx   = context.Background()
+			first = &adagio.Node_Spec{Name: "first", Runtime: runtime}
+			then  = &adagio.Node_Spec{Name: "then", Runtime: runtime}
+			claim = &adagio.Claim{Id: "cancelled"}
+
+			run, err = repo.StartRun(ctx, &adagio.GraphSpec{
+				Nodes: []*adagio.Node_Spec{first, then},
+				Edges: []*adagio.Edge{{Source: first.Name, Destination: then.Name}},
+			})
+		)
+		require.Nil(t, err)
+
+		_, ok, err := repo.ClaimNode(ctx, run.Id, first.Name, claim)
+		require.Nil(t, err)
+		require.True(t, ok)
+
+		child, err := repo.StartRun(ctx, &adagio.GraphSpec{
+			Nodes: []*adagio.Node_Spec{{Name: "child", Runtime: runtime}},
+		}, adagio.WithParent(run.Id, first.Name))
+		require.Nil(t, err)
+
+		require.Nil(t, repo.CancelRun(ctx, run.Id))
+
+		for _, id := range []string{run.Id, child.Id} {
+			t.Run("completes every unresolved node with a cancelled result", func(t *testing.T) {
+				run, err := repo.InspectRun(ctx, id)
+				require.Nil(t, err)
+
+				assert.Equal(t, adagio.Run_COMPLETED, run.Status)
+
+				for _, node := range run.Nodes {
+					assert.Equal(t, adagio.Node_COMPLETED, node.Status)
+					assert.Equal(t, []*adagio.Node_Result{adagio.CancelledResult()}, node.Attempts)
+				}
+			})
+		}
+
+		t.Run("the running node can no longer be finished", func(t *testing.T) {
+			assert.NotNil(t, repo.FinishNode(ctx, run.Id, first.Name, &adagio.Node_Result{Conclusion: adagio.Node_Result_SUCCESS}, claim))
+		})
+
+		t.Run("the remaining nodes can not be claimed", func(t *testing.T) {
+			_, ok, err := repo.ClaimNode(ctx, run.Id, then.Name, &adagio.Claim{Id: "too-late"})
+			require.Nil(t, err)
+			assert.False(t, ok)
+		})
+	})
+
+	t.Run("a watched run", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		events := make(chan *adagio.Event, 10)
+		require.Nil(t, repo.WatchRuns(ctx, events))
+
+		run, err := repo.StartRun(ctx, &adagio.GraphSpec{
+			Nodes: []*adagio.Node_Spec{{Name: "watched", Runtime: runtime}},
+		})
+		require.Nil(t, err)
+
+		// awaitUpdate waits for a run updated event of the watched run
+		// ignoring those of runs updated by other scenarios
+		awaitUpdate := func(t *testing.T) {
+			timeout := time.After(5 * time.Second)
+			for {
+				select {
+				case event := <-events:
+					if event.RunID != run.Id {
+						continue
+					}
+
+					assert.Equal(t, adagio.Event_RUN_UPDATED, event.Type)
+					return
+				case <-timeout:
+					t.Fatal("timed out waiting for run updated event")
+				}
+			}
+		}
+
+		t.Run("is updated when started", awaitUpdate)
+
+		_, ok, err := repo.ClaimNode(ctx, run.Id, "watched", &adagio.Claim{Id: "watched"})
+		require.Nil(t, err)
+		require.True(t, ok)
+
+		t.Run("is updated when a node is claimed", awaitUpdate)
+
+		require.Nil(t, repo.FinishNode(ctx, run.Id, "watched", &adagio.Node_Result{Conclusion: adagio.Node_Result_SUCCESS}, &adagio.Claim{Id: "watched"}))
+
+		t.Run("is updated when a node is finished", awaitUpdate)
+	})
+
+	t.Run("a cancelled run with a running pooled node", func(t *testing.T) {
+		var (
+			ctx   = context.Background()
+			spec  = &adagio.Node_Spec{Name: "pooled", Runtime: runtime, Resources: map[string]int32{"harness": 1}}
+			claim = &adagio.Claim{Id: "cancelled-pooled"}
+		)
+
+		first, err := repo.StartRun(ctx, &adagio.GraphSpec{Nodes: []*adagio.Node_Spec{spec}})
+		require.Nil(t, err)
+
+		second, err := repo.StartRun(ctx, &adagio.GraphSpec{Nodes: []*adagio.Node_Spec{spec}})
+		require.Nil(t, err)
+
+		_, ok, err := repo.ClaimNode(ctx, first.Id, spec.Name, claim)
+		require.Nil(t, err)
+		require.True(t, ok)
+
+		require.Nil(t, repo.CancelRun(ctx, first.Id))
+
+		t.Run("releases the pools slot", func(t *testing.T) {
+			secondClaim := &adagio.Claim{Id: "cancelled-pooled-second"}
+
+			_, ok, err := repo.ClaimNode(ctx, second.Id, spec.Name, secondClaim)
+			require.Nil(t, err)
+			require.True(t, ok)
+
+			require.Nil(t, repo.FinishNode(ctx, second.Id, spec.Name, &adagio.Node_Result{Conclusion: adagio.Node_Result_SUCCESS}, secondClaim))
+		})
+
+		t.Run("the cancelled node can no longer be finished", func(t *testing.T) {
+			assert.NotNil(t, repo.FinishNode(ctx, first.Id, spec.Name, &adagio.Node_Result{Conclusion: adagio.Node_Result_SUCCESS}, claim))
+		})
+	})
+
+	t.Run("a run with an invalid approval timeout", func(t *testing.T) {
+		_, err := repo.StartRun(context.Background(), &adagio.GraphSpec{
+			Nodes: []*adagio.Node_Spec{
+				{Name: "gate", Approval: &adagio.Node_Spec_Approval{Timeout: "soon"}},
+			},
+		})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("a rescheduled node", func(t *testing.T) {
+		var (
+			ctx      = context.Background()
+			claim    = &adagio.Claim{Id: "sensor"}
+			run, err = repo.StartRun(ctx, &adagio.GraphSpec{
+				Nodes: []*adagio.Node_Spec{a},
+			})
+		)
+		require.Nil(t, err)
+
+		claimed, ok, err := repo.ClaimNode(ctx, run.Id, a.Name, claim)
+		require.Nil(t, err)
+		require.True(t, ok)
+
+		startedAt := claimed.StartedAt
+		notBefore := clock().Add(time.Minute)
+
+		before, err := repo.Stats(ctx)
+		require.Nil(t, err)
+
+		require.Nil(t, repo.RescheduleNode(ctx, run.Id, a.Name, notBefore, claim))
+
+		t.Run("is scheduled without an attempt", func(t *testing.T) {
+			run, err := repo.InspectRun(ctx, run.Id)
+			require.Nil(t, err)
+
+			node, err := run.GetNodeByName(a.Name)
+			require.Nil(t, err)
+
+			assert.Equal(t, adagio.Node_SCHEDULED, node.Status)
+			assert.Equal(t, notBefore.Format(time.RFC3339Nano), node.NotBefore)
+			assert.Empty(t, node.Attempts)
+		})
+
+		t.Run("stats do not report the scheduled node as ready", func(t *testing.T) {
+			stats, err := repo.Stats(ctx)
+			require.Nil(t, err)
+
+			assert.Equal(t, before.NodeCounts.ScheduledCount+1, stats.NodeCounts.ScheduledCount)
+			assert.Equal(t, before.NodeCounts.ReadyCount, stats.NodeCounts.ReadyCount)
+			assert.Equal(t, before.UnschedulableCounts, stats.UnschedulableCounts)
+		})
+
+		t.Run("can not be claimed before it is scheduled", func(t *testing.T) {
+			_, _, err := repo.ClaimNode(ctx, run.Id, a.Name, &adagio.Claim{Id: "early"})
+
+			var scheduled *adagio.ScheduledError
+			require.True(t, errors.As(err, &scheduled), "error unexpected", err)
+			assert.True(t, notBefore.Equal(scheduled.NotBefore))
+		})
+
+		t.Run("is not promoted before it is scheduled", func(t *testing.T) {
+			require.Nil(t, repo.PromoteScheduled(ctx))
+
+			run, err := repo.InspectRun(ctx, run.Id)
+			require.Nil(t, err)
+
+			node, err := run.GetNodeByName(a.Name)
+			require.Nil(t, err)
+
+			assert.Equal(t, adagio.Node_SCHEDULED, node.Status)
+		})
+
+		advance(2 * time.Minute)
+
+		t.Run("is promoted to ready once scheduled", func(t *testing.T) {
+			var (
+				agent  = &adagio.Agent{Id: "promoted", Runtimes: []*adagio.Runtime{{Name: a.Runtime}}}
+				events = make(chan *adagio.Event, 100)
+			)
+
+			require.Nil(t, repo.Subscribe(ctx, agent, events, adagio.Event_NODE_READY))
+
+			defer repo.UnsubscribeAll(ctx, agent, events)
+
+			require.Nil(t, repo.PromoteScheduled(ctx))
+
+			run, err := repo.InspectRun(ctx, run.Id)
+			require.Nil(t, err)
+
+			node, err := run.GetNodeByName(a.Name)
+			require.Nil(t, err)
+
+			assert.Equal(t, adagio.Node_READY, node.Status)
+
+			// ready events for nodes of other runs may be delivered first
+			timeout := time.After(5 * time.Second)
+			for {
+				select {
+				case event := <-events:
+					if event.RunID != run.Id {
+						continue
+					}
+
+					assert.Equal(t, a.Name, event.NodeSpec.Name)
+				case <-timeout:
+					t.Fatal("timeout collecting event")
+				}
+
+				break
+			}
+		})
+
+		t.Run("can be claimed once scheduled", func(t *testing.T) {
+			node, ok, err := repo.ClaimNode(ctx, run.Id, a.Name, &adagio.Claim{Id: "later"})
+			require.Nil(t, err)
+			require.True(t, ok)
+
+			assert.Equal(t, adagio.Node_RUNNING, node.Status)
+			assert.Equal(t, startedAt, node.StartedAt)
+			assert.Empty(t, node.NotBefore)
+		})
+	})
+
+	t.Run("a node retried with backoff", func(t *testing.T) {
+		var (
+			ctx  = context.Background()
+			spec = &adagio.Node_Spec{
+				Name: "flakey",
+				Retry: map[string]*adagio.Node_Spec_Retry{
+					string(adagio.OnFail): {
+						MaxAttempts:  3,
+						InitialDelay: "1m",
+						Multiplier:   2,
+					},
+				},
+			}
+			failed   = &adagio.Node_Result{Conclusion: adagio.Node_Result_FAIL}
+			run, err = repo.StartRun(ctx, &adagio.GraphSpec{
+				Nodes: []*adagio.Node_Spec{spec},
+			})
+		)
+		require.Nil(t, err)
+
+		for i, delay := range []time.Duration{time.Minute, 2 * time.Minute} {
+			claim := &adagio.Claim{Id: fmt.Sprintf("flakey-%d", i)}
+
+			_, ok, err := repo.ClaimNode(ctx, run.Id, spec.Name, claim)
+			require.Nil(t, err)
+			require.True(t, ok)
+
+			require.Nil(t, repo.FinishNode(ctx, run.Id, spec.Name, failed, claim))
+
+			notBefore := clock().Add(delay)
+
+			t.Run(fmt.Sprintf("retry %d is scheduled after %v", i+1, delay), func(t *testing.T) {
+				run, err := repo.InspectRun(ctx, run.Id)
+				require.Nil(t, err)
+
+				node, err := run.GetNodeByName(spec.Name)
+				require.Nil(t, err)
+
+				assert.Equal(t, adagio.Node_SCHEDULED, node.Status)
+				assert.Equal(t, notBefore.Format(time.RFC3339Nano), node.NotBefore)
+				assert.Len(t, node.Attempts, i+1)
+			})
+
+			t.Run(fmt.Sprintf("retry %d can not be claimed before the delay", i+1), func(t *testing.T) {
+				_, _, err := repo.ClaimNode(ctx, run.Id, spec.Name, &adagio.Claim{Id: "early"})
+				assert.True(t, errors.Is(err, adagio.ErrNodeScheduled), "error unexpected", err)
+			})
+
+			advance(delay)
+
+			require.Nil(t, repo.PromoteScheduled(ctx))
+		}
+
+		t.Run("the final retry is attempted", func(t *testing.T) {
+			claim := &adagio.Claim{Id: "flakey-final"}
+
+			node, ok, err := repo.ClaimNode(ctx, run.Id, spec.Name, claim)
+			require.Nil(t, err)
+			require.True(t, ok)
+
+			// each attempt records its own start time
+			assert.Equal(t, clock().Format(time.RFC3339Nano), node.StartedAt)
+
+			require.Nil(t, repo.FinishNode(ctx, run.Id, spec.Name, failed, claim))
+
+			run, err := repo.InspectRun(ctx, run.Id)
+			require.Nil(t, err)
+
+			assert.Equal(t, adagio.Run_COMPLETED, run.Status)
+		})
+	})
+
+	t.Run("a run with an invalid selector", func(t *testing.T) {
+		_, err := repo.StartRun(context.Background(), &adagio.GraphSpec{
+			Nodes: []*adagio.Node_Spec{
+				{Name: "a", Selector: &adagio.Node_Spec_Selector{
+					MatchExpressions: []*adagio.Node_Spec_Selector_Requirement{
+						{Key: "zone", Operator: adagio.Node_Spec_Selector_Requirement_IN},
+					},
+				}},
+			},
+		})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("a subscription for a subset of runtimes", func(t *testing.T) {
+		var (
+			ctx         = context.Background()
+			supported   = &adagio.Node_Spec{Name: "supported", Runtime: "supported"}
+			unsupported = &adagio.Node_Spec{Name: "unsupported", Runtime: "unsupported"}
+			run, err    = repo.StartRun(ctx, &adagio.GraphSpec{
+				Nodes: []*adagio.Node_Spec{supported, unsupported},
+			})
+		)
+		require.Nil(t, err)
+
+		var (
+			agent  = &adagio.Agent{Id: "subset", Runtimes: []*adagio.Runtime{{Name: "supported"}}}
+			events = make(chan *adagio.Event, 10)
+		)
+
+		require.Nil(t, repo.Subscribe(ctx, agent, events, adagio.Event_NODE_READY))
+
+		defer repo.UnsubscribeAll(ctx, agent, events)
+
+		t.Run("only receives events for supported runtimes", func(t *testing.T) {
+			select {
+			case event := <-events:
+				assert.Equal(t, &adagio.Event{RunID: run.Id, NodeSpec: supported, Type: adagio.Event_NODE_READY}, event)
+			case <-time.After(5 * time.Second):
+				t.Fatal("timeout collecting event")
+			}
+
+			select {
+			case event := <-events:
+				t.Errorf("unexpected event %v", event)
+			case <-time.After(100 * time.Millisecond):
+			}
+		})
+
+		t.Run("ready nodes record when they became ready", func(t *testing.T) {
+			run, err := repo.InspectRun(ctx, run.Id)
+			require.Nil(t, err)
+
+			node, err := run.GetNodeByName(unsupported.Name)
+			require.Nil(t, err)
+
+			assert.NotEmpty(t, node.ReadyAt)
+		})
+
+		t.Run("stats report the unschedulable node", func(t *testing.T) {
+			stats, err := repo.Stats(ctx)
+			require.Nil(t, err)
+
+			assert.Equal(t, int64(1), stats.UnschedulableCounts["unsupported"])
+			assert.Equal(t, int64(0), stats.UnschedulableCounts["supported"])
+		})
+	})
+
+	t.Run("a run with an invalid retry delay", func(t *testing.T) {
+		_, err := repo.StartRun(context.Background(), &adagio.GraphSpec{
+			Nodes: []*adagio.Node_Spec{
+				{Name: "a", Retry: map[string]*adagio.Node_Spec_Retry{
+					string(adagio.OnFail): {MaxAttempts: 1, InitialDelay: "later"},
+				}},
+			},
+		})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("a run with max parallelism", func(t *testing.T) {
+		var (
+			ctx      = context.Background()
+			run, err = repo.StartRun(ctx, &adagio.GraphSpec{
+				Nodes: []*adagio.Node_Spec{
+					{Name: "a", Runtime: runtime},
+					{Name: "b", Runtime: runtime},
+				},
+				MaxParallelism: 1,
+			})
+			claim = &adagio.Claim{Id: "parallel-a"}
+		)
+		require.Nil(t, err)
+
+		assert.Equal(t, int32(1), run.MaxParallelism)
+
+		_, ok, err := repo.ClaimNode(ctx, run.Id, "a", claim)
+		require.Nil(t, err)
+		require.True(t, ok)
+
+		t.Run("can not claim beyond the limit", func(t *testing.T) {
+			_, _, err := repo.ClaimNode(ctx, run.Id, "b", &adagio.Claim{Id: "parallel-b-early"})
+			assert.True(t, errors.Is(err, adagio.ErrConcurrencyLimit), "error unexpected", err)
+		})
+
+		require.Nil(t, repo.FinishNode(ctx, run.Id, "a", &adagio.Node_Result{Conclusion: adagio.Node_Result_SUCCESS}, claim))
+
+		t.Run("can claim once a node has finished", func(t *testing.T) {
+			_, ok, err := repo.ClaimNode(ctx, run.Id, "b", &adagio.Claim{Id: "parallel-b"})
+			require.Nil(t, err)
+			assert.True(t, ok)
+		})
+	})
+
+	t.Run("runs requiring a resource pool", func(t *testing.T) {
+		var (
+			ctx   = context.Background()
+			spec  = &adagio.Node_Spec{Name: "a", Runtime: runtime, Resources: map[string]int32{"harness": 1}}
+			claim = &adagio.Claim{Id: "resource-first"}
+		)
+
+		first, err := repo.StartRun(ctx, &adagio.GraphSpec{Nodes: []*adagio.Node_Spec{spec}})
+		require.Nil(t, err)
+
+		second, err := repo.StartRun(ctx, &adagio.GraphSpec{Nodes: []*adagio.Node_Spec{spec}})
+		require.Nil(t, err)
+
+		_, ok, err := repo.ClaimNode(ctx, first.Id, spec.Name, claim)
+		require.Nil(t, err)
+		require.True(t, ok)
+
+		t.Run("can not claim beyond the pools slots", func(t *testing.T) {
+			_, _, err := repo.ClaimNode(ctx, second.Id, spec.Name, &adagio.Claim{Id: "resource-second-early"})
+			assert.True(t, errors.Is(err, adagio.ErrConcurrencyLimit), "error unexpected", err)
+		})
+
+		require.Nil(t, repo.FinishNode(ctx, first.Id, spec.Name, &adagio.Node_Result{Conclusion: adagio.Node_Result_SUCCESS}, claim))
+
+		t.Run("can claim once the slot is released", func(t *testing.T) {
+			_, ok, err := repo.ClaimNode(ctx, second.Id, spec.Name, &adagio.Claim{Id: "resource-second"})
+			require.Nil(t, err)
+			assert.True(t, ok)
+		})
+
+		t.Run("a run requiring an unknown pool is rejected", func(t *testing.T) {
+			_, err := repo.StartRun(ctx, &adagio.GraphSpec{
+				Nodes: []*adagio.Node_Spec{
+					{Name: "a", Runtime: runtime, Resources: map[string]int32{"unknown": 1}},
+				},
+			})
+			assert.NotNil(t, err)
+		})
+
+		t.Run("a run requiring more slots than the pool has is rejected", func(t *testing.T) {
+			_, err := repo.StartRun(ctx, &adagio.GraphSpec{
+				Nodes: []*adagio.Node_Spec{
+					{Name: "a", Runtime: runtime, Resources: map[string]int32{"harness": 2}},
+				},
+			})
+			assert.NotNil(t, err)
+		})
+	})
+
+	t.Run("a run with a priority", func(t *testing.T) {
+		var (
+			ctx      = context.Background()
+			spec     = &adagio.Node_Spec{Name: "urgent", Runtime: "prioritized", Priority: 2}
+			run, err = repo.StartRun(ctx, &adagio.GraphSpec{
+				Nodes:    []*adagio.Node_Spec{spec},
+				Priority: 5,
+			})
+		)
+		require.Nil(t, err)
+
+		assert.Equal(t, int32(5), run.Priority)
+
+		var (
+			agent  = &adagio.Agent{Id: "prioritized", Runtimes: []*adagio.Runtime{{Name: "prioritized"}}}
+			events = make(chan *adagio.Event, 10)
+		)
+
+		require.Nil(t, repo.Subscribe(ctx, agent, events, adagio.Event_NODE_READY))
+
+		defer repo.UnsubscribeAll(ctx, agent, events)
+
+		t.Run("ready events carry the nodes effective priority", func(t *testing.T) {
+			select {
+			case event := <-events:
+				assert.Equal(t, &adagio.Event{RunID: run.Id, NodeSpec: spec, Type: adagio.Event_NODE_READY, Priority: 7}, event)
+			case <-time.After(5 * time.Second):
+				t.Fatal("timeout collecting event")
+			}
+		})
+	})
+
+	t.Run("an agent heartbeat", func(t *testing.T) {
+		var (
+			ctx    = context.Background()
+			agent  = &adagio.Agent{Id: "beating", Runtimes: []*adagio.Runtime{{Name: "beating"}}, Hostname: "host"}
+			events = make(chan *adagio.Event, 10)
+		)
+
+		require.Nil(t, repo.Subscribe(ctx, agent, events, adagio.Event_NODE_READY))
+
+		defer repo.UnsubscribeAll(ctx, agent, events)
+
+		require.Nil(t, repo.Heartbeat(ctx, &adagio.Agent{
+			Id:            agent.Id,
+			Runtimes:      agent.Runtimes,
+			Hostname:      agent.Hostname,
+			LastHeartbeat: clock().Format(time.RFC3339Nano),
+			Current:       &adagio.Agent_Work{RunId: "run", Node: "node"},
+		}))
+
+		t.Run("the agent is listed with its heartbeat", func(t *testing.T) {
+			agents, err := repo.ListAgents(ctx)
+			require.Nil(t, err)
+
+			var found *adagio.Agent
+			for _, a := range agents {
+				if a.Id == agent.Id {
+					found = a
+				}
+			}
+
+			require.NotNil(t, found)
+			assert.Equal(t, "host", found.Hostname)
+			assert.Equal(t, clock().Format(time.RFC3339Nano), found.LastHeartbeat)
+			assert.Equal(t, &adagio.Agent_Work{RunId: "run", Node: "node"}, found.Current)
+		})
+
+		t.Run("an unknown agent heartbeat is rejected", func(t *testing.T) {
+			err := repo.Heartbeat(ctx, &adagio.Agent{Id: "unknown"})
+			assert.True(t, errors.Is(err, adagio.ErrAgentDoesNotExist), "error unexpected", err)
+		})
+	})
+
+	t.Run("a node reporting progress", func(t *testing.T) {
+		var (
+			ctx      = context.Background()
+			spec     = &adagio.Node_Spec{Name: "progressing", Runtime: runtime}
+			claim    = &adagio.Claim{Id: "progressing"}
+			progress = &adagio.Node_Progress{
+				Percent:  50,
+				Message:  "halfway",
+				Metadata: map[string]*adagio.MetadataValue{"step": {Values: []string{"2"}}},
+			}
+			run, err = repo.StartRun(ctx, &adagio.GraphSpec{Nodes: []*adagio.Node_Spec{spec}})
+		)
+		require.Nil(t, err)
+
+		_, ok, err := repo.ClaimNode(ctx, run.Id, spec.Name, claim)
+		require.Nil(t, err)
+		require.True(t, ok)
+
+		require.Nil(t, repo.ReportProgress(ctx, run.Id, spec.Name, progress, claim))
+
+		t.Run("the progress is recorded on the running node", func(t *testing.T) {
+			run, err := repo.InspectRun(ctx, run.Id)
+			require.Nil(t, err)
+
+			node, err := run.GetNodeByName(spec.Name)
+			require.Nil(t, err)
+
+			assert.Equal(t, progress, node.Progress)
+		})
+
+		t.Run("progress can not be reported with another claim", func(t *testing.T) {
+			err := repo.ReportProgress(ctx, run.Id, spec.Name, progress, &adagio.Claim{Id: "other"})
+			assert.True(t, errors.Is(err, adagio.ErrClaimNotHeld), "error unexpected", err)
+		})
+
+		require.Nil(t, repo.FinishNode(ctx, run.Id, spec.Name, &adagio.Node_Result{Conclusion: adagio.Node_Result_SUCCESS}, claim))
+
+		t.Run("progress can not be reported once the node has finished", func(t *testing.T) {
+			err := repo.ReportProgress(ctx, run.Id, spec.Name, progress, claim)
+			assert.True(t, errors.Is(err, adagio.ErrClaimNotHeld), "error unexpected", err)
+		})
+	})
+
+	t.Run("a node writing logs", func(t *testing.T) {
+		var (
+			ctx      = context.Background()
+			spec     = &adagio.Node_Spec{Name: "logging", Runtime: runtime}
+			run, err = repo.StartRun(ctx, &adagio.GraphSpec{Nodes: []*adagio.Node_Spec{spec}})
+			lines    = func(texts ...string) (lines []*adagio.LogLine) {
+				for _, text := range texts {
+					lines = append(lines, &adagio.LogLine{Timestamp: clock().Format(time.RFC3339Nano), Text: text})
+				}
+
+				return
+			}
+		)
+		require.Nil(t, err)
+
+		require.Nil(t, repo.AppendLogs(ctx, run.Id, spec.Name, 1, lines("one", "two")))
+		require.Nil(t, repo.AppendLogs(ctx, run.Id, spec.Name, 1, lines("three")))
+		require.Nil(t, repo.AppendLogs(ctx, run.Id, spec.Name, 2, lines("other")))
+
+		t.Run("the lines of an attempt are read in order", func(t *testing.T) {
+			found, err := repo.ReadLogs(ctx, run.Id, spec.Name, 1, 0)
+			require.Nil(t, err)
+
+			assert.Equal(t, lines("one", "two", "three"), found)
+		})
+
+		t.Run("the lines of an attempt are read from an offset", func(t *testing.T) {
+			found, err := repo.ReadLogs(ctx, run.Id, spec.Name, 1, 2)
+			require.Nil(t, err)
+
+			assert.Equal(t, lines("three"), found)
+
+			found, err = repo.ReadLogs(ctx, run.Id, spec.Name, 1, 3)
+			require.Nil(t, err)
+
+			assert.Empty(t, found)
+		})
+
+		t.Run("the lines of each attempt are kept apart", func(t *testing.T) {
+			found, err := repo.ReadLogs(ctx, run.Id, spec.Name, 2, 0)
+			require.Nil(t, err)
+
+			assert.Equal(t, lines("other"), found)
+		})
+
+		t.Run("logs can not be appended for an unknown node", func(t *testing.T) {
+			err := repo.AppendLogs(ctx, run.Id, "unknown", 1, lines("missing"))
+			assert.True(t, errors.Is(err, adagio.ErrMissingNode), "error unexpected", err)
+		})
+	})
+
+	t.Run("a node records the history of its transitions", func(t *testing.T) {
+		var (
+			ctx  = context.Background()
+			x    = &adagio.Node_Spec{Name: "x", Runtime: runtime, Retry: map[string]*adagio.Node_Spec_Retry{"fail": {MaxAttempts: 2}}}
+			y    = &adagio.Node_Spec{Name: "y", Runtime: runtime}
+			spec = &adagio.GraphSpec{
+				Nodes: []*adagio.Node_Spec{x, y},
+				Edges: []*adagio.Edge{{Source: "x", Destination: "y"}},
+			}
+			run, err = repo.StartRun(ctx, spec)
+
+			history    []*adagio.Node_Transition
+			transition = func(typ adagio.Node_Transition_Type, attempt int32, claim *adagio.Claim, conclusion adagio.Node_Result_Conclusion) {
+				transition := &adagio.Node_Transition{
+					Type:       typ,
+					At:         clock().Format(time.RFC3339Nano),
+					Attempt:    attempt,
+					Conclusion: conclusion,
+				}
+
+				if claim != nil {
+					transition.AgentId = claim.AgentId
+					transition.ClaimId = claim.Id
+				}
+
+				history = append(history, transition)
+			}
+			claim = func(id, agent string, attempt int32) *adagio.Claim {
+				advance(time.Minute)
+
+				claim := &adagio.Claim{Id: id, AgentId: agent}
+
+				_, ok, err := repo.ClaimNode(ctx, run.Id, x.Name, claim)
+				require.Nil(t, err)
+				require.True(t, ok)
+
+				transition(adagio.Node_Transition_CLAIMED, attempt, claim, adagio.Node_Result_NONE)
+
+				return claim
+			}
+		)
+		require.Nil(t, err)
+
+		transition(adagio.Node_Transition_READY, 1, nil, adagio.Node_Result_NONE)
+
+		// the first claim is rescheduled
+		first := claim("first", "agent-a", 1)
+
+		advance(time.Minute)
+		require.Nil(t, repo.RescheduleNode(ctx, run.Id, x.Name, clock(), first))
+
+		transition(adagio.Node_Transition_RESCHEDULED, 1, first, adagio.Node_Result_NONE)
+		transition(adagio.Node_Transition_READY, 1, nil, adagio.Node_Result_NONE)
+
+		// the second claim fails and the node is retried
+		second := claim("second", "agent-b", 1)
+
+		advance(time.Minute)
+		require.Nil(t, repo.FinishNode(ctx, run.Id, x.Name, fail("x"), second))
+
+		transition(adagio.Node_Transition_FINISHED, 1, second, adagio.Node_Result_FAIL)
+		transition(adagio.Node_Transition_RETRIED, 2, nil, adagio.Node_Result_NONE)
+		transition(adagio.Node_Transition_READY, 2, nil, adagio.Node_Result_NONE)
+
+		// the third claim succeeds
+		third := claim("third", "agent-a", 2)
+
+		attributed := success("x")
+		attributed.StartedAt = clock().Format(time.RFC3339Nano)
+
+		advance(time.Minute)
+
+		attributed.FinishedAt = clock().Format(time.RFC3339Nano)
+		attributed.ClaimId = third.Id
+		attributed.AgentId = third.AgentId
+		attributed.Hostname = "host-a"
+
+		require.Nil(t, repo.FinishNode(ctx, run.Id, x.Name, attributed, third))
+
+		transition(adagio.Node_Transition_FINISHED, 2, third, adagio.Node_Result_SUCCESS)
+
+		run, err = repo.InspectRun(ctx, run.Id)
+		require.Nil(t, err)
+
+		t.Run("each transition of the attempts is recorded", func(t *testing.T) {
+			node, err := run.GetNodeByName(x.Name)
+			require.Nil(t, err)
+
+			assert.Equal(t, history, node.History)
+		})
+
+		t.Run("the timing and attribution of each attempt is persisted", func(t *testing.T) {
+			node, err := run.GetNodeByName(x.Name)
+			require.Nil(t, err)
+
+			assert.Equal(t, []*adagio.Node_Result{fail("x"), attributed}, node.Attempts)
+		})
+
+		t.Run("the outgoing node records when it became ready", func(t *testing.T) {
+			node, err := run.GetNodeByName(y.Name)
+			require.Nil(t, err)
+
+			assert.Equal(t, []*adagio.Node_Transition{
+				{Type: adagio.Node_Transition_READY, At: clock().Format(time.RFC3339Nano), Attempt: 1},
+			}, node.History)
+		})
+	})
+
+	t.Run("webhooks are managed", func(t *testing.T) {
+		ctx := context.Background()
+
+		webhook, err := adagio.NewWebhook("http://example.com/hook", "secret", adagio.Webhook_RUN_FAILED)
+		require.Nil(t, err)
+
+		require.Nil(t, repo.CreateWebhook(ctx, webhook))
+
+		t.Run("the webhook can be inspected", func(t *testing.T) {
+			found, err := repo.InspectWebhook(ctx, webhook.Id)
+			require.Nil(t, err)
+
+			assert.Equal(t, webhook, found)
+		})
+
+		t.Run("the webhook is listed", func(t *testing.T) {
+			webhooks, err := repo.ListWebhooks(ctx)
+			require.Nil(t, err)
+
+			assert.Equal(t, []*adagio.Webhook{webhook}, webhooks)
+		})
+
+		t.Run("the webhook can be updated", func(t *testing.T) {
+			updated := proto.Clone(webhook).(*adagio.Webhook)
+			updated.Events = append(updated.Events, adagio.Webhook_APPROVAL_REQUESTED)
+
+			require.Nil(t, repo.UpdateWebhook(ctx, updated))
+
+			found, err := repo.InspectWebhook(ctx, webhook.Id)
+			require.Nil(t, err)
+
+			assert.Equal(t, updated, found)
+		})
+
+		t.Run("a delivery is created once per notification", func(t *testing.T) {
+			delivery := &adagio.Delivery{
+				WebhookId:    webhook.Id,
+				Notification: &adagio.Notification{Id: "run/completed", Event: adagio.Webhook_RUN_FAILED, RunId: "run"},
+				Status:       adagio.Delivery_PENDING,
+				CreatedAt:    clock().Format(time.RFC3339Nano),
+			}
+
+			require.Nil(t, repo.CreateDelivery(ctx, delivery))
+
+			err := repo.CreateDelivery(ctx, delivery)
+			assert.True(t, errors.Is(err, adagio.ErrDeliveryExists), "error unexpected", err)
+
+			t.Run("and its attempts are recorded", func(t *testing.T) {
+				delivery.Status = adagio.Delivery_DELIVERED
+				delivery.Attempts = 2
+				delivery.ResponseCode = 200
+
+				require.Nil(t, repo.UpdateDelivery(ctx, delivery))
+
+				deliveries, err := repo.ListDeliveries(ctx, webhook.Id)
+				require.Nil(t, err)
+
+				assert.Equal(t, []*adagio.Delivery{delivery}, deliveries)
+			})
+		})
+
+		t.Run("an unknown delivery can not be updated", func(t *testing.T) {
+			err := repo.UpdateDelivery(ctx, &adagio.Delivery{WebhookId: webhook.Id, Notification: &adagio.Notification{Id: "unknown"}})
+			assert.True(t, errors.Is(err, adagio.ErrDeliveryDoesNotExist), "error unexpected", err)
+		})
+
+		t.Run("a delivery can not be created for an unknown webhook", func(t *testing.T) {
+			err := repo.CreateDelivery(ctx, &adagio.Delivery{WebhookId: "unknown", Notification: &adagio.Notification{Id: "run/completed"}})
+			assert.True(t, errors.Is(err, adagio.ErrWebhookDoesNotExist), "error unexpected", err)
+		})
+
+		t.Run("the webhook can be deleted along with its deliveries", func(t *testing.T) {
+			require.Nil(t, repo.DeleteWebhook(ctx, webhook.Id))
+
+			_, err := repo.InspectWebhook(ctx, webhook.Id)
+			assert.True(t, errors.Is(err, adagio.ErrWebhookDoesNotExist), "error unexpected", err)
+
+			_, err = repo.ListDeliveries(ctx, webhook.Id)
+			assert.True(t, errors.Is(err, adagio.ErrWebhookDoesNotExist), "error unexpected", err)
+
+			webhooks, err := repo.ListWebhooks(ctx)
+			require.Nil(t, err)
+
+			assert.Empty(t, webhooks)
+
+			err = repo.UpdateWebhook(ctx, webhook)
+			assert.True(t, errors.Is(err, adagio.ErrWebhookDoesNotExist), "error unexpected", err)
+
+			err = repo.DeleteWebhook(ctx, webhook.Id)
+			assert.True(t, errors.Is(err, adagio.ErrWebhookDoesNotExist), "error unexpected", err)
+		})
+	})
+
+	t.Run("the audit log is appended to", func(t *testing.T) {
+		var (
+			ctx    = context.Background()
+			events []*adagio.AuditEvent
+		)
+
+		for _, runID := range []string{"first", "second", "first"} {
+			event := adagio.NewAuditEvent("Approve")
+			event.Actor = "harness"
+			event.RunId = runID
+			event.Outcome = adagio.AuditEvent_SUCCEEDED
+
+			require.Nil(t, repo.AppendAuditEvent(ctx, event))
+
+			// events are listed from the most recently appended
+			events = append([]*adagio.AuditEvent{event}, events...)
+		}
+
+		t.Run("every event is listed", func(t *testing.T) {
+			found, err := repo.ListAuditEvents(ctx, controlplane.AuditListRequest{})
+			require.Nil(t, err)
+
+			assert.Equal(t, events, found)
+		})
+
+		t.Run("the events of a run are listed", func(t *testing.T) {
+			found, err := repo.ListAuditEvents(ctx, controlplane.AuditListRequest{RunID: "first"})
+			require.Nil(t, err)
+
+			assert.Equal(t, []*adagio.AuditEvent{events[0], events[2]}, found)
+		})
+
+		t.Run("the listed events are limited", func(t *testing.T) {
+			limit := uint64(1)
+
+			found, err := repo.ListAuditEvents(ctx, controlplane.AuditListRequest{RunID: "first", Limit: &limit})
+			require.Nil(t, err)
+
+			assert.Equal(t, events[:1], found)
+
+			found, err = repo.ListAuditEvents(ctx, controlplane.AuditListRequest{Limit: &limit})
+			require.Nil(t, err)
+
+			assert.Equal(t, events[:1], found)
+		})
+	})
+}
+
+// TestLayer is used by the TestHarness to run a prebaked scenario of calls (claims and finishes)
+// and expect a state of the world
+type TestLayer struct {
+	Name        string
+	Repository  Repository
+	Run         *adagio.Run
+	Unclaimable []string
+	Claimable   map[string]*adagio.Node
+	Finish      map[string]adagio.Node_Result_Conclusion
+	Events      []*adagio.Event
+	RunStatus   adagio.Run_Status
+}
+
+// Exec executes the test layer against the provided testing T given a context
+func (l *TestLayer) Exec(ctx context.Context, t *testing.T) {
+	t.Helper()
+
+	var (
+		agent = &adagio.Agent{
+			Id:       "foo",
+			Runtimes: []*adagio.Runtime{{Name: runtime}},
+			Labels:   map[string]string{"zone": "a"},
+		}
+		events    = make(chan *adagio.Event, len(l.Events))
+		collected = make([]*adagio.Event, 0)
+		err       = l.Repository.Subscribe(ctx, agent, events, adagio.Event_NODE_READY)
+	)
+	require.Nil(t, err)
+
+	defer func() {
+		l.Repository.UnsubscribeAll(ctx, agent, events)
+
+		close(events)
+	}()
+
+	var claims map[string]*adagio.Claim
+	t.Run(l.Name, func(t *testing.T) {
+		canNotClaim(ctx, t, l.Repository, l.Run, l.Unclaimable...)
+
+		claims = canClaim(ctx, t, l.Repository, l.Run, l.Claimable)
+	})
+
+	canFinish(ctx, t, l.Repository, l.Run, l.Finish, claims)
+
+	t.Run(fmt.Sprintf("the run is reported with a status of %q", l.RunStatus), func(t *testing.T) {
+		// check run reports expected status
+		run, err := l.Repository.InspectRun(ctx, l.Run.Id)
+		require.Nil(t, err)
+		require.Equal(t, l.RunStatus, run.Status)
+	})
+
+	for i := 0; i < len(l.Events); i++ {
+		select {
+		case event := <-events:
+			collected = append(collected, event)
+		case <-time.After(5 * time.Second):
+			fmt.Printf("collected so far %#v", collected)
+			t.Error("timeout collecting events")
+			return
+		}
+	}
+
+	sort.SliceStable(collected, func(i, j int) bool {
+		return collected[i].NodeSpec.Name < collected[j].NodeSpec.Name
+	})
+
+	if l.Events != nil {
+		if !assert.Equal(t, l.Events, collected) {
+			fmt.Println(pretty.Diff(l.Events, collected))
+		}
+	}
+
+	t.Run("the subscribed agent is listed", func(t *testing.T) {
+		agents, err := l.Repository.ListAgents(ctx)
+		require.Nil(t, err)
+		require.Len(t, agents, 1)
+
+		assert.Equal(t, agent, agents[0])
+	})
+}
+
+func canNotClaim(ctx context.Context, t *testing.T, repo Repository, run *adagio.Run, names ...string) {
+	t.Helper()
+
+	t.Run("can not claim", func(t *testing.T) {
+		t.Parallel()
+
+		for _, name := range names {
+			t.Run(fmt.Sprintf("node %q cannot be claimed because it is not ready", name), func(t *testing.T) {
+				func(name string) {
+					t.Parallel()
+
+					_, _, err := repo.ClaimNode(ctx, run.Id, name, newClaim())
+					assert.True(t, errors.Is(err, adagio.ErrNodeNotReady), "error unexpected", err)
+				}(name)
+			})
+		}
+	})
+}
+
+func canClaim(ctx context.Context, t *testing.T, repo Repository, run *adagio.Run, nodes map[string]*adagio.Node) (claims map[string]*adagio.Claim) {
+	t.Helper()
+
+	var mu sync.Mutex
+	claims = map[string]*adagio.Claim{}
+
+	t.Run("can claim", func(t *testing.T) {
+		t.Parallel()
+
+		for name, node := range nodes {
+			t.Run(fmt.Sprintf("5 concurrent claim attempts for node %q", name), func(t *testing.T) {
+				func(name string, node *adagio.Node) {
+					t.Parallel()
+
+					claimed, claim := attemptNClaims(ctx, t, repo, run, name, 5)
+					node.Claim = claim
+
+					t.Run("and it returns the correct node", func(t *testing.T) {
+						assert.Equal(t, node, withoutHistory(claimed))
+					})
+
+					mu.Lock()
+					claims[name] = claim
+					mu.Unlock()
+				}(name, node)
+			})
+		}
+	})
+
+	return
+}
+
+func canFinish(ctx context.Context, t *testing.T, repo Repository, run *adagio.Run, names map[string]adagio.Node_Result_Conclusion, claims map[string]*adagio.Claim) {
+	t.Helper()
+
+	t.Run("can finish", func(t *testing.T) {
+		for name, conclusion := range names {
+			claim := claims[name]
+			require.NotNil(t, claim, "claim is missing")
+
+			t.Run(fmt.Sprintf("node %q", name), func(t *testing.T) {
+				func(name string, conclusion adagio.Node_Result_Conclusion, claim *adagio.Claim) {
+					t.Parallel()
+
+					assert.Nil(t, repo.FinishNode(ctx, run.Id, name, &adagio.Node_Result{
+						Conclusion: conclusion,
+						Output:     []byte(name),
+					}, claim))
+				}(name, conclusion, claim)
+			})
+		}
+	})
+}
+
+func attemptNClaims(ctx context.Context, t *testing.T, repo Repository, run *adagio.Run, name string, n int) (node *adagio.Node, claim *adagio.Claim) {
+	t.Helper()
+
+	t.Run("only one successful claim is made", func(t *testing.T) {
+		var (
+			wg    sync.WaitGroup
+			mu    sync.Mutex
+			count int32
+		)
+
+		for i := 0; i < n; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				var (
+					cclaim         = newClaim()
+					cnode, ok, err = repo.ClaimNode(ctx, run.Id, name, cclaim)
+				)
+				require.Nil(t, err)
+
+				if ok {
+					mu.Lock()
+					count++
+					node = cnode
+					claim = cclaim
+					mu.Unlock()
+
+					return
+				}
+
+				require.Nil(t, cnode)
+			}()
+		}
+
+		wg.Wait()
+
+		require.Equal(t, int32(1), count)
+	})
+
+	return
+}
+
+func waiting(spec *adagio.Node_Spec) *adagio.Node {
+	return node(spec, adagio.Node_WAITING, nil)
+}
+
+func ready(spec *adagio.Node_Spec, inputs map[string][]byte) *adagio.Node {
+	n := node(spec, adagio.Node_READY, inputs)
+	n.ReadyAt = when.Format(time.RFC3339)
+	return n
+}
+
+func running(spec *adagio.Node_Spec, inputs map[string][]byte, attempts ...*adagio.Node_Result) *adagio.Node {
+	n := node(spec, adagio.Node_RUNNING, inputs)
+	n.ReadyAt = when.Format(time.RFC3339)
+	n.StartedAt = when.Format(time.RFC3339)
+	n.Attempts = attempts
+	return n
+}
 
 func completed(spec *adagio.Node_Spec, inputs map[string][]byte, attempts ...*adagio.Node_Result) *adagio.Node {
 	n := node(spec, adagio.Node_COMPLETED, inputs)
 	n.Attempts = attempts
 
+	// nodes completed without an attempt were never ready
+	if len(attempts) > 0 {
+		n.ReadyAt = when.Format(time.RFC3339)
+	}
+
 	n.StartedAt = when.Format(time.RFC3339)
 	n.FinishedAt = when.Format(time.RFC3339)
 	return n
@@ -1067,10 +2542,23 @@ func newClaim() *adagio.Claim {
 	}
 }
 
-func stripClaims(nodes []*adagio.Node) []*adagio.Node {
+func stripClaimsAndHistory(nodes []*adagio.Node) []*adagio.Node {
 	for _, n := range nodes {
 		n.Claim = nil
+		n.History = nil
 	}
 
 	return nodes
 }
+
+// withoutHistory returns a copy of the node without its history
+func withoutHistory(n *adagio.Node) *adagio.Node {
+	if n == nil {
+		return nil
+	}
+
+	n = proto.Clone(n).(*adagio.Node)
+	n.History = nil
+
+	return n
+}
@@ -27,6 +27,8 @@ var _ = math.Inf
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 type StatsRequest struct {
+	// namespace of the call (defaults to "default")
+	Namespace            string   `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -57,6 +59,13 @@ func (m *StatsRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_StatsRequest proto.InternalMessageInfo
 
+func (m *StatsRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
 type StatsResponse struct {
 	Stats                *adagio.Stats `protobuf:"bytes,1,opt,name=stats,proto3" json:"stats,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
@@ -97,10 +106,12 @@ func (m *StatsResponse) GetStats() *adagio.Stats {
 }
 
 type StartRequest struct {
-	Spec                 *adagio.GraphSpec `protobuf:"bytes,1,opt,name=spec,proto3" json:"spec,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	Spec *adagio.GraphSpec `protobuf:"bytes,1,opt,name=spec,proto3" json:"spec,omitempty"`
+	// namespace of the call (defaults to "default")
+	Namespace            string   `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *StartRequest) Reset()         { *m = StartRequest{} }
@@ -135,6 +146,13 @@ func (m *StartRequest) GetSpec() *adagio.GraphSpec {
 	return nil
 }
 
+func (m *StartRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
 type StartResponse struct {
 	Run                  *adagio.Run `protobuf:"bytes,1,opt,name=run,proto3" json:"run,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
@@ -175,7 +193,9 @@ func (m *StartResponse) GetRun() *adagio.Run {
 }
 
 type InspectRequest struct {
-	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// namespace of the call (defaults to "default")
+	Namespace            string   `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -213,6 +233,13 @@ func (m *InspectRequest) GetId() string {
 	return ""
 }
 
+func (m *InspectRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
 type InspectResponse struct {
 	Run                  *adagio.Run `protobuf:"bytes,1,opt,name=run,proto3" json:"run,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
@@ -253,9 +280,11 @@ func (m *InspectResponse) GetRun() *adagio.Run {
 }
 
 type ListRequest struct {
-	StartNs              int64    `protobuf:"varint,1,opt,name=start_ns,json=startNs,proto3" json:"start_ns,omitempty"`
-	FinishNs             int64    `protobuf:"varint,2,opt,name=finish_ns,json=finishNs,proto3" json:"finish_ns,omitempty"`
-	Limit                uint64   `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	StartNs  int64  `protobuf:"varint,1,opt,name=start_ns,json=startNs,proto3" json:"start_ns,omitempty"`
+	FinishNs int64  `protobuf:"varint,2,opt,name=finish_ns,json=finishNs,proto3" json:"finish_ns,omitempty"`
+	Limit    uint64 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	// namespace of the call (defaults to "default")
+	Namespace            string   `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -307,6 +336,13 @@ func (m *ListRequest) GetLimit() uint64 {
 	return 0
 }
 
+func (m *ListRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
 type ListRunsResponse struct {
 	Runs                 []*adagio.Run `protobuf:"bytes,1,rep,name=runs,proto3" json:"runs,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
@@ -385,244 +421,1527 @@ func (m *ListAgentsResponse) GetAgents() []*adagio.Agent {
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*StatsRequest)(nil), "adagio.rpc.controlplane.StatsRequest")
-	proto.RegisterType((*StatsResponse)(nil), "adagio.rpc.controlplane.StatsResponse")
-	proto.RegisterType((*StartRequest)(nil), "adagio.rpc.controlplane.StartRequest")
-	proto.RegisterType((*StartResponse)(nil), "adagio.rpc.controlplane.StartResponse")
-	proto.RegisterType((*InspectRequest)(nil), "adagio.rpc.controlplane.InspectRequest")
-	proto.RegisterType((*InspectResponse)(nil), "adagio.rpc.controlplane.InspectResponse")
-	proto.RegisterType((*ListRequest)(nil), "adagio.rpc.controlplane.ListRequest")
-	proto.RegisterType((*ListRunsResponse)(nil), "adagio.rpc.controlplane.ListRunsResponse")
-	proto.RegisterType((*ListAgentsResponse)(nil), "adagio.rpc.controlplane.ListAgentsResponse")
+type InspectAgentRequest struct {
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// namespace of the call (defaults to "default")
+	Namespace            string   `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func init() { proto.RegisterFile("pkg/rpc/controlplane/service.proto", fileDescriptor_44473a7dc25ad712) }
+func (m *InspectAgentRequest) Reset()         { *m = InspectAgentRequest{} }
+func (m *InspectAgentRequest) String() string { return proto.CompactTextString(m) }
+func (*InspectAgentRequest) ProtoMessage()    {}
+func (*InspectAgentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_44473a7dc25ad712, []int{9}
+}
 
-var fileDescriptor_44473a7dc25ad712 = []byte{
-	// 512 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0x4b, 0x6e, 0x13, 0x31,
-	0x18, 0x56, 0x5e, 0x6d, 0xf2, 0xe7, 0xd1, 0xc6, 0x54, 0xa4, 0x0c, 0x20, 0x22, 0x43, 0x20, 0x14,
-	0x69, 0xa6, 0x6a, 0x61, 0x03, 0x62, 0x01, 0x2c, 0x10, 0x12, 0xaa, 0xd0, 0x74, 0x07, 0x0b, 0x34,
-	0x4c, 0x4c, 0x6a, 0x35, 0xb5, 0x8d, 0xed, 0xe9, 0x06, 0xb1, 0xe1, 0x0a, 0x9c, 0x88, 0x33, 0x70,
-	0x05, 0x0e, 0x82, 0xfc, 0xdb, 0x99, 0xa4, 0xa8, 0x49, 0xbb, 0x1a, 0xd9, 0xdf, 0xcb, 0xe3, 0xff,
-	0x93, 0x81, 0xaa, 0xd3, 0x69, 0xa2, 0x55, 0x9e, 0xe4, 0x52, 0x58, 0x2d, 0x67, 0x6a, 0x96, 0x09,
-	0x96, 0x18, 0xa6, 0xcf, 0x79, 0xce, 0x62, 0xa5, 0xa5, 0x95, 0x64, 0x90, 0x4d, 0xb2, 0x29, 0x97,
-	0xb1, 0x56, 0x79, 0xbc, 0x4c, 0x8b, 0x06, 0x4e, 0xec, 0xc1, 0xf0, 0xf1, 0x8a, 0xe8, 0xce, 0x54,
-	0xca, 0xe9, 0x8c, 0x25, 0x99, 0xe2, 0x49, 0x26, 0x84, 0xb4, 0x99, 0xe5, 0x52, 0x18, 0x8f, 0xd2,
-	0x1e, 0x74, 0x8e, 0x6d, 0x66, 0x4d, 0xca, 0xbe, 0x15, 0xcc, 0x58, 0xfa, 0x14, 0xba, 0x61, 0x6d,
-	0x94, 0x14, 0x86, 0x91, 0xfb, 0xd0, 0x30, 0x6e, 0x63, 0xb7, 0x32, 0xac, 0x8c, 0xdb, 0x07, 0xdd,
-	0x38, 0x98, 0x7b, 0x96, 0xc7, 0xe8, 0x33, 0x74, 0xd1, 0x36, 0xb8, 0x90, 0x11, 0xd4, 0x8d, 0x62,
-	0x79, 0xd0, 0xf4, 0xe7, 0x9a, 0xb7, 0x3a, 0x53, 0x27, 0xc7, 0x8a, 0xe5, 0x29, 0xc2, 0x34, 0xc6,
-	0x30, 0x27, 0x0b, 0x61, 0x77, 0xa1, 0xa6, 0x0b, 0x11, 0x64, 0xed, 0xb9, 0x2c, 0x2d, 0x44, 0xea,
-	0xf6, 0xe9, 0x10, 0x7a, 0xef, 0x84, 0x53, 0x96, 0x41, 0x3d, 0xa8, 0xf2, 0x09, 0xf2, 0x5b, 0x69,
-	0x95, 0x4f, 0xe8, 0x3e, 0x6c, 0x95, 0x8c, 0xeb, 0x79, 0x7e, 0x82, 0xf6, 0x7b, 0x6e, 0x4a, 0xc3,
-	0x5b, 0xd0, 0x34, 0xee, 0x48, 0x9f, 0x85, 0xff, 0xe3, 0x5a, 0xba, 0x89, 0xeb, 0x23, 0x43, 0x6e,
-	0x43, 0xeb, 0x2b, 0x17, 0xdc, 0x9c, 0x38, 0xac, 0x8a, 0x58, 0xd3, 0x6f, 0x1c, 0x19, 0xb2, 0x03,
-	0x8d, 0x19, 0x3f, 0xe3, 0x76, 0xb7, 0x36, 0xac, 0x8c, 0xeb, 0xa9, 0x5f, 0xd0, 0x43, 0xd8, 0x46,
-	0xf3, 0x42, 0x2c, 0x2e, 0xf4, 0x1e, 0xd4, 0x75, 0x81, 0xee, 0xb5, 0xff, 0x0f, 0x84, 0x00, 0x7d,
-	0x01, 0xc4, 0x89, 0x5e, 0x4d, 0x99, 0x58, 0x9a, 0xc3, 0x08, 0x36, 0x32, 0xdc, 0x09, 0xc2, 0x72,
-	0x10, 0xc8, 0x4b, 0x03, 0x78, 0xf0, 0xbb, 0x0e, 0x9d, 0x37, 0xbe, 0x17, 0x1f, 0x5c, 0x2f, 0x08,
-	0x87, 0x06, 0x8e, 0x8a, 0x8c, 0xe2, 0x15, 0xd5, 0x89, 0x97, 0x0b, 0x10, 0x3d, 0xbc, 0x8a, 0xe6,
-	0xcf, 0x43, 0xfb, 0x3f, 0xff, 0xfc, 0xfd, 0x55, 0x6d, 0x93, 0x56, 0x72, 0xbe, 0x9f, 0x60, 0x0b,
-	0xc8, 0x29, 0x46, 0x69, 0xbb, 0x3e, 0xaa, 0x6c, 0xc9, 0xfa, 0xa8, 0x45, 0x2b, 0xe8, 0x0d, 0x8c,
-	0xea, 0x46, 0x4d, 0x17, 0xe5, 0xae, 0xe8, 0x79, 0x65, 0x8f, 0x9c, 0x41, 0x73, 0x7e, 0xb5, 0xe4,
-	0xc1, 0x4a, 0xa3, 0xa5, 0xd1, 0x46, 0x8f, 0xd7, 0xb3, 0x96, 0x66, 0x44, 0xb7, 0x31, 0x11, 0x48,
-	0x99, 0x48, 0x0a, 0xd8, 0x0c, 0xc5, 0x22, 0x8f, 0x56, 0xfa, 0x5c, 0x2c, 0x67, 0x34, 0xbe, 0x9a,
-	0x18, 0xf2, 0x06, 0x98, 0xd7, 0x27, 0x5b, 0xf3, 0xbc, 0xe4, 0x3b, 0x9f, 0xbc, 0xdc, 0xfb, 0x41,
-	0x0c, 0xc0, 0xa2, 0x0b, 0xd7, 0xfc, 0xcf, 0x27, 0x6b, 0x59, 0x17, 0x6b, 0x45, 0x09, 0x26, 0x77,
-	0x08, 0xb8, 0x64, 0xdf, 0xa1, 0xd7, 0x37, 0x3f, 0xee, 0x5c, 0xf6, 0x12, 0x7d, 0xd9, 0xc0, 0x27,
-	0xe3, 0xf0, 0x5f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x8c, 0x1c, 0x66, 0x59, 0xa8, 0x04, 0x00, 0x00,
+func (m *InspectAgentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InspectAgentRequest.Unmarshal(m, b)
+}
+func (m *InspectAgentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InspectAgentRequest.Marshal(b, m, deterministic)
+}
+func (m *InspectAgentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InspectAgentRequest.Merge(m, src)
+}
+func (m *InspectAgentRequest) XXX_Size() int {
+	return xxx_messageInfo_InspectAgentRequest.Size(m)
+}
+func (m *InspectAgentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_InspectAgentRequest.DiscardUnknown(m)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+var xxx_messageInfo_InspectAgentRequest proto.InternalMessageInfo
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (m *InspectAgentRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
 
-// ControlPlaneClient is the client API for ControlPlane service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type ControlPlaneClient interface {
-	Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error)
-	Start(ctx context.Context, in *StartRequest, opts ...grpc.CallOption) (*StartResponse, error)
-	ListRuns(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListRunsResponse, error)
-	Inspect(ctx context.Context, in *InspectRequest, opts ...grpc.CallOption) (*InspectResponse, error)
-	ListAgents(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListAgentsResponse, error)
+func (m *InspectAgentRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
 }
 
-type controlPlaneClient struct {
-	cc *grpc.ClientConn
+type InspectAgentResponse struct {
+	Agent                *adagio.Agent `protobuf:"bytes,1,opt,name=agent,proto3" json:"agent,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
-func NewControlPlaneClient(cc *grpc.ClientConn) ControlPlaneClient {
-	return &controlPlaneClient{cc}
+func (m *InspectAgentResponse) Reset()         { *m = InspectAgentResponse{} }
+func (m *InspectAgentResponse) String() string { return proto.CompactTextString(m) }
+func (*InspectAgentResponse) ProtoMessage()    {}
+func (*InspectAgentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_44473a7dc25ad712, []int{10}
 }
 
-func (c *controlPlaneClient) Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error) {
-	out := new(StatsResponse)
-	err := c.cc.Invoke(ctx, "/adagio.rpc.controlplane.ControlPlane/Stats", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *InspectAgentResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InspectAgentResponse.Unmarshal(m, b)
+}
+func (m *InspectAgentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InspectAgentResponse.Marshal(b, m, deterministic)
+}
+func (m *InspectAgentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InspectAgentResponse.Merge(m, src)
+}
+func (m *InspectAgentResponse) XXX_Size() int {
+	return xxx_messageInfo_InspectAgentResponse.Size(m)
+}
+func (m *InspectAgentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_InspectAgentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InspectAgentResponse proto.InternalMessageInfo
+
+func (m *InspectAgentResponse) GetAgent() *adagio.Agent {
+	if m != nil {
+		return m.Agent
 	}
-	return out, nil
+	return nil
 }
 
-func (c *controlPlaneClient) Start(ctx context.Context, in *StartRequest, opts ...grpc.CallOption) (*StartResponse, error) {
-	out := new(StartResponse)
-	err := c.cc.Invoke(ctx, "/adagio.rpc.controlplane.ControlPlane/Start", in, out, opts...)
-	if err != nil {
-		return nil, err
+type ApprovalRequest struct {
+	RunId    string `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	Node     string `protobuf:"bytes,2,opt,name=node,proto3" json:"node,omitempty"`
+	Approver string `protobuf:"bytes,3,opt,name=approver,proto3" json:"approver,omitempty"`
+	Comment  string `protobuf:"bytes,4,opt,name=comment,proto3" json:"comment,omitempty"`
+	// namespace of the call (defaults to "default")
+	Namespace            string   `protobuf:"bytes,5,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ApprovalRequest) Reset()         { *m = ApprovalRequest{} }
+func (m *ApprovalRequest) String() string { return proto.CompactTextString(m) }
+func (*ApprovalRequest) ProtoMessage()    {}
+func (*ApprovalRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_44473a7dc25ad712, []int{11}
+}
+
+func (m *ApprovalRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApprovalRequest.Unmarshal(m, b)
+}
+func (m *ApprovalRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApprovalRequest.Marshal(b, m, deterministic)
+}
+func (m *ApprovalRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApprovalRequest.Merge(m, src)
+}
+func (m *ApprovalRequest) XXX_Size() int {
+	return xxx_messageInfo_ApprovalRequest.Size(m)
+}
+func (m *ApprovalRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApprovalRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApprovalRequest proto.InternalMessageInfo
+
+func (m *ApprovalRequest) GetRunId() string {
+	if m != nil {
+		return m.RunId
 	}
-	return out, nil
+	return ""
 }
 
-func (c *controlPlaneClient) ListRuns(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListRunsResponse, error) {
-	out := new(ListRunsResponse)
-	err := c.cc.Invoke(ctx, "/adagio.rpc.controlplane.ControlPlane/ListRuns", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ApprovalRequest) GetNode() string {
+	if m != nil {
+		return m.Node
 	}
-	return out, nil
+	return ""
 }
 
-func (c *controlPlaneClient) Inspect(ctx context.Context, in *InspectRequest, opts ...grpc.CallOption) (*InspectResponse, error) {
-	out := new(InspectResponse)
-	err := c.cc.Invoke(ctx, "/adagio.rpc.controlplane.ControlPlane/Inspect", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ApprovalRequest) GetApprover() string {
+	if m != nil {
+		return m.Approver
 	}
-	return out, nil
+	return ""
 }
 
-func (c *controlPlaneClient) ListAgents(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListAgentsResponse, error) {
-	out := new(ListAgentsResponse)
-	err := c.cc.Invoke(ctx, "/adagio.rpc.controlplane.ControlPlane/ListAgents", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ApprovalRequest) GetComment() string {
+	if m != nil {
+		return m.Comment
 	}
-	return out, nil
+	return ""
 }
 
-// ControlPlaneServer is the server API for ControlPlane service.
-type ControlPlaneServer interface {
-	Stats(context.Context, *StatsRequest) (*StatsResponse, error)
-	Start(context.Context, *StartRequest) (*StartResponse, error)
-	ListRuns(context.Context, *ListRequest) (*ListRunsResponse, error)
-	Inspect(context.Context, *InspectRequest) (*InspectResponse, error)
-	ListAgents(context.Context, *ListRequest) (*ListAgentsResponse, error)
+func (m *ApprovalRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
 }
 
-// UnimplementedControlPlaneServer can be embedded to have forward compatible implementations.
-type UnimplementedControlPlaneServer struct {
+type ApprovalResponse struct {
+	Run                  *adagio.Run `protobuf:"bytes,1,opt,name=run,proto3" json:"run,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
-func (*UnimplementedControlPlaneServer) Stats(ctx context.Context, req *StatsRequest) (*StatsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Stats not implemented")
+func (m *ApprovalResponse) Reset()         { *m = ApprovalResponse{} }
+func (m *ApprovalResponse) String() string { return proto.CompactTextString(m) }
+func (*ApprovalResponse) ProtoMessage()    {}
+func (*ApprovalResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_44473a7dc25ad712, []int{12}
 }
-func (*UnimplementedControlPlaneServer) Start(ctx context.Context, req *StartRequest) (*StartResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Start not implemented")
+
+func (m *ApprovalResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApprovalResponse.Unmarshal(m, b)
 }
-func (*UnimplementedControlPlaneServer) ListRuns(ctx context.Context, req *ListRequest) (*ListRunsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListRuns not implemented")
+func (m *ApprovalResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApprovalResponse.Marshal(b, m, deterministic)
 }
-func (*UnimplementedControlPlaneServer) Inspect(ctx context.Context, req *InspectRequest) (*InspectResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Inspect not implemented")
+func (m *ApprovalResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApprovalResponse.Merge(m, src)
 }
-func (*UnimplementedControlPlaneServer) ListAgents(ctx context.Context, req *ListRequest) (*ListAgentsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListAgents not implemented")
+func (m *ApprovalResponse) XXX_Size() int {
+	return xxx_messageInfo_ApprovalResponse.Size(m)
 }
-
-func RegisterControlPlaneServer(s *grpc.Server, srv ControlPlaneServer) {
-	s.RegisterService(&_ControlPlane_serviceDesc, srv)
+func (m *ApprovalResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApprovalResponse.DiscardUnknown(m)
 }
 
-func _ControlPlane_Stats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(StatsRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(ControlPlaneServer).Stats(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/adagio.rpc.controlplane.ControlPlane/Stats",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlPlaneServer).Stats(ctx, req.(*StatsRequest))
+var xxx_messageInfo_ApprovalResponse proto.InternalMessageInfo
+
+func (m *ApprovalResponse) GetRun() *adagio.Run {
+	if m != nil {
+		return m.Run
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _ControlPlane_Start_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(StartRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(ControlPlaneServer).Start(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/adagio.rpc.controlplane.ControlPlane/Start",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlPlaneServer).Start(ctx, req.(*StartRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+type UnschedulableRequest struct {
+	// minimum duration a node must have been ready for to be listed
+	ThresholdNs int64 `protobuf:"varint,1,opt,name=threshold_ns,json=thresholdNs,proto3" json:"threshold_ns,omitempty"`
+	// namespace of the call (defaults to "default")
+	Namespace            string   `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func _ControlPlane_ListRuns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(ControlPlaneServer).ListRuns(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/adagio.rpc.controlplane.ControlPlane/ListRuns",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlPlaneServer).ListRuns(ctx, req.(*ListRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+func (m *UnschedulableRequest) Reset()         { *m = UnschedulableRequest{} }
+func (m *UnschedulableRequest) String() string { return proto.CompactTextString(m) }
+func (*UnschedulableRequest) ProtoMessage()    {}
+func (*UnschedulableRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_44473a7dc25ad712, []int{13}
 }
 
-func _ControlPlane_Inspect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(InspectRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(ControlPlaneServer).Inspect(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/adagio.rpc.controlplane.ControlPlane/Inspect",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlPlaneServer).Inspect(ctx, req.(*InspectRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+func (m *UnschedulableRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnschedulableRequest.Unmarshal(m, b)
+}
+func (m *UnschedulableRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnschedulableRequest.Marshal(b, m, deterministic)
+}
+func (m *UnschedulableRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnschedulableRequest.Merge(m, src)
+}
+func (m *UnschedulableRequest) XXX_Size() int {
+	return xxx_messageInfo_UnschedulableRequest.Size(m)
+}
+func (m *UnschedulableRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnschedulableRequest.DiscardUnknown(m)
 }
 
-func _ControlPlane_ListAgents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(ControlPlaneServer).ListAgents(ctx, in)
+var xxx_messageInfo_UnschedulableRequest proto.InternalMessageInfo
+
+func (m *UnschedulableRequest) GetThresholdNs() int64 {
+	if m != nil {
+		return m.ThresholdNs
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/adagio.rpc.controlplane.ControlPlane/ListAgents",
+	return 0
+}
+
+func (m *UnschedulableRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+type UnschedulableNode struct {
+	RunId                string       `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	Node                 *adagio.Node `protobuf:"bytes,2,opt,name=node,proto3" json:"node,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *UnschedulableNode) Reset()         { *m = UnschedulableNode{} }
+func (m *UnschedulableNode) String() string { return proto.CompactTextString(m) }
+func (*UnschedulableNode) ProtoMessage()    {}
+func (*UnschedulableNode) Descriptor() ([]byte, []int) {
+	return fileDescriptor_44473a7dc25ad712, []int{14}
+}
+
+func (m *UnschedulableNode) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnschedulableNode.Unmarshal(m, b)
+}
+func (m *UnschedulableNode) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnschedulableNode.Marshal(b, m, deterministic)
+}
+func (m *UnschedulableNode) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnschedulableNode.Merge(m, src)
+}
+func (m *UnschedulableNode) XXX_Size() int {
+	return xxx_messageInfo_UnschedulableNode.Size(m)
+}
+func (m *UnschedulableNode) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnschedulableNode.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnschedulableNode proto.InternalMessageInfo
+
+func (m *UnschedulableNode) GetRunId() string {
+	if m != nil {
+		return m.RunId
+	}
+	return ""
+}
+
+func (m *UnschedulableNode) GetNode() *adagio.Node {
+	if m != nil {
+		return m.Node
+	}
+	return nil
+}
+
+type UnschedulableResponse struct {
+	Nodes                []*UnschedulableNode `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *UnschedulableResponse) Reset()         { *m = UnschedulableResponse{} }
+func (m *UnschedulableResponse) String() string { return proto.CompactTextString(m) }
+func (*UnschedulableResponse) ProtoMessage()    {}
+func (*UnschedulableResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_44473a7dc25ad712, []int{15}
+}
+
+func (m *UnschedulableResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnschedulableResponse.Unmarshal(m, b)
+}
+func (m *UnschedulableResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnschedulableResponse.Marshal(b, m, deterministic)
+}
+func (m *UnschedulableResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnschedulableResponse.Merge(m, src)
+}
+func (m *UnschedulableResponse) XXX_Size() int {
+	return xxx_messageInfo_UnschedulableResponse.Size(m)
+}
+func (m *UnschedulableResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnschedulableResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnschedulableResponse proto.InternalMessageInfo
+
+func (m *UnschedulableResponse) GetNodes() []*UnschedulableNode {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+type StreamLogsRequest struct {
+	RunId string `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	Node  string `protobuf:"bytes,2,opt,name=node,proto3" json:"node,omitempty"`
+	// attempt (starting at 1) of which to stream the logs (defaults to the latest)
+	Attempt int32 `protobuf:"varint,3,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	// continue streaming lines until the attempt has finished
+	Follow bool `protobuf:"varint,4,opt,name=follow,proto3" json:"follow,omitempty"`
+	// namespace of the call (defaults to "default")
+	Namespace            string   `protobuf:"bytes,5,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StreamLogsRequest) Reset()         { *m = StreamLogsRequest{} }
+func (m *StreamLogsRequest) String() string { return proto.CompactTextString(m) }
+func (*StreamLogsRequest) ProtoMessage()    {}
+func (*StreamLogsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_44473a7dc25ad712, []int{16}
+}
+
+func (m *StreamLogsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamLogsRequest.Unmarshal(m, b)
+}
+func (m *StreamLogsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamLogsRequest.Marshal(b, m, deterministic)
+}
+func (m *StreamLogsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamLogsRequest.Merge(m, src)
+}
+func (m *StreamLogsRequest) XXX_Size() int {
+	return xxx_messageInfo_StreamLogsRequest.Size(m)
+}
+func (m *StreamLogsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamLogsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamLogsRequest proto.InternalMessageInfo
+
+func (m *StreamLogsRequest) GetRunId() string {
+	if m != nil {
+		return m.RunId
+	}
+	return ""
+}
+
+func (m *StreamLogsRequest) GetNode() string {
+	if m != nil {
+		return m.Node
+	}
+	return ""
+}
+
+func (m *StreamLogsRequest) GetAttempt() int32 {
+	if m != nil {
+		return m.Attempt
+	}
+	return 0
+}
+
+func (m *StreamLogsRequest) GetFollow() bool {
+	if m != nil {
+		return m.Follow
+	}
+	return false
+}
+
+func (m *StreamLogsRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+type StreamLogsResponse struct {
+	Lines                []*adagio.LogLine `protobuf:"bytes,1,rep,name=lines,proto3" json:"lines,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *StreamLogsResponse) Reset()         { *m = StreamLogsResponse{} }
+func (m *StreamLogsResponse) String() string { return proto.CompactTextString(m) }
+func (*StreamLogsResponse) ProtoMessage()    {}
+func (*StreamLogsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_44473a7dc25ad712, []int{17}
+}
+
+func (m *StreamLogsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamLogsResponse.Unmarshal(m, b)
+}
+func (m *StreamLogsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamLogsResponse.Marshal(b, m, deterministic)
+}
+func (m *StreamLogsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamLogsResponse.Merge(m, src)
+}
+func (m *StreamLogsResponse) XXX_Size() int {
+	return xxx_messageInfo_StreamLogsResponse.Size(m)
+}
+func (m *StreamLogsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamLogsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamLogsResponse proto.InternalMessageInfo
+
+func (m *StreamLogsResponse) GetLines() []*adagio.LogLine {
+	if m != nil {
+		return m.Lines
+	}
+	return nil
+}
+
+type CreateWebhookRequest struct {
+	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	// key with which the payloads posted to the webhook are signed
+	Secret string                 `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	Events []adagio.Webhook_Event `protobuf:"varint,3,rep,packed,name=events,proto3,enum=adagio.Webhook_Event" json:"events,omitempty"`
+	// namespace of the call (defaults to "default")
+	Namespace            string   `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateWebhookRequest) Reset()         { *m = CreateWebhookRequest{} }
+func (m *CreateWebhookRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateWebhookRequest) ProtoMessage()    {}
+func (*CreateWebhookRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_44473a7dc25ad712, []int{18}
+}
+
+func (m *CreateWebhookRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateWebhookRequest.Unmarshal(m, b)
+}
+func (m *CreateWebhookRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateWebhookRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateWebhookRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateWebhookRequest.Merge(m, src)
+}
+func (m *CreateWebhookRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateWebhookRequest.Size(m)
+}
+func (m *CreateWebhookRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateWebhookRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateWebhookRequest proto.InternalMessageInfo
+
+func (m *CreateWebhookRequest) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *CreateWebhookRequest) GetSecret() string {
+	if m != nil {
+		return m.Secret
+	}
+	return ""
+}
+
+func (m *CreateWebhookRequest) GetEvents() []adagio.Webhook_Event {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *CreateWebhookRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+type UpdateWebhookRequest struct {
+	Id  string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Url string `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	// replaces the secret of the webhook given it is not empty
+	Secret string                 `protobuf:"bytes,3,opt,name=secret,proto3" json:"secret,omitempty"`
+	Events []adagio.Webhook_Event `protobuf:"varint,4,rep,packed,name=events,proto3,enum=adagio.Webhook_Event" json:"events,omitempty"`
+	// namespace of the call (defaults to "default")
+	Namespace            string   `protobuf:"bytes,5,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateWebhookRequest) Reset()         { *m = UpdateWebhookRequest{} }
+func (m *UpdateWebhookRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateWebhookRequest) ProtoMessage()    {}
+func (*UpdateWebhookRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_44473a7dc25ad712, []int{19}
+}
+
+func (m *UpdateWebhookRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateWebhookRequest.Unmarshal(m, b)
+}
+func (m *UpdateWebhookRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateWebhookRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateWebhookRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateWebhookRequest.Merge(m, src)
+}
+func (m *UpdateWebhookRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateWebhookRequest.Size(m)
+}
+func (m *UpdateWebhookRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateWebhookRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateWebhookRequest proto.InternalMessageInfo
+
+func (m *UpdateWebhookRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *UpdateWebhookRequest) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *UpdateWebhookRequest) GetSecret() string {
+	if m != nil {
+		return m.Secret
+	}
+	return ""
+}
+
+func (m *UpdateWebhookRequest) GetEvents() []adagio.Webhook_Event {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *UpdateWebhookRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+type InspectWebhookRequest struct {
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// namespace of the call (defaults to "default")
+	Namespace            string   `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InspectWebhookRequest) Reset()         { *m = InspectWebhookRequest{} }
+func (m *InspectWebhookRequest) String() string { return proto.CompactTextString(m) }
+func (*InspectWebhookRequest) ProtoMessage()    {}
+func (*InspectWebhookRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_44473a7dc25ad712, []int{20}
+}
+
+func (m *InspectWebhookRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InspectWebhookRequest.Unmarshal(m, b)
+}
+func (m *InspectWebhookRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InspectWebhookRequest.Marshal(b, m, deterministic)
+}
+func (m *InspectWebhookRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InspectWebhookRequest.Merge(m, src)
+}
+func (m *InspectWebhookRequest) XXX_Size() int {
+	return xxx_messageInfo_InspectWebhookRequest.Size(m)
+}
+func (m *InspectWebhookRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_InspectWebhookRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InspectWebhookRequest proto.InternalMessageInfo
+
+func (m *InspectWebhookRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *InspectWebhookRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+type DeleteWebhookRequest struct {
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// namespace of the call (defaults to "default")
+	Namespace            string   `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteWebhookRequest) Reset()         { *m = DeleteWebhookRequest{} }
+func (m *DeleteWebhookRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteWebhookRequest) ProtoMessage()    {}
+func (*DeleteWebhookRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_44473a7dc25ad712, []int{21}
+}
+
+func (m *DeleteWebhookRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteWebhookRequest.Unmarshal(m, b)
+}
+func (m *DeleteWebhookRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteWebhookRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteWebhookRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteWebhookRequest.Merge(m, src)
+}
+func (m *DeleteWebhookRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteWebhookRequest.Size(m)
+}
+func (m *DeleteWebhookRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteWebhookRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteWebhookRequest proto.InternalMessageInfo
+
+func (m *DeleteWebhookRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *DeleteWebhookRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+type DeleteWebhookResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteWebhookResponse) Reset()         { *m = DeleteWebhookResponse{} }
+func (m *DeleteWebhookResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteWebhookResponse) ProtoMessage()    {}
+func (*DeleteWebhookResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_44473a7dc25ad712, []int{22}
+}
+
+func (m *DeleteWebhookResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteWebhookResponse.Unmarshal(m, b)
+}
+func (m *DeleteWebhookResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteWebhookResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteWebhookResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteWebhookResponse.Merge(m, src)
+}
+func (m *DeleteWebhookResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteWebhookResponse.Size(m)
+}
+func (m *DeleteWebhookResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteWebhookResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteWebhookResponse proto.InternalMessageInfo
+
+// webhooks are returned without their secrets
+type WebhookResponse struct {
+	Webhook              *adagio.Webhook `protobuf:"bytes,1,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *WebhookResponse) Reset()         { *m = WebhookResponse{} }
+func (m *WebhookResponse) String() string { return proto.CompactTextString(m) }
+func (*WebhookResponse) ProtoMessage()    {}
+func (*WebhookResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_44473a7dc25ad712, []int{23}
+}
+
+func (m *WebhookResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WebhookResponse.Unmarshal(m, b)
+}
+func (m *WebhookResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WebhookResponse.Marshal(b, m, deterministic)
+}
+func (m *WebhookResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WebhookResponse.Merge(m, src)
+}
+func (m *WebhookResponse) XXX_Size() int {
+	return xxx_messageInfo_WebhookResponse.Size(m)
+}
+func (m *WebhookResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WebhookResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WebhookResponse proto.InternalMessageInfo
+
+func (m *WebhookResponse) GetWebhook() *adagio.Webhook {
+	if m != nil {
+		return m.Webhook
+	}
+	return nil
+}
+
+type ListWebhooksRequest struct {
+	// namespace of the call (defaults to "default")
+	Namespace            string   `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListWebhooksRequest) Reset()         { *m = ListWebhooksRequest{} }
+func (m *ListWebhooksRequest) String() string { return proto.CompactTextString(m) }
+func (*ListWebhooksRequest) ProtoMessage()    {}
+func (*ListWebhooksRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_44473a7dc25ad712, []int{24}
+}
+
+func (m *ListWebhooksRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListWebhooksRequest.Unmarshal(m, b)
+}
+func (m *ListWebhooksRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListWebhooksRequest.Marshal(b, m, deterministic)
+}
+func (m *ListWebhooksRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListWebhooksRequest.Merge(m, src)
+}
+func (m *ListWebhooksRequest) XXX_Size() int {
+	return xxx_messageInfo_ListWebhooksRequest.Size(m)
+}
+func (m *ListWebhooksRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListWebhooksRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListWebhooksRequest proto.InternalMessageInfo
+
+func (m *ListWebhooksRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+type ListWebhooksResponse struct {
+	Webhooks             []*adagio.Webhook `protobuf:"bytes,1,rep,name=webhooks,proto3" json:"webhooks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ListWebhooksResponse) Reset()         { *m = ListWebhooksResponse{} }
+func (m *ListWebhooksResponse) String() string { return proto.CompactTextString(m) }
+func (*ListWebhooksResponse) ProtoMessage()    {}
+func (*ListWebhooksResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_44473a7dc25ad712, []int{25}
+}
+
+func (m *ListWebhooksResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListWebhooksResponse.Unmarshal(m, b)
+}
+func (m *ListWebhooksResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListWebhooksResponse.Marshal(b, m, deterministic)
+}
+func (m *ListWebhooksResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListWebhooksResponse.Merge(m, src)
+}
+func (m *ListWebhooksResponse) XXX_Size() int {
+	return xxx_messageInfo_ListWebhooksResponse.Size(m)
+}
+func (m *ListWebhooksResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListWebhooksResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListWebhooksResponse proto.InternalMessageInfo
+
+func (m *ListWebhooksResponse) GetWebhooks() []*adagio.Webhook {
+	if m != nil {
+		return m.Webhooks
+	}
+	return nil
+}
+
+type ListDeliveriesRequest struct {
+	WebhookId string `protobuf:"bytes,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	// namespace of the call (defaults to "default")
+	Namespace            string   `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListDeliveriesRequest) Reset()         { *m = ListDeliveriesRequest{} }
+func (m *ListDeliveriesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListDeliveriesRequest) ProtoMessage()    {}
+func (*ListDeliveriesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_44473a7dc25ad712, []int{26}
+}
+
+func (m *ListDeliveriesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDeliveriesRequest.Unmarshal(m, b)
+}
+func (m *ListDeliveriesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDeliveriesRequest.Marshal(b, m, deterministic)
+}
+func (m *ListDeliveriesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDeliveriesRequest.Merge(m, src)
+}
+func (m *ListDeliveriesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListDeliveriesRequest.Size(m)
+}
+func (m *ListDeliveriesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDeliveriesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListDeliveriesRequest proto.InternalMessageInfo
+
+func (m *ListDeliveriesRequest) GetWebhookId() string {
+	if m != nil {
+		return m.WebhookId
+	}
+	return ""
+}
+
+func (m *ListDeliveriesRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+type ListDeliveriesResponse struct {
+	Deliveries           []*adagio.Delivery `protobuf:"bytes,1,rep,name=deliveries,proto3" json:"deliveries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ListDeliveriesResponse) Reset()         { *m = ListDeliveriesResponse{} }
+func (m *ListDeliveriesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListDeliveriesResponse) ProtoMessage()    {}
+func (*ListDeliveriesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_44473a7dc25ad712, []int{27}
+}
+
+func (m *ListDeliveriesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDeliveriesResponse.Unmarshal(m, b)
+}
+func (m *ListDeliveriesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDeliveriesResponse.Marshal(b, m, deterministic)
+}
+func (m *ListDeliveriesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDeliveriesResponse.Merge(m, src)
+}
+func (m *ListDeliveriesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListDeliveriesResponse.Size(m)
+}
+func (m *ListDeliveriesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDeliveriesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListDeliveriesResponse proto.InternalMessageInfo
+
+func (m *ListDeliveriesResponse) GetDeliveries() []*adagio.Delivery {
+	if m != nil {
+		return m.Deliveries
+	}
+	return nil
+}
+
+type ListAuditEventsRequest struct {
+	// only list the events of calls which targeted the run
+	RunId string `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	// maximum number of events listed (0 is unlimited)
+	Limit uint64 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// namespace of the call (defaults to "default")
+	Namespace            string   `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListAuditEventsRequest) Reset()         { *m = ListAuditEventsRequest{} }
+func (m *ListAuditEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListAuditEventsRequest) ProtoMessage()    {}
+func (*ListAuditEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_44473a7dc25ad712, []int{28}
+}
+
+func (m *ListAuditEventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAuditEventsRequest.Unmarshal(m, b)
+}
+func (m *ListAuditEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAuditEventsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListAuditEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAuditEventsRequest.Merge(m, src)
+}
+func (m *ListAuditEventsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListAuditEventsRequest.Size(m)
+}
+func (m *ListAuditEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAuditEventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAuditEventsRequest proto.InternalMessageInfo
+
+func (m *ListAuditEventsRequest) GetRunId() string {
+	if m != nil {
+		return m.RunId
+	}
+	return ""
+}
+
+func (m *ListAuditEventsRequest) GetLimit() uint64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *ListAuditEventsRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+type ListAuditEventsResponse struct {
+	// events ordered from the most recent
+	Events               []*adagio.AuditEvent `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ListAuditEventsResponse) Reset()         { *m = ListAuditEventsResponse{} }
+func (m *ListAuditEventsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListAuditEventsResponse) ProtoMessage()    {}
+func (*ListAuditEventsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_44473a7dc25ad712, []int{29}
+}
+
+func (m *ListAuditEventsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAuditEventsResponse.Unmarshal(m, b)
+}
+func (m *ListAuditEventsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAuditEventsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListAuditEventsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAuditEventsResponse.Merge(m, src)
+}
+func (m *ListAuditEventsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListAuditEventsResponse.Size(m)
+}
+func (m *ListAuditEventsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAuditEventsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAuditEventsResponse proto.InternalMessageInfo
+
+func (m *ListAuditEventsResponse) GetEvents() []*adagio.AuditEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*StatsRequest)(nil), "adagio.rpc.controlplane.StatsRequest")
+	proto.RegisterType((*StatsResponse)(nil), "adagio.rpc.controlplane.StatsResponse")
+	proto.RegisterType((*StartRequest)(nil), "adagio.rpc.controlplane.StartRequest")
+	proto.RegisterType((*StartResponse)(nil), "adagio.rpc.controlplane.StartResponse")
+	proto.RegisterType((*InspectRequest)(nil), "adagio.rpc.controlplane.InspectRequest")
+	proto.RegisterType((*InspectResponse)(nil), "adagio.rpc.controlplane.InspectResponse")
+	proto.RegisterType((*ListRequest)(nil), "adagio.rpc.controlplane.ListRequest")
+	proto.RegisterType((*ListRunsResponse)(nil), "adagio.rpc.controlplane.ListRunsResponse")
+	proto.RegisterType((*ListAgentsResponse)(nil), "adagio.rpc.controlplane.ListAgentsResponse")
+	proto.RegisterType((*InspectAgentRequest)(nil), "adagio.rpc.controlplane.InspectAgentRequest")
+	proto.RegisterType((*InspectAgentResponse)(nil), "adagio.rpc.controlplane.InspectAgentResponse")
+	proto.RegisterType((*ApprovalRequest)(nil), "adagio.rpc.controlplane.ApprovalRequest")
+	proto.RegisterType((*ApprovalResponse)(nil), "adagio.rpc.controlplane.ApprovalResponse")
+	proto.RegisterType((*UnschedulableRequest)(nil), "adagio.rpc.controlplane.UnschedulableRequest")
+	proto.RegisterType((*UnschedulableNode)(nil), "adagio.rpc.controlplane.UnschedulableNode")
+	proto.RegisterType((*UnschedulableResponse)(nil), "adagio.rpc.controlplane.UnschedulableResponse")
+	proto.RegisterType((*StreamLogsRequest)(nil), "adagio.rpc.controlplane.StreamLogsRequest")
+	proto.RegisterType((*StreamLogsResponse)(nil), "adagio.rpc.controlplane.StreamLogsResponse")
+	proto.RegisterType((*CreateWebhookRequest)(nil), "adagio.rpc.controlplane.CreateWebhookRequest")
+	proto.RegisterType((*UpdateWebhookRequest)(nil), "adagio.rpc.controlplane.UpdateWebhookRequest")
+	proto.RegisterType((*InspectWebhookRequest)(nil), "adagio.rpc.controlplane.InspectWebhookRequest")
+	proto.RegisterType((*DeleteWebhookRequest)(nil), "adagio.rpc.controlplane.DeleteWebhookRequest")
+	proto.RegisterType((*DeleteWebhookResponse)(nil), "adagio.rpc.controlplane.DeleteWebhookResponse")
+	proto.RegisterType((*WebhookResponse)(nil), "adagio.rpc.controlplane.WebhookResponse")
+	proto.RegisterType((*ListWebhooksRequest)(nil), "adagio.rpc.controlplane.ListWebhooksRequest")
+	proto.RegisterType((*ListWebhooksResponse)(nil), "adagio.rpc.controlplane.ListWebhooksResponse")
+	proto.RegisterType((*ListDeliveriesRequest)(nil), "adagio.rpc.controlplane.ListDeliveriesRequest")
+	proto.RegisterType((*ListDeliveriesResponse)(nil), "adagio.rpc.controlplane.ListDeliveriesResponse")
+	proto.RegisterType((*ListAuditEventsRequest)(nil), "adagio.rpc.controlplane.ListAuditEventsRequest")
+	proto.RegisterType((*ListAuditEventsResponse)(nil), "adagio.rpc.controlplane.ListAuditEventsResponse")
+}
+
+func init() {
+	proto.RegisterFile("pkg/rpc/controlplane/service.proto", fileDescriptor_44473a7dc25ad712)
+}
+
+var fileDescriptor_44473a7dc25ad712 = []byte{
+	// 1368 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0x5d, 0x6f, 0x1b, 0x45,
+	0x17, 0xd6, 0xda, 0x71, 0x6c, 0x1f, 0xdb, 0x71, 0x3c, 0xb1, 0xeb, 0x74, 0xdb, 0xbe, 0xcd, 0xbb,
+	0xd0, 0x36, 0x71, 0x9b, 0xdd, 0x90, 0x96, 0x1b, 0x4a, 0x11, 0x25, 0xad, 0x50, 0x51, 0x14, 0xa1,
+	0x0d, 0x55, 0x05, 0x37, 0xd5, 0xd6, 0x9e, 0xda, 0x4b, 0xd7, 0xbb, 0xcb, 0x7e, 0xb8, 0xaa, 0xaa,
+	0x5e, 0xb4, 0x17, 0x80, 0x84, 0x40, 0x48, 0x15, 0x12, 0x37, 0x5c, 0xf0, 0x9b, 0xf8, 0x0b, 0xfc,
+	0x10, 0x34, 0x5f, 0xfb, 0x65, 0x7b, 0xbd, 0x0d, 0xe2, 0xca, 0x9e, 0x99, 0x33, 0xe7, 0x79, 0xce,
+	0x99, 0x73, 0x66, 0x1e, 0x1b, 0x14, 0xf7, 0xd9, 0x58, 0xf3, 0xdc, 0xa1, 0x36, 0x74, 0xec, 0xc0,
+	0x73, 0x2c, 0xd7, 0x32, 0x6c, 0xac, 0xf9, 0xd8, 0x9b, 0x99, 0x43, 0xac, 0xba, 0x9e, 0x13, 0x38,
+	0xa8, 0x6f, 0x8c, 0x8c, 0xb1, 0xe9, 0xa8, 0x9e, 0x3b, 0x54, 0x93, 0x66, 0x72, 0x9f, 0x6c, 0x66,
+	0x8b, 0xfc, 0x83, 0xed, 0x90, 0x2f, 0x8e, 0x1d, 0x67, 0x6c, 0x61, 0xcd, 0x70, 0x4d, 0xcd, 0xb0,
+	0x6d, 0x27, 0x30, 0x02, 0xd3, 0xb1, 0x7d, 0xb6, 0xaa, 0xdc, 0x80, 0xe6, 0x69, 0x60, 0x04, 0xbe,
+	0x8e, 0xbf, 0x0b, 0xb1, 0x1f, 0xa0, 0x8b, 0x50, 0xb7, 0x8d, 0x29, 0xf6, 0x5d, 0x63, 0x88, 0xb7,
+	0xa5, 0x1d, 0x69, 0xb7, 0xae, 0xc7, 0x13, 0xca, 0x2d, 0x68, 0x71, 0x6b, 0xdf, 0x75, 0x6c, 0x1f,
+	0xa3, 0xf7, 0xa0, 0xe2, 0x93, 0x09, 0x6a, 0xda, 0x38, 0x6c, 0xa9, 0x1c, 0x9a, 0x59, 0xb1, 0x35,
+	0xe5, 0x94, 0x62, 0x78, 0x81, 0xc0, 0xb8, 0x02, 0x6b, 0xbe, 0x8b, 0x87, 0x7c, 0x4f, 0x47, 0xec,
+	0xf9, 0xdc, 0x33, 0xdc, 0xc9, 0xa9, 0x8b, 0x87, 0x3a, 0x5d, 0x4e, 0x53, 0x29, 0x65, 0xa9, 0xa8,
+	0xd0, 0xe2, 0x4e, 0x39, 0x95, 0x4b, 0x50, 0xf6, 0x42, 0x9b, 0x3b, 0x6d, 0x08, 0xa7, 0x7a, 0x68,
+	0xeb, 0x64, 0x5e, 0xf9, 0x04, 0x36, 0x1e, 0xd8, 0xc4, 0x6f, 0x44, 0x63, 0x03, 0x4a, 0xe6, 0x88,
+	0xc7, 0x58, 0x32, 0x47, 0x2b, 0xf0, 0x0e, 0xa0, 0x1d, 0xed, 0x2f, 0x86, 0xf8, 0x02, 0x1a, 0xc7,
+	0xa6, 0x1f, 0xc1, 0x9d, 0x87, 0x9a, 0x4f, 0x08, 0x3f, 0xb6, 0x59, 0xb6, 0xca, 0x7a, 0x95, 0x8e,
+	0x4f, 0x7c, 0x74, 0x01, 0xea, 0x4f, 0x4d, 0xdb, 0xf4, 0x27, 0x64, 0xad, 0x44, 0xd7, 0x6a, 0x6c,
+	0xe2, 0xc4, 0x47, 0x5d, 0xa8, 0x58, 0xe6, 0xd4, 0x0c, 0xb6, 0xcb, 0x3b, 0xd2, 0xee, 0x9a, 0xce,
+	0x06, 0x69, 0xb2, 0x6b, 0x59, 0xb2, 0x37, 0x61, 0x93, 0x42, 0x87, 0x76, 0x7c, 0x54, 0x97, 0x61,
+	0xcd, 0x0b, 0x29, 0x76, 0x39, 0x4b, 0x97, 0x2e, 0x28, 0xb7, 0x01, 0x91, 0x4d, 0x77, 0xc7, 0xd8,
+	0x4e, 0x9c, 0xf0, 0x15, 0x58, 0x37, 0xe8, 0x0c, 0xdf, 0x18, 0x1d, 0x31, 0xb5, 0xd3, 0xf9, 0xa2,
+	0x72, 0x04, 0x5b, 0x3c, 0x3d, 0x6c, 0xfe, 0x4c, 0x39, 0xbe, 0x0d, 0xdd, 0xb4, 0x93, 0xb8, 0xca,
+	0x28, 0x4c, 0xb6, 0xca, 0x98, 0x15, 0x5b, 0x53, 0x7e, 0x95, 0xa0, 0x7d, 0xd7, 0x75, 0x3d, 0x67,
+	0x66, 0x58, 0x02, 0xbe, 0x07, 0xeb, 0x5e, 0x68, 0x3f, 0x8e, 0x28, 0x54, 0xbc, 0xd0, 0x7e, 0x30,
+	0x42, 0x08, 0xd6, 0x6c, 0x67, 0x24, 0x08, 0xd0, 0xef, 0x48, 0x86, 0x9a, 0x41, 0x77, 0x63, 0x8f,
+	0x66, 0xba, 0xae, 0x47, 0x63, 0xb4, 0x0d, 0xd5, 0xa1, 0x33, 0x9d, 0x12, 0x06, 0x2c, 0xd5, 0x62,
+	0x98, 0x8e, 0xa7, 0x92, 0x8d, 0xe7, 0x03, 0xd8, 0x8c, 0x19, 0x15, 0x2b, 0x9a, 0x47, 0xd0, 0x7d,
+	0x68, 0xfb, 0xc3, 0x09, 0x1e, 0x85, 0x96, 0xf1, 0xc4, 0xc2, 0x22, 0x92, 0xff, 0x43, 0x33, 0x98,
+	0x78, 0xd8, 0x9f, 0x38, 0xd6, 0x28, 0xae, 0xa0, 0x46, 0x34, 0x77, 0xe2, 0xaf, 0xc8, 0xed, 0x31,
+	0x74, 0x52, 0x8e, 0x4f, 0x48, 0xd0, 0x4b, 0xf2, 0xb3, 0x93, 0xc8, 0x4f, 0xe3, 0xb0, 0x29, 0x48,
+	0x92, 0x2d, 0x2c, 0x5b, 0xca, 0xd7, 0xd0, 0xcb, 0xd0, 0xe4, 0xe1, 0x7d, 0x0a, 0x15, 0x62, 0x20,
+	0xaa, 0x65, 0xa0, 0x2e, 0xb9, 0xaf, 0xd4, 0x39, 0x32, 0x3a, 0xdb, 0xa8, 0xfc, 0x22, 0x41, 0xe7,
+	0x34, 0xf0, 0xb0, 0x31, 0x3d, 0x76, 0xc6, 0xfe, 0x19, 0x4e, 0x72, 0x1b, 0xaa, 0x46, 0x10, 0xe0,
+	0xa9, 0xcb, 0x5a, 0xa6, 0xa2, 0x8b, 0x21, 0x3a, 0x07, 0xeb, 0x4f, 0x1d, 0xcb, 0x72, 0x9e, 0xd3,
+	0x63, 0xac, 0xe9, 0x7c, 0xb4, 0xe2, 0x14, 0x6f, 0x03, 0x4a, 0xf2, 0x89, 0xfa, 0xa2, 0x62, 0x99,
+	0x76, 0x14, 0x68, 0x5b, 0x04, 0x7a, 0xec, 0x8c, 0x8f, 0x4d, 0x1b, 0xeb, 0x6c, 0x55, 0xf9, 0x59,
+	0x82, 0xee, 0x91, 0x87, 0x8d, 0x00, 0x3f, 0xc2, 0x4f, 0x26, 0x8e, 0xf3, 0x4c, 0x04, 0xb4, 0x09,
+	0xe5, 0xd0, 0xb3, 0x78, 0x34, 0xe4, 0x2b, 0x61, 0xe7, 0xe3, 0xa1, 0x87, 0x03, 0x1e, 0x0d, 0x1f,
+	0xa1, 0x7d, 0x58, 0xc7, 0x33, 0xda, 0x81, 0xe5, 0x9d, 0xf2, 0xee, 0xc6, 0x61, 0x4f, 0x40, 0x71,
+	0x8f, 0xea, 0xfd, 0x19, 0xed, 0x44, 0x66, 0xb4, 0xe2, 0x66, 0xf8, 0x43, 0x82, 0xee, 0x43, 0x77,
+	0x34, 0xcf, 0x27, 0xdb, 0xa9, 0x9c, 0x5f, 0x69, 0x11, 0xbf, 0xf2, 0x12, 0x7e, 0x6b, 0xef, 0xcc,
+	0x6f, 0x2e, 0xd9, 0xf7, 0xa1, 0xc7, 0xaf, 0x80, 0x15, 0xfc, 0xf2, 0xab, 0xfd, 0x1e, 0x74, 0xef,
+	0x61, 0x0b, 0x07, 0xf8, 0x5f, 0x79, 0xe9, 0x43, 0x2f, 0xe3, 0x85, 0x1d, 0xbe, 0xf2, 0x31, 0xb4,
+	0x33, 0x53, 0x68, 0x0f, 0xaa, 0xcf, 0xd9, 0x14, 0xef, 0xed, 0x76, 0x26, 0x0d, 0xba, 0x58, 0x57,
+	0x6e, 0xc2, 0x16, 0xb9, 0x68, 0xf9, 0x7c, 0xc1, 0xa7, 0xf7, 0x08, 0xba, 0xe9, 0x4d, 0x1c, 0xf7,
+	0x3a, 0xd4, 0xb8, 0xdf, 0xb9, 0x52, 0x14, 0xc0, 0x91, 0x81, 0xf2, 0x15, 0xf4, 0x88, 0x93, 0x7b,
+	0xd8, 0x32, 0x67, 0xd8, 0x33, 0x71, 0x84, 0x7d, 0x09, 0x80, 0x1b, 0xc5, 0x2d, 0x56, 0xe7, 0x33,
+	0x0f, 0x56, 0xa5, 0xe9, 0x0b, 0x38, 0x97, 0xf5, 0xca, 0xc9, 0x1d, 0x00, 0x8c, 0xa2, 0x59, 0x4e,
+	0x6f, 0x53, 0xd0, 0xe3, 0xf6, 0x2f, 0xf4, 0x84, 0x8d, 0x32, 0x64, 0xbe, 0xee, 0x86, 0x23, 0x33,
+	0xa0, 0x75, 0xb3, 0xea, 0x06, 0x88, 0x9e, 0xc7, 0xd2, 0xd2, 0xe7, 0xb1, 0x3c, 0x5f, 0x64, 0xfd,
+	0x39, 0x10, 0xce, 0x78, 0x10, 0x15, 0x33, 0x63, 0x8b, 0xa2, 0xb7, 0x26, 0x32, 0x16, 0x95, 0x7c,
+	0xf8, 0x06, 0x41, 0xf3, 0x88, 0xdd, 0x69, 0x5f, 0x92, 0x3b, 0x0d, 0x99, 0x50, 0xa1, 0xc2, 0x07,
+	0x5d, 0x59, 0x7a, 0xed, 0x25, 0xc5, 0x96, 0x7c, 0x75, 0x95, 0x19, 0x2f, 0xb7, 0xce, 0x9b, 0xbf,
+	0xfe, 0x7e, 0x5b, 0x6a, 0xa0, 0xba, 0x36, 0x3b, 0xd0, 0xa8, 0xa6, 0x42, 0xcf, 0x28, 0x94, 0x17,
+	0xe4, 0x43, 0x79, 0x41, 0x21, 0xa8, 0x58, 0x45, 0x29, 0x5b, 0x14, 0xaa, 0x25, 0xd7, 0x08, 0x14,
+	0x91, 0x05, 0x1f, 0x49, 0x03, 0x34, 0x85, 0x9a, 0x90, 0x13, 0xe8, 0xfd, 0xa5, 0x8e, 0x12, 0x62,
+	0x47, 0xde, 0xcb, 0xb7, 0x4a, 0xe8, 0x12, 0x65, 0x93, 0x22, 0x02, 0x8a, 0x10, 0x51, 0x08, 0x55,
+	0x7e, 0x07, 0xa0, 0x6b, 0x4b, 0xfd, 0xa4, 0xc5, 0x9c, 0xbc, 0xbb, 0xda, 0x90, 0xe3, 0xf5, 0x29,
+	0x5e, 0x07, 0xb5, 0x05, 0x9e, 0xf6, 0xd2, 0x1c, 0xdd, 0x19, 0xbc, 0x42, 0x3e, 0x40, 0xac, 0x7f,
+	0x0a, 0xc6, 0x79, 0x3d, 0xd7, 0x2a, 0x2d, 0xa5, 0x14, 0x44, 0x91, 0x9b, 0x08, 0x08, 0x32, 0xd3,
+	0x4d, 0xe8, 0x7b, 0x09, 0x9a, 0x49, 0xcd, 0x83, 0x6e, 0xac, 0x0a, 0x24, 0xa9, 0xaf, 0xe4, 0xfd,
+	0x82, 0xd6, 0x9c, 0xc1, 0x79, 0xca, 0x60, 0x0b, 0x75, 0x62, 0x06, 0x22, 0xfa, 0xdf, 0x24, 0xa8,
+	0x32, 0xb1, 0x82, 0xd1, 0xf2, 0x64, 0x66, 0x04, 0x96, 0xbc, 0x57, 0xc0, 0x92, 0x63, 0x7f, 0x48,
+	0xb1, 0x35, 0x65, 0x10, 0xe7, 0x9d, 0xf5, 0xf3, 0x9d, 0xc1, 0x2b, 0x8d, 0x3e, 0xfd, 0xda, 0x4b,
+	0xf2, 0x41, 0x86, 0x5c, 0x79, 0x91, 0xda, 0x7b, 0x2b, 0xc1, 0xba, 0x8e, 0xbf, 0x25, 0xc5, 0xf0,
+	0x9f, 0xd0, 0xba, 0x45, 0x69, 0xa9, 0xca, 0x5e, 0x01, 0x5a, 0x1e, 0xe5, 0xc1, 0x59, 0x75, 0xc8,
+	0x09, 0xa7, 0x54, 0x0c, 0xda, 0x2f, 0xa6, 0x76, 0x04, 0x4b, 0xb5, 0xa8, 0x39, 0xa7, 0x7a, 0x99,
+	0x52, 0x3d, 0x8f, 0xfa, 0x84, 0x2a, 0xa3, 0x16, 0xa6, 0xf0, 0x7f, 0x97, 0x00, 0x62, 0xa9, 0x82,
+	0x06, 0x39, 0x3d, 0x9f, 0xd1, 0x57, 0xf2, 0xf5, 0x42, 0xb6, 0x9c, 0x88, 0x46, 0x89, 0xec, 0xa1,
+	0x6b, 0x05, 0x72, 0x66, 0x39, 0x63, 0xff, 0x40, 0x42, 0xaf, 0x25, 0x68, 0xa5, 0x74, 0x50, 0x4e,
+	0xb2, 0x16, 0xe9, 0xa5, 0x9c, 0x06, 0xcf, 0x3e, 0xce, 0xbc, 0xc1, 0xe5, 0x26, 0x61, 0x27, 0x9e,
+	0x3e, 0x72, 0x68, 0xaf, 0x25, 0x68, 0x26, 0xdf, 0xd0, 0x9c, 0x5e, 0x5b, 0xf0, 0x3e, 0xcb, 0xfb,
+	0x05, 0xad, 0x39, 0x8d, 0x2e, 0xa5, 0xb1, 0x81, 0x52, 0x34, 0xd0, 0x0f, 0x52, 0xf4, 0x3b, 0x54,
+	0x24, 0x42, 0x5d, 0xd5, 0xc3, 0x67, 0xce, 0xc4, 0x05, 0x4a, 0xa1, 0x87, 0xb6, 0x92, 0x14, 0x44,
+	0xc3, 0xff, 0x28, 0x41, 0x2b, 0xa5, 0x04, 0xf3, 0xca, 0x77, 0x81, 0x62, 0x7c, 0x07, 0x1e, 0xff,
+	0xa3, 0x3c, 0xb6, 0x95, 0x45, 0x3c, 0xc8, 0xc1, 0xfc, 0x24, 0x41, 0x2b, 0x25, 0xb4, 0x72, 0xa8,
+	0x2c, 0x92, 0x75, 0xb2, 0x5a, 0xd4, 0x3c, 0x9d, 0x98, 0xc1, 0xc2, 0xc4, 0xfc, 0x29, 0xc1, 0x46,
+	0x5a, 0xcf, 0xe4, 0x1c, 0xd1, 0x42, 0x39, 0x25, 0x6b, 0x85, 0xed, 0x39, 0x21, 0x95, 0x12, 0xda,
+	0x45, 0x57, 0xd3, 0x84, 0x62, 0x4d, 0x46, 0x5a, 0x29, 0x96, 0x49, 0xa4, 0x8c, 0xda, 0x19, 0x09,
+	0x83, 0xf2, 0x41, 0xe7, 0x15, 0x95, 0x7c, 0x50, 0x7c, 0xc3, 0x22, 0x21, 0x62, 0x10, 0x83, 0xcf,
+	0xce, 0x7d, 0xd3, 0x5d, 0xf4, 0xb7, 0xd5, 0x93, 0x75, 0xfa, 0xff, 0xd2, 0xcd, 0x7f, 0x06, 0x00,
+	0x60, 0xe3, 0xfa, 0xfa, 0xd5, 0x12, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// ControlPlaneClient is the client API for ControlPlane service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ControlPlaneClient interface {
+	Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error)
+	Start(ctx context.Context, in *StartRequest, opts ...grpc.CallOption) (*StartResponse, error)
+	ListRuns(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListRunsResponse, error)
+	Inspect(ctx context.Context, in *InspectRequest, opts ...grpc.CallOption) (*InspectResponse, error)
+	ListAgents(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListAgentsResponse, error)
+	InspectAgent(ctx context.Context, in *InspectAgentRequest, opts ...grpc.CallOption) (*InspectAgentResponse, error)
+	Approve(ctx context.Context, in *ApprovalRequest, opts ...grpc.CallOption) (*ApprovalResponse, error)
+	Reject(ctx context.Context, in *ApprovalRequest, opts ...grpc.CallOption) (*ApprovalResponse, error)
+	ListUnschedulable(ctx context.Context, in *UnschedulableRequest, opts ...grpc.CallOption) (*UnschedulableResponse, error)
+	StreamLogs(ctx context.Context, in *StreamLogsRequest, opts ...grpc.CallOption) (ControlPlane_StreamLogsClient, error)
+	CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*WebhookResponse, error)
+	ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error)
+	InspectWebhook(ctx context.Context, in *InspectWebhookRequest, opts ...grpc.CallOption) (*WebhookResponse, error)
+	UpdateWebhook(ctx context.Context, in *UpdateWebhookRequest, opts ...grpc.CallOption) (*WebhookResponse, error)
+	DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*DeleteWebhookResponse, error)
+	ListDeliveries(ctx context.Context, in *ListDeliveriesRequest, opts ...grpc.CallOption) (*ListDeliveriesResponse, error)
+	ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error)
+}
+
+type controlPlaneClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewControlPlaneClient(cc *grpc.ClientConn) ControlPlaneClient {
+	return &controlPlaneClient{cc}
+}
+
+func (c *controlPlaneClient) Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error) {
+	out := new(StatsResponse)
+	err := c.cc.Invoke(ctx, "/adagio.rpc.controlplane.ControlPlane/Stats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) Start(ctx context.Context, in *StartRequest, opts ...grpc.CallOption) (*StartResponse, error) {
+	out := new(StartResponse)
+	err := c.cc.Invoke(ctx, "/adagio.rpc.controlplane.ControlPlane/Start", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) ListRuns(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListRunsResponse, error) {
+	out := new(ListRunsResponse)
+	err := c.cc.Invoke(ctx, "/adagio.rpc.controlplane.ControlPlane/ListRuns", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) Inspect(ctx context.Context, in *InspectRequest, opts ...grpc.CallOption) (*InspectResponse, error) {
+	out := new(InspectResponse)
+	err := c.cc.Invoke(ctx, "/adagio.rpc.controlplane.ControlPlane/Inspect", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) ListAgents(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListAgentsResponse, error) {
+	out := new(ListAgentsResponse)
+	err := c.cc.Invoke(ctx, "/adagio.rpc.controlplane.ControlPlane/ListAgents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) InspectAgent(ctx context.Context, in *InspectAgentRequest, opts ...grpc.CallOption) (*InspectAgentResponse, error) {
+	out := new(InspectAgentResponse)
+	err := c.cc.Invoke(ctx, "/adagio.rpc.controlplane.ControlPlane/InspectAgent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) Approve(ctx context.Context, in *ApprovalRequest, opts ...grpc.CallOption) (*ApprovalResponse, error) {
+	out := new(ApprovalResponse)
+	err := c.cc.Invoke(ctx, "/adagio.rpc.controlplane.ControlPlane/Approve", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) Reject(ctx context.Context, in *ApprovalRequest, opts ...grpc.CallOption) (*ApprovalResponse, error) {
+	out := new(ApprovalResponse)
+	err := c.cc.Invoke(ctx, "/adagio.rpc.controlplane.ControlPlane/Reject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) ListUnschedulable(ctx context.Context, in *UnschedulableRequest, opts ...grpc.CallOption) (*UnschedulableResponse, error) {
+	out := new(UnschedulableResponse)
+	err := c.cc.Invoke(ctx, "/adagio.rpc.controlplane.ControlPlane/ListUnschedulable", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) StreamLogs(ctx context.Context, in *StreamLogsRequest, opts ...grpc.CallOption) (ControlPlane_StreamLogsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ControlPlane_serviceDesc.Streams[0], "/adagio.rpc.controlplane.ControlPlane/StreamLogs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &controlPlaneStreamLogsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ControlPlane_StreamLogsClient interface {
+	Recv() (*StreamLogsResponse, error)
+	grpc.ClientStream
+}
+
+type controlPlaneStreamLogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *controlPlaneStreamLogsClient) Recv() (*StreamLogsResponse, error) {
+	m := new(StreamLogsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *controlPlaneClient) CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*WebhookResponse, error) {
+	out := new(WebhookResponse)
+	err := c.cc.Invoke(ctx, "/adagio.rpc.controlplane.ControlPlane/CreateWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error) {
+	out := new(ListWebhooksResponse)
+	err := c.cc.Invoke(ctx, "/adagio.rpc.controlplane.ControlPlane/ListWebhooks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) InspectWebhook(ctx context.Context, in *InspectWebhookRequest, opts ...grpc.CallOption) (*WebhookResponse, error) {
+	out := new(WebhookResponse)
+	err := c.cc.Invoke(ctx, "/adagio.rpc.controlplane.ControlPlane/InspectWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) UpdateWebhook(ctx context.Context, in *UpdateWebhookRequest, opts ...grpc.CallOption) (*WebhookResponse, error) {
+	out := new(WebhookResponse)
+	err := c.cc.Invoke(ctx, "/adagio.rpc.controlplane.ControlPlane/UpdateWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*DeleteWebhookResponse, error) {
+	out := new(DeleteWebhookResponse)
+	err := c.cc.Invoke(ctx, "/adagio.rpc.controlplane.ControlPlane/DeleteWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) ListDeliveries(ctx context.Context, in *ListDeliveriesRequest, opts ...grpc.CallOption) (*ListDeliveriesResponse, error) {
+	out := new(ListDeliveriesResponse)
+	err := c.cc.Invoke(ctx, "/adagio.rpc.controlplane.ControlPlane/ListDeliveries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlPlaneClient) ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error) {
+	out := new(ListAuditEventsResponse)
+	err := c.cc.Invoke(ctx, "/adagio.rpc.controlplane.ControlPlane/ListAuditEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ControlPlaneServer is the server API for ControlPlane service.
+type ControlPlaneServer interface {
+	Stats(context.Context, *StatsRequest) (*StatsResponse, error)
+	Start(context.Context, *StartRequest) (*StartResponse, error)
+	ListRuns(context.Context, *ListRequest) (*ListRunsResponse, error)
+	Inspect(context.Context, *InspectRequest) (*InspectResponse, error)
+	ListAgents(context.Context, *ListRequest) (*ListAgentsResponse, error)
+	InspectAgent(context.Context, *InspectAgentRequest) (*InspectAgentResponse, error)
+	Approve(context.Context, *ApprovalRequest) (*ApprovalResponse, error)
+	Reject(context.Context, *ApprovalRequest) (*ApprovalResponse, error)
+	ListUnschedulable(context.Context, *UnschedulableRequest) (*UnschedulableResponse, error)
+	StreamLogs(*StreamLogsRequest, ControlPlane_StreamLogsServer) error
+	CreateWebhook(context.Context, *CreateWebhookRequest) (*WebhookResponse, error)
+	ListWebhooks(context.Context, *ListWebhooksRequest) (*ListWebhooksResponse, error)
+	InspectWebhook(context.Context, *InspectWebhookRequest) (*WebhookResponse, error)
+	UpdateWebhook(context.Context, *UpdateWebhookRequest) (*WebhookResponse, error)
+	DeleteWebhook(context.Context, *DeleteWebhookRequest) (*DeleteWebhookResponse, error)
+	ListDeliveries(context.Context, *ListDeliveriesRequest) (*ListDeliveriesResponse, error)
+	ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error)
+}
+
+// UnimplementedControlPlaneServer can be embedded to have forward compatible implementations.
+type UnimplementedControlPlaneServer struct {
+}
+
+func (*UnimplementedControlPlaneServer) Stats(ctx context.Context, req *StatsRequest) (*StatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Stats not implemented")
+}
+func (*UnimplementedControlPlaneServer) Start(ctx context.Context, req *StartRequest) (*StartResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Start not implemented")
+}
+func (*UnimplementedControlPlaneServer) ListRuns(ctx context.Context, req *ListRequest) (*ListRunsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRuns not implemented")
+}
+func (*UnimplementedControlPlaneServer) Inspect(ctx context.Context, req *InspectRequest) (*InspectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Inspect not implemented")
+}
+func (*UnimplementedControlPlaneServer) ListAgents(ctx context.Context, req *ListRequest) (*ListAgentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAgents not implemented")
+}
+func (*UnimplementedControlPlaneServer) InspectAgent(ctx context.Context, req *InspectAgentRequest) (*InspectAgentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InspectAgent not implemented")
+}
+func (*UnimplementedControlPlaneServer) Approve(ctx context.Context, req *ApprovalRequest) (*ApprovalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Approve not implemented")
+}
+func (*UnimplementedControlPlaneServer) Reject(ctx context.Context, req *ApprovalRequest) (*ApprovalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reject not implemented")
+}
+func (*UnimplementedControlPlaneServer) ListUnschedulable(ctx context.Context, req *UnschedulableRequest) (*UnschedulableResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUnschedulable not implemented")
+}
+func (*UnimplementedControlPlaneServer) StreamLogs(req *StreamLogsRequest, srv ControlPlane_StreamLogsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamLogs not implemented")
+}
+func (*UnimplementedControlPlaneServer) CreateWebhook(ctx context.Context, req *CreateWebhookRequest) (*WebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWebhook not implemented")
+}
+func (*UnimplementedControlPlaneServer) ListWebhooks(ctx context.Context, req *ListWebhooksRequest) (*ListWebhooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhooks not implemented")
+}
+func (*UnimplementedControlPlaneServer) InspectWebhook(ctx context.Context, req *InspectWebhookRequest) (*WebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InspectWebhook not implemented")
+}
+func (*UnimplementedControlPlaneServer) UpdateWebhook(ctx context.Context, req *UpdateWebhookRequest) (*WebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateWebhook not implemented")
+}
+func (*UnimplementedControlPlaneServer) DeleteWebhook(ctx context.Context, req *DeleteWebhookRequest) (*DeleteWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWebhook not implemented")
+}
+func (*UnimplementedControlPlaneServer) ListDeliveries(ctx context.Context, req *ListDeliveriesRequest) (*ListDeliveriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeliveries not implemented")
+}
+func (*UnimplementedControlPlaneServer) ListAuditEvents(ctx context.Context, req *ListAuditEventsRequest) (*ListAuditEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditEvents not implemented")
+}
+
+func RegisterControlPlaneServer(s *grpc.Server, srv ControlPlaneServer) {
+	s.RegisterService(&_ControlPlane_serviceDesc, srv)
+}
+
+func _ControlPlane_Stats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).Stats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/adagio.rpc.controlplane.ControlPlane/Stats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).Stats(ctx, req.(*StatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_Start_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).Start(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/adagio.rpc.controlplane.ControlPlane/Start",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).Start(ctx, req.(*StartRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_ListRuns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).ListRuns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/adagio.rpc.controlplane.ControlPlane/ListRuns",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).ListRuns(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_Inspect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InspectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).Inspect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/adagio.rpc.controlplane.ControlPlane/Inspect",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).Inspect(ctx, req.(*InspectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_ListAgents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).ListAgents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/adagio.rpc.controlplane.ControlPlane/ListAgents",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(ControlPlaneServer).ListAgents(ctx, req.(*ListRequest))
@@ -630,6 +1949,225 @@ func _ControlPlane_ListAgents_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ControlPlane_InspectAgent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InspectAgentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).InspectAgent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/adagio.rpc.controlplane.ControlPlane/InspectAgent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).InspectAgent(ctx, req.(*InspectAgentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_Approve_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApprovalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).Approve(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/adagio.rpc.controlplane.ControlPlane/Approve",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).Approve(ctx, req.(*ApprovalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_Reject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApprovalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).Reject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/adagio.rpc.controlplane.ControlPlane/Reject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).Reject(ctx, req.(*ApprovalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_ListUnschedulable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnschedulableRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).ListUnschedulable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/adagio.rpc.controlplane.ControlPlane/ListUnschedulable",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).ListUnschedulable(ctx, req.(*UnschedulableRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_StreamLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamLogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ControlPlaneServer).StreamLogs(m, &controlPlaneStreamLogsServer{stream})
+}
+
+type ControlPlane_StreamLogsServer interface {
+	Send(*StreamLogsResponse) error
+	grpc.ServerStream
+}
+
+type controlPlaneStreamLogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *controlPlaneStreamLogsServer) Send(m *StreamLogsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ControlPlane_CreateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).CreateWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/adagio.rpc.controlplane.ControlPlane/CreateWebhook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).CreateWebhook(ctx, req.(*CreateWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_ListWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).ListWebhooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/adagio.rpc.controlplane.ControlPlane/ListWebhooks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).ListWebhooks(ctx, req.(*ListWebhooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_InspectWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InspectWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).InspectWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/adagio.rpc.controlplane.ControlPlane/InspectWebhook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).InspectWebhook(ctx, req.(*InspectWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_UpdateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).UpdateWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/adagio.rpc.controlplane.ControlPlane/UpdateWebhook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).UpdateWebhook(ctx, req.(*UpdateWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_DeleteWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).DeleteWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/adagio.rpc.controlplane.ControlPlane/DeleteWebhook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).DeleteWebhook(ctx, req.(*DeleteWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_ListDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeliveriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).ListDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/adagio.rpc.controlplane.ControlPlane/ListDeliveries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).ListDeliveries(ctx, req.(*ListDeliveriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlPlane_ListAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlPlaneServer).ListAuditEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/adagio.rpc.controlplane.ControlPlane/ListAuditEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlPlaneServer).ListAuditEvents(ctx, req.(*ListAuditEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ControlPlane_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "adagio.rpc.controlplane.ControlPlane",
 	HandlerType: (*ControlPlaneServer)(nil),
@@ -654,7 +2192,57 @@ var _ControlPlane_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ListAgents",
 			Handler:    _ControlPlane_ListAgents_Handler,
 		},
+		{
+			MethodName: "InspectAgent",
+			Handler:    _ControlPlane_InspectAgent_Handler,
+		},
+		{
+			MethodName: "Approve",
+			Handler:    _ControlPlane_Approve_Handler,
+		},
+		{
+			MethodName: "Reject",
+			Handler:    _ControlPlane_Reject_Handler,
+		},
+		{
+			MethodName: "ListUnschedulable",
+			Handler:    _ControlPlane_ListUnschedulable_Handler,
+		},
+		{
+			MethodName: "CreateWebhook",
+			Handler:    _ControlPlane_CreateWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhooks",
+			Handler:    _ControlPlane_ListWebhooks_Handler,
+		},
+		{
+			MethodName: "InspectWebhook",
+			Handler:    _ControlPlane_InspectWebhook_Handler,
+		},
+		{
+			MethodName: "UpdateWebhook",
+			Handler:    _ControlPlane_UpdateWebhook_Handler,
+		},
+		{
+			MethodName: "DeleteWebhook",
+			Handler:    _ControlPlane_DeleteWebhook_Handler,
+		},
+		{
+			MethodName: "ListDeliveries",
+			Handler:    _ControlPlane_ListDeliveries_Handler,
+		},
+		{
+			MethodName: "ListAuditEvents",
+			Handler:    _ControlPlane_ListAuditEvents_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamLogs",
+			Handler:       _ControlPlane_StreamLogs_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/rpc/controlplane/service.proto",
 }
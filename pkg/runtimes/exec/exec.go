@@ -1,7 +1,9 @@
 package exec
 
 import (
+	"bytes"
 	"context"
+	"io"
 	"os/exec"
 
 	"github.com/georgemac/adagio/pkg/adagio"
@@ -51,14 +53,22 @@ func NewFunction(command string, args ...string) *Function {
 
 // Run spawns a subprocess for the desired command and returns the combined
 // output writer as an adagio Result output slice of bytes
-func (fn *Function) Run(context.Context) (*adagio.Result, error) {
-	data, err := exec.Command(fn.Command, fn.Args...).CombinedOutput()
-	if err != nil {
+// The combined output is also streamed to the agent log writer as it is written
+func (fn *Function) Run(ctx context.Context) (*adagio.Result, error) {
+	var (
+		buf bytes.Buffer
+		cmd = exec.Command(fn.Command, fn.Args...)
+	)
+
+	cmd.Stdout = io.MultiWriter(&buf, agent.LogWriter(ctx))
+	cmd.Stderr = cmd.Stdout
+
+	if err := cmd.Run(); err != nil {
 		return nil, err
 	}
 
 	return &adagio.Result{
 		Conclusion: adagio.Result_SUCCESS,
-		Output:     data,
+		Output:     buf.Bytes(),
 	}, nil
 }
@@ -0,0 +1,224 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/georgemac/adagio/pkg/adagio"
+	"github.com/georgemac/adagio/pkg/agent"
+	runtime "github.com/georgemac/adagio/pkg/runtimes"
+	"github.com/georgemac/adagio/pkg/workflow"
+)
+
+const name = "http"
+
+var (
+	_ workflow.Function = (*Function)(nil)
+)
+
+// Runtime returns the http package agent.Runtime
+func Runtime() agent.Runtime {
+	return agent.RuntimeFunc(name, func() agent.Function {
+		return runtime.Function(blankFunction())
+	})
+}
+
+func blankFunction() *Function {
+	c := &Function{Builder: runtime.NewBuilder(name), client: http.DefaultClient}
+
+	c.String(&c.Method, "method", false, http.MethodGet)
+	c.String(&c.URL, "url", true, "")
+	c.Strings(&c.Headers, "headers", false)
+	c.String(&c.Body, "body", false, "")
+	c.Duration(&c.Timeout, "timeout", false, 0)
+	c.Strings(&c.ExpectedStatus, "expected_status", false, "200-299")
+	c.Strings(&c.FailStatus, "fail_status", false, "400-499")
+
+	return c
+}
+
+// Function is a struct which implements the agent.Runtime
+// It performs a single HTTP request and concludes based on the
+// status code of the response.
+// Given the status code is within one of the expected status ranges
+// the result is a success. Given it is within one of the fail status
+// ranges the result is a failure. Otherwise, or when the request
+// cannot be made, an error is returned
+type Function struct {
+	*runtime.Builder
+	Method         string
+	URL            string
+	Headers        []string
+	Body           string
+	Timeout        time.Duration
+	ExpectedStatus []string
+	FailStatus     []string
+
+	client *http.Client
+}
+
+// NewFunction constructs and configures a new http Function pointer
+func NewFunction(method, url string, opts ...Option) *Function {
+	function := blankFunction()
+	function.Method = method
+	function.URL = url
+	function.ExpectedStatus = []string{"200-299"}
+	function.FailStatus = []string{"400-499"}
+
+	Options(opts).Apply(function)
+
+	return function
+}
+
+// Option is a function option for the Function type
+type Option func(*Function)
+
+// Options is a slice of Option types
+type Options []Option
+
+// Apply functions each option in order on the provided Function
+func (o Options) Apply(c *Function) {
+	for _, opt := range o {
+		opt(c)
+	}
+}
+
+// WithHeader adds a header to the request made by the function
+func WithHeader(key, value string) Option {
+	return func(c *Function) {
+		c.Headers = append(c.Headers, fmt.Sprintf("%s: %s", key, value))
+	}
+}
+
+// WithBody configures the body of the request made by the function
+func WithBody(body string) Option {
+	return func(c *Function) {
+		c.Body = body
+	}
+}
+
+// WithTimeout configures a timeout for the request made by the function
+func WithTimeout(dur time.Duration) Option {
+	return func(c *Function) {
+		c.Timeout = dur
+	}
+}
+
+// WithExpectedStatus configures the status code ranges (e.g. "200-299" or "204")
+// which lead to a successful conclusion
+func WithExpectedStatus(ranges ...string) Option {
+	return func(c *Function) {
+		c.ExpectedStatus = ranges
+	}
+}
+
+// WithFailStatus configures the status code ranges (e.g. "400-499" or "404")
+// which lead to a failed conclusion
+func WithFailStatus(ranges ...string) Option {
+	return func(c *Function) {
+		c.FailStatus = ranges
+	}
+}
+
+// Run performs the configured request and returns the response body
+// as the result output. The status code and response headers are
+// recorded in the result metadata
+func (function *Function) Run(ctx context.Context) (*adagio.Result, error) {
+	if function.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, function.Timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, function.Method, function.URL, strings.NewReader(function.Body))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, header := range function.Headers {
+		parts := strings.SplitN(header, ":", 2)
+		if len(parts) < 2 {
+			return nil, fmt.Errorf("http: malformed header %q", header)
+		}
+
+		req.Header.Add(strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]))
+	}
+
+	resp, err := function.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &adagio.Result{
+		Metadata: map[string]*adagio.MetadataValue{
+			"http.status_code": {Values: []string{strconv.Itoa(resp.StatusCode)}},
+		},
+		Output: body,
+	}
+
+	for key, values := range resp.Header {
+		result.Metadata["http.header."+key] = &adagio.MetadataValue{Values: values}
+	}
+
+	expected, err := inRanges(resp.StatusCode, function.ExpectedStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	if expected {
+		result.Conclusion = adagio.Result_SUCCESS
+		return result, nil
+	}
+
+	failed, err := inRanges(resp.StatusCode, function.FailStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	if failed {
+		result.Conclusion = adagio.Result_FAIL
+		return result, nil
+	}
+
+	return nil, fmt.Errorf("http: unexpected status %q", resp.Status)
+}
+
+// inRanges returns true if the status code falls within one of the
+// provided ranges. A range is either a single code (e.g. "404") or
+// an inclusive range of codes (e.g. "400-499")
+func inRanges(code int, ranges []string) (bool, error) {
+	for _, rng := range ranges {
+		var (
+			parts    = strings.SplitN(rng, "-", 2)
+			min, err = strconv.Atoi(strings.TrimSpace(parts[0]))
+			max      = min
+		)
+		if err != nil {
+			return false, fmt.Errorf("http: malformed status range %q", rng)
+		}
+
+		if len(parts) > 1 {
+			if max, err = strconv.Atoi(strings.TrimSpace(parts[1])); err != nil {
+				return false, fmt.Errorf("http: malformed status range %q", rng)
+			}
+		}
+
+		if code >= min && code <= max {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,145 @@
+package http
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/georgemac/adagio/pkg/adagio"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Function_Run(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/echo":
+			body, _ := ioutil.ReadAll(r.Body)
+
+			w.Header().Set("X-Method", r.Method)
+			w.Header().Set("X-Token", r.Header.Get("X-Token"))
+			w.Write(body)
+		case "/slow":
+			time.Sleep(100 * time.Millisecond)
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte("not found"))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+
+	defer server.Close()
+
+	for _, testCase := range []struct {
+		name     string
+		function *Function
+		inputs   map[string][]byte
+		result   *adagio.Result
+		// response headers echoed by the server
+		headers map[string][]string
+		err     bool
+	}{
+		{
+			name: "happy path",
+			function: NewFunction(http.MethodPost, server.URL+"/echo",
+				WithHeader("X-Token", "secret"),
+				WithBody("hello")),
+			result: &adagio.Result{
+				Conclusion: adagio.Result_SUCCESS,
+				Output:     []byte("hello"),
+			},
+			headers: map[string][]string{
+				"X-Method": {http.MethodPost},
+				"X-Token":  {"secret"},
+			},
+		},
+		{
+			name: "happy path - body from input",
+			function: func() *Function {
+				fn := NewFunction(http.MethodPut, server.URL+"/echo")
+				require.Nil(t, fn.SetArgumentFromInput("body", "other_node"))
+				return fn
+			}(),
+			inputs: map[string][]byte{"other_node": []byte("from input")},
+			result: &adagio.Result{
+				Conclusion: adagio.Result_SUCCESS,
+				Output:     []byte("from input"),
+			},
+		},
+		{
+			name:     "fail status",
+			function: NewFunction(http.MethodGet, server.URL+"/missing"),
+			result: &adagio.Result{
+				Conclusion: adagio.Result_FAIL,
+				Output:     []byte("not found"),
+			},
+		},
+		{
+			name:     "configured expected status",
+			function: NewFunction(http.MethodGet, server.URL+"/missing", WithExpectedStatus("200", "404")),
+			result: &adagio.Result{
+				Conclusion: adagio.Result_SUCCESS,
+				Output:     []byte("not found"),
+			},
+		},
+		{
+			name:     "status outside of ranges is an error",
+			function: NewFunction(http.MethodGet, server.URL+"/broken"),
+			err:      true,
+		},
+		{
+			name:     "timeout is an error",
+			function: NewFunction(http.MethodGet, server.URL+"/slow", WithTimeout(10*time.Millisecond)),
+			err:      true,
+		},
+		{
+			name:     "transport error",
+			function: NewFunction(http.MethodGet, "http://127.0.0.1:0/"),
+			err:      true,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			spec, err := testCase.function.NewSpec("foo")
+			require.Nil(t, err)
+
+			fn := blankFunction()
+			require.Nil(t, fn.Parse(&adagio.Node{Spec: spec, Inputs: testCase.inputs}))
+
+			result, err := fn.Run(context.Background())
+			if testCase.err {
+				assert.NotNil(t, err)
+				return
+			}
+
+			require.Nil(t, err)
+
+			assert.Equal(t, testCase.result.Conclusion, result.Conclusion)
+			assert.Equal(t, testCase.result.Output, result.Output)
+			assert.NotEmpty(t, result.Metadata["http.status_code"].Values)
+
+			for header, values := range testCase.headers {
+				require.Contains(t, result.Metadata, "http.header."+header)
+				assert.Equal(t, values, result.Metadata["http.header."+header].Values)
+			}
+		})
+	}
+}
+
+func Test_Function_Run_Metadata(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Method", r.Method)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+
+	defer server.Close()
+
+	result, err := NewFunction(http.MethodDelete, server.URL).Run(context.Background())
+	require.Nil(t, err)
+
+	assert.Equal(t, []string{"202"}, result.Metadata["http.status_code"].Values)
+	assert.Equal(t, []string{http.MethodDelete}, result.Metadata["http.header.X-Method"].Values)
+}
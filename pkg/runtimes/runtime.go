@@ -30,6 +30,8 @@ func (t ArgumentType) String() string {
 		return "time.Time"
 	case JSONArgumentType:
 		return "json.Marshaller"
+	case DurationArgumentType:
+		return "time.Duration"
 	default:
 		return "unknown"
 	}
@@ -46,6 +48,8 @@ const (
 	TimeArgumentType
 	// JSONArgumentType represents any value marshalled to and from using JSON
 	JSONArgumentType
+	// DurationArgumentType represents a time.Duration type argument
+	DurationArgumentType
 )
 
 // ParseRunner is a type which has a separate function for parsing a node
@@ -253,6 +257,36 @@ func (b *Builder) Time(v *time.Time, name string, required bool, defaultValue ti
 	b.arguments[name] = argument
 }
 
+// Duration configures a duration argument which will set the pointer on calls
+// to builder.Parse() and read the value at the end of the pointer on calls
+// to builder.NewSpec(). Values are formatted as duration strings (e.g. "1m30s")
+func (b *Builder) Duration(v *time.Duration, name string, required bool, defaultValue time.Duration) {
+	argument := newArgument(name, DurationArgumentType, required, []string{defaultValue.String()})
+	argument.asMetadata = func() ([]string, error) {
+		if v == nil {
+			if required {
+				return nil, errors.New("argument is required")
+			}
+
+			return nil, nil
+		}
+
+		return []string{v.String()}, nil
+	}
+
+	argument.parse = func(vs []string) (err error) {
+		if len(vs) < 1 {
+			return errors.New("no value set for key")
+		}
+
+		*v, err = time.ParseDuration(vs[0])
+
+		return err
+	}
+
+	b.arguments[name] = argument
+}
+
 // JSON configures an argument which should be called with a pointer to
 // any json marshallable type
 // It will set the pointer on calls to builder.Parse() by calling unmarshal
@@ -103,6 +103,28 @@ func Test_Builder_Spec(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "happy path - duration",
+			setup: func() *Builder {
+				var (
+					builder       = NewBuilder("foo")
+					durationField = 90 * time.Second
+				)
+
+				builder.Duration(&durationField, "duration_field", false, 0)
+
+				return builder
+			},
+			spec: &adagio.Node_Spec{
+				Name:    "happy path - duration",
+				Runtime: "foo",
+				Metadata: map[string]*adagio.MetadataValue{
+					"adagio.arguments.foo.duration_field": {
+						Values: []string{"1m30s"},
+					},
+				},
+			},
+		},
 	} {
 		t.Run(testCase.name, func(t *testing.T) {
 			b := testCase.setup()
@@ -265,6 +287,35 @@ func Test_Builder_Parse(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "happy path - durations",
+			node: &adagio.Node{
+				Spec: &adagio.Node_Spec{
+					Name:    "a",
+					Runtime: "foo",
+					Metadata: map[string]*adagio.MetadataValue{
+						"adagio.arguments.foo.duration_field": {
+							Values: []string{"2h45m"},
+						},
+					},
+				},
+			},
+			setup: func() (*Builder, func(*testing.T)) {
+				var (
+					builder              = NewBuilder("foo")
+					durationField        time.Duration
+					defaultDurationField time.Duration
+				)
+
+				builder.Duration(&durationField, "duration_field", false, 0)
+				builder.Duration(&defaultDurationField, "default_duration_field", false, 5*time.Second)
+
+				return builder, func(t *testing.T) {
+					assert.Equal(t, 2*time.Hour+45*time.Minute, durationField)
+					assert.Equal(t, 5*time.Second, defaultDurationField)
+				}
+			},
+		},
 	} {
 		t.Run(testCase.name, func(t *testing.T) {
 			builder, assert := testCase.setup()
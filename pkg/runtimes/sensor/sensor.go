@@ -0,0 +1,295 @@
+package sensor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"github.com/georgemac/adagio/pkg/adagio"
+	"github.com/georgemac/adagio/pkg/agent"
+	runtime "github.com/georgemac/adagio/pkg/runtimes"
+	"github.com/georgemac/adagio/pkg/workflow"
+)
+
+const (
+	name = "sensor"
+
+	// FileType is the sensor type which checks for at least one
+	// file matching the target glob pattern
+	FileType = "file"
+	// TCPType is the sensor type which checks the target address
+	// accepts TCP connections
+	TCPType = "tcp"
+	// HTTPType is the sensor type which checks a GET request to the
+	// target URL responds with 200 OK
+	HTTPType = "http"
+	// RunType is the sensor type which checks the run identified by
+	// the target has completed successfully
+	RunType = "run"
+
+	// RescheduleMode releases the nodes claim between pokes
+	RescheduleMode = "reschedule"
+	// PokeMode retains the nodes claim and waits in-process between pokes
+	PokeMode = "poke"
+
+	defaultPokeInterval = 30 * time.Second
+)
+
+var (
+	_ workflow.Function = (*Function)(nil)
+
+	// ErrTimeout is returned when the sensor condition is not met
+	// before the configured timeout
+	ErrTimeout = errors.New("sensor timed out")
+	// ErrRunFailed is returned when the run sensed by a run sensor
+	// completes unsuccessfully
+	ErrRunFailed = errors.New("sensed run failed")
+)
+
+// Repository is the minimal interface required to
+// sense the completion of another run
+type Repository interface {
+	InspectRun(ctx context.Context, id string) (*adagio.Run, error)
+}
+
+// Runtime returns the sensor package agent.Runtime
+// Runs sensed by run sensors are inspected using the provided repository
+func Runtime(repo Repository) agent.Runtime {
+	return agent.RuntimeFunc(name, func() agent.Function {
+		function := blankFunction()
+		function.repo = repo
+
+		return runtime.Function(function)
+	})
+}
+
+func blankFunction() *Function {
+	c := &Function{Builder: runtime.NewBuilder(name), client: http.DefaultClient, now: time.Now}
+
+	c.String(&c.Type, "type", true, "")
+	c.String(&c.Target, "target", true, "")
+	c.Duration(&c.PokeInterval, "poke_interval", false, defaultPokeInterval)
+	c.Duration(&c.Timeout, "timeout", false, 0)
+	c.String(&c.Mode, "mode", false, RescheduleMode)
+
+	return c
+}
+
+// Function is a struct which implements the agent.Runtime
+// It checks whether an external condition has been met and concludes
+// successfully once it has.
+// Until then the condition is poked once per poke interval. In reschedule
+// mode the node is rescheduled between pokes, which releases its claim and
+// frees the agent to process other nodes. In poke mode the function waits
+// in-process between pokes.
+// Given the condition is not met within the timeout (measured from when the
+// node was first claimed) the result is a failure
+type Function struct {
+	*runtime.Builder
+	Type         string
+	Target       string
+	PokeInterval time.Duration
+	Timeout      time.Duration
+	Mode         string
+
+	repo   Repository
+	client *http.Client
+	now    func() time.Time
+	node   *adagio.Node
+}
+
+// NewFunction configures a new sensor Function pointer which
+// senses the target using the provided sensor type
+func NewFunction(typ, target string, opts ...Option) *Function {
+	function := blankFunction()
+	function.Type = typ
+	function.Target = target
+	function.PokeInterval = defaultPokeInterval
+	function.Mode = RescheduleMode
+
+	Options(opts).Apply(function)
+
+	return function
+}
+
+// Option is a function option for the Function type
+type Option func(*Function)
+
+// Options is a slice of Option types
+type Options []Option
+
+// Apply functions each option in order on the provided Function
+func (o Options) Apply(c *Function) {
+	for _, opt := range o {
+		opt(c)
+	}
+}
+
+// WithPokeInterval configures the interval between pokes
+func WithPokeInterval(interval time.Duration) Option {
+	return func(c *Function) {
+		c.PokeInterval = interval
+	}
+}
+
+// WithTimeout configures the duration after which the sensor fails
+// given the condition has not been met
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Function) {
+		c.Timeout = timeout
+	}
+}
+
+// WithMode configures the mode of the sensor (either "reschedule" or "poke")
+func WithMode(mode string) Option {
+	return func(c *Function) {
+		c.Mode = mode
+	}
+}
+
+// Parse retains the node being parsed in order to measure the timeout
+// from when it was first claimed and then delegates to the builder
+func (fn *Function) Parse(node *adagio.Node) error {
+	fn.node = node
+
+	return fn.Builder.Parse(node)
+}
+
+// Run pokes the configured condition and returns a successful result once it is met.
+// Otherwise, in reschedule mode, it returns an agent.Reschedule error in order for
+// the node to be poked again after the poke interval
+func (fn *Function) Run(ctx context.Context) (*adagio.Result, error) {
+	if fn.Mode != RescheduleMode && fn.Mode != PokeMode {
+		return nil, fmt.Errorf("sensor: unknown mode %q", fn.Mode)
+	}
+
+	if fn.PokeInterval <= 0 {
+		return nil, fmt.Errorf("sensor: poke interval must be positive")
+	}
+
+	started, err := fn.started()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		met, err := fn.poke(ctx)
+		if err != nil {
+			if errors.Is(err, ErrRunFailed) {
+				return failed(err), nil
+			}
+
+			return nil, err
+		}
+
+		if met {
+			return &adagio.Result{
+				Conclusion: adagio.Result_SUCCESS,
+				Output:     []byte(fn.Target),
+			}, nil
+		}
+
+		if fn.Timeout > 0 && fn.now().Sub(started) >= fn.Timeout {
+			return failed(fmt.Errorf("%w waiting on %s %q", ErrTimeout, fn.Type, fn.Target)), nil
+		}
+
+		if fn.Mode == RescheduleMode {
+			return nil, agent.Reschedule(fn.PokeInterval)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(fn.PokeInterval):
+		}
+	}
+}
+
+// started returns the time the node was first claimed
+// defaulting to now when unknown
+func (fn *Function) started() (time.Time, error) {
+	if fn.node == nil || fn.node.StartedAt == "" {
+		return fn.now(), nil
+	}
+
+	return time.Parse(time.RFC3339Nano, fn.node.StartedAt)
+}
+
+func (fn *Function) poke(ctx context.Context) (bool, error) {
+	switch fn.Type {
+	case FileType:
+		matches, err := filepath.Glob(fn.Target)
+		if err != nil {
+			return false, err
+		}
+
+		return len(matches) > 0, nil
+	case TCPType:
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, "tcp", fn.Target)
+		if err != nil {
+			// connection refused (or similar) means not yet met
+			return false, nil
+		}
+
+		return true, conn.Close()
+	case HTTPType:
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, fn.Target, nil)
+		if err != nil {
+			return false, err
+		}
+
+		resp, err := fn.client.Do(req)
+		if err != nil {
+			// transport errors mean not yet met
+			return false, nil
+		}
+
+		defer resp.Body.Close()
+
+		return resp.StatusCode == http.StatusOK, nil
+	case RunType:
+		if fn.repo == nil {
+			return false, errors.New("sensor: run sensor requires a repository")
+		}
+
+		run, err := fn.repo.InspectRun(ctx, fn.Target)
+		if err != nil {
+			return false, err
+		}
+
+		if run.Status != adagio.Run_COMPLETED {
+			return false, nil
+		}
+
+		for _, node := range run.Nodes {
+			if node.Status == adagio.Node_SKIPPED {
+				continue
+			}
+
+			succeeded := false
+			adagio.VisitLatestAttempt(node, func(attempt *adagio.Node_Result) {
+				succeeded = attempt.Conclusion == adagio.Node_Result_SUCCESS
+			})
+
+			if !succeeded {
+				return false, fmt.Errorf("%w: run %q node %q", ErrRunFailed, run.Id, node.Spec.Name)
+			}
+		}
+
+		return true, nil
+	default:
+		return false, fmt.Errorf("sensor: unknown type %q", fn.Type)
+	}
+}
+
+func failed(err error) *adagio.Result {
+	return &adagio.Result{
+		Conclusion: adagio.Result_FAIL,
+		Output:     []byte(err.Error()),
+	}
+}
@@ -0,0 +1,203 @@
+package sensor
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/georgemac/adagio/pkg/adagio"
+	"github.com/georgemac/adagio/pkg/agent"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Function_Run(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ready" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "sensor")
+	require.Nil(t, err)
+
+	defer os.RemoveAll(dir)
+
+	require.Nil(t, ioutil.WriteFile(filepath.Join(dir, "data.csv"), []byte("a,b"), 0644))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+
+	defer listener.Close()
+
+	// reserve an address which is not listening
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	closed.Close()
+
+	repo := repository{
+		"running":   {Id: "running", Status: adagio.Run_RUNNING},
+		"succeeded": completed("succeeded", adagio.Node_Result_SUCCESS),
+		"failed":    completed("failed", adagio.Node_Result_FAIL),
+	}
+
+	longAgo := time.Now().Add(-time.Hour).Format(time.RFC3339Nano)
+
+	for _, testCase := range []struct {
+		name       string
+		function   *Function
+		startedAt  string
+		conclusion adagio.Result_Conclusion
+		reschedule time.Duration
+		err        bool
+	}{
+		{
+			name:       "file exists",
+			function:   NewFunction(FileType, filepath.Join(dir, "*.csv")),
+			conclusion: adagio.Result_SUCCESS,
+		},
+		{
+			name:       "file does not exist",
+			function:   NewFunction(FileType, filepath.Join(dir, "*.json"), WithPokeInterval(time.Minute)),
+			reschedule: time.Minute,
+		},
+		{
+			name:       "tcp port open",
+			function:   NewFunction(TCPType, listener.Addr().String()),
+			conclusion: adagio.Result_SUCCESS,
+		},
+		{
+			name:       "tcp port closed",
+			function:   NewFunction(TCPType, closed.Addr().String()),
+			reschedule: defaultPokeInterval,
+		},
+		{
+			name:       "http endpoint ok",
+			function:   NewFunction(HTTPType, server.URL+"/ready"),
+			conclusion: adagio.Result_SUCCESS,
+		},
+		{
+			name:       "http endpoint unavailable",
+			function:   NewFunction(HTTPType, server.URL+"/other"),
+			reschedule: defaultPokeInterval,
+		},
+		{
+			name:       "run succeeded",
+			function:   NewFunction(RunType, "succeeded"),
+			conclusion: adagio.Result_SUCCESS,
+		},
+		{
+			name:       "run still running",
+			function:   NewFunction(RunType, "running"),
+			reschedule: defaultPokeInterval,
+		},
+		{
+			name:       "run failed",
+			function:   NewFunction(RunType, "failed"),
+			conclusion: adagio.Result_FAIL,
+		},
+		{
+			name:       "timed out",
+			function:   NewFunction(FileType, filepath.Join(dir, "*.json"), WithTimeout(time.Minute)),
+			startedAt:  longAgo,
+			conclusion: adagio.Result_FAIL,
+		},
+		{
+			name:       "met after timeout",
+			function:   NewFunction(FileType, filepath.Join(dir, "*.csv"), WithTimeout(time.Minute)),
+			startedAt:  longAgo,
+			conclusion: adagio.Result_SUCCESS,
+		},
+		{
+			name:     "poke mode times out",
+			function: NewFunction(FileType, filepath.Join(dir, "*.json"), WithMode(PokeMode), WithPokeInterval(time.Millisecond), WithTimeout(20*time.Millisecond)),
+			// poke mode waits in-process until the timeout
+			conclusion: adagio.Result_FAIL,
+		},
+		{
+			name:     "unknown type",
+			function: NewFunction("foo", "bar"),
+			err:      true,
+		},
+		{
+			name:     "unknown mode",
+			function: NewFunction(FileType, "bar", WithMode("foo")),
+			err:      true,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			spec, err := testCase.function.NewSpec("foo")
+			require.Nil(t, err)
+
+			fn := Runtime(repo).NewFunction()
+
+			result, err := fn.Run(context.Background(), &adagio.Node{Spec: spec, StartedAt: testCase.startedAt})
+			if testCase.reschedule > 0 {
+				after, ok := agent.RescheduleAfter(err)
+				require.True(t, ok, "expected reschedule", err)
+				assert.Equal(t, testCase.reschedule, after)
+				return
+			}
+
+			if testCase.err {
+				assert.NotNil(t, err)
+				return
+			}
+
+			require.Nil(t, err)
+			assert.Equal(t, testCase.conclusion, result.Conclusion)
+		})
+	}
+}
+
+func Test_Function_Run_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	fn := NewFunction(FileType, "/does/not/exist", WithMode(PokeMode), WithPokeInterval(time.Minute))
+
+	_, err := fn.Run(ctx)
+	assert.Equal(t, context.Canceled, err)
+}
+
+type repository map[string]*adagio.Run
+
+func (r repository) InspectRun(_ context.Context, id string) (*adagio.Run, error) {
+	run, ok := r[id]
+	if !ok {
+		return nil, adagio.ErrRunDoesNotExist
+	}
+
+	return run, nil
+}
+
+func completed(id string, conclusion adagio.Node_Result_Conclusion) *adagio.Run {
+	return &adagio.Run{
+		Id:     id,
+		Status: adagio.Run_COMPLETED,
+		Nodes: []*adagio.Node{
+			{
+				Spec:     &adagio.Node_Spec{Name: "a"},
+				Status:   adagio.Node_COMPLETED,
+				Attempts: []*adagio.Node_Result{{Conclusion: adagio.Node_Result_SUCCESS}},
+			},
+			{
+				Spec:   &adagio.Node_Spec{Name: "b"},
+				Status: adagio.Node_SKIPPED,
+			},
+			{
+				Spec:     &adagio.Node_Spec{Name: "c"},
+				Status:   adagio.Node_COMPLETED,
+				Attempts: []*adagio.Node_Result{{Conclusion: conclusion}},
+			},
+		},
+	}
+}
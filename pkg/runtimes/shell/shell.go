@@ -0,0 +1,232 @@
+package shell
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/georgemac/adagio/pkg/adagio"
+	"github.com/georgemac/adagio/pkg/agent"
+	runtime "github.com/georgemac/adagio/pkg/runtimes"
+	"github.com/georgemac/adagio/pkg/workflow"
+)
+
+const (
+	name = "shell"
+
+	// EnvRunID is the environment variable containing the ID of the run
+	EnvRunID = "ADAGIO_RUN_ID"
+	// EnvNodeName is the environment variable containing the name of the node
+	EnvNodeName = "ADAGIO_NODE_NAME"
+	// EnvAttempt is the environment variable containing the current attempt number
+	// starting from 1
+	EnvAttempt = "ADAGIO_ATTEMPT"
+	// EnvOutput is the environment variable containing the path of the file
+	// the contents of which becomes the output of the node
+	EnvOutput = "ADAGIO_OUTPUT"
+	// EnvInputPrefix is the prefix of the environment variables which contain
+	// the paths to files containing each of the nodes inputs
+	EnvInputPrefix = "ADAGIO_INPUT_"
+)
+
+var (
+	_ workflow.Function = (*Function)(nil)
+
+	defaultInterpreter = []string{"/bin/sh"}
+)
+
+// Runtime returns the shell package agent.Runtime
+func Runtime() agent.Runtime {
+	return agent.RuntimeFunc(name, func() agent.Function {
+		return runtime.Function(blankFunction())
+	})
+}
+
+func blankFunction() *Function {
+	c := &Function{Builder: runtime.NewBuilder(name)}
+
+	c.String(&c.Script, "script", true, "")
+	c.Strings(&c.Interpreter, "interpreter", false, defaultInterpreter...)
+
+	return c
+}
+
+// Function is a struct which implements the agent.Runtime
+// It writes the script for a provided node to a temporary file
+// and invokes it using the configured interpreter.
+// Each of the nodes inputs are written to temporary files which
+// are exposed to the script via ADAGIO_INPUT_<NAME> environment variables
+type Function struct {
+	*runtime.Builder
+	Script      string
+	Interpreter []string
+
+	node *adagio.Node
+}
+
+// NewFunction configures a new shell.Function pointer
+// The interpreter defaults to /bin/sh when none is provided
+func NewFunction(script string, interpreter ...string) *Function {
+	fn := blankFunction()
+	fn.Script = script
+	fn.Interpreter = defaultInterpreter
+	if len(interpreter) > 0 {
+		fn.Interpreter = interpreter
+	}
+	return fn
+}
+
+// Parse retains the node being parsed in order to expose its
+// inputs and details to the script and then delegates to the builder.
+// It returns an error given two of the nodes inputs share an environment variable
+func (fn *Function) Parse(node *adagio.Node) error {
+	if err := checkInputs(node.Inputs); err != nil {
+		return err
+	}
+
+	fn.node = node
+
+	return fn.Builder.Parse(node)
+}
+
+// Run writes the script and inputs to a temporary directory and runs
+// the script using the interpreter. The contents of the file located at
+// ADAGIO_OUTPUT are returned as the result output. Given the script
+// exits with a non-zero status the result is concluded as a failure
+func (fn *Function) Run(ctx context.Context) (*adagio.Result, error) {
+	if len(fn.Interpreter) < 1 {
+		return nil, errors.New("shell: interpreter not set")
+	}
+
+	dir, err := ioutil.TempDir("", "adagio-shell")
+	if err != nil {
+		return nil, err
+	}
+
+	defer os.RemoveAll(dir)
+
+	var (
+		scriptPath = filepath.Join(dir, "script")
+		outputPath = filepath.Join(dir, "output")
+		env        = fn.environment(ctx, outputPath)
+	)
+
+	if err := ioutil.WriteFile(scriptPath, []byte(fn.Script), 0700); err != nil {
+		return nil, err
+	}
+
+	if err := ioutil.WriteFile(outputPath, nil, 0600); err != nil {
+		return nil, err
+	}
+
+	if fn.node != nil {
+		inputsDir := filepath.Join(dir, "inputs")
+		if err := os.Mkdir(inputsDir, 0700); err != nil {
+			return nil, err
+		}
+
+		for input, data := range fn.node.Inputs {
+			path := filepath.Join(inputsDir, envName(input))
+			if err := ioutil.WriteFile(path, data, 0600); err != nil {
+				return nil, err
+			}
+
+			env = append(env, EnvInputPrefix+envName(input)+"="+path)
+		}
+	}
+
+	args := append(append([]string{}, fn.Interpreter[1:]...), scriptPath)
+
+	cmd := exec.CommandContext(ctx, fn.Interpreter[0], args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), env...)
+
+	// the combined output is streamed to the agent log writer as it is written
+	cmd.Stdout = agent.LogWriter(ctx)
+	cmd.Stderr = cmd.Stdout
+
+	runErr := cmd.Run()
+
+	output, err := ioutil.ReadFile(outputPath)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &adagio.Result{
+		Conclusion: adagio.Result_SUCCESS,
+		Metadata:   map[string]*adagio.MetadataValue{},
+		Output:     output,
+	}
+
+	if runErr != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(runErr, &exitErr) || ctx.Err() != nil {
+			return nil, runErr
+		}
+
+		result.Conclusion = adagio.Result_FAIL
+		result.Metadata["shell.exit_code"] = &adagio.MetadataValue{
+			Values: []string{fmt.Sprintf("%d", exitErr.ExitCode())},
+		}
+	}
+
+	return result, nil
+}
+
+func (fn *Function) environment(ctx context.Context, outputPath string) []string {
+	env := []string{EnvOutput + "=" + outputPath}
+
+	if runID, ok := agent.RunIDFromContext(ctx); ok {
+		env = append(env, EnvRunID+"="+runID)
+	}
+
+	if fn.node != nil {
+		env = append(env,
+			EnvNodeName+"="+fn.node.Spec.Name,
+			fmt.Sprintf("%s=%d", EnvAttempt, len(fn.node.Attempts)+1))
+	}
+
+	return env
+}
+
+// checkInputs ensures each input name converts to a distinct environment variable
+// (e.g. "some-node" and "some_node" both become "SOME_NODE")
+func checkInputs(inputs map[string][]byte) error {
+	names := make([]string, 0, len(inputs))
+	for input := range inputs {
+		names = append(names, input)
+	}
+
+	sort.Strings(names)
+
+	seen := map[string]string{}
+	for _, input := range names {
+		env := envName(input)
+		if other, ok := seen[env]; ok {
+			return fmt.Errorf("shell: inputs %q and %q are both exposed as %s", other, input, EnvInputPrefix+env)
+		}
+
+		seen[env] = input
+	}
+
+	return nil
+}
+
+// envName converts an input name into a form suitable for use
+// as the suffix of an environment variable (e.g. "some-node" -> "SOME_NODE")
+func envName(input string) string {
+	return strings.Map(func(r rune) rune {
+		if r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return '_'
+		}
+
+		return unicode.ToUpper(r)
+	}, input)
+}
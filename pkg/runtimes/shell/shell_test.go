@@ -0,0 +1,103 @@
+package shell
+
+import (
+	"context"
+	"testing"
+
+	"github.com/georgemac/adagio/pkg/adagio"
+	"github.com/georgemac/adagio/pkg/agent"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Function_Run(t *testing.T) {
+	for _, testCase := range []struct {
+		name       string
+		function   *Function
+		inputs     map[string][]byte
+		conclusion adagio.Result_Conclusion
+		output     string
+		exitCode   []string
+	}{
+		{
+			name:       "happy path",
+			function:   NewFunction(`echo -n "hello" > "$ADAGIO_OUTPUT"`),
+			conclusion: adagio.Result_SUCCESS,
+			output:     "hello",
+		},
+		{
+			name: "run details and inputs",
+			function: NewFunction(`
+printf "%s %s %s " "$ADAGIO_RUN_ID" "$ADAGIO_NODE_NAME" "$ADAGIO_ATTEMPT" >> "$ADAGIO_OUTPUT"
+cat "$ADAGIO_INPUT_OTHER_NODE" >> "$ADAGIO_OUTPUT"`),
+			inputs: map[string][]byte{
+				"other-node": []byte("other output"),
+			},
+			conclusion: adagio.Result_SUCCESS,
+			output:     "run foo 2 other output",
+		},
+		{
+			name:       "configured interpreter",
+			function:   NewFunction(`basename "$0" | tr -d "\n" > "$ADAGIO_OUTPUT"`, "/bin/sh", "-e"),
+			conclusion: adagio.Result_SUCCESS,
+			output:     "script",
+		},
+		{
+			name:       "non-zero exit code",
+			function:   NewFunction(`echo -n "partial" > "$ADAGIO_OUTPUT"; exit 3`),
+			conclusion: adagio.Result_FAIL,
+			output:     "partial",
+			exitCode:   []string{"3"},
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			spec, err := testCase.function.NewSpec("foo")
+			require.Nil(t, err)
+
+			var (
+				ctx  = agent.WithRunID(context.Background(), "run")
+				node = &adagio.Node{
+					Spec:     spec,
+					Inputs:   testCase.inputs,
+					Attempts: []*adagio.Node_Result{{Conclusion: adagio.Node_Result_ERROR}},
+				}
+				fn = blankFunction()
+			)
+
+			require.Nil(t, fn.Parse(node))
+
+			result, err := fn.Run(ctx)
+			require.Nil(t, err)
+
+			assert.Equal(t, testCase.conclusion, result.Conclusion)
+			assert.Equal(t, testCase.output, string(result.Output))
+
+			if testCase.exitCode != nil {
+				assert.Equal(t, testCase.exitCode, result.Metadata["shell.exit_code"].Values)
+			}
+		})
+	}
+}
+
+func Test_Function_Parse_CollidingInputs(t *testing.T) {
+	spec, err := NewFunction(`cat "$ADAGIO_INPUT_OTHER_NODE" > "$ADAGIO_OUTPUT"`).NewSpec("foo")
+	require.Nil(t, err)
+
+	err = blankFunction().Parse(&adagio.Node{
+		Spec: spec,
+		Inputs: map[string][]byte{
+			"other-node": []byte("first output"),
+			"other_node": []byte("second output"),
+		},
+	})
+	require.NotNil(t, err)
+
+	assert.Equal(t, `shell: inputs "other-node" and "other_node" are both exposed as ADAGIO_INPUT_OTHER_NODE`, err.Error())
+}
+
+func Test_Function_Run_MissingInterpreter(t *testing.T) {
+	fn := NewFunction("exit 0", "/does/not/exist")
+
+	_, err := fn.Run(context.Background())
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,342 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/georgemac/adagio/pkg/adagio"
+	"github.com/georgemac/adagio/pkg/agent"
+	"github.com/georgemac/adagio/pkg/logging"
+	runtime "github.com/georgemac/adagio/pkg/runtimes"
+	"github.com/georgemac/adagio/pkg/workflow"
+)
+
+const (
+	name = "workflow"
+
+	// cancelTimeout bounds the time taken to cancel a child run
+	cancelTimeout = 10 * time.Second
+)
+
+var (
+	_ workflow.Function = (*Function)(nil)
+
+	// ErrWorkflowNotFound is returned when a node references a
+	// workflow name which has not been registered with the runtime
+	ErrWorkflowNotFound = errors.New("workflow not found")
+)
+
+// Repository is the minimal interface required to start, await
+// the completion of and cancel child runs
+type Repository interface {
+	StartRun(context.Context, *adagio.GraphSpec, ...adagio.RunOption) (*adagio.Run, error)
+	InspectRun(ctx context.Context, id string) (*adagio.Run, error)
+	CancelRun(ctx context.Context, id string) error
+}
+
+// Runtime returns the workflow package agent.Runtime
+// Child runs are started and inspected using the provided repository
+func Runtime(repo Repository, opts ...RuntimeOption) agent.Runtime {
+	config := &config{
+		workflows:    map[string]*adagio.GraphSpec{},
+		pollInterval: time.Second,
+	}
+
+	RuntimeOptions(opts).Apply(config)
+
+	return agent.RuntimeFunc(name, func() agent.Function {
+		function := blankFunction()
+		function.repo = repo
+		function.config = config
+
+		return runtime.Function(function)
+	})
+}
+
+type config struct {
+	workflows    map[string]*adagio.GraphSpec
+	pollInterval time.Duration
+}
+
+// RuntimeOption is a functional option for the workflow Runtime
+type RuntimeOption func(*config)
+
+// RuntimeOptions is a slice of RuntimeOption types
+type RuntimeOptions []RuntimeOption
+
+// Apply calls each option in turn on the provided config
+func (o RuntimeOptions) Apply(c *config) {
+	for _, opt := range o {
+		opt(c)
+	}
+}
+
+// WithWorkflow registers a graph specification under the provided
+// name which can then be referenced by nodes using the runtime
+func WithWorkflow(name string, spec *adagio.GraphSpec) RuntimeOption {
+	return func(c *config) {
+		c.workflows[name] = spec
+	}
+}
+
+// WithPollInterval configures the interval at which child
+// runs are inspected to check whether or not they have completed
+func WithPollInterval(interval time.Duration) RuntimeOption {
+	return func(c *config) {
+		c.pollInterval = interval
+	}
+}
+
+func blankFunction() *Function {
+	c := &Function{Builder: runtime.NewBuilder(name)}
+
+	c.String(&c.Name, "name", false, "")
+	c.JSON(&c.Spec, "spec", false)
+
+	return c
+}
+
+// Function is a struct which implements the agent.Runtime
+// It starts a child run from either an embedded graph specification
+// or a workflow registered with the runtime by name and waits for the
+// child run to complete
+type Function struct {
+	*runtime.Builder
+	Name string
+	Spec *adagio.GraphSpec
+
+	repo   Repository
+	config *config
+	node   *adagio.Node
+}
+
+// NewFunction configures a new workflow Function pointer which
+// starts a child run from the provided graph specification
+func NewFunction(spec *adagio.GraphSpec) *Function {
+	fn := blankFunction()
+	fn.Spec = spec
+	return fn
+}
+
+// NewNamedFunction configures a new workflow Function pointer which
+// starts a child run from the workflow registered under the provided name
+func NewNamedFunction(name string) *Function {
+	fn := blankFunction()
+	fn.Name = name
+	return fn
+}
+
+// Parse retains the node being parsed in order to link the child
+// run to it and then delegates to the builder
+func (fn *Function) Parse(node *adagio.Node) error {
+	fn.node = node
+
+	return fn.Builder.Parse(node)
+}
+
+// Run starts the child run and waits for it to complete. Given the current attempt of the
+// node already started a child run (e.g. before its claim was orphaned) that run is awaited.
+// Given all the nodes in the child run succeed (or are skipped) the result is a success.
+// The result output is a JSON object mapping the names of the child runs leaf
+// nodes to their respective outputs.
+// While waiting the proportion of the child runs nodes which are resolved is reported
+// as the progress of the node. Given the context is cancelled the child run is
+// cancelled and the function returns the context error
+func (fn *Function) Run(ctx context.Context) (*adagio.Result, error) {
+	spec, err := fn.spec()
+	if err != nil {
+		return nil, err
+	}
+
+	run, err := fn.child(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	ticker := time.NewTicker(fn.config.pollInterval)
+	defer ticker.Stop()
+
+	for run.Status != adagio.Run_COMPLETED {
+		select {
+		case <-ctx.Done():
+			fn.cancel(ctx, run)
+
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+
+		if run, err = fn.repo.InspectRun(ctx, run.Id); err != nil {
+			return nil, err
+		}
+
+		if err := agent.ReportProgress(ctx, progress(run)); err != nil {
+			agent.Logger(ctx).WithError(err).Warn("workflow: reporting progress")
+		}
+	}
+
+	return result(run)
+}
+
+// child returns the child run of the current attempt of the node, starting it
+// given the attempt has not already started one
+func (fn *Function) child(ctx context.Context, spec *adagio.GraphSpec) (*adagio.Run, error) {
+	runID, ok := agent.RunIDFromContext(ctx)
+	if !ok || fn.node == nil {
+		return fn.repo.StartRun(ctx, spec)
+	}
+
+	parent, err := fn.repo.InspectRun(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	var latest string
+	for _, link := range parent.Children {
+		if link.Node == fn.node.Spec.Name {
+			latest = link.RunId
+		}
+	}
+
+	if latest != "" {
+		run, err := fn.repo.InspectRun(ctx, latest)
+		if err != nil {
+			return nil, err
+		}
+
+		// the child runs of finished attempts have completed (or been cancelled)
+		// and were started before the attempt finished
+		if run.Status != adagio.Run_COMPLETED || startedAfter(run, lastFinished(fn.node)) {
+			return run, nil
+		}
+	}
+
+	return fn.repo.StartRun(ctx, spec, adagio.WithParent(runID, fn.node.Spec.Name))
+}
+
+// lastFinished returns the time at which the latest attempt of the node finished
+// which is the zero time given no attempt has finished
+func lastFinished(node *adagio.Node) (finished time.Time) {
+	for _, transition := range node.History {
+		if transition.Type != adagio.Node_Transition_FINISHED {
+			continue
+		}
+
+		if at, err := time.Parse(time.RFC3339Nano, transition.At); err == nil {
+			finished = at
+		}
+	}
+
+	return
+}
+
+// startedAfter returns true given the run was created after t
+func startedAfter(run *adagio.Run, t time.Time) bool {
+	createdAt, err := time.Parse(time.RFC3339Nano, run.CreatedAt)
+	return err == nil && createdAt.After(t)
+}
+
+// cancel cancels the child run so that none of its remaining nodes are run
+// once the node which started it has been abandoned
+func (fn *Function) cancel(ctx context.Context, run *adagio.Run) {
+	// the context of the function is done so the child is cancelled within its own
+	cancelCtx, cancel := context.WithTimeout(context.Background(), cancelTimeout)
+	defer cancel()
+
+	if err := fn.repo.CancelRun(cancelCtx, run.Id); err != nil {
+		agent.Logger(ctx).WithError(err).WithField(logging.RunIDKey, run.Id).Error("workflow: cancelling child run")
+	}
+}
+
+// progress describes the proportion of the child runs nodes which are resolved
+func progress(run *adagio.Run) *adagio.Node_Progress {
+	resolved := 0
+	for _, node := range run.Nodes {
+		if adagio.IsResolved(node) {
+			resolved++
+		}
+	}
+
+	progress := &adagio.Node_Progress{
+		Message: fmt.Sprintf("%d of %d nodes resolved", resolved, len(run.Nodes)),
+		Metadata: map[string]*adagio.MetadataValue{
+			"workflow.run_id": {Values: []string{run.Id}},
+		},
+	}
+
+	if len(run.Nodes) > 0 {
+		progress.Percent = 100 * float64(resolved) / float64(len(run.Nodes))
+	}
+
+	return progress
+}
+
+func (fn *Function) spec() (*adagio.GraphSpec, error) {
+	if fn.Name != "" {
+		spec, ok := fn.config.workflows[fn.Name]
+		if !ok {
+			return nil, fmt.Errorf("workflow %q: %w", fn.Name, ErrWorkflowNotFound)
+		}
+
+		return spec, nil
+	}
+
+	if fn.Spec == nil || len(fn.Spec.Nodes) == 0 {
+		return nil, errors.New("workflow: either a name or a spec with nodes must be provided")
+	}
+
+	return fn.Spec, nil
+}
+
+func result(run *adagio.Run) (*adagio.Result, error) {
+	var (
+		graph   = adagio.GraphFrom(run)
+		outputs = map[string]string{}
+		result  = &adagio.Result{
+			Conclusion: adagio.Result_SUCCESS,
+			Metadata: map[string]*adagio.MetadataValue{
+				"workflow.run_id": {Values: []string{run.Id}},
+			},
+		}
+	)
+
+	for _, node := range run.Nodes {
+		if node.Status == adagio.Node_SKIPPED {
+			// skipped nodes neither fail the run nor contribute to the output
+			continue
+		}
+
+		succeeded := false
+		adagio.VisitLatestAttempt(node, func(attempt *adagio.Node_Result) {
+			succeeded = attempt.Conclusion == adagio.Node_Result_SUCCESS
+		})
+
+		if !succeeded {
+			result.Conclusion = adagio.Result_FAIL
+			continue
+		}
+
+		outgoing, err := graph.Outgoing(node)
+		if err != nil {
+			return nil, err
+		}
+
+		// only leaf nodes contribute to the output
+		if len(outgoing) > 0 {
+			continue
+		}
+
+		outputs[node.Spec.Name] = string(node.Attempts[len(node.Attempts)-1].Output)
+	}
+
+	data, err := json.Marshal(outputs)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Output = data
+
+	return result, nil
+}
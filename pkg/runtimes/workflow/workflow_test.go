@@ -0,0 +1,249 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/georgemac/adagio/pkg/adagio"
+	"github.com/georgemac/adagio/pkg/agent"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	child = &adagio.GraphSpec{
+		Nodes: []*adagio.Node_Spec{{Name: "a"}, {Name: "b"}, {Name: "c"}},
+		Edges: []*adagio.Edge{
+			{Source: "a", Destination: "b"},
+			{Source: "a", Destination: "c"},
+		},
+	}
+
+	success = &adagio.Node_Result{Conclusion: adagio.Node_Result_SUCCESS}
+)
+
+func Test_Function_Run(t *testing.T) {
+	for _, testCase := range []struct {
+		name     string
+		function *Function
+		results  map[string]*adagio.Node_Result
+		result   *adagio.Result
+		err      error
+	}{
+		{
+			name:     "embedded spec succeeds",
+			function: NewFunction(child),
+			results: map[string]*adagio.Node_Result{
+				"a": success,
+				"b": {Conclusion: adagio.Node_Result_SUCCESS, Output: []byte("b")},
+				"c": {Conclusion: adagio.Node_Result_SUCCESS, Output: []byte("c")},
+			},
+			result: &adagio.Result{
+				Conclusion: adagio.Result_SUCCESS,
+				Output:     []byte(`{"b":"b","c":"c"}`),
+			},
+		},
+		{
+			name:     "registered workflow fails",
+			function: NewNamedFunction("child"),
+			results: map[string]*adagio.Node_Result{
+				"a": success,
+				"b": {Conclusion: adagio.Node_Result_FAIL, Output: []byte("b")},
+				"c": {Conclusion: adagio.Node_Result_SUCCESS, Output: []byte("c")},
+			},
+			result: &adagio.Result{
+				Conclusion: adagio.Result_FAIL,
+				Output:     []byte(`{"c":"c"}`),
+			},
+		},
+		{
+			name:     "skipped nodes are ignored",
+			function: NewFunction(child),
+			results: map[string]*adagio.Node_Result{
+				"a": success,
+				"b": nil,
+				"c": {Conclusion: adagio.Node_Result_SUCCESS, Output: []byte("c")},
+			},
+			result: &adagio.Result{
+				Conclusion: adagio.Result_SUCCESS,
+				Output:     []byte(`{"c":"c"}`),
+			},
+		},
+		{
+			name:     "unknown registered workflow",
+			function: NewNamedFunction("unknown"),
+			err:      ErrWorkflowNotFound,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			var (
+				repo = &repository{results: testCase.results}
+				rt   = Runtime(repo, WithWorkflow("child", child), WithPollInterval(time.Millisecond))
+				ctx  = agent.WithRunID(context.Background(), "parent")
+			)
+
+			spec, err := testCase.function.NewSpec("foo")
+			require.Nil(t, err)
+
+			result, err := rt.NewFunction().Run(ctx, &adagio.Node{Spec: spec})
+			if testCase.err != nil {
+				assert.True(t, errors.Is(err, testCase.err), "unexpected error", err)
+				return
+			}
+
+			require.Nil(t, err)
+
+			assert.Equal(t, testCase.result.Conclusion, result.Conclusion)
+			assert.Equal(t, testCase.result.Output, result.Output)
+			assert.Equal(t, []string{"child"}, result.Metadata["workflow.run_id"].Values)
+
+			assert.Equal(t, child, repo.spec)
+			assert.Equal(t, &adagio.Run_Link{RunId: "parent", Node: "foo"}, repo.parent)
+		})
+	}
+}
+
+func Test_Function_Run_Cancelled(t *testing.T) {
+	var (
+		repo        = &repository{}
+		rt          = Runtime(repo, WithPollInterval(time.Millisecond))
+		ctx, cancel = context.WithCancel(context.Background())
+	)
+
+	spec, err := NewFunction(child).NewSpec("foo")
+	require.Nil(t, err)
+
+	cancel()
+
+	_, err = rt.NewFunction().Run(ctx, &adagio.Node{Spec: spec})
+	assert.Equal(t, context.Canceled, err)
+
+	// the child run is cancelled along with the node which started it
+	require.NotEmpty(t, repo.started)
+	assert.Equal(t, []string{repo.started}, repo.cancelled)
+}
+
+func Test_Function_Run_ExistingChild(t *testing.T) {
+	for _, testCase := range []struct {
+		name    string
+		history []*adagio.Node_Transition
+		// previous inspections of the existing child
+		inspect int
+		started bool
+	}{
+		{
+			name:    "the child of the current attempt is awaited once the node is claimed again",
+			started: false,
+		},
+		{
+			name: "a child is started for an attempt after the previous attempt finished",
+			history: []*adagio.Node_Transition{
+				{Type: adagio.Node_Transition_FINISHED, At: time.Now().Add(time.Hour).Format(time.RFC3339Nano)},
+			},
+			inspect: 1,
+			started: true,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			var (
+				repo = &repository{
+					spec:     child,
+					inspect:  testCase.inspect,
+					children: []*adagio.Run_Link{{RunId: "child", Node: "foo"}},
+					results:  map[string]*adagio.Node_Result{"a": success, "b": success, "c": success},
+				}
+				rt  = Runtime(repo, WithPollInterval(time.Millisecond))
+				ctx = agent.WithRunID(context.Background(), "parent")
+			)
+
+			spec, err := NewFunction(child).NewSpec("foo")
+			require.Nil(t, err)
+
+			result, err := rt.NewFunction().Run(ctx, &adagio.Node{Spec: spec, History: testCase.history})
+			require.Nil(t, err)
+
+			assert.Equal(t, adagio.Result_SUCCESS, result.Conclusion)
+			assert.Equal(t, testCase.started, repo.started != "")
+		})
+	}
+}
+
+type repository struct {
+	results map[string]*adagio.Node_Result
+	inspect int
+
+	// child runs linked to the parent run
+	children []*adagio.Run_Link
+
+	spec   *adagio.GraphSpec
+	parent *adagio.Run_Link
+
+	started   string
+	cancelled []string
+}
+
+func (r *repository) StartRun(_ context.Context, spec *adagio.GraphSpec, opts ...adagio.RunOption) (*adagio.Run, error) {
+	run, err := adagio.NewRun(spec, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	r.spec = spec
+	r.parent = run.Parent
+	r.started = run.Id
+
+	if run.Parent != nil {
+		r.children = append(r.children, &adagio.Run_Link{RunId: run.Id, Node: run.Parent.Node})
+	}
+
+	return run, nil
+}
+
+func (r *repository) CancelRun(ctx context.Context, id string) error {
+	// runs are cancelled even though the context of the function is done
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.cancelled = append(r.cancelled, id)
+
+	return nil
+}
+
+func (r *repository) InspectRun(_ context.Context, id string) (*adagio.Run, error) {
+	if id == "parent" {
+		return &adagio.Run{Id: id, Children: r.children}, nil
+	}
+
+	run, err := adagio.NewRun(r.spec)
+	if err != nil {
+		return nil, err
+	}
+
+	run.Id = "child"
+
+	// report the run as running on first inspection
+	r.inspect++
+	if r.inspect < 2 {
+		run.Status = adagio.Run_RUNNING
+		return run, nil
+	}
+
+	run.Status = adagio.Run_COMPLETED
+	for _, node := range run.Nodes {
+		node.Status = adagio.Node_COMPLETED
+		if result, ok := r.results[node.Spec.Name]; ok {
+			// a nil result signifies the node was skipped
+			if result == nil {
+				node.Status = adagio.Node_SKIPPED
+				continue
+			}
+
+			node.Attempts = []*adagio.Node_Result{result}
+		}
+	}
+
+	return run, nil
+}
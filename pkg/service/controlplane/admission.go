@@ -0,0 +1,80 @@
+package controlplane
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/georgemac/adagio/pkg/adagio"
+)
+
+// Admission decides whether runs can be started in a namespace. A run is admitted
+// given its nodes can be placed on the registered agents and its namespace has fewer
+// runs which have not completed than its quota. Child runs count as part of their parent
+// run so they are not subject to the quota. It is shared by every starter of runs
+// within a process, such as the control plane and the workflow runtime
+type Admission struct {
+	// maximum number of concurrent runs of each namespace
+	quotas map[string]int
+	// serializes the quota checks and starts of runs
+	mu sync.Mutex
+}
+
+// NewAdmission constructs an Admission which enforces the maximum number of concurrent
+// runs of each named namespace. Runs started in a namespace count against its quota until
+// every one of their nodes has completed. Namespaces without a quota are unlimited
+func NewAdmission(quotas map[string]int) *Admission {
+	return &Admission{quotas: quotas}
+}
+
+// StartRun admits a run of the spec into the namespace and starts it using the repository
+// of the namespace. It returns an error wrapping ErrNodeUnplaceable or ErrRunQuotaExceeded
+// given the run is not admitted
+func (a *Admission) StartRun(ctx context.Context, repo Repository, namespace string, spec *adagio.GraphSpec, opts ...adagio.RunOption) (*adagio.Run, error) {
+	agents, err := repo.ListAgents(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := adagio.CheckPlacement(spec, agents); err != nil {
+		return nil, err
+	}
+
+	// child runs were admitted along with their parent run
+	probe := &adagio.Run{}
+	adagio.RunOptions(opts).Apply(probe)
+
+	namespace = adagio.Namespace(namespace)
+	if quota, ok := a.quotas[namespace]; ok && probe.Parent == nil {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+
+		if err := checkQuota(ctx, repo, namespace, quota); err != nil {
+			return nil, err
+		}
+	}
+
+	return repo.StartRun(ctx, spec, opts...)
+}
+
+// checkQuota returns an error wrapping ErrRunQuotaExceeded given the repository of the
+// namespace has as many runs which have not completed as its quota, not counting child runs
+func checkQuota(ctx context.Context, repo Repository, namespace string, quota int) error {
+	runs, err := repo.ListRuns(ctx, ListRequest{})
+	if err != nil {
+		return err
+	}
+
+	var running int
+	for _, run := range runs {
+		if run.Parent == nil && !adagio.RunCompleted(run) {
+			running++
+		}
+	}
+
+	if running >= quota {
+		return fmt.Errorf("namespace %q has %d of %d concurrent runs: %w", namespace, running, quota, adagio.ErrRunQuotaExceeded)
+	}
+
+	return nil
+}
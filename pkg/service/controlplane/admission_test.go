@@ -0,0 +1,92 @@
+package controlplane_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/georgemac/adagio/pkg/adagio"
+	"github.com/georgemac/adagio/pkg/agent"
+	"github.com/georgemac/adagio/pkg/logging"
+	"github.com/georgemac/adagio/pkg/memory"
+	"github.com/georgemac/adagio/pkg/runtimes/workflow"
+	"github.com/georgemac/adagio/pkg/service/controlplane"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// admitted starts the runs of the workflow runtime through the admission
+type admitted struct {
+	*memory.Repository
+	admission *controlplane.Admission
+}
+
+func (a admitted) StartRun(ctx context.Context, spec *adagio.GraphSpec, opts ...adagio.RunOption) (*adagio.Run, error) {
+	return a.admission.StartRun(ctx, a.Repository, adagio.DefaultNamespace, spec, opts...)
+}
+
+func Test_Admission_StartRun_Workflow(t *testing.T) {
+	var (
+		ctx       = context.Background()
+		repo      = memory.New(memory.WithLogger(logging.Discard()))
+		admission = controlplane.NewAdmission(map[string]int{adagio.DefaultNamespace: 1})
+		child     = &adagio.GraphSpec{Nodes: []*adagio.Node_Spec{{Name: "a", Runtime: "test"}}}
+	)
+
+	spec, err := workflow.NewFunction(child).NewSpec("workflow")
+	require.Nil(t, err)
+
+	parent, err := admission.StartRun(ctx, repo, "", &adagio.GraphSpec{Nodes: []*adagio.Node_Spec{spec}})
+	require.Nil(t, err)
+
+	node, claimed, err := repo.ClaimNode(ctx, parent.Id, spec.Name, &adagio.Claim{Id: "workflow"})
+	require.Nil(t, err)
+	require.True(t, claimed)
+
+	var (
+		rt     = workflow.Runtime(admitted{repo, admission}, workflow.WithPollInterval(time.Millisecond))
+		result = make(chan *adagio.Result, 1)
+	)
+
+	go func() {
+		res, err := rt.NewFunction().Run(agent.WithRunID(ctx, parent.Id), node)
+		assert.Nil(t, err)
+
+		result <- res
+	}()
+
+	var childID string
+	require.Eventually(t, func() bool {
+		run, err := repo.InspectRun(ctx, parent.Id)
+		require.Nil(t, err)
+
+		if len(run.Children) == 0 {
+			return false
+		}
+
+		childID = run.Children[0].RunId
+		return true
+	}, 5*time.Second, time.Millisecond, "the child run is admitted within the quota of its parent")
+
+	t.Run("another run exceeds the quota", func(t *testing.T) {
+		_, err := admission.StartRun(ctx, repo, "", child)
+		assert.True(t, errors.Is(err, adagio.ErrRunQuotaExceeded), "unexpected error", err)
+	})
+
+	claim := &adagio.Claim{Id: "child"}
+
+	_, claimed, err = repo.ClaimNode(ctx, childID, "a", claim)
+	require.Nil(t, err)
+	require.True(t, claimed)
+
+	require.Nil(t, repo.FinishNode(ctx, childID, "a", &adagio.Node_Result{Conclusion: adagio.Node_Result_SUCCESS}, claim))
+
+	select {
+	case res := <-result:
+		require.NotNil(t, res)
+		assert.Equal(t, adagio.Result_SUCCESS, res.Conclusion)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the workflow node")
+	}
+}
@@ -0,0 +1,157 @@
+package controlplane
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/georgemac/adagio/pkg/adagio"
+	"github.com/georgemac/adagio/pkg/auth"
+	"github.com/georgemac/adagio/pkg/logging"
+	"github.com/georgemac/adagio/pkg/rpc/controlplane"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// ActorMetadataKey is the key of the incoming metadata which identifies the caller
+// of the control plane recorded on audit events. It is advisory as callers assert it
+// themselves and so it is ignored given callers are authenticated
+const ActorMetadataKey = "adagio-actor"
+
+// ListAuditEvents returns the audit events of the mutating calls made to the namespace
+// of the control plane ordered from the most recent, optionally those of a single run
+func (s *Service) ListAuditEvents(ctx context.Context, req *controlplane.ListAuditEventsRequest) (*controlplane.ListAuditEventsResponse, error) {
+	listReq := AuditListRequest{RunID: req.RunId}
+	if req.Limit > 0 {
+		listReq.Limit = &req.Limit
+	}
+
+	repo, err := s.lookup(req.Namespace)
+	if err != nil {
+		return nil, errors.Wrap(err, "control plane: listing audit events")
+	}
+
+	events, err := repo.ListAuditEvents(ctx, listReq)
+	if err != nil {
+		return nil, errors.Wrap(err, "control plane: listing audit events")
+	}
+
+	return &controlplane.ListAuditEventsResponse{Events: events}, nil
+}
+
+// audit appends an event recording the outcome of a call to the named RPC to the
+// audit log of the repository of the namespace. Failing to append the event is
+// logged as the outcome of the call can no longer be changed
+func (s *Service) audit(ctx context.Context, namespace, rpc, runID, summary string, err error) {
+	event := adagio.NewAuditEvent(rpc)
+	event.Actor = s.actor(ctx)
+	event.RunId = runID
+	event.Summary = summary
+	event.Outcome = adagio.AuditEvent_SUCCEEDED
+
+	if p, ok := peer.FromContext(ctx); ok {
+		event.Peer = p.Addr.String()
+	}
+
+	if err != nil {
+		event.Outcome = adagio.AuditEvent_FAILED
+		event.Error = err.Error()
+	}
+
+	s.appendAuditEvent(ctx, namespace, event)
+}
+
+// Denied appends an event to the audit log recording that a call was denied by the
+// role-based access control policy. It is an auth.DeniedFunc. Given the namespace of
+// the call has not been constructed the event is appended to the audit log of the
+// default namespace, so that denied calls do not construct namespaces
+func (s *Service) Denied(ctx context.Context, method string, req interface{}, err error) {
+	var (
+		rpc       = auth.MethodName(method)
+		namespace = auth.Namespace(req)
+		event     = adagio.NewAuditEvent(rpc)
+	)
+
+	event.Actor = s.actor(ctx)
+	event.Summary = fmt.Sprintf("namespace=%s", namespace)
+	event.Outcome = adagio.AuditEvent_DENIED
+	event.Error = status.Convert(err).Message()
+
+	if p, ok := peer.FromContext(ctx); ok {
+		event.Peer = p.Addr.String()
+	}
+
+	if r, ok := req.(interface{ GetRunId() string }); ok {
+		event.RunId = r.GetRunId()
+	}
+
+	s.logger.WithError(err).WithFields(logging.Fields{
+		"rpc":                rpc,
+		"actor":              event.Actor,
+		logging.NamespaceKey: namespace,
+	}).Warn("control plane: call denied")
+
+	if !s.constructed(namespace) {
+		namespace = adagio.DefaultNamespace
+	}
+
+	s.appendAuditEvent(ctx, namespace, event)
+}
+
+// appendAuditEvent appends the event to the audit log of the repository of the namespace
+// Events of calls to namespaces which are invalid or not served are only logged
+func (s *Service) appendAuditEvent(ctx context.Context, namespace string, event *adagio.AuditEvent) {
+	repo, err := s.repository(namespace)
+	if err == nil {
+		err = repo.AppendAuditEvent(ctx, event)
+	}
+
+	if err != nil {
+		s.logger.WithError(err).WithFields(logging.Fields{
+			"rpc":                event.Rpc,
+			logging.NamespaceKey: namespace,
+		}).Error("control plane: appending audit event")
+	}
+}
+
+// approver returns the approver recorded on the result of an approval. Given callers are
+// authenticated it is the subject of the caller and an error is returned given the request
+// names anyone else. Otherwise it is the approver requested, or the actor given none is
+// (see actor)
+func (s *Service) approver(ctx context.Context, requested string) (string, error) {
+	if identity, ok := auth.FromContext(ctx); ok && identity.Subject != "" {
+		if requested != "" && requested != identity.Subject {
+			return "", fmt.Errorf("approver %q is not the authenticated caller %q", requested, identity.Subject)
+		}
+
+		return identity.Subject, nil
+	}
+
+	if requested != "" {
+		return requested, nil
+	}
+
+	return s.actor(ctx), nil
+}
+
+// actor returns the subject of the authenticated caller. Given callers are not
+// authenticated it returns the identity the caller asserted in the incoming metadata.
+// Otherwise it returns "anonymous"
+func (s *Service) actor(ctx context.Context) string {
+	if identity, ok := auth.FromContext(ctx); ok && identity.Subject != "" {
+		return identity.Subject
+	}
+
+	if s.authenticated {
+		return "anonymous"
+	}
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(ActorMetadataKey); len(values) > 0 && values[0] != "" {
+			return values[0]
+		}
+	}
+
+	return "anonymous"
+}
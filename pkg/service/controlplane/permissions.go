@@ -0,0 +1,27 @@
+package controlplane
+
+import "github.com/georgemac/adagio/pkg/auth"
+
+const fullMethodPrefix = "/adagio.rpc.controlplane.ControlPlane/"
+
+// Permissions is the minimum role a caller must hold in the namespace of a
+// call to the control plane keyed by the full method name of each RPC
+var Permissions = map[string]auth.Role{
+	fullMethodPrefix + "Stats":             auth.RoleViewer,
+	fullMethodPrefix + "ListRuns":          auth.RoleViewer,
+	fullMethodPrefix + "Inspect":           auth.RoleViewer,
+	fullMethodPrefix + "ListAgents":        auth.RoleViewer,
+	fullMethodPrefix + "InspectAgent":      auth.RoleViewer,
+	fullMethodPrefix + "ListUnschedulable": auth.RoleViewer,
+	fullMethodPrefix + "StreamLogs":        auth.RoleViewer,
+	fullMethodPrefix + "Start":             auth.RoleOperator,
+	fullMethodPrefix + "Approve":           auth.RoleOperator,
+	fullMethodPrefix + "Reject":            auth.RoleOperator,
+	fullMethodPrefix + "CreateWebhook":     auth.RoleAdmin,
+	fullMethodPrefix + "ListWebhooks":      auth.RoleAdmin,
+	fullMethodPrefix + "InspectWebhook":    auth.RoleAdmin,
+	fullMethodPrefix + "UpdateWebhook":     auth.RoleAdmin,
+	fullMethodPrefix + "DeleteWebhook":     auth.RoleAdmin,
+	fullMethodPrefix + "ListDeliveries":    auth.RoleAdmin,
+	fullMethodPrefix + "ListAuditEvents":   auth.RoleAdmin,
+}
@@ -2,24 +2,51 @@ package controlplane
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/georgemac/adagio/pkg/adagio"
+	"github.com/georgemac/adagio/pkg/logging"
 	"github.com/georgemac/adagio/pkg/rpc/controlplane"
+	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
 )
 
 var _ controlplane.ControlPlaneServer = (*Service)(nil)
 
 // Repository is an implementation of a backing repository
-// which can report on the status of runs, list runs and agents
-// and start new runs given a graph specification
+// which can report on the status of runs, list runs and agents,
+// start new runs given a graph specification, read the logs of nodes,
+// resolve approvals, manage webhooks and their deliveries and
+// append to and list the audit log
 type Repository interface {
 	Stats(context.Context) (*adagio.Stats, error)
-	StartRun(context.Context, *adagio.GraphSpec) (*adagio.Run, error)
+	StartRun(context.Context, *adagio.GraphSpec, ...adagio.RunOption) (*adagio.Run, error)
 	InspectRun(ctx context.Context, id string) (*adagio.Run, error)
 	ListRuns(context.Context, ListRequest) ([]*adagio.Run, error)
 	ListAgents(context.Context) ([]*adagio.Agent, error)
+	ReadLogs(ctx context.Context, runID, name string, attempt int32, offset int) ([]*adagio.LogLine, error)
+	ResolveApproval(ctx context.Context, runID, name string, result *adagio.Node_Result) error
+	ExpireApprovals(context.Context) error
+	PromoteScheduled(context.Context) error
+	CreateWebhook(context.Context, *adagio.Webhook) error
+	InspectWebhook(ctx context.Context, id string) (*adagio.Webhook, error)
+	ListWebhooks(context.Context) ([]*adagio.Webhook, error)
+	UpdateWebhook(context.Context, *adagio.Webhook) error
+	DeleteWebhook(ctx context.Context, id string) error
+	ListDeliveries(ctx context.Context, webhookID string) ([]*adagio.Delivery, error)
+	AppendAuditEvent(context.Context, *adagio.AuditEvent) error
+	ListAuditEvents(context.Context, AuditListRequest) ([]*adagio.AuditEvent, error)
+}
+
+// Namespaces is a type which returns the repository of each namespace and lists the
+// namespaces it has constructed. Repository constructs the namespace given it has not
+// yet been constructed and is called for mutating calls. Lookup does not construct the
+// namespace and is called for read-only calls
+type Namespaces interface {
+	Repository(namespace string) (Repository, error)
+	Lookup(namespace string) (Repository, error)
+	Namespaces() []string
 }
 
 // ListRequest is a request structure with predicates used to
@@ -30,16 +57,67 @@ type ListRequest struct {
 	Limit  *uint64
 }
 
+// AuditListRequest is a request structure with predicates used to
+// retrieve a list of audit events
+type AuditListRequest struct {
+	RunID string
+	Limit *uint64
+}
+
 // Service is an adagio control plane server implementation which
-// adapts call to a Repository implementation
+// adapts calls to the Repository implementation of their namespace
 type Service struct {
-	repo Repository
+	namespaces Namespaces
+	logger     logging.Logger
+
+	// admits the runs started in each namespace
+	admission *Admission
+
+	// interval on which followed logs are polled
+	logsInterval time.Duration
+	// callers are authenticated so asserted actors are ignored
+	authenticated bool
+}
+
+// Option is a functional option for the Service
+type Option func(*Service)
+
+// WithLogger configures the logger on which the runs started and errors
+// expiring approvals are logged (defaults to info level logfmt on stderr)
+func WithLogger(logger logging.Logger) Option {
+	return func(s *Service) {
+		s.logger = logger
+	}
+}
+
+// WithAdmission configures the admission through which runs are started
+// (defaults to an admission without any namespace quotas)
+func WithAdmission(admission *Admission) Option {
+	return func(s *Service) {
+		s.admission = admission
+	}
 }
 
-// New constructs and configures a new Service instance
-func New(repo Repository) *Service {
+// WithAuthentication configures the service for callers which are authenticated.
+// The subject of the caller is recorded as the actor of audit events and the
+// actor asserted in the incoming metadata (see ActorMetadataKey) is ignored
+func WithAuthentication() Option {
+	return func(s *Service) {
+		s.authenticated = true
+	}
+}
+
+// New constructs and configures a new Service instance which serves the namespaces
+func New(namespaces Namespaces, opts ...Option) *Service {
 	s := &Service{
-		repo: repo,
+		namespaces:   namespaces,
+		logger:       logging.Default(),
+		admission:    NewAdmission(nil),
+		logsInterval: time.Second,
+	}
+
+	for _, opt := range opts {
+		opt(s)
 	}
 
 	return s
@@ -47,7 +125,12 @@ func New(repo Repository) *Service {
 
 // Stats adapts a controle plane stats request into a repository Stats call and returns the result
 func (s *Service) Stats(ctx context.Context, req *controlplane.StatsRequest) (*controlplane.StatsResponse, error) {
-	stats, err := s.repo.Stats(ctx)
+	repo, err := s.lookup(req.Namespace)
+	if err != nil {
+		return nil, errors.Wrap(err, "control plane: fetching stats")
+	}
+
+	stats, err := repo.Stats(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -56,18 +139,43 @@ func (s *Service) Stats(ctx context.Context, req *controlplane.StatsRequest) (*c
 }
 
 // Start adapts a control plane start request into a repository Start Run call and returns the result
-func (s *Service) Start(ctx context.Context, req *controlplane.StartRequest) (*controlplane.StartResponse, error) {
-	run, err := s.repo.StartRun(ctx, req.Spec)
+// Runs containing nodes with selectors which no registered agent can satisfy are rejected
+func (s *Service) Start(ctx context.Context, req *controlplane.StartRequest) (resp *controlplane.StartResponse, err error) {
+	defer func() {
+		var runID string
+		if resp != nil {
+			runID = resp.Run.Id
+		}
+
+		s.audit(ctx, req.Namespace, "Start", runID, fmt.Sprintf("nodes=%d edges=%d", len(req.Spec.GetNodes()), len(req.Spec.GetEdges())), err)
+	}()
+
+	repo, err := s.repository(req.Namespace)
 	if err != nil {
 		return nil, errors.Wrap(err, "control plane: starting run")
 	}
 
+	run, err := s.admission.StartRun(ctx, repo, req.Namespace, req.Spec)
+	if err != nil {
+		return nil, errors.Wrap(err, "control plane: starting run")
+	}
+
+	s.logger.WithFields(logging.Fields{
+		logging.RunIDKey:     run.Id,
+		logging.NamespaceKey: adagio.Namespace(req.Namespace),
+	}).Info("run started")
+
 	return &controlplane.StartResponse{Run: run}, nil
 }
 
 // Inspect adapts a control plane inspect request into a repository InspectRun call and returns the result
 func (s *Service) Inspect(ctx context.Context, req *controlplane.InspectRequest) (*controlplane.InspectResponse, error) {
-	run, err := s.repo.InspectRun(ctx, req.Id)
+	repo, err := s.lookup(req.Namespace)
+	if err != nil {
+		return nil, errors.Wrap(err, "control plane: inspecting run")
+	}
+
+	run, err := repo.InspectRun(ctx, req.Id)
 	if err != nil {
 		return nil, errors.Wrap(err, "control plane: starting run")
 	}
@@ -77,8 +185,14 @@ func (s *Service) Inspect(ctx context.Context, req *controlplane.InspectRequest)
 
 // ListRuns adapts a control plane list request into a ListRuns call and returns the result
 func (s *Service) ListRuns(ctx context.Context, r *controlplane.ListRequest) (*controlplane.ListRunsResponse, error) {
-	req := ListRequest{
-		Limit: &r.Limit,
+	repo, err := s.lookup(r.Namespace)
+	if err != nil {
+		return nil, errors.Wrap(err, "control plane: listing runs")
+	}
+
+	var req ListRequest
+	if r.Limit > 0 {
+		req.Limit = &r.Limit
 	}
 
 	if r.StartNs > 0 {
@@ -91,7 +205,7 @@ func (s *Service) ListRuns(ctx context.Context, r *controlplane.ListRequest) (*c
 		req.Finish = &until
 	}
 
-	runs, err := s.repo.ListRuns(ctx, req)
+	runs, err := repo.ListRuns(ctx, req)
 	if err != nil {
 		return nil, errors.Wrap(err, "control plane: listing runs")
 	}
@@ -100,11 +214,417 @@ func (s *Service) ListRuns(ctx context.Context, r *controlplane.ListRequest) (*c
 }
 
 // ListAgents adapts a control plane ListRequest into a repository ListAgents call and returns the result
-func (s *Service) ListAgents(ctx context.Context, _ *controlplane.ListRequest) (*controlplane.ListAgentsResponse, error) {
-	agents, err := s.repo.ListAgents(ctx)
+func (s *Service) ListAgents(ctx context.Context, req *controlplane.ListRequest) (*controlplane.ListAgentsResponse, error) {
+	repo, err := s.lookup(req.Namespace)
+	if err != nil {
+		return nil, errors.Wrap(err, "control plane: listing agents")
+	}
+
+	agents, err := repo.ListAgents(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "control place: listing agents")
 	}
 
 	return &controlplane.ListAgentsResponse{Agents: agents}, nil
 }
+
+// InspectAgent returns the agent identified by the requested ID from
+// the agents listed by the repository
+func (s *Service) InspectAgent(ctx context.Context, req *controlplane.InspectAgentRequest) (*controlplane.InspectAgentResponse, error) {
+	repo, err := s.lookup(req.Namespace)
+	if err != nil {
+		return nil, errors.Wrap(err, "control plane: inspecting agent")
+	}
+
+	agents, err := repo.ListAgents(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "control plane: inspecting agent")
+	}
+
+	for _, agent := range agents {
+		if agent.Id == req.Id {
+			return &controlplane.InspectAgentResponse{Agent: agent}, nil
+		}
+	}
+
+	return nil, errors.Wrapf(adagio.ErrAgentDoesNotExist, "control plane: inspecting agent %q", req.Id)
+}
+
+// ListUnschedulable lists the ready nodes which no registered agent can claim
+// and which have been ready for at least the requested threshold
+func (s *Service) ListUnschedulable(ctx context.Context, req *controlplane.UnschedulableRequest) (*controlplane.UnschedulableResponse, error) {
+	repo, err := s.lookup(req.Namespace)
+	if err != nil {
+		return nil, errors.Wrap(err, "control plane: listing unschedulable nodes")
+	}
+
+	agents, err := repo.ListAgents(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "control plane: listing unschedulable nodes")
+	}
+
+	runs, err := repo.ListRuns(ctx, ListRequest{})
+	if err != nil {
+		return nil, errors.Wrap(err, "control plane: listing unschedulable nodes")
+	}
+
+	var (
+		now    = time.Now()
+		cutoff = now.Add(-time.Duration(req.ThresholdNs))
+		resp   = &controlplane.UnschedulableResponse{}
+	)
+
+	for _, run := range runs {
+		for _, node := range run.Nodes {
+			if !adagio.Unschedulable(node, agents) {
+				continue
+			}
+
+			readyAt, err := time.Parse(time.RFC3339Nano, node.ReadyAt)
+			if err == nil && readyAt.After(cutoff) {
+				continue
+			}
+
+			resp.Nodes = append(resp.Nodes, &controlplane.UnschedulableNode{RunId: run.Id, Node: node})
+		}
+	}
+
+	return resp, nil
+}
+
+// StreamLogs sends the logs of the requested attempt of a node (defaulting to the latest)
+// Given follow is requested the logs are streamed until the attempt concludes
+func (s *Service) StreamLogs(req *controlplane.StreamLogsRequest, stream controlplane.ControlPlane_StreamLogsServer) error {
+	ctx := stream.Context()
+
+	repo, err := s.lookup(req.Namespace)
+	if err != nil {
+		return errors.Wrap(err, "control plane: streaming logs")
+	}
+
+	node, err := inspectNode(ctx, repo, req.RunId, req.Node)
+	if err != nil {
+		return errors.Wrap(err, "control plane: streaming logs")
+	}
+
+	attempt := req.Attempt
+	if attempt < 1 {
+		attempt = adagio.CurrentAttempt(node)
+	}
+
+	offset := 0
+	for {
+		// an attempt is over once it is recorded on the node
+		done := !req.Follow || int32(len(node.Attempts)) >= attempt || node.Status != adagio.Node_RUNNING
+
+		lines, err := repo.ReadLogs(ctx, req.RunId, req.Node, attempt, offset)
+		if err != nil {
+			return errors.Wrap(err, "control plane: streaming logs")
+		}
+
+		if len(lines) > 0 {
+			if err := stream.Send(&controlplane.StreamLogsResponse{Lines: lines}); err != nil {
+				return errors.Wrap(err, "control plane: streaming logs")
+			}
+
+			offset += len(lines)
+		}
+
+		if done {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(s.logsInterval):
+		}
+
+		if node, err = inspectNode(ctx, repo, req.RunId, req.Node); err != nil {
+			return errors.Wrap(err, "control plane: streaming logs")
+		}
+	}
+}
+
+func inspectNode(ctx context.Context, repo Repository, runID, name string) (*adagio.Node, error) {
+	run, err := repo.InspectRun(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, node := range run.Nodes {
+		if node.Spec.Name == name {
+			return node, nil
+		}
+	}
+
+	return nil, errors.Wrapf(adagio.ErrMissingNode, "node %q", name)
+}
+
+// Approve adapts a control plane approval request into a repository ResolveApproval call
+// which succeeds the approval node and returns the resulting run
+func (s *Service) Approve(ctx context.Context, req *controlplane.ApprovalRequest) (*controlplane.ApprovalResponse, error) {
+	return s.resolveApproval(ctx, req, true)
+}
+
+// Reject adapts a control plane approval request into a repository ResolveApproval call
+// which fails the approval node and returns the resulting run
+func (s *Service) Reject(ctx context.Context, req *controlplane.ApprovalRequest) (*controlplane.ApprovalResponse, error) {
+	return s.resolveApproval(ctx, req, false)
+}
+
+func (s *Service) resolveApproval(ctx context.Context, req *controlplane.ApprovalRequest, approved bool) (_ *controlplane.ApprovalResponse, err error) {
+	approver := req.Approver
+
+	defer func() {
+		rpc := "Reject"
+		if approved {
+			rpc = "Approve"
+		}
+
+		s.audit(ctx, req.Namespace, rpc, req.RunId, fmt.Sprintf("node=%s approver=%s comment=%q", req.Node, approver, req.Comment), err)
+	}()
+
+	repo, err := s.repository(req.Namespace)
+	if err != nil {
+		return nil, errors.Wrap(err, "control plane: resolving approval")
+	}
+
+	resolved, err := s.approver(ctx, req.Approver)
+	if err != nil {
+		return nil, errors.Wrap(err, "control plane: resolving approval")
+	}
+
+	approver = resolved
+
+	result := adagio.ApprovalResult(approved, approver, req.Comment)
+	if err := repo.ResolveApproval(ctx, req.RunId, req.Node, result); err != nil {
+		return nil, errors.Wrap(err, "control plane: resolving approval")
+	}
+
+	run, err := repo.InspectRun(ctx, req.RunId)
+	if err != nil {
+		return nil, errors.Wrap(err, "control plane: resolving approval")
+	}
+
+	return &controlplane.ApprovalResponse{Run: run}, nil
+}
+
+// CreateWebhook constructs a webhook from the request, stores it in the repository
+// and returns it without its secret
+func (s *Service) CreateWebhook(ctx context.Context, req *controlplane.CreateWebhookRequest) (resp *controlplane.WebhookResponse, err error) {
+	defer func() {
+		summary := fmt.Sprintf("url=%s events=%s", req.Url, req.Events)
+		if resp != nil {
+			summary = fmt.Sprintf("webhook=%s %s", resp.Webhook.Id, summary)
+		}
+
+		s.audit(ctx, req.Namespace, "CreateWebhook", "", summary, err)
+	}()
+
+	repo, err := s.repository(req.Namespace)
+	if err != nil {
+		return nil, errors.Wrap(err, "control plane: creating webhook")
+	}
+
+	webhook, err := adagio.NewWebhook(req.Url, req.Secret, req.Events...)
+	if err != nil {
+		return nil, errors.Wrap(err, "control plane: creating webhook")
+	}
+
+	if err := repo.CreateWebhook(ctx, webhook); err != nil {
+		return nil, errors.Wrap(err, "control plane: creating webhook")
+	}
+
+	s.logger.WithField(logging.WebhookIDKey, webhook.Id).Info("webhook created")
+
+	return &controlplane.WebhookResponse{Webhook: withoutSecret(webhook)}, nil
+}
+
+// ListWebhooks returns the webhooks stored in the repository without their secrets
+func (s *Service) ListWebhooks(ctx context.Context, req *controlplane.ListWebhooksRequest) (*controlplane.ListWebhooksResponse, error) {
+	repo, err := s.lookup(req.Namespace)
+	if err != nil {
+		return nil, errors.Wrap(err, "control plane: listing webhooks")
+	}
+
+	webhooks, err := repo.ListWebhooks(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "control plane: listing webhooks")
+	}
+
+	resp := &controlplane.ListWebhooksResponse{}
+	for _, webhook := range webhooks {
+		resp.Webhooks = append(resp.Webhooks, withoutSecret(webhook))
+	}
+
+	return resp, nil
+}
+
+// InspectWebhook returns the requested webhook without its secret
+func (s *Service) InspectWebhook(ctx context.Context, req *controlplane.InspectWebhookRequest) (*controlplane.WebhookResponse, error) {
+	repo, err := s.lookup(req.Namespace)
+	if err != nil {
+		return nil, errors.Wrap(err, "control plane: inspecting webhook")
+	}
+
+	webhook, err := repo.InspectWebhook(ctx, req.Id)
+	if err != nil {
+		return nil, errors.Wrap(err, "control plane: inspecting webhook")
+	}
+
+	return &controlplane.WebhookResponse{Webhook: withoutSecret(webhook)}, nil
+}
+
+// UpdateWebhook replaces the URL and events of the requested webhook (and its secret
+// given one is provided) and returns the updated webhook without its secret
+func (s *Service) UpdateWebhook(ctx context.Context, req *controlplane.UpdateWebhookRequest) (_ *controlplane.WebhookResponse, err error) {
+	defer func() {
+		s.audit(ctx, req.Namespace, "UpdateWebhook", "", fmt.Sprintf("webhook=%s url=%s events=%s secret_changed=%t", req.Id, req.Url, req.Events, req.Secret != ""), err)
+	}()
+
+	repo, err := s.repository(req.Namespace)
+	if err != nil {
+		return nil, errors.Wrap(err, "control plane: updating webhook")
+	}
+
+	webhook, err := repo.InspectWebhook(ctx, req.Id)
+	if err != nil {
+		return nil, errors.Wrap(err, "control plane: updating webhook")
+	}
+
+	webhook = proto.Clone(webhook).(*adagio.Webhook)
+	webhook.Url = req.Url
+	webhook.Events = req.Events
+
+	if req.Secret != "" {
+		webhook.Secret = req.Secret
+	}
+
+	if err := adagio.ValidateWebhook(webhook); err != nil {
+		return nil, errors.Wrap(err, "control plane: updating webhook")
+	}
+
+	if err := repo.UpdateWebhook(ctx, webhook); err != nil {
+		return nil, errors.Wrap(err, "control plane: updating webhook")
+	}
+
+	return &controlplane.WebhookResponse{Webhook: withoutSecret(webhook)}, nil
+}
+
+// DeleteWebhook removes the requested webhook and its deliveries from the repository
+func (s *Service) DeleteWebhook(ctx context.Context, req *controlplane.DeleteWebhookRequest) (_ *controlplane.DeleteWebhookResponse, err error) {
+	defer func() {
+		s.audit(ctx, req.Namespace, "DeleteWebhook", "", fmt.Sprintf("webhook=%s", req.Id), err)
+	}()
+
+	repo, err := s.repository(req.Namespace)
+	if err != nil {
+		return nil, errors.Wrap(err, "control plane: deleting webhook")
+	}
+
+	if err := repo.DeleteWebhook(ctx, req.Id); err != nil {
+		return nil, errors.Wrap(err, "control plane: deleting webhook")
+	}
+
+	s.logger.WithField(logging.WebhookIDKey, req.Id).Info("webhook deleted")
+
+	return &controlplane.DeleteWebhookResponse{}, nil
+}
+
+// ListDeliveries returns the log of the deliveries made to the requested webhook
+func (s *Service) ListDeliveries(ctx context.Context, req *controlplane.ListDeliveriesRequest) (*controlplane.ListDeliveriesResponse, error) {
+	repo, err := s.lookup(req.Namespace)
+	if err != nil {
+		return nil, errors.Wrap(err, "control plane: listing deliveries")
+	}
+
+	deliveries, err := repo.ListDeliveries(ctx, req.WebhookId)
+	if err != nil {
+		return nil, errors.Wrap(err, "control plane: listing deliveries")
+	}
+
+	return &controlplane.ListDeliveriesResponse{Deliveries: deliveries}, nil
+}
+
+// repository returns the repository of the namespace (the default namespace when empty)
+// constructing the namespace given it has not yet been constructed
+func (s *Service) repository(namespace string) (Repository, error) {
+	namespace = adagio.Namespace(namespace)
+	if err := adagio.ValidateNamespace(namespace); err != nil {
+		return nil, err
+	}
+
+	return s.namespaces.Repository(namespace)
+}
+
+// lookup returns the repository of the namespace (the default namespace when empty)
+// for read-only calls without constructing the namespace
+func (s *Service) lookup(namespace string) (Repository, error) {
+	namespace = adagio.Namespace(namespace)
+	if err := adagio.ValidateNamespace(namespace); err != nil {
+		return nil, err
+	}
+
+	return s.namespaces.Lookup(namespace)
+}
+
+// constructed returns true given the namespace has been constructed
+func (s *Service) constructed(namespace string) bool {
+	for _, name := range s.namespaces.Namespaces() {
+		if name == namespace {
+			return true
+		}
+	}
+
+	return false
+}
+
+// withoutSecret returns a copy of the webhook with its secret removed
+func withoutSecret(webhook *adagio.Webhook) *adagio.Webhook {
+	webhook = proto.Clone(webhook).(*adagio.Webhook)
+	webhook.Secret = ""
+
+	return webhook
+}
+
+// ExpireApprovals calls ExpireApprovals on the repository of each namespace on the
+// provided interval in order to fail approval nodes which have expired. It blocks
+// until the context is cancelled
+func (s *Service) ExpireApprovals(ctx context.Context, interval time.Duration) {
+	s.sweep(ctx, interval, "expiring approvals", Repository.ExpireApprovals)
+}
+
+// PromoteScheduled calls PromoteScheduled on the repository of each namespace on the
+// provided interval in order to make ready scheduled nodes whose delay has passed
+// which were not promoted by the repository which scheduled them. It blocks
+// until the context is cancelled
+func (s *Service) PromoteScheduled(ctx context.Context, interval time.Duration) {
+	s.sweep(ctx, interval, "promoting scheduled nodes", Repository.PromoteScheduled)
+}
+
+// sweep calls fn with the repository of each namespace on the provided interval
+// until the context is cancelled
+func (s *Service) sweep(ctx context.Context, interval time.Duration, action string, fn func(Repository, context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		for _, namespace := range s.namespaces.Namespaces() {
+			repo, err := s.namespaces.Repository(namespace)
+			if err == nil {
+				err = fn(repo, ctx)
+			}
+
+			if err != nil {
+				s.logger.WithError(err).WithField(logging.NamespaceKey, namespace).Error("control plane: " + action)
+			}
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// UnaryServerInterceptor returns an interceptor which starts a span for each
+// unary call continuing any trace context carried by the incoming metadata
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		ctx, span := startServerSpan(ctx, info.FullMethod)
+		defer func() { End(span, err) }()
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns an interceptor which starts a span for each
+// streaming call continuing any trace context carried by the incoming metadata
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		ctx, span := startServerSpan(stream.Context(), info.FullMethod)
+		defer func() { End(span, err) }()
+
+		return handler(srv, serverStream{stream, ctx})
+	}
+}
+
+func startServerSpan(ctx context.Context, method string) (context.Context, trace.Span) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		ctx = propagator.Extract(ctx, metadataCarrier(md))
+	}
+
+	return Start(ctx, method,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attribute.String("rpc.system", "grpc")))
+}
+
+// serverStream overrides the context of a server stream
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s serverStream) Context() context.Context { return s.ctx }
+
+// metadataCarrier adapts grpc metadata to a propagation.TextMapCarrier
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	if values := metadata.MD(c).Get(key); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+func (c metadataCarrier) Set(key, value string) { metadata.MD(c).Set(key, value) }
+
+func (c metadataCarrier) Keys() (keys []string) {
+	for key := range c {
+		keys = append(keys, key)
+	}
+
+	return
+}
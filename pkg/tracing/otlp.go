@@ -0,0 +1,292 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+var _ sdktrace.SpanExporter = (*OTLPExporter)(nil)
+
+// OTLPExporter is a span exporter which sends spans to an OTLP/HTTP endpoint
+// (e.g. an OpenTelemetry collector) using the JSON encoding of the protocol
+type OTLPExporter struct {
+	endpoint string
+	headers  map[string]string
+	client   *http.Client
+}
+
+// OTLPOption is a functional option for the OTLPExporter
+type OTLPOption func(*OTLPExporter)
+
+// WithHeaders configures headers sent with each export request
+func WithHeaders(headers map[string]string) OTLPOption {
+	return func(e *OTLPExporter) {
+		e.headers = headers
+	}
+}
+
+// WithHTTPClient overrides the client used to send export requests
+func WithHTTPClient(client *http.Client) OTLPOption {
+	return func(e *OTLPExporter) {
+		e.client = client
+	}
+}
+
+// NewOTLPExporter constructs a new exporter which sends spans to the provided
+// endpoint (e.g. "http://127.0.0.1:4318/v1/traces")
+func NewOTLPExporter(endpoint string, opts ...OTLPOption) *OTLPExporter {
+	e := &OTLPExporter{
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e
+}
+
+// ExportSpans sends the spans grouped by resource and instrumentation scope
+func (e *OTLPExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	data, err := json.Marshal(encodeSpans(spans))
+	if err != nil {
+		return fmt.Errorf("otlp: encoding spans: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, e.endpoint, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("otlp: exporting spans: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range e.headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := e.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("otlp: exporting spans: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("otlp: exporting spans: unexpected status %q", resp.Status)
+	}
+
+	return nil
+}
+
+// Shutdown is a no-op as the exporter holds no resources
+func (e *OTLPExporter) Shutdown(context.Context) error { return nil }
+
+// the following types mirror the JSON encoding of the OTLP ExportTraceServiceRequest
+
+type otlpRequest struct {
+	ResourceSpans []*otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource      `json:"resource"`
+	ScopeSpans []*otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope   `json:"scope"`
+	Spans []*otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Events            []otlpEvent    `json:"events,omitempty"`
+	Links             []otlpLink     `json:"links,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpEvent struct {
+	TimeUnixNano string         `json:"timeUnixNano"`
+	Name         string         `json:"name"`
+	Attributes   []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpLink struct {
+	TraceID    string         `json:"traceId"`
+	SpanID     string         `json:"spanId"`
+	Attributes []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue *string         `json:"stringValue,omitempty"`
+	BoolValue   *bool           `json:"boolValue,omitempty"`
+	IntValue    *string         `json:"intValue,omitempty"`
+	DoubleValue *float64        `json:"doubleValue,omitempty"`
+	ArrayValue  *otlpArrayValue `json:"arrayValue,omitempty"`
+}
+
+type otlpArrayValue struct {
+	Values []otlpValue `json:"values"`
+}
+
+func encodeSpans(spans []sdktrace.ReadOnlySpan) *otlpRequest {
+	var (
+		req       = &otlpRequest{}
+		resources = map[attribute.Distinct]*otlpResourceSpans{}
+		scopes    = map[*otlpResourceSpans]map[string]*otlpScopeSpans{}
+	)
+
+	for _, span := range spans {
+		res := span.Resource()
+
+		key := res.Equivalent()
+		rs, ok := resources[key]
+		if !ok {
+			rs = &otlpResourceSpans{Resource: otlpResource{Attributes: encodeAttributes(res.Attributes())}}
+			resources[key] = rs
+			scopes[rs] = map[string]*otlpScopeSpans{}
+			req.ResourceSpans = append(req.ResourceSpans, rs)
+		}
+
+		scope := span.InstrumentationScope()
+		ss, ok := scopes[rs][scope.Name+"@"+scope.Version]
+		if !ok {
+			ss = &otlpScopeSpans{Scope: otlpScope{Name: scope.Name, Version: scope.Version}}
+			scopes[rs][scope.Name+"@"+scope.Version] = ss
+			rs.ScopeSpans = append(rs.ScopeSpans, ss)
+		}
+
+		ss.Spans = append(ss.Spans, encodeSpan(span))
+	}
+
+	return req
+}
+
+func encodeSpan(span sdktrace.ReadOnlySpan) *otlpSpan {
+	sc := span.SpanContext()
+
+	s := &otlpSpan{
+		TraceID:           sc.TraceID().String(),
+		SpanID:            sc.SpanID().String(),
+		Name:              span.Name(),
+		Kind:              int(span.SpanKind()),
+		StartTimeUnixNano: unixNano(span.StartTime()),
+		EndTimeUnixNano:   unixNano(span.EndTime()),
+		Attributes:        encodeAttributes(span.Attributes()),
+	}
+
+	if parent := span.Parent(); parent.IsValid() {
+		s.ParentSpanID = parent.SpanID().String()
+	}
+
+	for _, event := range span.Events() {
+		s.Events = append(s.Events, otlpEvent{
+			TimeUnixNano: unixNano(event.Time),
+			Name:         event.Name,
+			Attributes:   encodeAttributes(event.Attributes),
+		})
+	}
+
+	for _, link := range span.Links() {
+		s.Links = append(s.Links, otlpLink{
+			TraceID:    link.SpanContext.TraceID().String(),
+			SpanID:     link.SpanContext.SpanID().String(),
+			Attributes: encodeAttributes(link.Attributes),
+		})
+	}
+
+	// OTLP status codes are ordered unset, ok then error
+	switch status := span.Status(); status.Code {
+	case codes.Ok:
+		s.Status = otlpStatus{Code: 1}
+	case codes.Error:
+		s.Status = otlpStatus{Code: 2, Message: status.Description}
+	}
+
+	return s
+}
+
+func encodeAttributes(attrs []attribute.KeyValue) (kvs []otlpKeyValue) {
+	for _, attr := range attrs {
+		kvs = append(kvs, otlpKeyValue{Key: string(attr.Key), Value: encodeValue(attr.Value)})
+	}
+
+	return
+}
+
+func encodeValue(v attribute.Value) (value otlpValue) {
+	switch v.Type() {
+	case attribute.BOOL:
+		b := v.AsBool()
+		value.BoolValue = &b
+	case attribute.INT64:
+		i := strconv.FormatInt(v.AsInt64(), 10)
+		value.IntValue = &i
+	case attribute.FLOAT64:
+		f := v.AsFloat64()
+		value.DoubleValue = &f
+	case attribute.BOOLSLICE:
+		value.ArrayValue = &otlpArrayValue{}
+		for _, b := range v.AsBoolSlice() {
+			value.ArrayValue.Values = append(value.ArrayValue.Values, encodeValue(attribute.BoolValue(b)))
+		}
+	case attribute.INT64SLICE:
+		value.ArrayValue = &otlpArrayValue{}
+		for _, i := range v.AsInt64Slice() {
+			value.ArrayValue.Values = append(value.ArrayValue.Values, encodeValue(attribute.Int64Value(i)))
+		}
+	case attribute.FLOAT64SLICE:
+		value.ArrayValue = &otlpArrayValue{}
+		for _, f := range v.AsFloat64Slice() {
+			value.ArrayValue.Values = append(value.ArrayValue.Values, encodeValue(attribute.Float64Value(f)))
+		}
+	case attribute.STRINGSLICE:
+		value.ArrayValue = &otlpArrayValue{}
+		for _, s := range v.AsStringSlice() {
+			value.ArrayValue.Values = append(value.ArrayValue.Values, encodeValue(attribute.StringValue(s)))
+		}
+	default:
+		s := v.Emit()
+		value.StringValue = &s
+	}
+
+	return
+}
+
+func unixNano(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
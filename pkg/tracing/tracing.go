@@ -0,0 +1,87 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/georgemac/adagio/pkg/adagio"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/georgemac/adagio"
+
+// Attribute keys recorded on adagio spans
+const (
+	RunIDKey      = attribute.Key("adagio.run_id")
+	NodeKey       = attribute.Key("adagio.node")
+	RuntimeKey    = attribute.Key("adagio.runtime")
+	AttemptKey    = attribute.Key("adagio.attempt")
+	ConclusionKey = attribute.Key("adagio.conclusion")
+	ClaimedKey    = attribute.Key("adagio.claimed")
+)
+
+// propagator encodes span contexts as W3C trace context
+var propagator = propagation.TraceContext{}
+
+// Start starts a span as a child of any span carried by the context
+// using the globally registered tracer provider
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, opts...)
+}
+
+// End records the error (if any) on the span and ends it
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+// StartRun starts and ends the root span of a new run and records its trace context
+// on the run. The span is linked to any span carried by the context. Spans for the
+// nodes of the run continue the trace from the context recorded (see RunContext)
+func StartRun(ctx context.Context, run *adagio.Run) {
+	ctx, span := Start(ctx, "run",
+		trace.WithNewRoot(),
+		trace.WithLinks(trace.LinkFromContext(ctx)),
+		trace.WithAttributes(RunIDKey.String(run.Id)))
+	defer span.End()
+
+	if !span.SpanContext().IsValid() {
+		return
+	}
+
+	run.TraceContext = map[string]string{}
+	propagator.Inject(ctx, propagation.MapCarrier(run.TraceContext))
+}
+
+// RunContext returns a copy of the context which carries the span context of
+// the root span of a run, as recorded by StartRun, as its remote parent
+func RunContext(ctx context.Context, traceContext map[string]string) context.Context {
+	if len(traceContext) == 0 {
+		return ctx
+	}
+
+	return propagator.Extract(ctx, propagation.MapCarrier(traceContext))
+}
+
+// NewProvider constructs a tracer provider which batches spans to the exporter
+// and identifies them as originating from the named service
+func NewProvider(service string, exporter sdktrace.SpanExporter) *sdktrace.TracerProvider {
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", service))),
+	)
+}
+
+// StartNode starts a span for an operation on the named node of a run
+func StartNode(ctx context.Context, operation, runID, name string) (context.Context, trace.Span) {
+	return Start(ctx, operation, trace.WithAttributes(RunIDKey.String(runID), NodeKey.String(name)))
+}
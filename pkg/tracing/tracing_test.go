@@ -0,0 +1,156 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/georgemac/adagio/pkg/adagio"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func recorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	var (
+		recorder = tracetest.NewSpanRecorder()
+		previous = otel.GetTracerProvider()
+	)
+
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	return recorder
+}
+
+func Test_StartRun(t *testing.T) {
+	t.Run("without a tracer provider no trace context is recorded", func(t *testing.T) {
+		run := &adagio.Run{Id: "run"}
+
+		StartRun(context.Background(), run)
+
+		assert.Nil(t, run.TraceContext)
+	})
+
+	t.Run("nodes continue the trace of the run", func(t *testing.T) {
+		var (
+			spans        = recorder(t)
+			run          = &adagio.Run{Id: "run"}
+			parent, span = Start(context.Background(), "StartRun")
+		)
+
+		StartRun(parent, run)
+		span.End()
+
+		require.Contains(t, run.TraceContext, "traceparent")
+
+		// a node span started in another process from the recorded context
+		_, node := StartNode(RunContext(context.Background(), run.TraceContext), "node", run.Id, "foo")
+		End(node, errors.New("boom"))
+
+		ended := spans.Ended()
+		require.Len(t, ended, 3)
+
+		// the root span is ended once the run is started
+		var (
+			root    = ended[0]
+			caller  = ended[1]
+			attempt = ended[2]
+		)
+
+		assert.Equal(t, "run", root.Name())
+		assert.False(t, root.Parent().IsValid(), "run span should be a root span")
+		assert.NotEqual(t, caller.SpanContext().TraceID(), root.SpanContext().TraceID())
+
+		// the root span is linked to the span which started the run
+		require.Len(t, root.Links(), 1)
+		assert.Equal(t, caller.SpanContext().SpanID(), root.Links()[0].SpanContext.SpanID())
+
+		assert.Equal(t, root.SpanContext().TraceID(), attempt.SpanContext().TraceID())
+		assert.Equal(t, root.SpanContext().SpanID(), attempt.Parent().SpanID())
+		assert.Contains(t, attempt.Attributes(), RunIDKey.String("run"))
+		assert.Contains(t, attempt.Attributes(), NodeKey.String("foo"))
+		assert.Equal(t, "boom", attempt.Status().Description)
+	})
+}
+
+func Test_RunContext_Empty(t *testing.T) {
+	ctx := RunContext(context.Background(), nil)
+
+	assert.False(t, trace.SpanContextFromContext(ctx).IsValid())
+}
+
+func Test_OTLPExporter(t *testing.T) {
+	var (
+		received otlpRequest
+		headers  http.Header
+		server   = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			headers = r.Header
+
+			data, err := ioutil.ReadAll(r.Body)
+			require.Nil(t, err)
+			require.Nil(t, json.Unmarshal(data, &received))
+		}))
+		exporter = NewOTLPExporter(server.URL, WithHeaders(map[string]string{"Authorization": "token"}))
+		spans    = tracetest.NewSpanRecorder()
+		provider = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))
+	)
+
+	defer server.Close()
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+	_, child := provider.Tracer("test").Start(ctx, "child", trace.WithAttributes(AttemptKey.Int(2), ClaimedKey.Bool(true)))
+	End(child, errors.New("boom"))
+	parent.End()
+
+	require.Nil(t, exporter.ExportSpans(context.Background(), spans.Ended()))
+
+	assert.Equal(t, "application/json", headers.Get("Content-Type"))
+	assert.Equal(t, "token", headers.Get("Authorization"))
+
+	require.Len(t, received.ResourceSpans, 1)
+	require.Len(t, received.ResourceSpans[0].ScopeSpans, 1)
+
+	scope := received.ResourceSpans[0].ScopeSpans[0]
+	assert.Equal(t, "test", scope.Scope.Name)
+	require.Len(t, scope.Spans, 2)
+
+	var (
+		encodedChild  = scope.Spans[0]
+		encodedParent = scope.Spans[1]
+	)
+
+	assert.Equal(t, "child", encodedChild.Name)
+	assert.Equal(t, child.SpanContext().TraceID().String(), encodedChild.TraceID)
+	assert.Equal(t, parent.SpanContext().SpanID().String(), encodedChild.ParentSpanID)
+	assert.Equal(t, otlpStatus{Code: 2, Message: "boom"}, encodedChild.Status)
+
+	two, yes := "2", true
+	assert.Equal(t, []otlpKeyValue{
+		{Key: string(AttemptKey), Value: otlpValue{IntValue: &two}},
+		{Key: string(ClaimedKey), Value: otlpValue{BoolValue: &yes}},
+	}, encodedChild.Attributes)
+
+	assert.Equal(t, "parent", encodedParent.Name)
+	assert.Empty(t, encodedParent.ParentSpanID)
+	assert.Equal(t, otlpStatus{}, encodedParent.Status)
+}
+
+func Test_OTLPExporter_UnexpectedStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	err := NewOTLPExporter(server.URL).ExportSpans(context.Background(), nil)
+	assert.NotNil(t, err)
+}
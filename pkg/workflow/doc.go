@@ -37,7 +37,8 @@
 //			a       = builder.Node("a", success)
 //			b       = builder.Node("b", success)
 //			c       = builder.Node("c", success)
-//			d       = builder.Node("d", potentialPanic, workflow.WithRetry(adagio.OnError, 3))
+//			d       = builder.Node("d", potentialPanic, workflow.WithRetry(adagio.OnError, 3,
+//				workflow.WithBackoff(time.Second, 2)))
 //			e       = builder.Node("e", success)
 //			f       = builder.Node("f", success)
 //			g       = builder.Node("g", runLS)
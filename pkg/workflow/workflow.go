@@ -3,6 +3,7 @@ package workflow
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/georgemac/adagio/pkg/adagio"
 	"github.com/georgemac/adagio/pkg/rpc/controlplane"
@@ -36,14 +37,88 @@ func (o NodeOptions) Apply(spec *adagio.Node_Spec) {
 }
 
 // WithRetry configures a retry for a specified condition up to
-// maxAttempts times on a Node_Spec
-func WithRetry(condition adagio.RetryCondition, maxAttempts int32) NodeOption {
+// maxAttempts times on a Node_Spec. The delay between attempts
+// can be configured using the provided retry options
+func WithRetry(condition adagio.RetryCondition, maxAttempts int32, opts ...RetryOption) NodeOption {
 	return func(spec *adagio.Node_Spec) {
 		if spec.Retry == nil {
 			spec.Retry = map[string]*adagio.Node_Spec_Retry{}
 		}
 
-		spec.Retry[string(condition)] = &adagio.Node_Spec_Retry{MaxAttempts: maxAttempts}
+		retry := &adagio.Node_Spec_Retry{MaxAttempts: maxAttempts}
+
+		RetryOptions(opts).Apply(retry)
+
+		spec.Retry[string(condition)] = retry
+	}
+}
+
+// RetryOption is a functional option for a node specs retry
+type RetryOption func(*adagio.Node_Spec_Retry)
+
+// RetryOptions is a slice of RetryOption types
+type RetryOptions []RetryOption
+
+// Apply calls each option in turn on the provided Node_Spec_Retry
+func (o RetryOptions) Apply(retry *adagio.Node_Spec_Retry) {
+	for _, opt := range o {
+		opt(retry)
+	}
+}
+
+// WithBackoff configures the delay before the first retry and the
+// multiplier applied to the delay for each subsequent retry
+func WithBackoff(initialDelay time.Duration, multiplier float64) RetryOption {
+	return func(retry *adagio.Node_Spec_Retry) {
+		retry.InitialDelay = initialDelay.String()
+		retry.Multiplier = multiplier
+	}
+}
+
+// WithMaxDelay configures the upper bound on the delay between retries
+func WithMaxDelay(maxDelay time.Duration) RetryOption {
+	return func(retry *adagio.Node_Spec_Retry) {
+		retry.MaxDelay = maxDelay.String()
+	}
+}
+
+// WithJitter configures the fraction (between 0 and 1) by which
+// each delay between retries is randomly reduced
+func WithJitter(jitter float64) RetryOption {
+	return func(retry *adagio.Node_Spec_Retry) {
+		retry.Jitter = jitter
+	}
+}
+
+// WithMapOver configures a Node_Spec to be expanded into one instance
+// per element of the JSON array output by the named dependency
+func WithMapOver(name string) NodeOption {
+	return func(spec *adagio.Node_Spec) {
+		spec.Map = &adagio.Node_Spec_Map{Over: name}
+	}
+}
+
+// WithSelector configures a Node_Spec to only be claimed by
+// agents with labels which match the provided selector
+func WithSelector(selector *adagio.Node_Spec_Selector) NodeOption {
+	return func(spec *adagio.Node_Spec) {
+		spec.Selector = selector
+	}
+}
+
+// WithResources configures a Node_Spec to require the provided number
+// of slots from each of the named global resource pools
+func WithResources(resources map[string]int32) NodeOption {
+	return func(spec *adagio.Node_Spec) {
+		spec.Resources = resources
+	}
+}
+
+// WithPriority configures the priority of a Node_Spec relative
+// to the other nodes, which is added to the priority of its run
+func WithPriority(priority int32) NodeOption {
+	return func(spec *adagio.Node_Spec) {
+		spec.Priority = priority
 	}
 }
 
@@ -52,9 +127,11 @@ func WithRetry(condition adagio.RetryCondition, maxAttempts int32) NodeOption {
 // configure connections between nodes and then invoke the
 // built graph spec onto a client which produces a new Run
 type Builder struct {
-	err   error
-	nodes []Node
-	edges []*adagio.Edge
+	err            error
+	nodes          []Node
+	edges          []*adagio.Edge
+	maxParallelism int32
+	priority       int32
 }
 
 // NewBuilder creates and configures a new Builder
@@ -72,6 +149,22 @@ func (b *Builder) Node(name string, fn Function, opts ...NodeOption) (n Node) {
 	return
 }
 
+// MaxParallelism limits the number of nodes of runs of the built
+// graph specification which can be running at once
+func (b *Builder) MaxParallelism(max int32) *Builder {
+	b.maxParallelism = max
+
+	return b
+}
+
+// Priority configures the priority of runs of the built graph specification
+// Agents prefer the ready nodes of higher priority runs
+func (b *Builder) Priority(priority int32) *Builder {
+	b.priority = priority
+
+	return b
+}
+
 // Build constructs a graph specification from the builders state
 func (b *Builder) Build() (*adagio.GraphSpec, error) {
 	if b.err != nil {
@@ -79,8 +172,10 @@ func (b *Builder) Build() (*adagio.GraphSpec, error) {
 	}
 
 	spec := &adagio.GraphSpec{
-		Nodes: make([]*adagio.Node_Spec, 0, len(b.nodes)),
-		Edges: b.edges,
+		Nodes:          make([]*adagio.Node_Spec, 0, len(b.nodes)),
+		Edges:          b.edges,
+		MaxParallelism: b.maxParallelism,
+		Priority:       b.priority,
 	}
 
 	for _, node := range b.nodes {
@@ -122,13 +217,13 @@ type Node struct {
 }
 
 // DependencyOption is a function which manipulates a dependency
-// between two nodes
-type DependencyOption func(from, on Node)
+// between two nodes and the edge which connects them
+type DependencyOption func(from, on Node, edge *adagio.Edge)
 
 // MapOutputTo maps the output of the dependency onto
 // the argument name of the callee
 func MapOutputTo(argument string) DependencyOption {
-	return func(from, on Node) {
+	return func(from, on Node, _ *adagio.Edge) {
 		if fn, ok := from.fn.(InputMappableFunction); ok {
 			fn.SetArgumentFromInput(argument, on.name)
 			return
@@ -138,15 +233,25 @@ func MapOutputTo(argument string) DependencyOption {
 	}
 }
 
+// WithCondition configures the dependency to only be followed
+// given the result of the dependency satisfies the condition
+func WithCondition(condition *adagio.Edge_Condition) DependencyOption {
+	return func(_, _ Node, edge *adagio.Edge) {
+		edge.Condition = condition
+	}
+}
+
 // DependsOn creates a connection from the provided nodes (sources)
 // to the callee node (destination) on the original builder
 func (n Node) DependsOn(node Node, opts ...DependencyOption) {
+	edge := &adagio.Edge{
+		Source:      node.name,
+		Destination: n.name,
+	}
+
 	for _, opt := range opts {
-		opt(n, node)
+		opt(n, node, edge)
 	}
 
-	n.builder.edges = append(n.builder.edges, &adagio.Edge{
-		Source:      node.name,
-		Destination: n.name,
-	})
+	n.builder.edges = append(n.builder.edges, edge)
 }
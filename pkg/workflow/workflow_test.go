@@ -3,6 +3,7 @@ package workflow
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/georgemac/adagio/pkg/adagio"
 	"github.com/georgemac/adagio/pkg/rpc/controlplane"
@@ -67,3 +68,108 @@ func Test_Builder_Simple(t *testing.T) {
 	assert.Equal(t, "c", mapped.input)
 	assert.Equal(t, "first_argument", mapped.argument)
 }
+
+func Test_Builder(t *testing.T) {
+	emptySpec := FunctionFunc(func(name string) (*adagio.Node_Spec, error) {
+		return &adagio.Node_Spec{Name: name}, nil
+	})
+
+	for _, testCase := range []struct {
+		name string
+		// build adds nodes to the builder and returns a function
+		// which asserts on any state beyond the built spec
+		build func(*Builder) func(*testing.T)
+		spec  *adagio.GraphSpec
+	}{
+		{
+			name: "map node",
+			build: func(builder *Builder) func(*testing.T) {
+				var (
+					mapped = Mappable(emptySpec)
+					a      = builder.Node("a", emptySpec)
+					b      = builder.Node("b", mapped, WithMapOver("a"))
+				)
+
+				b.DependsOn(a, MapOutputTo("first_argument"))
+
+				return func(t *testing.T) {
+					assert.Equal(t, "a", mapped.input)
+					assert.Equal(t, "first_argument", mapped.argument)
+				}
+			},
+			spec: &adagio.GraphSpec{
+				Nodes: []*adagio.Node_Spec{
+					{Name: "a"},
+					{Name: "b", Map: &adagio.Node_Spec_Map{Over: "a"}},
+				},
+				Edges: []*adagio.Edge{
+					{Source: "a", Destination: "b"},
+				},
+			},
+		},
+		{
+			name: "conditional edge",
+			build: func(builder *Builder) func(*testing.T) {
+				var (
+					a = builder.Node("a", emptySpec)
+					b = builder.Node("b", emptySpec)
+				)
+
+				b.DependsOn(a, WithCondition(&adagio.Edge_Condition{Metadata: map[string]string{"deploy": "true"}}))
+
+				return nil
+			},
+			spec: &adagio.GraphSpec{
+				Nodes: []*adagio.Node_Spec{{Name: "a"}, {Name: "b"}},
+				Edges: []*adagio.Edge{
+					{
+						Source:      "a",
+						Destination: "b",
+						Condition:   &adagio.Edge_Condition{Metadata: map[string]string{"deploy": "true"}},
+					},
+				},
+			},
+		},
+		{
+			name: "retry backoff",
+			build: func(builder *Builder) func(*testing.T) {
+				builder.Node("a", emptySpec, WithRetry(adagio.OnFail, 3,
+					WithBackoff(time.Second, 2),
+					WithMaxDelay(time.Minute),
+					WithJitter(0.1)))
+
+				return nil
+			},
+			spec: &adagio.GraphSpec{
+				Nodes: []*adagio.Node_Spec{
+					{
+						Name: "a",
+						Retry: map[string]*adagio.Node_Spec_Retry{
+							"fail": {
+								MaxAttempts:  3,
+								InitialDelay: "1s",
+								Multiplier:   2,
+								MaxDelay:     "1m0s",
+								Jitter:       0.1,
+							},
+						},
+					},
+				},
+			},
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			builder := NewBuilder()
+
+			check := testCase.build(builder)
+
+			spec, err := builder.Build()
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.spec, spec)
+
+			if check != nil {
+				check(t)
+			}
+		})
+	}
+}